@@ -24,3 +24,10 @@ func GetConfig() *Config {
 func MustRegisterSubSection(subSectionKey string, section config.Config) config.Section {
 	return rootSection.MustRegisterSection(subSectionKey, section)
 }
+
+// MustRegisterSubSectionWithUpdates registers a config subsection whose updatesFn is called every time its value is
+// loaded or updated, e.g. to validate it once instead of on every use.
+func MustRegisterSubSectionWithUpdates(subSectionKey string, section config.Config,
+	updatesFn config.SectionUpdated) config.Section {
+	return rootSection.MustRegisterSectionWithUpdates(subSectionKey, section, updatesFn)
+}
@@ -0,0 +1,121 @@
+package errors
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sync"
+
+	"github.com/flyteorg/flytestdlib/logger"
+)
+
+// Target identifies the plugin that reported a failure and the project of the task. Failures classified without a
+// target (e.g. while building the phase of a task) only match rules applying to all plugins and projects.
+type Target struct {
+	PluginID string
+	Project  string
+}
+
+type rule struct {
+	Rule
+	messagePattern *regexp.Regexp
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r rule) matches(target Target, code, message string) bool {
+	return (len(r.Plugins) == 0 || contains(r.Plugins, target.PluginID)) &&
+		(len(r.Projects) == 0 || contains(r.Projects, target.Project)) &&
+		(len(r.Codes) == 0 || contains(r.Codes, code)) &&
+		(r.messagePattern == nil || r.messagePattern.MatchString(message))
+}
+
+// Classifier applies the rules of a Config to failures.
+type Classifier struct {
+	rules []rule
+}
+
+// Classify returns the classification of a failure, as decided by the first rule matching it, or current if none
+// matches.
+func (c *Classifier) Classify(target Target, code, message string, current Classification) Classification {
+	for _, r := range c.rules {
+		if !r.matches(target, code, message) {
+			continue
+		}
+
+		if r.Class != ClassUnknown {
+			current.Class = r.Class
+		}
+
+		if r.Retryable != nil {
+			current.Retryable = *r.Retryable
+		}
+
+		return current
+	}
+
+	return current
+}
+
+// NewClassifier creates a classifier applying the rules of cfg. It fails if a rule has an unknown class or an invalid
+// message pattern.
+func NewClassifier(cfg *Config) (*Classifier, error) {
+	rules := make([]rule, 0, len(cfg.Rules))
+	for i, r := range cfg.Rules {
+		if !r.Class.IsValid() {
+			return nil, fmt.Errorf("rule [%v] has unknown class [%v], expected one of %v", i, r.Class, Classes)
+		}
+
+		compiled := rule{Rule: r}
+		if len(r.MessagePattern) > 0 {
+			pattern, err := regexp.Compile(r.MessagePattern)
+			if err != nil {
+				return nil, fmt.Errorf("rule [%v] has invalid message pattern [%v]: %w", i, r.MessagePattern, err)
+			}
+
+			compiled.messagePattern = pattern
+		}
+
+		rules = append(rules, compiled)
+	}
+
+	return &Classifier{rules: rules}, nil
+}
+
+var (
+	classifierLock sync.Mutex
+	classifierCfg  *Config
+	classifier     *Classifier
+)
+
+// configuredClassifier returns the classifier of the current config, created again whenever the config changes.
+// Invalid configs are logged and ignored.
+func configuredClassifier() *Classifier {
+	cfg := GetConfig()
+	classifierLock.Lock()
+	defer classifierLock.Unlock()
+	if cfg != classifierCfg {
+		c, err := NewClassifier(cfg)
+		if err != nil {
+			logger.Errorf(context.TODO(), "Ignoring invalid error classification config: %v", err)
+			c = &Classifier{}
+		}
+
+		classifier, classifierCfg = c, cfg
+	}
+
+	return classifier
+}
+
+// Classify returns the classification of a failure as decided by the configured rules, or current if none matches.
+func Classify(target Target, code, message string, current Classification) Classification {
+	return configuredClassifier().Classify(target, code, message, current)
+}
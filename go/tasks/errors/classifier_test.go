@@ -0,0 +1,61 @@
+package errors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifier_Classify(t *testing.T) {
+	retryable, permanent := true, false
+	c, err := NewClassifier(&Config{Rules: []Rule{
+		{Plugins: []string{"databricks"}, Projects: []string{"flytesnacks"}, Codes: []string{"500"}, Retryable: &permanent},
+		{Codes: []string{"500", "503"}, Class: ClassInfrastructure},
+		{MessagePattern: "(?i)quota exceeded", Class: ClassQuota, Retryable: &retryable},
+		{Plugins: []string{"spark"}, Class: ClassUser},
+	}})
+	assert.NoError(t, err)
+
+	user := Classification{Class: ClassUser}
+	tests := []struct {
+		name     string
+		target   Target
+		code     string
+		message  string
+		expected Classification
+	}{
+		{"plugin and project", Target{"databricks", "flytesnacks"}, "500", "", Classification{Class: ClassUser}},
+		{"other project", Target{"databricks", "flyteexamples"}, "500", "",
+			Classification{Class: ClassInfrastructure}},
+		{"code", Target{}, "503", "", Classification{Class: ClassInfrastructure}},
+		{"message pattern", Target{}, "400", "Quota Exceeded for project", Classification{Class: ClassQuota,
+			Retryable: true}},
+		{"plugin", Target{PluginID: "spark"}, "OOMKilled", "", user},
+		{"no match", Target{PluginID: "ray"}, "400", "bad request", user},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, c.Classify(tt.target, tt.code, tt.message, user))
+		})
+	}
+}
+
+func TestNewClassifier(t *testing.T) {
+	_, err := NewClassifier(&Config{Rules: []Rule{{Class: "network"}}})
+	assert.EqualError(t, err, "rule [0] has unknown class [network], expected one of [user system infra quota timeout]")
+
+	_, err = NewClassifier(&Config{Rules: []Rule{{MessagePattern: "("}}})
+	assert.Error(t, err)
+}
+
+func TestClassify(t *testing.T) {
+	defer func() { assert.NoError(t, SetConfig(defaultConfig)) }()
+
+	assert.NoError(t, SetConfig(&Config{Rules: []Rule{{Codes: []string{"Throttled"}, Class: ClassQuota}}}))
+	assert.Equal(t, Classification{Class: ClassQuota}, Classify(Target{}, "Throttled", "", Classification{}))
+
+	// An invalid config is ignored.
+	assert.NoError(t, SetConfig(&Config{Rules: []Rule{{Codes: []string{"Throttled"}, Class: "network"}}}))
+	assert.Equal(t, Classification{}, Classify(Target{}, "Throttled", "", Classification{}))
+}
@@ -0,0 +1,51 @@
+package errors
+
+import (
+	"github.com/flyteorg/flyteplugins/go/tasks/config"
+)
+
+var (
+	defaultConfig = &Config{}
+
+	cfgSection = config.MustRegisterSubSection("errorClassification", defaultConfig)
+)
+
+// Config lets operators remap the class and retry behavior of task failures.
+type Config struct {
+	// Rules are evaluated in order, the first rule matching a failure decides its classification.
+	Rules []Rule `json:"rules" pflag:"-,Rules remapping the class and retry behavior of task failures."`
+}
+
+// Rule remaps the failures matching all of its (non-empty) selectors. Codes and MessagePattern match the code and the
+// message of the failure, Plugins and Projects the plugin that reported it and the project of the task.
+type Rule struct {
+	Plugins        []string `json:"plugins" pflag:",Ids of the plugins the rule applies to. Applies to all plugins if empty."`
+	Projects       []string `json:"projects" pflag:",Projects the rule applies to. Applies to all projects if empty."`
+	Codes          []string `json:"codes" pflag:",Error codes the rule applies to. Applies to all codes if empty."`
+	MessagePattern string   `json:"messagePattern" pflag:",Regular expression matched against error messages."`
+	// Class is the class of the matching failures. Their class is kept if empty.
+	Class Class `json:"class" pflag:",Class of the matching failures."`
+	// Retryable tells whether the matching failures are retryable. Their retry behavior is kept if unset.
+	Retryable *bool `json:"retryable" pflag:",Whether the matching failures are retryable."`
+}
+
+// HasTargetedRules returns true if some rules only apply to some plugins or projects. Only the error-classification
+// interceptor knows the plugin and the project of failures, such rules require it.
+func (c Config) HasTargetedRules() bool {
+	for _, r := range c.Rules {
+		if len(r.Plugins) > 0 || len(r.Projects) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func GetConfig() *Config {
+	return cfgSection.GetConfig().(*Config)
+}
+
+// SetConfig should be used for unit testing only
+func SetConfig(cfg *Config) error {
+	return cfgSection.SetConfig(cfg)
+}
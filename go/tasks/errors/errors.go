@@ -16,8 +16,11 @@ const (
 	CacheFailed                errors.ErrorCode = "AutoRefreshCacheFailed"
 	RuntimeFailure             errors.ErrorCode = "RuntimeFailure"
 	CorruptedPluginState       errors.ErrorCode = "CorruptedPluginState"
+	UnsupportedStateVersion    errors.ErrorCode = "UnsupportedStateVersion"
+	SecretNotFound             errors.ErrorCode = "SecretNotFound"
 	ResourceManagerFailure     errors.ErrorCode = "ResourceManagerFailure"
 	BackOffError               errors.ErrorCode = "BackOffError"
+	PluginPanicked             errors.ErrorCode = "PluginPanicked"
 )
 
 func Errorf(errorCode errors.ErrorCode, msgFmt string, args ...interface{}) error {
@@ -0,0 +1,72 @@
+package errors
+
+import (
+	"net/http"
+)
+
+// Class is the category of a task failure. Together with whether the failure is retryable, it decides how the system
+// reacts to it: user failures count against the retries of the task, the others against the system retries.
+type Class string
+
+const (
+	// ClassUnknown is the class of failures that weren't classified.
+	ClassUnknown Class = ""
+	// ClassUser is the class of failures caused by the task itself, e.g. a bad task specification or user code failing.
+	ClassUser Class = "user"
+	// ClassSystem is the class of failures caused by the system running the task, e.g. a misconfigured plugin.
+	ClassSystem Class = "system"
+	// ClassInfrastructure is the class of failures caused by the infrastructure the task runs on, e.g. an interrupted
+	// node or an unavailable remote service.
+	ClassInfrastructure Class = "infra"
+	// ClassQuota is the class of failures caused by exhausted quotas or throttling.
+	ClassQuota Class = "quota"
+	// ClassTimeout is the class of failures caused by operations timing out.
+	ClassTimeout Class = "timeout"
+)
+
+// Classes lists the known classes, except ClassUnknown.
+var Classes = []Class{ClassUser, ClassSystem, ClassInfrastructure, ClassQuota, ClassTimeout}
+
+// IsValid returns true if c is a known class or ClassUnknown.
+func (c Class) IsValid() bool {
+	if c == ClassUnknown {
+		return true
+	}
+
+	for _, known := range Classes {
+		if c == known {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsUser returns true if failures of the class are attributed to the task rather than to the system.
+func (c Class) IsUser() bool {
+	return c == ClassUser
+}
+
+// Classification tells how the system reacts to a failure.
+type Classification struct {
+	Class     Class
+	Retryable bool
+}
+
+// ClassifyHTTPStatus returns the classification of a failed call to a remote service that responded with the status
+// code: client errors are user failures, except for throttling, timeouts and authentication errors, and server errors
+// are retryable infrastructure failures.
+func ClassifyHTTPStatus(statusCode int) Classification {
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		return Classification{Class: ClassQuota, Retryable: true}
+	case statusCode == http.StatusRequestTimeout || statusCode == http.StatusGatewayTimeout:
+		return Classification{Class: ClassTimeout, Retryable: true}
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		return Classification{Class: ClassSystem}
+	case statusCode >= http.StatusInternalServerError:
+		return Classification{Class: ClassInfrastructure, Retryable: true}
+	default:
+		return Classification{Class: ClassUser}
+	}
+}
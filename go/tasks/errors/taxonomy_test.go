@@ -0,0 +1,40 @@
+package errors
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClass_IsValid(t *testing.T) {
+	assert.True(t, ClassUnknown.IsValid())
+	for _, c := range Classes {
+		assert.True(t, c.IsValid())
+	}
+
+	assert.False(t, Class("network").IsValid())
+}
+
+func TestClassifyHTTPStatus(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		expected   Classification
+	}{
+		{http.StatusBadRequest, Classification{Class: ClassUser}},
+		{http.StatusUnprocessableEntity, Classification{Class: ClassUser}},
+		{http.StatusUnauthorized, Classification{Class: ClassSystem}},
+		{http.StatusForbidden, Classification{Class: ClassSystem}},
+		{http.StatusRequestTimeout, Classification{Class: ClassTimeout, Retryable: true}},
+		{http.StatusTooManyRequests, Classification{Class: ClassQuota, Retryable: true}},
+		{http.StatusInternalServerError, Classification{Class: ClassInfrastructure, Retryable: true}},
+		{http.StatusServiceUnavailable, Classification{Class: ClassInfrastructure, Retryable: true}},
+		{http.StatusGatewayTimeout, Classification{Class: ClassTimeout, Retryable: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.statusCode), func(t *testing.T) {
+			assert.Equal(t, tt.expected, ClassifyHTTPStatus(tt.statusCode))
+		})
+	}
+}
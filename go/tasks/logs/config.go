@@ -1,13 +1,19 @@
 package logs
 
 import (
-	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"context"
+
+	stdConfig "github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/logger"
+
 	"github.com/flyteorg/flyteplugins/go/tasks/config"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tasklog"
 )
 
 //go:generate pflags LogConfig --default-var=DefaultConfig
 
-// TemplateURI is a URI that accepts templates. See: go/tasks/pluginmachinery/tasklog/template.go for available templates.
+// TemplateURI is a URI that accepts templates. See: go/tasks/pluginmachinery/tasklog/template.go for available templates
+// and functions.
 type TemplateURI = string
 
 // LogConfig encapsulates plugins' log configs
@@ -31,24 +37,102 @@ type LogConfig struct {
 	StackdriverLogResourceName string      `json:"stackdriver-logresourcename" pflag:",Name of the logresource in stackdriver"`
 	StackDriverTemplateURI     TemplateURI `json:"stackdriver-template-uri" pflag:",Template Uri to use when building stackdriver log links"`
 
+	Loki    LokiConfig    `json:"loki" pflag:",Configures log links to Grafana Explore backed by a Loki datasource."`
+	Datadog DatadogConfig `json:"datadog" pflag:",Configures log links to Datadog Log Explorer."`
+	Splunk  SplunkConfig  `json:"splunk" pflag:",Configures log links to Splunk search."`
+	Kibana  KibanaConfig  `json:"kibana" pflag:",Configures log links to Kibana Discover."`
+
+	ExcludeContainerRoles ContainerRolesConfig `json:"exclude-container-roles" pflag:",Roles of the containers for which log links shouldn't be generated."`
+
 	Templates []TemplateLogPluginConfig `json:"templates" pflag:"-,"`
+
+	// Overrides customize the config above for specific plugins, projects and domains. See LogConfig.Resolve.
+	Overrides []LogConfigOverride `json:"overrides" pflag:"-,Overrides of the log config for specific plugins, projects and domains."`
+
+	// Signer configures the signing of all log links. A key-secret is read from the backends configured in the secrets
+	// config section, and read again every key-refresh-interval.
+	Signer tasklog.SignerConfig `json:"signer" pflag:"-,Configures the signing of log links."`
+}
+
+// ContainerRolesConfig selects containers in a pod by their role. See tasklog.ContainerRole.
+type ContainerRolesConfig struct {
+	Primary bool `json:"primary" pflag:",The container running the task code"`
+	Sidecar bool `json:"sidecar" pflag:",All other containers in the pod"`
+	Init    bool `json:"init" pflag:",Init containers"`
+}
+
+// LokiConfig configures log links that open Grafana Explore with a LogQL query over the time range of the pod.
+type LokiConfig struct {
+	Enabled       bool   `json:"enabled" pflag:",Enable log links to Grafana Loki"`
+	GrafanaURL    string `json:"grafana-url" pflag:",Base URL of the Grafana instance (e.g. https://grafana.example.com)"`
+	OrgID         int    `json:"org-id" pflag:",Grafana organization id"`
+	DatasourceUID string `json:"datasource-uid" pflag:",UID of the Loki datasource in Grafana"`
+	Query         string `json:"query" pflag:",LogQL query template"`
+}
+
+// DatadogConfig configures log links that open the Datadog Log Explorer over the time range of the pod.
+type DatadogConfig struct {
+	Enabled bool   `json:"enabled" pflag:",Enable log links to Datadog"`
+	Site    string `json:"site" pflag:",Datadog site (e.g. datadoghq.com or datadoghq.eu)"`
+	Query   string `json:"query" pflag:",Log search query template"`
+}
+
+// SplunkConfig configures log links that open a Splunk search over the time range of the pod.
+type SplunkConfig struct {
+	Enabled bool   `json:"enabled" pflag:",Enable log links to Splunk"`
+	URL     string `json:"url" pflag:",Base URL of the Splunk web UI (e.g. https://splunk.example.com)"`
+	App     string `json:"app" pflag:",Splunk app to run the search in"`
+	Query   string `json:"query" pflag:",SPL search query template"`
 }
 
-type TemplateLogPluginConfig struct {
-	DisplayName   string                     `json:"displayName" pflag:",Display name for the generated log when displayed in the console."`
-	TemplateURIs  []TemplateURI              `json:"templateUris" pflag:",URI Templates for generating task log links."`
-	MessageFormat core.TaskLog_MessageFormat `json:"messageFormat" pflag:",Log Message Format."`
+// KibanaConfig configures log links that open Kibana Discover with a KQL query over the time range of the pod.
+type KibanaConfig struct {
+	Enabled  bool   `json:"enabled" pflag:",Enable log links to Kibana"`
+	URL      string `json:"url" pflag:",Base URL of Kibana (e.g. https://kibana.example.com)"`
+	DataView string `json:"data-view" pflag:",Id of the Kibana data view (index pattern) to search. Kibana's default is used if empty."`
+	Query    string `json:"query" pflag:",KQL query template"`
 }
 
+type TemplateLogPluginConfig = tasklog.TemplateLogPluginConfig
+
 var (
 	DefaultConfig = LogConfig{
 		IsKubernetesEnabled:   true,
 		KubernetesTemplateURI: "http://localhost:30082/#!/log/{{ .namespace }}/{{ .podName }}/pod?namespace={{ .namespace }}",
+		// Only the primary container gets log links by default, like before links were generated for all containers.
+		ExcludeContainerRoles: ContainerRolesConfig{
+			Sidecar: true,
+			Init:    true,
+		},
+		Loki: LokiConfig{
+			OrgID: defaultLokiOrgID,
+			Query: defaultLokiQuery,
+		},
+		Datadog: DatadogConfig{
+			Site:  defaultDatadogSite,
+			Query: defaultDatadogQuery,
+		},
+		Splunk: SplunkConfig{
+			App:   defaultSplunkApp,
+			Query: defaultSplunkQuery,
+		},
+		Kibana: KibanaConfig{
+			Query: defaultKibanaQuery,
+		},
 	}
 
-	logConfigSection = config.MustRegisterSubSection("logs", &DefaultConfig)
+	logConfigSection = config.MustRegisterSubSectionWithUpdates("logs", &DefaultConfig, onLogConfigUpdated)
 )
 
+// onLogConfigUpdated validates the log link templates once, whenever the config is loaded or updated, so that
+// malformed templates are reported as soon as they're configured. The config is kept, InitializeLogPlugins fails to
+// build the links of the tasks using it.
+func onLogConfigUpdated(ctx context.Context, newValue stdConfig.Config) {
+	if err := validateTemplates(newValue.(*LogConfig)); err != nil {
+		logger.Errorf(ctx, "Invalid log link templates. Error: %v", err)
+	}
+}
+
 func GetLogConfig() *LogConfig {
 	return logConfigSection.GetConfig().(*LogConfig)
 }
@@ -61,5 +61,24 @@ func (cfg LogConfig) GetPFlagSet(prefix string) *pflag.FlagSet {
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "gcp-project"), DefaultConfig.GCPProjectName, "Name of the project in GCP")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "stackdriver-logresourcename"), DefaultConfig.StackdriverLogResourceName, "Name of the logresource in stackdriver")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "stackdriver-template-uri"), DefaultConfig.StackDriverTemplateURI, "Template Uri to use when building stackdriver log links")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "loki.enabled"), DefaultConfig.Loki.Enabled, "Enable log links to Grafana Loki")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "loki.grafana-url"), DefaultConfig.Loki.GrafanaURL, "Base URL of the Grafana instance (e.g. https://grafana.example.com)")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "loki.org-id"), DefaultConfig.Loki.OrgID, "Grafana organization id")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "loki.datasource-uid"), DefaultConfig.Loki.DatasourceUID, "UID of the Loki datasource in Grafana")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "loki.query"), DefaultConfig.Loki.Query, "LogQL query template")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "datadog.enabled"), DefaultConfig.Datadog.Enabled, "Enable log links to Datadog")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "datadog.site"), DefaultConfig.Datadog.Site, "Datadog site (e.g. datadoghq.com or datadoghq.eu)")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "datadog.query"), DefaultConfig.Datadog.Query, "Log search query template")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "splunk.enabled"), DefaultConfig.Splunk.Enabled, "Enable log links to Splunk")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "splunk.url"), DefaultConfig.Splunk.URL, "Base URL of the Splunk web UI (e.g. https://splunk.example.com)")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "splunk.app"), DefaultConfig.Splunk.App, "Splunk app to run the search in")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "splunk.query"), DefaultConfig.Splunk.Query, "SPL search query template")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "kibana.enabled"), DefaultConfig.Kibana.Enabled, "Enable log links to Kibana")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "kibana.url"), DefaultConfig.Kibana.URL, "Base URL of Kibana (e.g. https://kibana.example.com)")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "kibana.data-view"), DefaultConfig.Kibana.DataView, "Id of the Kibana data view (index pattern) to search. Kibana's default is used if empty.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "kibana.query"), DefaultConfig.Kibana.Query, "KQL query template")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "exclude-container-roles.primary"), DefaultConfig.ExcludeContainerRoles.Primary, "The container running the task code")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "exclude-container-roles.sidecar"), DefaultConfig.ExcludeContainerRoles.Sidecar, "All other containers in the pod")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "exclude-container-roles.init"), DefaultConfig.ExcludeContainerRoles.Init, "Init containers")
 	return cmdFlags
 }
@@ -253,4 +253,270 @@ func TestLogConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_loki.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("loki.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("loki.enabled"); err == nil {
+				testDecodeJson_LogConfig(t, fmt.Sprintf("%v", vBool), &actual.Loki.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_loki.grafana-url", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("loki.grafana-url", testValue)
+			if vString, err := cmdFlags.GetString("loki.grafana-url"); err == nil {
+				testDecodeJson_LogConfig(t, fmt.Sprintf("%v", vString), &actual.Loki.GrafanaURL)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_loki.org-id", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("loki.org-id", testValue)
+			if vInt, err := cmdFlags.GetInt("loki.org-id"); err == nil {
+				testDecodeJson_LogConfig(t, fmt.Sprintf("%v", vInt), &actual.Loki.OrgID)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_loki.datasource-uid", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("loki.datasource-uid", testValue)
+			if vString, err := cmdFlags.GetString("loki.datasource-uid"); err == nil {
+				testDecodeJson_LogConfig(t, fmt.Sprintf("%v", vString), &actual.Loki.DatasourceUID)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_loki.query", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("loki.query", testValue)
+			if vString, err := cmdFlags.GetString("loki.query"); err == nil {
+				testDecodeJson_LogConfig(t, fmt.Sprintf("%v", vString), &actual.Loki.Query)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_datadog.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("datadog.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("datadog.enabled"); err == nil {
+				testDecodeJson_LogConfig(t, fmt.Sprintf("%v", vBool), &actual.Datadog.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_datadog.site", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("datadog.site", testValue)
+			if vString, err := cmdFlags.GetString("datadog.site"); err == nil {
+				testDecodeJson_LogConfig(t, fmt.Sprintf("%v", vString), &actual.Datadog.Site)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_datadog.query", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("datadog.query", testValue)
+			if vString, err := cmdFlags.GetString("datadog.query"); err == nil {
+				testDecodeJson_LogConfig(t, fmt.Sprintf("%v", vString), &actual.Datadog.Query)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_splunk.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("splunk.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("splunk.enabled"); err == nil {
+				testDecodeJson_LogConfig(t, fmt.Sprintf("%v", vBool), &actual.Splunk.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_splunk.url", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("splunk.url", testValue)
+			if vString, err := cmdFlags.GetString("splunk.url"); err == nil {
+				testDecodeJson_LogConfig(t, fmt.Sprintf("%v", vString), &actual.Splunk.URL)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_splunk.app", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("splunk.app", testValue)
+			if vString, err := cmdFlags.GetString("splunk.app"); err == nil {
+				testDecodeJson_LogConfig(t, fmt.Sprintf("%v", vString), &actual.Splunk.App)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_splunk.query", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("splunk.query", testValue)
+			if vString, err := cmdFlags.GetString("splunk.query"); err == nil {
+				testDecodeJson_LogConfig(t, fmt.Sprintf("%v", vString), &actual.Splunk.Query)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_kibana.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("kibana.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("kibana.enabled"); err == nil {
+				testDecodeJson_LogConfig(t, fmt.Sprintf("%v", vBool), &actual.Kibana.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_kibana.url", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("kibana.url", testValue)
+			if vString, err := cmdFlags.GetString("kibana.url"); err == nil {
+				testDecodeJson_LogConfig(t, fmt.Sprintf("%v", vString), &actual.Kibana.URL)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_kibana.data-view", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("kibana.data-view", testValue)
+			if vString, err := cmdFlags.GetString("kibana.data-view"); err == nil {
+				testDecodeJson_LogConfig(t, fmt.Sprintf("%v", vString), &actual.Kibana.DataView)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_kibana.query", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("kibana.query", testValue)
+			if vString, err := cmdFlags.GetString("kibana.query"); err == nil {
+				testDecodeJson_LogConfig(t, fmt.Sprintf("%v", vString), &actual.Kibana.Query)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_exclude-container-roles.primary", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("exclude-container-roles.primary", testValue)
+			if vBool, err := cmdFlags.GetBool("exclude-container-roles.primary"); err == nil {
+				testDecodeJson_LogConfig(t, fmt.Sprintf("%v", vBool), &actual.ExcludeContainerRoles.Primary)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_exclude-container-roles.sidecar", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("exclude-container-roles.sidecar", testValue)
+			if vBool, err := cmdFlags.GetBool("exclude-container-roles.sidecar"); err == nil {
+				testDecodeJson_LogConfig(t, fmt.Sprintf("%v", vBool), &actual.ExcludeContainerRoles.Sidecar)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_exclude-container-roles.init", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("exclude-container-roles.init", testValue)
+			if vBool, err := cmdFlags.GetBool("exclude-container-roles.init"); err == nil {
+				testDecodeJson_LogConfig(t, fmt.Sprintf("%v", vBool), &actual.ExcludeContainerRoles.Init)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 }
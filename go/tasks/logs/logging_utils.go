@@ -3,13 +3,18 @@ package logs
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/secretmanager"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tasklog"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flytestdlib/logger"
 	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type logPlugin struct {
@@ -17,8 +22,23 @@ type logPlugin struct {
 	Plugin tasklog.Plugin
 }
 
+// templateVarsProvider is implemented by task execution IDs that make additional variables available to log link
+// templates, e.g. the IDs of the subtasks of k8s array tasks.
+type templateVarsProvider interface {
+	GetLogTemplateVars() map[string]string
+}
+
+// extraTemplateVars returns the additional variables the task execution ID makes available to log link templates.
+func extraTemplateVars(taskExecID pluginsCore.TaskExecutionID) map[string]string {
+	if provider, ok := taskExecID.(templateVarsProvider); ok {
+		return provider.GetLogTemplateVars()
+	}
+
+	return nil
+}
+
 // Internal
-func GetLogsForContainerInPod(ctx context.Context, logPlugin tasklog.Plugin, pod *v1.Pod, index uint32, nameSuffix string) ([]*core.TaskLog, error) {
+func GetLogsForContainerInPod(ctx context.Context, logPlugin tasklog.Plugin, taskExecID pluginsCore.TaskExecutionID, pod *v1.Pod, index uint32, nameSuffix string) ([]*core.TaskLog, error) {
 	if logPlugin == nil {
 		return nil, nil
 	}
@@ -38,16 +58,19 @@ func GetLogsForContainerInPod(ctx context.Context, logPlugin tasklog.Plugin, pod
 		return nil, nil
 	}
 
+	taskExecIdentifier := taskExecID.GetID()
 	logs, err := logPlugin.GetTaskLogs(
 		tasklog.Input{
-			PodName:           pod.Name,
-			PodUID:            string(pod.GetUID()),
-			Namespace:         pod.Namespace,
-			ContainerName:     pod.Spec.Containers[index].Name,
-			ContainerID:       pod.Status.ContainerStatuses[index].ContainerID,
-			LogName:           nameSuffix,
-			PodUnixStartTime:  pod.CreationTimestamp.Unix(),
-			PodUnixFinishTime: time.Now().Unix(),
+			PodName:                 pod.Name,
+			PodUID:                  string(pod.GetUID()),
+			Namespace:               pod.Namespace,
+			ContainerName:           pod.Spec.Containers[index].Name,
+			ContainerID:             pod.Status.ContainerStatuses[index].ContainerID,
+			LogName:                 nameSuffix,
+			PodUnixStartTime:        pod.CreationTimestamp.Unix(),
+			PodUnixFinishTime:       time.Now().Unix(),
+			TaskExecutionIdentifier: &taskExecIdentifier,
+			ExtraTemplateVars:       extraTemplateVars(taskExecID),
 		},
 	)
 
@@ -58,11 +81,158 @@ func GetLogsForContainerInPod(ctx context.Context, logPlugin tasklog.Plugin, pod
 	return logs.TaskLogs, nil
 }
 
+// GetLogsForContainersInPod builds log links for all containers and init containers in the pod. The primary container
+// (the one named primaryContainerName or, if empty, the first container) is named using nameSuffix while other
+// containers are additionally labelled by their role and name. Containers that have no status yet are skipped.
+func GetLogsForContainersInPod(ctx context.Context, logPlugin tasklog.Plugin, taskExecID pluginsCore.TaskExecutionID,
+	pod *v1.Pod, primaryContainerName string, nameSuffix string) ([]*core.TaskLog, error) {
+
+	if logPlugin == nil {
+		return nil, nil
+	}
+
+	if pod == nil {
+		logger.Error(ctx, "cannot extract logs for a nil container")
+		return nil, nil
+	}
+
+	if len(primaryContainerName) == 0 && len(pod.Spec.Containers) > 0 {
+		primaryContainerName = pod.Spec.Containers[0].Name
+	}
+
+	taskExecIdentifier := taskExecID.GetID()
+	input := tasklog.Input{
+		PodName:                 pod.Name,
+		PodUID:                  string(pod.GetUID()),
+		Namespace:               pod.Namespace,
+		PodUnixStartTime:        pod.CreationTimestamp.Unix(),
+		PodUnixFinishTime:       time.Now().Unix(),
+		TaskExecutionIdentifier: &taskExecIdentifier,
+		ExtraTemplateVars:       extraTemplateVars(taskExecID),
+	}
+
+	var taskLogs []*core.TaskLog
+	addLogs := func(containers []v1.Container, statuses []v1.ContainerStatus, isInit bool) error {
+		for idx, container := range containers {
+			var status *v1.ContainerStatus
+			for i := range statuses {
+				if statuses[i].Name == container.Name {
+					status = &statuses[i]
+					break
+				}
+			}
+
+			// Fallback to the status at the same index if statuses aren't named.
+			if status == nil && idx < len(statuses) && len(statuses[idx].Name) == 0 {
+				status = &statuses[idx]
+			}
+
+			if status == nil {
+				continue
+			}
+
+			role := tasklog.ContainerRoleSidecar
+			if isInit {
+				role = tasklog.ContainerRoleInit
+			} else if container.Name == primaryContainerName {
+				role = tasklog.ContainerRolePrimary
+			}
+
+			input.ContainerName = container.Name
+			input.ContainerID = status.ContainerID
+			input.ContainerRole = role
+			input.LogName = nameSuffix
+			if role != tasklog.ContainerRolePrimary {
+				input.LogName = fmt.Sprintf("%s [%s: %s]", nameSuffix, role, container.Name)
+			}
+
+			o, err := logPlugin.GetTaskLogs(input)
+			if err != nil {
+				return err
+			}
+
+			taskLogs = append(taskLogs, o.TaskLogs...)
+		}
+
+		return nil
+	}
+
+	if err := addLogs(pod.Spec.Containers, pod.Status.ContainerStatuses, false); err != nil {
+		return nil, err
+	}
+
+	if err := addLogs(pod.Spec.InitContainers, pod.Status.InitContainerStatuses, true); err != nil {
+		return nil, err
+	}
+
+	return taskLogs, nil
+}
+
+// GetLogsForPodByName builds log links for the containers of the pod named podName, like GetLogsForContainersInPod. It's
+// meant for plugins of custom resources, which know the names of the pods their resources run but not the pods. The pod
+// is read using reader. If reader is nil or the pod can't be read, e.g. because it wasn't created yet, only the links of
+// the primary container are built, from the names of the pod and of the container.
+func GetLogsForPodByName(ctx context.Context, logPlugin tasklog.Plugin, taskExecID pluginsCore.TaskExecutionID,
+	reader client.Reader, namespace, podName, primaryContainerName, nameSuffix string) ([]*core.TaskLog, error) {
+
+	if logPlugin == nil {
+		return nil, nil
+	}
+
+	if reader != nil && len(podName) > 0 {
+		pod := &v1.Pod{}
+		err := reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: podName}, pod)
+		if err == nil {
+			return GetLogsForContainersInPod(ctx, logPlugin, taskExecID, pod, primaryContainerName, nameSuffix)
+		}
+
+		if !k8serrors.IsNotFound(err) {
+			logger.Warnf(ctx, "Failed to read pod [%v/%v] to build its log links, building the links of its primary "+
+				"container only. Error: %v", namespace, podName, err)
+		}
+	}
+
+	taskExecIdentifier := taskExecID.GetID()
+	o, err := logPlugin.GetTaskLogs(tasklog.Input{
+		PodName:                 podName,
+		Namespace:               namespace,
+		ContainerName:           primaryContainerName,
+		ContainerRole:           tasklog.ContainerRolePrimary,
+		LogName:                 nameSuffix,
+		TaskExecutionIdentifier: &taskExecIdentifier,
+		ExtraTemplateVars:       extraTemplateVars(taskExecID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return o.TaskLogs, nil
+}
+
 type taskLogPluginWrapper struct {
-	logPlugins []logPlugin
+	logPlugins    []logPlugin
+	signer        tasklog.URLSigner
+	excludedRoles ContainerRolesConfig
+}
+
+func (t taskLogPluginWrapper) isExcluded(role tasklog.ContainerRole) bool {
+	switch role {
+	case tasklog.ContainerRolePrimary:
+		return t.excludedRoles.Primary
+	case tasklog.ContainerRoleSidecar:
+		return t.excludedRoles.Sidecar
+	case tasklog.ContainerRoleInit:
+		return t.excludedRoles.Init
+	default:
+		return false
+	}
 }
 
 func (t taskLogPluginWrapper) GetTaskLogs(input tasklog.Input) (logOutput tasklog.Output, err error) {
+	if t.isExcluded(input.ContainerRole) {
+		return tasklog.Output{}, nil
+	}
+
 	logs := make([]*core.TaskLog, 0, len(t.logPlugins))
 	suffix := input.LogName
 	for _, plugin := range t.logPlugins {
@@ -72,6 +242,14 @@ func (t taskLogPluginWrapper) GetTaskLogs(input tasklog.Input) (logOutput tasklo
 			return tasklog.Output{}, err
 		}
 
+		if t.signer != nil {
+			for _, l := range o.TaskLogs {
+				if l.Uri, err = t.signer.Sign(l.Uri); err != nil {
+					return tasklog.Output{}, err
+				}
+			}
+		}
+
 		logs = append(logs, o.TaskLogs...)
 	}
 
@@ -80,8 +258,89 @@ func (t taskLogPluginWrapper) GetTaskLogs(input tasklog.Input) (logOutput tasklo
 	}, nil
 }
 
-// InitializeLogPlugins initializes log plugin based on config.
+// validateTemplates checks that all configured log link templates can be parsed, including the ones the overrides of
+// cfg set.
+func validateTemplates(cfg *LogConfig) error {
+	if err := validateOwnTemplates(cfg); err != nil {
+		return err
+	}
+
+	base := *cfg
+	base.Overrides = nil
+	for i, o := range cfg.Overrides {
+		overlaid, err := o.Config.ApplyTo(base)
+		if err != nil {
+			return fmt.Errorf("invalid log config override [%d]: %w", i, err)
+		}
+
+		if err := validateOwnTemplates(&overlaid); err != nil {
+			return fmt.Errorf("invalid log config override [%d]: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// validateOwnTemplates checks that the log link templates of cfg, ignoring its overrides, can be parsed.
+func validateOwnTemplates(cfg *LogConfig) error {
+	err := tasklog.ValidateTemplateURIs([]string{cfg.KubernetesTemplateURI, cfg.CloudwatchTemplateURI,
+		cfg.StackDriverTemplateURI, cfg.Loki.Query, cfg.Datadog.Query, cfg.Splunk.Query, cfg.Kibana.Query})
+	if err != nil {
+		return err
+	}
+
+	for _, template := range cfg.Templates {
+		if err := tasklog.ValidateTemplateURIs(template.TemplateURIs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// signers caches the signers of log links by config, so that a signer is created once per config rather than every
+// time the links of a task are built. Like the signers of web API plugins, which are created when the plugins are
+// loaded, cached signers read signing keys held in secrets again every key refresh interval.
+var signers sync.Map
+
+// getSigner returns the signer described by cfg. k8s plugins don't have access to the secret manager of task executions
+// when building log links, so signing keys held in secrets are read from the backends of the plugins' secret manager
+// (see the secrets config section).
+func getSigner(ctx context.Context, cfg tasklog.SignerConfig) (tasklog.URLSigner, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	if s, found := signers.Load(cfg); found {
+		return s.(tasklog.URLSigner), nil
+	}
+
+	var secrets tasklog.SecretGetter
+	if len(cfg.KeySecretName) > 0 {
+		manager, err := secretmanager.NewManagerFromConfig(secretmanager.GetConfig())
+		if err != nil {
+			return nil, err
+		}
+
+		secrets = manager
+	}
+
+	signer, err := tasklog.NewURLSigner(ctx, cfg, secrets)
+	if err != nil {
+		return nil, err
+	}
+
+	signers.Store(cfg, signer)
+	return signer, nil
+}
+
+// InitializeLogPlugins initializes log plugin based on config. It fails if any of the templates of cfg, or of its
+// overrides, can't be parsed.
 func InitializeLogPlugins(cfg *LogConfig) (tasklog.Plugin, error) {
+	if err := validateTemplates(cfg); err != nil {
+		return nil, fmt.Errorf("invalid log link templates: %w", err)
+	}
+
 	// Use a list to maintain order.
 	logPlugins := make([]logPlugin, 0, 2)
 
@@ -111,6 +370,22 @@ func InitializeLogPlugins(cfg *LogConfig) (tasklog.Plugin, error) {
 		}
 	}
 
+	if cfg.Loki.Enabled {
+		logPlugins = append(logPlugins, logPlugin{Name: "Loki Logs", Plugin: lokiLogPlugin{cfg: cfg.Loki}})
+	}
+
+	if cfg.Datadog.Enabled {
+		logPlugins = append(logPlugins, logPlugin{Name: "Datadog Logs", Plugin: datadogLogPlugin{cfg: cfg.Datadog}})
+	}
+
+	if cfg.Splunk.Enabled {
+		logPlugins = append(logPlugins, logPlugin{Name: "Splunk Logs", Plugin: splunkLogPlugin{cfg: cfg.Splunk}})
+	}
+
+	if cfg.Kibana.Enabled {
+		logPlugins = append(logPlugins, logPlugin{Name: "Kibana Logs", Plugin: kibanaLogPlugin{cfg: cfg.Kibana}})
+	}
+
 	if len(cfg.Templates) > 0 {
 		for _, cfg := range cfg.Templates {
 			logPlugins = append(logPlugins, logPlugin{Name: cfg.DisplayName, Plugin: tasklog.NewTemplateLogPlugin(cfg.TemplateURIs, cfg.MessageFormat)})
@@ -121,7 +396,14 @@ func InitializeLogPlugins(cfg *LogConfig) (tasklog.Plugin, error) {
 		return nil, nil
 	}
 
+	signer, err := getSigner(context.TODO(), cfg.Signer)
+	if err != nil {
+		return nil, err
+	}
+
 	return taskLogPluginWrapper{
-		logPlugins: logPlugins,
+		logPlugins:    logPlugins,
+		signer:        signer,
+		excludedRoles: cfg.ExcludeContainerRoles,
 	}, nil
 }
@@ -2,22 +2,43 @@ package logs
 
 import (
 	"context"
+	"os"
 	"testing"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	pluginsCoreMock "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tasklog"
 	"github.com/go-test/deep"
 	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 const podName = "PodName"
 
+func dummyTaskExecID() *pluginsCoreMock.TaskExecutionID {
+	tID := &pluginsCoreMock.TaskExecutionID{}
+	tID.OnGetID().Return(core.TaskExecutionIdentifier{
+		NodeExecutionId: &core.NodeExecutionIdentifier{
+			ExecutionId: &core.WorkflowExecutionIdentifier{
+				Name:    "my-execution-name",
+				Project: "my-execution-project",
+				Domain:  "my-execution-domain",
+			},
+			NodeId: "n0",
+		},
+		RetryAttempt: 1,
+	})
+	return tID
+}
+
 func TestGetLogsForContainerInPod_NoPlugins(t *testing.T) {
 	logPlugin, err := InitializeLogPlugins(&LogConfig{})
 	assert.NoError(t, err)
-	l, err := GetLogsForContainerInPod(context.TODO(), logPlugin, nil, 0, " Suffix")
+	l, err := GetLogsForContainerInPod(context.TODO(), logPlugin, dummyTaskExecID(), nil, 0, " Suffix")
 	assert.NoError(t, err)
 	assert.Nil(t, l)
 }
@@ -29,7 +50,7 @@ func TestGetLogsForContainerInPod_NoLogs(t *testing.T) {
 		CloudwatchLogGroup:  "/kubernetes/flyte-production",
 	})
 	assert.NoError(t, err)
-	p, err := GetLogsForContainerInPod(context.TODO(), logPlugin, nil, 0, " Suffix")
+	p, err := GetLogsForContainerInPod(context.TODO(), logPlugin, dummyTaskExecID(), nil, 0, " Suffix")
 	assert.NoError(t, err)
 	assert.Nil(t, p)
 }
@@ -60,7 +81,7 @@ func TestGetLogsForContainerInPod_BadIndex(t *testing.T) {
 	}
 	pod.Name = podName
 
-	p, err := GetLogsForContainerInPod(context.TODO(), logPlugin, pod, 1, " Suffix")
+	p, err := GetLogsForContainerInPod(context.TODO(), logPlugin, dummyTaskExecID(), pod, 1, " Suffix")
 	assert.NoError(t, err)
 	assert.Nil(t, p)
 }
@@ -85,7 +106,7 @@ func TestGetLogsForContainerInPod_MissingStatus(t *testing.T) {
 	}
 	pod.Name = podName
 
-	p, err := GetLogsForContainerInPod(context.TODO(), logPlugin, pod, 1, " Suffix")
+	p, err := GetLogsForContainerInPod(context.TODO(), logPlugin, dummyTaskExecID(), pod, 1, " Suffix")
 	assert.NoError(t, err)
 	assert.Nil(t, p)
 }
@@ -115,7 +136,7 @@ func TestGetLogsForContainerInPod_Cloudwatch(t *testing.T) {
 	}
 	pod.Name = podName
 
-	logs, err := GetLogsForContainerInPod(context.TODO(), logPlugin, pod, 0, " Suffix")
+	logs, err := GetLogsForContainerInPod(context.TODO(), logPlugin, dummyTaskExecID(), pod, 0, " Suffix")
 	assert.Nil(t, err)
 	assert.Len(t, logs, 1)
 }
@@ -145,7 +166,7 @@ func TestGetLogsForContainerInPod_K8s(t *testing.T) {
 	}
 	pod.Name = podName
 
-	logs, err := GetLogsForContainerInPod(context.TODO(), logPlugin, pod, 0, " Suffix")
+	logs, err := GetLogsForContainerInPod(context.TODO(), logPlugin, dummyTaskExecID(), pod, 0, " Suffix")
 	assert.Nil(t, err)
 	assert.Len(t, logs, 1)
 }
@@ -178,7 +199,7 @@ func TestGetLogsForContainerInPod_All(t *testing.T) {
 	}
 	pod.Name = podName
 
-	logs, err := GetLogsForContainerInPod(context.TODO(), logPlugin, pod, 0, " Suffix")
+	logs, err := GetLogsForContainerInPod(context.TODO(), logPlugin, dummyTaskExecID(), pod, 0, " Suffix")
 	assert.Nil(t, err)
 	assert.Len(t, logs, 2)
 }
@@ -209,7 +230,7 @@ func TestGetLogsForContainerInPod_Stackdriver(t *testing.T) {
 	}
 	pod.Name = podName
 
-	logs, err := GetLogsForContainerInPod(context.TODO(), logPlugin, pod, 0, " Suffix")
+	logs, err := GetLogsForContainerInPod(context.TODO(), logPlugin, dummyTaskExecID(), pod, 0, " Suffix")
 	assert.Nil(t, err)
 	assert.Len(t, logs, 1)
 }
@@ -283,7 +304,7 @@ func assertTestSucceeded(tb testing.TB, config *LogConfig, expectedTaskLogs []*c
 		},
 	}
 
-	logs, err := GetLogsForContainerInPod(context.TODO(), logPlugin, pod, 0, " my-Suffix")
+	logs, err := GetLogsForContainerInPod(context.TODO(), logPlugin, dummyTaskExecID(), pod, 0, " my-Suffix")
 	assert.Nil(tb, err)
 	assert.Len(tb, logs, len(expectedTaskLogs))
 	if diff := deep.Equal(logs, expectedTaskLogs); len(diff) > 0 {
@@ -310,3 +331,253 @@ func TestGetLogsForContainerInPod_Templates(t *testing.T) {
 		},
 	})
 }
+
+func TestValidateTemplates(t *testing.T) {
+	err := validateTemplates(&LogConfig{
+		Templates: []TemplateLogPluginConfig{
+			{
+				DisplayName:   "StackDriver",
+				TemplateURIs:  []string{"https://my-log-server/{{ .namespace }"},
+				MessageFormat: core.TaskLog_JSON,
+			},
+		},
+	})
+	assert.Error(t, err)
+
+	t.Run("overrides", func(t *testing.T) {
+		cfg := &LogConfig{Overrides: overrides(t, `[
+			{"project": "p1", "config": {"templates": [{"templateUris": ["https://valid/{{ .podName }}"]}]}}
+		]`)}
+		assert.NoError(t, validateTemplates(cfg))
+
+		cfg.Overrides = append(cfg.Overrides, overrides(t, `[
+			{"project": "p2", "config": {"datadog": {"query": "pod:{{ .podName }"}}}
+		]`)...)
+		assert.EqualError(t, validateTemplates(cfg), "invalid log config override [1]: "+
+			tasklog.ValidateTemplateURIs([]string{"pod:{{ .podName }"}).Error())
+
+		cfg.Overrides = overrides(t, `[{"config": {"datadog": "not an object"}}]`)
+		assert.Error(t, validateTemplates(cfg))
+	})
+}
+
+func TestInitializeLogPlugins_InvalidTemplates(t *testing.T) {
+	_, err := InitializeLogPlugins(&LogConfig{
+		Templates: []TemplateLogPluginConfig{{TemplateURIs: []string{"https://my-log-server/{{ .namespace }"}}},
+	})
+	assert.Error(t, err)
+
+	_, err = InitializeLogPlugins(&LogConfig{
+		Overrides: overrides(t, `[{"config": {"kubernetes-template-uri": "https://k8s/{{ .podName"}}]`),
+	})
+	assert.Error(t, err)
+}
+
+func TestGetLogsForContainerInPod_TaskExecutionIdentity(t *testing.T) {
+	assertTestSucceeded(t, &LogConfig{
+		Templates: []TemplateLogPluginConfig{
+			{
+				DisplayName: "Datadog",
+				TemplateURIs: []string{
+					"https://app.datadoghq.com/logs?query=project%3A{{ .project }}%20domain%3A{{ .domain }}%20execution%3A{{ .executionName }}%20node%3A{{ .nodeId }}%20attempt%3A{{ .taskRetryAttempt }}",
+				},
+				MessageFormat: core.TaskLog_JSON,
+			},
+		},
+	}, []*core.TaskLog{
+		{
+			Uri:           "https://app.datadoghq.com/logs?query=project%3Amy-execution-project%20domain%3Amy-execution-domain%20execution%3Amy-execution-name%20node%3An0%20attempt%3A1",
+			MessageFormat: core.TaskLog_JSON,
+			Name:          "Datadog my-Suffix",
+		},
+	})
+}
+
+type templateVarsTaskExecID struct {
+	*pluginsCoreMock.TaskExecutionID
+}
+
+func (templateVarsTaskExecID) GetLogTemplateVars() map[string]string {
+	return map[string]string{"subtaskRetryAttempt": "2"}
+}
+
+func TestGetLogsForContainersInPod_ExtraTemplateVars(t *testing.T) {
+	logPlugin, err := InitializeLogPlugins(&LogConfig{
+		Templates: []TemplateLogPluginConfig{
+			{
+				DisplayName:   "Datadog",
+				TemplateURIs:  []string{"https://app.datadoghq.com/logs?attempt={{ .taskRetryAttempt }}-{{ .subtaskRetryAttempt }}"},
+				MessageFormat: core.TaskLog_JSON,
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	l, err := GetLogsForContainersInPod(context.TODO(), logPlugin, templateVarsTaskExecID{dummyTaskExecID()},
+		multiContainerPod(), "main", " my-Suffix")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, l)
+	assert.Equal(t, "https://app.datadoghq.com/logs?attempt=1-2", l[0].Uri)
+}
+
+func multiContainerPod() *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: v12.ObjectMeta{
+			Namespace: "my-namespace",
+			Name:      "my-pod",
+		},
+		Spec: v1.PodSpec{
+			InitContainers: []v1.Container{{Name: "downloader"}},
+			Containers:     []v1.Container{{Name: "uploader"}, {Name: "main"}, {Name: "pending"}},
+		},
+		Status: v1.PodStatus{
+			InitContainerStatuses: []v1.ContainerStatus{{Name: "downloader", ContainerID: "docker://d"}},
+			ContainerStatuses: []v1.ContainerStatus{
+				{Name: "main", ContainerID: "docker://m"},
+				{Name: "uploader", ContainerID: "docker://u"},
+			},
+		},
+	}
+}
+
+func TestGetLogsForContainersInPod(t *testing.T) {
+	templates := []TemplateLogPluginConfig{
+		{
+			DisplayName:  "Logs",
+			TemplateURIs: []string{"https://my-log-server/{{ .podName }}/{{ .containerName }}/{{ .containerId }}?role={{ .containerRole }}"},
+		},
+	}
+
+	t.Run("all roles", func(t *testing.T) {
+		logPlugin, err := InitializeLogPlugins(&LogConfig{Templates: templates})
+		assert.NoError(t, err)
+
+		logs, err := GetLogsForContainersInPod(context.TODO(), logPlugin, dummyTaskExecID(), multiContainerPod(), "main", " (User)")
+		assert.NoError(t, err)
+		assert.Equal(t, []*core.TaskLog{
+			{Uri: "https://my-log-server/my-pod/uploader/u?role=sidecar", Name: "Logs (User) [sidecar: uploader]"},
+			{Uri: "https://my-log-server/my-pod/main/m?role=primary", Name: "Logs (User)"},
+			{Uri: "https://my-log-server/my-pod/downloader/d?role=init", Name: "Logs (User) [init: downloader]"},
+		}, logs)
+	})
+
+	t.Run("default config", func(t *testing.T) {
+		cfg := DefaultConfig
+		cfg.IsKubernetesEnabled = false
+		cfg.Templates = templates
+		logPlugin, err := InitializeLogPlugins(&cfg)
+		assert.NoError(t, err)
+
+		logs, err := GetLogsForContainersInPod(context.TODO(), logPlugin, dummyTaskExecID(), multiContainerPod(), "main", " (User)")
+		assert.NoError(t, err)
+		assert.Equal(t, []*core.TaskLog{
+			{Uri: "https://my-log-server/my-pod/main/m?role=primary", Name: "Logs (User)"},
+		}, logs)
+	})
+
+	t.Run("first container is primary by default", func(t *testing.T) {
+		logPlugin, err := InitializeLogPlugins(&LogConfig{
+			Templates:             templates,
+			ExcludeContainerRoles: ContainerRolesConfig{Sidecar: true, Init: true},
+		})
+		assert.NoError(t, err)
+
+		logs, err := GetLogsForContainersInPod(context.TODO(), logPlugin, dummyTaskExecID(), multiContainerPod(), "", " (User)")
+		assert.NoError(t, err)
+		assert.Equal(t, []*core.TaskLog{
+			{Uri: "https://my-log-server/my-pod/uploader/u?role=primary", Name: "Logs (User)"},
+		}, logs)
+	})
+
+	t.Run("excluded roles", func(t *testing.T) {
+		logPlugin, err := InitializeLogPlugins(&LogConfig{
+			Templates:             templates,
+			ExcludeContainerRoles: ContainerRolesConfig{Primary: true, Init: true},
+		})
+		assert.NoError(t, err)
+
+		logs, err := GetLogsForContainersInPod(context.TODO(), logPlugin, dummyTaskExecID(), multiContainerPod(), "main", " (User)")
+		assert.NoError(t, err)
+		assert.Equal(t, []*core.TaskLog{
+			{Uri: "https://my-log-server/my-pod/uploader/u?role=sidecar", Name: "Logs (User) [sidecar: uploader]"},
+		}, logs)
+	})
+
+	t.Run("no plugins", func(t *testing.T) {
+		logs, err := GetLogsForContainersInPod(context.TODO(), nil, dummyTaskExecID(), multiContainerPod(), "main", " (User)")
+		assert.NoError(t, err)
+		assert.Nil(t, logs)
+	})
+}
+
+func TestGetLogsForPodByName(t *testing.T) {
+	logPlugin, err := InitializeLogPlugins(&LogConfig{Templates: []TemplateLogPluginConfig{
+		{
+			DisplayName:  "Logs",
+			TemplateURIs: []string{"https://my-log-server/{{ .podName }}/{{ .containerName }}/{{ .containerId }}?role={{ .containerRole }}"},
+		},
+	}})
+	assert.NoError(t, err)
+
+	reader := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(multiContainerPod()).Build()
+
+	t.Run("all containers of the pod", func(t *testing.T) {
+		logs, err := GetLogsForPodByName(context.TODO(), logPlugin, dummyTaskExecID(), reader, "my-namespace", "my-pod",
+			"main", " (User)")
+		assert.NoError(t, err)
+		assert.Equal(t, []*core.TaskLog{
+			{Uri: "https://my-log-server/my-pod/uploader/u?role=sidecar", Name: "Logs (User) [sidecar: uploader]"},
+			{Uri: "https://my-log-server/my-pod/main/m?role=primary", Name: "Logs (User)"},
+			{Uri: "https://my-log-server/my-pod/downloader/d?role=init", Name: "Logs (User) [init: downloader]"},
+		}, logs)
+	})
+
+	t.Run("pod not found", func(t *testing.T) {
+		logs, err := GetLogsForPodByName(context.TODO(), logPlugin, dummyTaskExecID(), reader, "my-namespace",
+			"other-pod", "main", " (User)")
+		assert.NoError(t, err)
+		assert.Equal(t, []*core.TaskLog{
+			{Uri: "https://my-log-server/other-pod/main/?role=primary", Name: "Logs (User)"},
+		}, logs)
+	})
+
+	t.Run("no reader", func(t *testing.T) {
+		logs, err := GetLogsForPodByName(context.TODO(), logPlugin, dummyTaskExecID(), nil, "my-namespace", "my-pod",
+			"main", " (User)")
+		assert.NoError(t, err)
+		assert.Equal(t, []*core.TaskLog{
+			{Uri: "https://my-log-server/my-pod/main/?role=primary", Name: "Logs (User)"},
+		}, logs)
+	})
+
+	t.Run("no plugins", func(t *testing.T) {
+		logs, err := GetLogsForPodByName(context.TODO(), nil, dummyTaskExecID(), reader, "my-namespace", "my-pod",
+			"main", " (User)")
+		assert.NoError(t, err)
+		assert.Nil(t, logs)
+	})
+}
+
+func TestInitializeLogPlugins_SignerKeySecret(t *testing.T) {
+	t.Setenv("_FSEC_LOGS_SIGNING_KEY", "my-key")
+	cfg := &LogConfig{
+		Templates: []TemplateLogPluginConfig{
+			{DisplayName: "Logs", TemplateURIs: []string{"https://my-log-server/{{ .podName }}"}},
+		},
+		Signer: tasklog.SignerConfig{Enabled: true, KeySecretName: "logs/signing_key"},
+	}
+
+	logPlugin, err := InitializeLogPlugins(cfg)
+	assert.NoError(t, err)
+
+	l, err := GetLogsForContainersInPod(context.TODO(), logPlugin, dummyTaskExecID(), multiContainerPod(), "main", "")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, l)
+	assert.Contains(t, l[0].Uri, "signature=")
+
+	t.Run("key is read once", func(t *testing.T) {
+		assert.NoError(t, os.Unsetenv("_FSEC_LOGS_SIGNING_KEY"))
+		_, err := InitializeLogPlugins(cfg)
+		assert.NoError(t, err)
+	})
+}
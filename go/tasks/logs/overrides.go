@@ -0,0 +1,216 @@
+package logs
+
+import (
+	"encoding/json"
+	"hash/fnv"
+	"sort"
+	"sync"
+
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+)
+
+// LogConfigOverlay is a partial LogConfig. Fields set in the overlay replace the ones of the config it's applied to,
+// nested sections (e.g. datadog) are merged field by field and lists (e.g. templates) are replaced as a whole.
+type LogConfigOverlay struct {
+	raw json.RawMessage
+}
+
+func (o *LogConfigOverlay) UnmarshalJSON(b []byte) error {
+	o.raw = append(json.RawMessage{}, b...)
+	return nil
+}
+
+func (o LogConfigOverlay) MarshalJSON() ([]byte, error) {
+	if len(o.raw) == 0 {
+		return []byte("{}"), nil
+	}
+
+	return o.raw, nil
+}
+
+// ApplyTo returns a copy of cfg with the overlay applied on top of it. cfg isn't modified.
+func (o LogConfigOverlay) ApplyTo(cfg LogConfig) (LogConfig, error) {
+	// Round-trip through json to deep copy cfg, so the overlay can't write to slices shared with cfg.
+	raw, err := json.Marshal(cfg)
+	if err != nil {
+		return LogConfig{}, err
+	}
+
+	res := LogConfig{}
+	if err = json.Unmarshal(raw, &res); err != nil {
+		return LogConfig{}, err
+	}
+
+	if len(o.raw) > 0 {
+		if err = json.Unmarshal(o.raw, &res); err != nil {
+			return LogConfig{}, err
+		}
+	}
+
+	return res, nil
+}
+
+// LogConfigOverride overlays a partial log config on top of the global one for tasks matching all of its (non-empty)
+// selectors.
+type LogConfigOverride struct {
+	// Plugins lists the ids of the plugins the override applies to. Plugins handling a single task type use the task
+	// type as their id (e.g. "spark", or "container_array" for the subtasks of k8s array tasks), all pod based tasks
+	// (container, sidecar and pod task types) use "pod" and web API plugins use their plugin id (e.g. "athena").
+	Plugins []string         `json:"plugins" pflag:",Ids of the plugins the override applies to. Applies to all plugins if empty."`
+	Project string           `json:"project" pflag:",Project the override applies to. Applies to all projects if empty."`
+	Domain  string           `json:"domain" pflag:",Domain the override applies to. Applies to all domains if empty."`
+	Config  LogConfigOverlay `json:"config" pflag:",Partial log config applied on top of the global one."`
+}
+
+func (o LogConfigOverride) matches(pluginID, project, domain string) bool {
+	if len(o.Project) > 0 && o.Project != project {
+		return false
+	}
+
+	if len(o.Domain) > 0 && o.Domain != domain {
+		return false
+	}
+
+	if len(o.Plugins) == 0 {
+		return true
+	}
+
+	for _, p := range o.Plugins {
+		if p == pluginID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// specificity ranks overrides so that the ones with more selectors are applied last. Project and domain rank higher
+// than plugins, so that a project override of all plugins wins over a global override of a single plugin.
+func (o LogConfigOverride) specificity() int {
+	s := 0
+	if len(o.Plugins) > 0 {
+		s++
+	}
+
+	if len(o.Domain) > 0 {
+		s += 2
+	}
+
+	if len(o.Project) > 0 {
+		s += 4
+	}
+
+	return s
+}
+
+// Resolve returns the log config for tasks handled by pluginID in the given project and domain. Matching overrides are
+// applied on top of c from the least to the most specific one (e.g. a project and domain override is applied after a
+// project one), overrides that are equally specific are applied in the order they are listed.
+func (c LogConfig) Resolve(pluginID, project, domain string) (*LogConfig, error) {
+	matching := make([]LogConfigOverride, 0, len(c.Overrides))
+	for _, o := range c.Overrides {
+		if o.matches(pluginID, project, domain) {
+			matching = append(matching, o)
+		}
+	}
+
+	if len(matching) == 0 {
+		return &c, nil
+	}
+
+	sort.SliceStable(matching, func(i, j int) bool {
+		return matching[i].specificity() < matching[j].specificity()
+	})
+
+	res := c
+	for _, o := range matching {
+		var err error
+		if res, err = o.Config.ApplyTo(res); err != nil {
+			return nil, err
+		}
+	}
+
+	res.Overrides = nil
+	return &res, nil
+}
+
+type resolveKey struct {
+	pluginID string
+	project  string
+	domain   string
+}
+
+// ConfigResolver caches the log configs resolved for the tasks of each plugin, project and domain, so that overrides
+// aren't applied every time log links are built. Resolved configs are cached until the config to resolve changes, which
+// is detected by hashing its contents. The zero value is ready to use.
+type ConfigResolver struct {
+	lock       sync.RWMutex
+	configHash uint64
+	resolved   map[resolveKey]*LogConfig
+}
+
+// hashConfig returns a hash of the contents of cfg.
+func hashConfig(cfg LogConfig) (uint64, error) {
+	raw, err := json.Marshal(cfg)
+	if err != nil {
+		return 0, err
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write(raw)
+	return h.Sum64(), nil
+}
+
+// Resolve returns cfg resolved for the tasks handled by pluginID in the given project and domain. The returned config
+// may be shared and must not be modified. See LogConfig.Resolve.
+func (r *ConfigResolver) Resolve(cfg LogConfig, pluginID, project, domain string) (*LogConfig, error) {
+	if len(cfg.Overrides) == 0 {
+		return &cfg, nil
+	}
+
+	configHash, err := hashConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	key := resolveKey{pluginID: pluginID, project: project, domain: domain}
+	r.lock.RLock()
+	res, found := r.resolved[key]
+	found = found && r.configHash == configHash
+	r.lock.RUnlock()
+	if found {
+		return res, nil
+	}
+
+	res, err = cfg.Resolve(pluginID, project, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.resolved == nil || r.configHash != configHash {
+		r.configHash = configHash
+		r.resolved = map[resolveKey]*LogConfig{}
+	}
+
+	r.resolved[key] = res
+	return res, nil
+}
+
+// ResolveForTask returns cfg resolved for the given plugin and the project and domain of the task execution. See
+// Resolve.
+func (r *ConfigResolver) ResolveForTask(cfg LogConfig, pluginID string,
+	taskExecMetadata pluginsCore.TaskExecutionMetadata) (*LogConfig, error) {
+	taskExecID := taskExecMetadata.GetTaskExecutionID().GetID()
+	execID := taskExecID.GetNodeExecutionId().GetExecutionId()
+	return r.Resolve(cfg, pluginID, execID.GetProject(), execID.GetDomain())
+}
+
+var globalConfigResolver ConfigResolver
+
+// GetLogConfigForTask returns the global log config resolved for the given plugin and the project and domain of the
+// task execution. The returned config may be shared and must not be modified. See LogConfig.Resolve.
+func GetLogConfigForTask(pluginID string, taskExecMetadata pluginsCore.TaskExecutionMetadata) (*LogConfig, error) {
+	return globalConfigResolver.ResolveForTask(*GetLogConfig(), pluginID, taskExecMetadata)
+}
@@ -0,0 +1,191 @@
+package logs
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	pluginsCoreMock "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tasklog"
+)
+
+func overrides(t *testing.T, raw string) []LogConfigOverride {
+	var res []LogConfigOverride
+	assert.NoError(t, json.Unmarshal([]byte(raw), &res))
+	return res
+}
+
+func TestLogConfig_Resolve(t *testing.T) {
+	cfg := LogConfig{
+		IsKubernetesEnabled: true,
+		KubernetesURL:       "k8s.com",
+		Datadog: DatadogConfig{
+			Site:  "datadoghq.com",
+			Query: "index:main",
+		},
+		Templates: []tasklog.TemplateLogPluginConfig{
+			{DisplayName: "global", TemplateURIs: []string{"https://global.com/{{ .podName }}"}},
+		},
+	}
+
+	cfg.Overrides = overrides(t, `[
+		{"project": "p1", "domain": "d1", "config": {"datadog": {"query": "index:p1-d1"}}},
+		{"project": "p1", "config": {"datadog": {"enabled": true, "query": "index:p1"}}},
+		{"plugins": ["dask", "ray"], "config": {"datadog": {"query": "index:distributed"}}},
+		{"domain": "d2", "config": {"templates": [{"displayName": "d2", "templateUris": ["https://d2.com"]}]}},
+		{"project": "p2", "config": {"kubernetes-enabled": false}}
+	]`)
+
+	t.Run("no match", func(t *testing.T) {
+		res, err := cfg.Resolve("pod", "p3", "d3")
+		assert.NoError(t, err)
+		assert.Equal(t, cfg, *res)
+	})
+
+	t.Run("plugin", func(t *testing.T) {
+		res, err := cfg.Resolve("dask", "p3", "d3")
+		assert.NoError(t, err)
+		assert.Equal(t, DatadogConfig{Site: "datadoghq.com", Query: "index:distributed"}, res.Datadog)
+		assert.True(t, res.IsKubernetesEnabled)
+		assert.Equal(t, "k8s.com", res.KubernetesURL)
+		assert.Equal(t, cfg.Templates, res.Templates)
+		assert.Nil(t, res.Overrides)
+	})
+
+	t.Run("project wins over plugin", func(t *testing.T) {
+		res, err := cfg.Resolve("ray", "p1", "d3")
+		assert.NoError(t, err)
+		assert.Equal(t, DatadogConfig{Enabled: true, Site: "datadoghq.com", Query: "index:p1"}, res.Datadog)
+	})
+
+	t.Run("project and domain win over project", func(t *testing.T) {
+		res, err := cfg.Resolve("pod", "p1", "d1")
+		assert.NoError(t, err)
+		assert.Equal(t, DatadogConfig{Enabled: true, Site: "datadoghq.com", Query: "index:p1-d1"}, res.Datadog)
+	})
+
+	t.Run("lists are replaced", func(t *testing.T) {
+		res, err := cfg.Resolve("pod", "p3", "d2")
+		assert.NoError(t, err)
+		assert.Equal(t, []tasklog.TemplateLogPluginConfig{
+			{DisplayName: "d2", TemplateURIs: []string{"https://d2.com"}},
+		}, res.Templates)
+	})
+
+	t.Run("disable", func(t *testing.T) {
+		res, err := cfg.Resolve("pod", "p2", "d1")
+		assert.NoError(t, err)
+		assert.False(t, res.IsKubernetesEnabled)
+		assert.Equal(t, "k8s.com", res.KubernetesURL)
+	})
+
+	t.Run("global config is not modified", func(t *testing.T) {
+		res, err := cfg.Resolve("pod", "p3", "d2")
+		assert.NoError(t, err)
+		res.Templates[0].DisplayName = "modified"
+		assert.Equal(t, "global", cfg.Templates[0].DisplayName)
+		assert.Equal(t, "index:main", cfg.Datadog.Query)
+		assert.Len(t, cfg.Overrides, 5)
+	})
+
+	t.Run("invalid overlay", func(t *testing.T) {
+		invalid := cfg
+		invalid.Overrides = overrides(t, `[{"config": {"datadog": "not an object"}}]`)
+		_, err := invalid.Resolve("pod", "p1", "d1")
+		assert.Error(t, err)
+	})
+}
+
+func TestLogConfigOverlay_MarshalJSON(t *testing.T) {
+	o := overrides(t, `[{"plugins": ["ray"], "config": {"datadog": {"query": "index:ray"}}}]`)
+	raw, err := json.Marshal(o)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[{"plugins": ["ray"], "project": "", "domain": "", "config": {"datadog": {"query": "index:ray"}}}]`,
+		string(raw))
+
+	raw, err = json.Marshal(LogConfigOverride{})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"plugins": null, "project": "", "domain": "", "config": {}}`, string(raw))
+}
+
+func TestGetLogConfigForTask(t *testing.T) {
+	assert.NoError(t, SetLogConfig(&LogConfig{
+		IsCloudwatchEnabled: true,
+		Overrides: overrides(t, `[
+			{"project": "my-execution-project", "domain": "my-execution-domain", "config": {"cloudwatch-region": "us-west-2"}},
+			{"project": "other-project", "config": {"cloudwatch-region": "eu-west-1"}}
+		]`),
+	}))
+	defer func() { assert.NoError(t, SetLogConfig(&DefaultConfig)) }()
+
+	taskExecMetadata := &pluginsCoreMock.TaskExecutionMetadata{}
+	taskExecMetadata.OnGetTaskExecutionID().Return(dummyTaskExecID())
+
+	cfg, err := GetLogConfigForTask("pod", taskExecMetadata)
+	assert.NoError(t, err)
+	assert.True(t, cfg.IsCloudwatchEnabled)
+	assert.Equal(t, "us-west-2", cfg.CloudwatchRegion)
+}
+
+func TestConfigResolver(t *testing.T) {
+	cfg := LogConfig{
+		CloudwatchRegion: "us-east-1",
+		Overrides:        overrides(t, `[{"project": "p1", "config": {"cloudwatch-region": "us-west-2"}}]`),
+	}
+
+	r := ConfigResolver{}
+	res, err := r.Resolve(cfg, "pod", "p1", "d1")
+	assert.NoError(t, err)
+	assert.Equal(t, "us-west-2", res.CloudwatchRegion)
+
+	t.Run("cached", func(t *testing.T) {
+		cached, err := r.Resolve(cfg, "pod", "p1", "d1")
+		assert.NoError(t, err)
+		assert.Same(t, res, cached)
+
+		other, err := r.Resolve(cfg, "pod", "p2", "d1")
+		assert.NoError(t, err)
+		assert.Equal(t, "us-east-1", other.CloudwatchRegion)
+	})
+
+	t.Run("updated config", func(t *testing.T) {
+		updated := cfg
+		updated.Overrides = overrides(t, `[{"project": "p1", "config": {"cloudwatch-region": "eu-west-1"}}]`)
+		res, err := r.Resolve(updated, "pod", "p1", "d1")
+		assert.NoError(t, err)
+		assert.Equal(t, "eu-west-1", res.CloudwatchRegion)
+	})
+
+	t.Run("updated base config", func(t *testing.T) {
+		other, err := r.Resolve(cfg, "pod", "p2", "d1")
+		assert.NoError(t, err)
+		assert.Equal(t, "us-east-1", other.CloudwatchRegion)
+
+		// The overrides are shared with the previous config, only the base config changed.
+		updated := cfg
+		updated.CloudwatchRegion = "us-east-2"
+		other, err = r.Resolve(updated, "pod", "p2", "d1")
+		assert.NoError(t, err)
+		assert.Equal(t, "us-east-2", other.CloudwatchRegion)
+	})
+
+	t.Run("overrides modified in place", func(t *testing.T) {
+		modified := cfg
+		modified.Overrides = overrides(t, `[{"project": "p1", "config": {"cloudwatch-region": "us-west-2"}}]`)
+		res, err := r.Resolve(modified, "pod", "p1", "d1")
+		assert.NoError(t, err)
+		assert.Equal(t, "us-west-2", res.CloudwatchRegion)
+
+		modified.Overrides[0] = overrides(t, `[{"project": "p1", "config": {"cloudwatch-region": "ap-south-1"}}]`)[0]
+		res, err = r.Resolve(modified, "pod", "p1", "d1")
+		assert.NoError(t, err)
+		assert.Equal(t, "ap-south-1", res.CloudwatchRegion)
+	})
+
+	t.Run("no overrides", func(t *testing.T) {
+		res, err := r.Resolve(LogConfig{CloudwatchRegion: "us-east-2"}, "pod", "p1", "d1")
+		assert.NoError(t, err)
+		assert.Equal(t, "us-east-2", res.CloudwatchRegion)
+	})
+}
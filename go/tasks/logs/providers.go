@@ -0,0 +1,173 @@
+package logs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tasklog"
+)
+
+// Default queries of the built-in log providers. They are templates that can use any of the variables documented in
+// tasklog.TemplateLogPlugin.
+const (
+	defaultLokiOrgID    = 1
+	defaultLokiQuery    = `{namespace="{{ .namespace }}", pod="{{ .podName }}", container="{{ .containerName }}"}`
+	defaultDatadogSite  = "datadoghq.com"
+	defaultDatadogQuery = "kube_namespace:{{ .namespace }} pod_name:{{ .podName }} kube_container_name:{{ .containerName }}"
+	defaultSplunkApp    = "search"
+	defaultSplunkQuery  = `search namespace="{{ .namespace }}" pod="{{ .podName }}" container_name="{{ .containerName }}"`
+	defaultKibanaQuery  = `kubernetes.namespace:"{{ .namespace }}" and kubernetes.pod.name:"{{ .podName }}" and kubernetes.container.name:"{{ .containerName }}"`
+)
+
+func stringOrDefault(val, def string) string {
+	if len(val) == 0 {
+		return def
+	}
+
+	return val
+}
+
+func unixMillis(unixTime int64) string {
+	return strconv.FormatInt(unixTime*1000, 10)
+}
+
+func singleTaskLog(uri string, input tasklog.Input) tasklog.Output {
+	return tasklog.Output{
+		TaskLogs: []*core.TaskLog{
+			{
+				Uri:           uri,
+				Name:          input.LogName,
+				MessageFormat: core.TaskLog_JSON,
+			},
+		},
+	}
+}
+
+// lokiLogPlugin builds links to Grafana Explore running a LogQL query against a Loki datasource.
+type lokiLogPlugin struct {
+	cfg LokiConfig
+}
+
+type grafanaExploreQuery struct {
+	RefID      string            `json:"refId"`
+	Expr       string            `json:"expr"`
+	Datasource map[string]string `json:"datasource,omitempty"`
+}
+
+type grafanaExploreState struct {
+	Datasource string                `json:"datasource,omitempty"`
+	Queries    []grafanaExploreQuery `json:"queries"`
+	Range      map[string]string     `json:"range"`
+}
+
+func (p lokiLogPlugin) GetTaskLogs(input tasklog.Input) (tasklog.Output, error) {
+	query, err := tasklog.RenderTemplate(stringOrDefault(p.cfg.Query, defaultLokiQuery), input)
+	if err != nil {
+		return tasklog.Output{}, err
+	}
+
+	state := grafanaExploreState{
+		Datasource: p.cfg.DatasourceUID,
+		Queries:    []grafanaExploreQuery{{RefID: "A", Expr: query}},
+		Range: map[string]string{
+			"from": unixMillis(input.PodUnixStartTime),
+			"to":   unixMillis(input.PodUnixFinishTime),
+		},
+	}
+
+	if len(p.cfg.DatasourceUID) > 0 {
+		state.Queries[0].Datasource = map[string]string{"type": "loki", "uid": p.cfg.DatasourceUID}
+	}
+
+	left, err := json.Marshal(state)
+	if err != nil {
+		return tasklog.Output{}, err
+	}
+
+	orgID := p.cfg.OrgID
+	if orgID == 0 {
+		orgID = defaultLokiOrgID
+	}
+
+	params := url.Values{}
+	params.Set("orgId", strconv.Itoa(orgID))
+	params.Set("left", string(left))
+	return singleTaskLog(fmt.Sprintf("%s/explore?%s", strings.TrimSuffix(p.cfg.GrafanaURL, "/"), params.Encode()), input), nil
+}
+
+// datadogLogPlugin builds links to the Datadog Log Explorer.
+type datadogLogPlugin struct {
+	cfg DatadogConfig
+}
+
+func (p datadogLogPlugin) GetTaskLogs(input tasklog.Input) (tasklog.Output, error) {
+	query, err := tasklog.RenderTemplate(stringOrDefault(p.cfg.Query, defaultDatadogQuery), input)
+	if err != nil {
+		return tasklog.Output{}, err
+	}
+
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("from_ts", unixMillis(input.PodUnixStartTime))
+	params.Set("to_ts", unixMillis(input.PodUnixFinishTime))
+	params.Set("live", "false")
+	return singleTaskLog(fmt.Sprintf("https://app.%s/logs?%s", stringOrDefault(p.cfg.Site, defaultDatadogSite),
+		params.Encode()), input), nil
+}
+
+// splunkLogPlugin builds links to a Splunk search.
+type splunkLogPlugin struct {
+	cfg SplunkConfig
+}
+
+func (p splunkLogPlugin) GetTaskLogs(input tasklog.Input) (tasklog.Output, error) {
+	query, err := tasklog.RenderTemplate(stringOrDefault(p.cfg.Query, defaultSplunkQuery), input)
+	if err != nil {
+		return tasklog.Output{}, err
+	}
+
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("earliest", strconv.FormatInt(input.PodUnixStartTime, 10))
+	params.Set("latest", strconv.FormatInt(input.PodUnixFinishTime, 10))
+	return singleTaskLog(fmt.Sprintf("%s/app/%s/search?%s", strings.TrimSuffix(p.cfg.URL, "/"),
+		url.PathEscape(stringOrDefault(p.cfg.App, defaultSplunkApp)), params.Encode()), input), nil
+}
+
+// kibanaLogPlugin builds links to Kibana Discover. Kibana encodes its state using rison.
+type kibanaLogPlugin struct {
+	cfg KibanaConfig
+}
+
+var risonStringReplacer = strings.NewReplacer("!", "!!", "'", "!'")
+
+// risonString encodes s as a rison string literal.
+func risonString(s string) string {
+	return "'" + risonStringReplacer.Replace(s) + "'"
+}
+
+func (p kibanaLogPlugin) GetTaskLogs(input tasklog.Input) (tasklog.Output, error) {
+	query, err := tasklog.RenderTemplate(stringOrDefault(p.cfg.Query, defaultKibanaQuery), input)
+	if err != nil {
+		return tasklog.Output{}, err
+	}
+
+	globalState := fmt.Sprintf("(time:(from:%s,to:%s))",
+		risonString(time.Unix(input.PodUnixStartTime, 0).UTC().Format(time.RFC3339)),
+		risonString(time.Unix(input.PodUnixFinishTime, 0).UTC().Format(time.RFC3339)))
+
+	appState := fmt.Sprintf("(query:(language:kuery,query:%s))", risonString(query))
+	if len(p.cfg.DataView) > 0 {
+		appState = fmt.Sprintf("(index:%s,query:(language:kuery,query:%s))", risonString(p.cfg.DataView),
+			risonString(query))
+	}
+
+	return singleTaskLog(fmt.Sprintf("%s/app/discover#/?_g=%s&_a=%s", strings.TrimSuffix(p.cfg.URL, "/"),
+		url.QueryEscape(globalState), url.QueryEscape(appState)), input), nil
+}
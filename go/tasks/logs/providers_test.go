@@ -0,0 +1,129 @@
+package logs
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tasklog"
+)
+
+func dummyLogInput() tasklog.Input {
+	return tasklog.Input{
+		PodName:           "my-pod",
+		Namespace:         "my-namespace",
+		ContainerName:     "main",
+		LogName:           "Logs",
+		PodUnixStartTime:  1426349294,
+		PodUnixFinishTime: 1623782877,
+	}
+}
+
+func assertSingleLog(t testing.TB, o tasklog.Output, err error, expectedURI string) {
+	assert.NoError(t, err)
+	if assert.Len(t, o.TaskLogs, 1) {
+		assert.Equal(t, expectedURI, o.TaskLogs[0].Uri)
+		assert.Equal(t, "Logs", o.TaskLogs[0].Name)
+		assert.Equal(t, core.TaskLog_JSON, o.TaskLogs[0].MessageFormat)
+	}
+}
+
+func TestLokiLogPlugin(t *testing.T) {
+	o, err := lokiLogPlugin{cfg: LokiConfig{
+		GrafanaURL:    "https://grafana.example.com/",
+		DatasourceUID: "loki-uid",
+	}}.GetTaskLogs(dummyLogInput())
+
+	assertSingleLog(t, o, err, "https://grafana.example.com/explore?left="+url.QueryEscape(
+		`{"datasource":"loki-uid","queries":[{"refId":"A","expr":"{namespace=\"my-namespace\", pod=\"my-pod\", container=\"main\"}","datasource":{"type":"loki","uid":"loki-uid"}}],"range":{"from":"1426349294000","to":"1623782877000"}}`)+
+		"&orgId=1")
+}
+
+func TestDatadogLogPlugin(t *testing.T) {
+	o, err := datadogLogPlugin{cfg: DatadogConfig{
+		Site:  "datadoghq.eu",
+		Query: "pod_name:{{ .podName }}",
+	}}.GetTaskLogs(dummyLogInput())
+
+	assertSingleLog(t, o, err,
+		"https://app.datadoghq.eu/logs?from_ts=1426349294000&live=false&query=pod_name%3Amy-pod&to_ts=1623782877000")
+}
+
+func TestSplunkLogPlugin(t *testing.T) {
+	o, err := splunkLogPlugin{cfg: SplunkConfig{
+		URL: "https://splunk.example.com",
+	}}.GetTaskLogs(dummyLogInput())
+
+	assertSingleLog(t, o, err, "https://splunk.example.com/app/search/search?earliest=1426349294&latest=1623782877&q="+
+		url.QueryEscape(`search namespace="my-namespace" pod="my-pod" container_name="main"`))
+}
+
+func TestKibanaLogPlugin(t *testing.T) {
+	t.Run("default data view", func(t *testing.T) {
+		o, err := kibanaLogPlugin{cfg: KibanaConfig{
+			URL:   "https://kibana.example.com",
+			Query: "kubernetes.pod.name:'{{ .podName }}'",
+		}}.GetTaskLogs(dummyLogInput())
+
+		assertSingleLog(t, o, err, "https://kibana.example.com/app/discover#/?_g="+
+			url.QueryEscape("(time:(from:'2015-03-14T16:08:14Z',to:'2021-06-15T18:47:57Z'))")+"&_a="+
+			url.QueryEscape("(query:(language:kuery,query:'kubernetes.pod.name:!'my-pod!''))"))
+	})
+
+	t.Run("data view", func(t *testing.T) {
+		o, err := kibanaLogPlugin{cfg: KibanaConfig{
+			URL:      "https://kibana.example.com",
+			DataView: "logs-*",
+			Query:    "{{ .podName }}",
+		}}.GetTaskLogs(dummyLogInput())
+
+		assertSingleLog(t, o, err, "https://kibana.example.com/app/discover#/?_g="+
+			url.QueryEscape("(time:(from:'2015-03-14T16:08:14Z',to:'2021-06-15T18:47:57Z'))")+"&_a="+
+			url.QueryEscape("(index:'logs-*',query:(language:kuery,query:'my-pod'))"))
+	})
+}
+
+func TestInitializeLogPlugins_Providers(t *testing.T) {
+	cfg := DefaultConfig
+	cfg.IsKubernetesEnabled = false
+	cfg.Loki.Enabled = true
+	cfg.Datadog.Enabled = true
+	cfg.Splunk.Enabled = true
+	cfg.Kibana.Enabled = true
+
+	p, err := InitializeLogPlugins(&cfg)
+	assert.NoError(t, err)
+
+	input := dummyLogInput()
+	input.LogName = ""
+	o, err := p.GetTaskLogs(input)
+	assert.NoError(t, err)
+	names := make([]string, 0, len(o.TaskLogs))
+	for _, l := range o.TaskLogs {
+		names = append(names, l.Name)
+	}
+
+	assert.Equal(t, []string{"Loki Logs", "Datadog Logs", "Splunk Logs", "Kibana Logs"}, names)
+}
+
+func TestInitializeLogPlugins_Signer(t *testing.T) {
+	cfg := DefaultConfig
+	cfg.Datadog.Enabled = true
+	cfg.Signer = tasklog.SignerConfig{Enabled: true, Key: "secret"}
+
+	p, err := InitializeLogPlugins(&cfg)
+	assert.NoError(t, err)
+
+	o, err := p.GetTaskLogs(dummyLogInput())
+	assert.NoError(t, err)
+	assert.Len(t, o.TaskLogs, 2)
+	for _, l := range o.TaskLogs {
+		assert.Regexp(t, `[?&]expires=\d+&signature=[\w-]+(#|$)`, l.Uri)
+	}
+
+	cfg.Signer = tasklog.SignerConfig{Enabled: true, KeySecretName: "log-key"}
+	_, err = InitializeLogPlugins(&cfg)
+	assert.Error(t, err)
+}
@@ -0,0 +1,125 @@
+// Package audit records the external side effects plugins have on behalf of users (e.g. creating kubernetes objects,
+// creating and deleting resources in remote services, writing to catalog or storage) for compliance. Records are sent
+// to a Sink, GetSink returns the one configured. Secrets are redacted from the targets and errors of records before
+// they reach the configured sink.
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/logger"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+)
+
+// Action is the kind of side effect recorded.
+type Action string
+
+const (
+	ActionK8sCreate     Action = "k8s.create"
+	ActionK8sDelete     Action = "k8s.delete"
+	ActionWebAPICreate  Action = "webapi.create"
+	ActionWebAPIDelete  Action = "webapi.delete"
+	ActionCatalogPut    Action = "catalog.put"
+	ActionCatalogUpdate Action = "catalog.update"
+	// ActionCatalogUpload is an upload to catalog enqueued through a catalog.AsyncClient. The write itself happens later,
+	// outside of the plugin call.
+	ActionCatalogUpload Action = "catalog.upload"
+	ActionStorageWrite  Action = "storage.write"
+	ActionStorageCopy   Action = "storage.copy"
+)
+
+// Outcome tells whether the side effect succeeded.
+type Outcome string
+
+const (
+	OutcomeSuccess Outcome = "success"
+	OutcomeFailure Outcome = "failure"
+)
+
+// Task identifies the task execution a side effect is taken on behalf of.
+type Task struct {
+	// ExecutionID is the generated name of the task execution.
+	ExecutionID string `json:"taskExecutionId,omitempty"`
+	Project     string `json:"project,omitempty"`
+	Domain      string `json:"domain,omitempty"`
+	// Principal is the identity the task runs as: its IAM role or kubernetes service account.
+	Principal string `json:"principal,omitempty"`
+	PluginID  string `json:"plugin,omitempty"`
+}
+
+// TaskFromMetadata returns the identity of the task execution of the plugin.
+func TaskFromMetadata(pluginID string, taskExecMetadata core.TaskExecutionMetadata) Task {
+	taskExecID := taskExecMetadata.GetTaskExecutionID()
+	execID := taskExecID.GetID().NodeExecutionId.GetExecutionId()
+	runAs := taskExecMetadata.GetSecurityContext().RunAs
+	principal := runAs.GetIamRole()
+	if len(principal) == 0 {
+		principal = runAs.GetK8SServiceAccount()
+	}
+
+	if len(principal) == 0 {
+		principal = taskExecMetadata.GetK8sServiceAccount()
+	}
+
+	return Task{
+		ExecutionID: taskExecID.GetGeneratedName(),
+		Project:     execID.GetProject(),
+		Domain:      execID.GetDomain(),
+		Principal:   principal,
+		PluginID:    pluginID,
+	}
+}
+
+type taskKey struct{}
+
+// WithTask returns a context carrying the identity of the task, for the side effects recorded with the context. The
+// audit interceptor sets it for all plugin calls.
+func WithTask(ctx context.Context, task Task) context.Context {
+	return context.WithValue(ctx, taskKey{}, task)
+}
+
+// TaskFromContext returns the identity of the task set by WithTask, or an empty one.
+func TaskFromContext(ctx context.Context) Task {
+	task, _ := ctx.Value(taskKey{}).(Task)
+	return task
+}
+
+// Record is a side effect taken on behalf of a task.
+type Record struct {
+	Time time.Time `json:"time"`
+	Task
+	Action Action `json:"action"`
+	// Target is what the action was taken on, e.g. the kind, namespace and name of a kubernetes object.
+	Target  string  `json:"target"`
+	Outcome Outcome `json:"outcome"`
+	// Error is the message of the error the action failed with.
+	Error string `json:"error,omitempty"`
+}
+
+// Sink stores records. Sinks must be safe for concurrent use.
+type Sink interface {
+	Record(ctx context.Context, record Record) error
+}
+
+// Log records that the action was taken on target on behalf of the task set in ctx, and failed if err isn't nil.
+// Failing to record is logged, it doesn't fail the action.
+func Log(ctx context.Context, sink Sink, action Action, target string, err error) {
+	record := Record{
+		Time:    time.Now(),
+		Task:    TaskFromContext(ctx),
+		Action:  action,
+		Target:  target,
+		Outcome: OutcomeSuccess,
+	}
+
+	if err != nil {
+		record.Outcome = OutcomeFailure
+		record.Error = err.Error()
+	}
+
+	if recordErr := sink.Record(ctx, record); recordErr != nil {
+		logger.Errorf(ctx, "Failed to record [%v] of [%v] in the audit log. Error: %v", action, target, recordErr)
+	}
+}
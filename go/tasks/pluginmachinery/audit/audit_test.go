@@ -0,0 +1,77 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+
+	coreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+)
+
+func dummyTaskExecMetadata(runAs *idlCore.Identity, serviceAccount string) *coreMocks.TaskExecutionMetadata {
+	tID := &coreMocks.TaskExecutionID{}
+	tID.OnGetID().Return(idlCore.TaskExecutionIdentifier{
+		NodeExecutionId: &idlCore.NodeExecutionIdentifier{
+			NodeId: "n0",
+			ExecutionId: &idlCore.WorkflowExecutionIdentifier{
+				Project: "flytesnacks",
+				Domain:  "development",
+				Name:    "exec-name",
+			},
+		},
+	})
+	tID.OnGetGeneratedName().Return("exec-name-n0-0")
+
+	tMeta := &coreMocks.TaskExecutionMetadata{}
+	tMeta.OnGetTaskExecutionID().Return(tID)
+	tMeta.OnGetSecurityContext().Return(idlCore.SecurityContext{RunAs: runAs})
+	tMeta.OnGetK8sServiceAccount().Return(serviceAccount)
+	return tMeta
+}
+
+func TestTaskFromMetadata(t *testing.T) {
+	expected := Task{
+		ExecutionID: "exec-name-n0-0",
+		Project:     "flytesnacks",
+		Domain:      "development",
+		Principal:   "arn:aws:iam::123:role/flyte",
+		PluginID:    "my-plugin",
+	}
+
+	assert.Equal(t, expected, TaskFromMetadata("my-plugin", dummyTaskExecMetadata(
+		&idlCore.Identity{IamRole: "arn:aws:iam::123:role/flyte", K8SServiceAccount: "run-as"}, "default")))
+
+	expected.Principal = "run-as"
+	assert.Equal(t, expected, TaskFromMetadata("my-plugin", dummyTaskExecMetadata(
+		&idlCore.Identity{K8SServiceAccount: "run-as"}, "default")))
+
+	expected.Principal = "default"
+	assert.Equal(t, expected, TaskFromMetadata("my-plugin", dummyTaskExecMetadata(nil, "default")))
+}
+
+func TestLog(t *testing.T) {
+	sink := NewMemorySink()
+	task := Task{ExecutionID: "exec-name-n0-0", Project: "flytesnacks", PluginID: "my-plugin"}
+	ctx := WithTask(context.Background(), task)
+	assert.Equal(t, task, TaskFromContext(ctx))
+	assert.Equal(t, Task{}, TaskFromContext(context.Background()))
+
+	Log(ctx, sink, ActionWebAPICreate, "job-1", nil)
+	Log(ctx, sink, ActionWebAPIDelete, "job-1", fmt.Errorf("not found"))
+
+	records := sink.Records()
+	if assert.Len(t, records, 2) {
+		assert.False(t, records[0].Time.IsZero())
+		assert.Equal(t, task, records[0].Task)
+		assert.Equal(t, ActionWebAPICreate, records[0].Action)
+		assert.Equal(t, "job-1", records[0].Target)
+		assert.Equal(t, OutcomeSuccess, records[0].Outcome)
+		assert.Empty(t, records[0].Error)
+
+		assert.Equal(t, OutcomeFailure, records[1].Outcome)
+		assert.Equal(t, "not found", records[1].Error)
+	}
+}
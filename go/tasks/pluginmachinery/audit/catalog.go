@@ -0,0 +1,69 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/catalog"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io"
+)
+
+type auditedCatalogClient struct {
+	catalog.Client
+	sink Sink
+}
+
+// catalogTarget identifies the artifact of key as task@cacheVersion.
+func catalogTarget(key catalog.Key) string {
+	return fmt.Sprintf("%v@%v", key.Identifier.String(), key.CacheVersion)
+}
+
+func (c auditedCatalogClient) Put(ctx context.Context, key catalog.Key, reader io.OutputReader,
+	metadata catalog.Metadata) (catalog.Status, error) {
+	status, err := c.Client.Put(ctx, key, reader, metadata)
+	Log(ctx, c.sink, ActionCatalogPut, catalogTarget(key), err)
+	return status, err
+}
+
+func (c auditedCatalogClient) Update(ctx context.Context, key catalog.Key, reader io.OutputReader,
+	metadata catalog.Metadata) (catalog.Status, error) {
+	status, err := c.Client.Update(ctx, key, reader, metadata)
+	Log(ctx, c.sink, ActionCatalogUpdate, catalogTarget(key), err)
+	return status, err
+}
+
+// NewCatalogClient wraps c so that its Put and Update calls are recorded in sink, on behalf of the task set in their
+// context by WithTask. Plugins write to catalog through the catalog.AsyncClient of their task execution context instead,
+// which NewTaskExecutionContext wraps using NewAsyncCatalogClient.
+func NewCatalogClient(c catalog.Client, sink Sink) catalog.Client {
+	if c == nil {
+		return nil
+	}
+
+	return auditedCatalogClient{Client: c, sink: sink}
+}
+
+type auditedAsyncCatalogClient struct {
+	catalog.AsyncClient
+	sink Sink
+}
+
+func (c auditedAsyncCatalogClient) Upload(ctx context.Context, requests ...catalog.UploadRequest) (
+	catalog.UploadFuture, error) {
+	future, err := c.AsyncClient.Upload(ctx, requests...)
+	for _, r := range requests {
+		Log(ctx, c.sink, ActionCatalogUpload, catalogTarget(r.Key), err)
+	}
+
+	return future, err
+}
+
+// NewAsyncCatalogClient wraps c so that the uploads enqueued through it are recorded in sink, on behalf of the task set
+// in their context by WithTask.
+func NewAsyncCatalogClient(c catalog.AsyncClient, sink Sink) catalog.AsyncClient {
+	if c == nil {
+		return nil
+	}
+
+	return auditedAsyncCatalogClient{AsyncClient: c, sink: sink}
+}
@@ -0,0 +1,68 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/catalog"
+	catalogMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/catalog/mocks"
+)
+
+func TestNewCatalogClient(t *testing.T) {
+	ctx := context.Background()
+	sink := NewMemorySink()
+	c := &catalogMocks.Client{}
+	c.OnPutMatch(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(catalog.Status{}, nil)
+	c.OnUpdateMatch(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(catalog.Status{},
+		fmt.Errorf("unavailable"))
+
+	key := catalog.Key{Identifier: idlCore.Identifier{Project: "flytesnacks", Name: "my-task"}, CacheVersion: "1.0"}
+	audited := NewCatalogClient(c, sink)
+	_, err := audited.Put(ctx, key, nil, catalog.Metadata{})
+	assert.NoError(t, err)
+	_, err = audited.Update(ctx, key, nil, catalog.Metadata{})
+	assert.Error(t, err)
+
+	records := sink.Records()
+	if assert.Len(t, records, 2) {
+		assert.Equal(t, ActionCatalogPut, records[0].Action)
+		assert.Equal(t, catalogTarget(key), records[0].Target)
+		assert.Contains(t, records[0].Target, "my-task")
+		assert.Equal(t, ActionCatalogUpdate, records[1].Action)
+		assert.Equal(t, OutcomeFailure, records[1].Outcome)
+	}
+
+	assert.Nil(t, NewCatalogClient(nil, sink))
+}
+
+func TestNewAsyncCatalogClient(t *testing.T) {
+	ctx := context.Background()
+	sink := NewMemorySink()
+	c := &catalogMocks.AsyncClient{}
+	c.OnUploadMatch(mock.Anything, mock.Anything, mock.Anything).Return(&catalogMocks.UploadFuture{}, nil)
+
+	keys := []catalog.Key{
+		{Identifier: idlCore.Identifier{Name: "my-task"}, CacheVersion: "1.0"},
+		{Identifier: idlCore.Identifier{Name: "my-task"}, CacheVersion: "2.0"},
+	}
+
+	audited := NewAsyncCatalogClient(c, sink)
+	_, err := audited.Upload(ctx, catalog.UploadRequest{Key: keys[0]}, catalog.UploadRequest{Key: keys[1]})
+	assert.NoError(t, err)
+
+	records := sink.Records()
+	if assert.Len(t, records, 2) {
+		for i, r := range records {
+			assert.Equal(t, ActionCatalogUpload, r.Action)
+			assert.Equal(t, catalogTarget(keys[i]), r.Target)
+			assert.Equal(t, OutcomeSuccess, r.Outcome)
+		}
+	}
+
+	assert.Nil(t, NewAsyncCatalogClient(nil, sink))
+}
@@ -0,0 +1,70 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// KubeClient mirrors pluginmachinery/core.KubeClient, which it's interchangeable with.
+type KubeClient interface {
+	GetClient() client.Client
+	GetCache() cache.Cache
+}
+
+type auditedKubeClient struct {
+	KubeClient
+	sink Sink
+}
+
+func (k auditedKubeClient) GetClient() client.Client {
+	return NewClient(k.KubeClient.GetClient(), k.sink)
+}
+
+// NewKubeClient wraps kubeClient so that the objects created and deleted through its client are recorded in sink. See
+// NewClient.
+func NewKubeClient(kubeClient KubeClient, sink Sink) KubeClient {
+	return auditedKubeClient{KubeClient: kubeClient, sink: sink}
+}
+
+type auditedClient struct {
+	client.Client
+	sink Sink
+}
+
+// target identifies obj as kind/namespace/name.
+func (c auditedClient) target(obj client.Object) string {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	if scheme := c.Scheme(); len(kind) == 0 && scheme != nil {
+		if gvk, err := apiutil.GVKForObject(obj, scheme); err == nil {
+			kind = gvk.Kind
+		}
+	}
+
+	return fmt.Sprintf("%v/%v/%v", kind, obj.GetNamespace(), obj.GetName())
+}
+
+func (c auditedClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	err := c.Client.Create(ctx, obj, opts...)
+	Log(ctx, c.sink, ActionK8sCreate, c.target(obj), err)
+	return err
+}
+
+func (c auditedClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	err := c.Client.Delete(ctx, obj, opts...)
+	Log(ctx, c.sink, ActionK8sDelete, c.target(obj), err)
+	return err
+}
+
+// NewClient wraps c so that its Create and Delete calls are recorded in sink, on behalf of the task set in their
+// context by WithTask.
+func NewClient(c client.Client, sink Sink) client.Client {
+	if c == nil {
+		return nil
+	}
+
+	return auditedClient{Client: c, sink: sink}
+}
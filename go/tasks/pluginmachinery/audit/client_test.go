@@ -0,0 +1,40 @@
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNewClient(t *testing.T) {
+	sink := NewMemorySink()
+	ctx := WithTask(context.Background(), Task{ExecutionID: "exec-name-n0-0"})
+	c := NewClient(fake.NewClientBuilder().WithScheme(scheme.Scheme).Build(), sink)
+
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "p1", Namespace: "ns"}}
+	assert.NoError(t, c.Create(ctx, pod))
+	assert.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(pod), &v1.Pod{}))
+	assert.NoError(t, c.Delete(ctx, pod))
+	assert.Error(t, c.Delete(ctx, pod))
+
+	records := sink.Records()
+	if assert.Len(t, records, 3) {
+		for _, r := range records {
+			assert.Equal(t, "exec-name-n0-0", r.ExecutionID)
+			assert.Equal(t, "Pod/ns/p1", r.Target)
+		}
+
+		assert.Equal(t, ActionK8sCreate, records[0].Action)
+		assert.Equal(t, ActionK8sDelete, records[1].Action)
+		assert.Equal(t, OutcomeSuccess, records[1].Outcome)
+		assert.Equal(t, OutcomeFailure, records[2].Outcome)
+	}
+
+	assert.Nil(t, NewClient(nil, sink))
+}
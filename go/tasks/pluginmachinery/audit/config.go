@@ -0,0 +1,43 @@
+package audit
+
+import (
+	"github.com/flyteorg/flyteplugins/go/tasks/config"
+)
+
+//go:generate pflags Config --default-var=defaultConfig
+
+// SinkType is the kind of sink records are sent to.
+type SinkType = string
+
+const (
+	// SinkTypeNone discards records.
+	SinkTypeNone SinkType = "none"
+	// SinkTypeFile appends records to a file, one JSON object per line.
+	SinkTypeFile SinkType = "file"
+	// SinkTypeMemory keeps records in memory.
+	SinkTypeMemory SinkType = "memory"
+)
+
+var (
+	defaultConfig = &Config{
+		Sink: SinkTypeNone,
+	}
+
+	cfgSection = config.MustRegisterSubSection("audit", defaultConfig)
+)
+
+// Config configures the audit log of the side effects plugins have.
+type Config struct {
+	Sink           SinkType `json:"sink" pflag:",Sink records are sent to: none, file or memory."`
+	File           string   `json:"file" pflag:",Path of the file the file sink appends records to."`
+	RedactPatterns []string `json:"redactPatterns" pflag:",Regular expressions matching secrets to redact from records, besides the ones redacted by default."`
+}
+
+func GetConfig() *Config {
+	return cfgSection.GetConfig().(*Config)
+}
+
+// SetConfig should be used for unit testing only
+func SetConfig(cfg *Config) error {
+	return cfgSection.SetConfig(cfg)
+}
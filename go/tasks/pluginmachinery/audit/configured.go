@@ -0,0 +1,104 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/flyteorg/flytestdlib/logger"
+)
+
+// NewSinkFromConfig creates the sink cfg configures. Secrets are redacted from the records sent to it.
+func NewSinkFromConfig(cfg *Config) (Sink, error) {
+	redactor, err := NewRedactor(cfg.RedactPatterns)
+	if err != nil {
+		return nil, err
+	}
+
+	var sink Sink
+	switch cfg.Sink {
+	case SinkTypeNone, "":
+		return NopSink, nil
+	case SinkTypeMemory:
+		sink = NewMemorySink()
+	case SinkTypeFile:
+		if len(cfg.File) == 0 {
+			return nil, fmt.Errorf("the file sink requires a file")
+		}
+
+		if sink, err = NewFileSink(cfg.File); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown sink type [%v], expected one of none, file or memory", cfg.Sink)
+	}
+
+	return NewRedactingSink(sink, redactor), nil
+}
+
+var (
+	// sinkLock guards sink and sinkCfg. Records are sent to sink while holding it for reading, so that the sink isn't
+	// closed while in use.
+	sinkLock sync.RWMutex
+	sinkCfg  *Config
+	sink     Sink
+)
+
+// configuredSink sends records to the sink of the current config.
+type configuredSink struct{}
+
+func (configuredSink) Record(ctx context.Context, record Record) error {
+	if err := reloadSink(); err != nil {
+		return err
+	}
+
+	sinkLock.RLock()
+	defer sinkLock.RUnlock()
+	return sink.Record(ctx, record)
+}
+
+// reloadSink creates the sink of the current config if it changed since the sink was last created, and closes the
+// previous one.
+func reloadSink() error {
+	cfg := GetConfig()
+	sinkLock.RLock()
+	current := cfg == sinkCfg
+	sinkLock.RUnlock()
+	if current {
+		return nil
+	}
+
+	sinkLock.Lock()
+	defer sinkLock.Unlock()
+	if cfg == sinkCfg {
+		return nil
+	}
+
+	s, err := NewSinkFromConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	if previous, ok := sink.(redactingSink); ok {
+		if c, ok := previous.Sink.(io.Closer); ok {
+			if err := c.Close(); err != nil {
+				logger.Warnf(context.TODO(), "Failed to close the previous audit sink. Error: %v", err)
+			}
+		}
+	}
+
+	sink, sinkCfg = s, cfg
+	return nil
+}
+
+// GetSink returns the sink shared by all plugins. It sends records to the sink of the current config, which is created
+// on first use and again whenever the config changes, so it can be kept for the lifetime of plugins. It fails if the
+// current config is invalid; records sent while the config is invalid fail the same way.
+func GetSink() (Sink, error) {
+	if err := reloadSink(); err != nil {
+		return nil, err
+	}
+
+	return configuredSink{}, nil
+}
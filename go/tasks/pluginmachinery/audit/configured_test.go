@@ -0,0 +1,79 @@
+package audit
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSinkFromConfig(t *testing.T) {
+	sink, err := NewSinkFromConfig(defaultConfig)
+	assert.NoError(t, err)
+	assert.Equal(t, NopSink, sink)
+
+	sink, err = NewSinkFromConfig(&Config{Sink: SinkTypeMemory, RedactPatterns: []string{"secret-[0-9]+"}})
+	assert.NoError(t, err)
+	assert.NoError(t, sink.Record(context.Background(), Record{Target: "job secret-123"}))
+	assert.Equal(t, []Record{{Target: "job [REDACTED]"}}, sink.(redactingSink).Sink.(*MemorySink).Records())
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	sink, err = NewSinkFromConfig(&Config{Sink: SinkTypeFile, File: path})
+	assert.NoError(t, err)
+	assert.NoError(t, sink.Record(context.Background(), Record{Target: "job"}))
+	assert.NoError(t, sink.(redactingSink).Sink.(*FileSink).Close())
+	_, err = os.Stat(path)
+	assert.NoError(t, err)
+
+	for _, cfg := range []*Config{
+		{Sink: SinkTypeFile},
+		{Sink: "kafka"},
+		{Sink: SinkTypeMemory, RedactPatterns: []string{"("}},
+	} {
+		_, err = NewSinkFromConfig(cfg)
+		assert.Error(t, err)
+	}
+}
+
+func TestGetSink(t *testing.T) {
+	defer func() { assert.NoError(t, SetConfig(defaultConfig)) }()
+
+	assert.NoError(t, SetConfig(&Config{Sink: SinkTypeMemory}))
+	sink, err := GetSink()
+	assert.NoError(t, err)
+	again, err := GetSink()
+	assert.NoError(t, err)
+	assert.True(t, sink == again)
+	assert.NoError(t, sink.Record(context.Background(), Record{Target: "job-1"}))
+	memory := currentSink(t).(*MemorySink)
+	assert.Equal(t, []Record{{Target: "job-1"}}, memory.Records())
+
+	// Sinks kept by plugins send records to the sink of the new config, the previous file sink is closed.
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	assert.NoError(t, SetConfig(&Config{Sink: SinkTypeFile, File: path}))
+	assert.NoError(t, sink.Record(context.Background(), Record{Target: "job-2"}))
+	file := currentSink(t).(*FileSink)
+	assert.Len(t, memory.Records(), 1)
+
+	assert.NoError(t, SetConfig(&Config{Sink: SinkTypeMemory}))
+	assert.NoError(t, sink.Record(context.Background(), Record{Target: "job-3"}))
+	assert.Error(t, file.Close())
+	raw, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(raw), "job-2")
+
+	assert.NoError(t, SetConfig(&Config{Sink: "kafka"}))
+	_, err = GetSink()
+	assert.Error(t, err)
+	assert.Error(t, sink.Record(context.Background(), Record{Target: "job-4"}))
+}
+
+func currentSink(t *testing.T) Sink {
+	sinkLock.RLock()
+	defer sinkLock.RUnlock()
+	s, ok := sink.(redactingSink)
+	assert.True(t, ok)
+	return s.Sink
+}
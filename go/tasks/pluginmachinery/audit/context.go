@@ -0,0 +1,32 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/flyteorg/flytestdlib/storage"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/catalog"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+)
+
+type taskExecutionContext struct {
+	core.TaskExecutionContext
+	sink Sink
+}
+
+func (c taskExecutionContext) Catalog() catalog.AsyncClient {
+	return NewAsyncCatalogClient(c.TaskExecutionContext.Catalog(), c.sink)
+}
+
+func (c taskExecutionContext) DataStore() *storage.DataStore {
+	return NewDataStore(c.TaskExecutionContext.DataStore(), c.sink)
+}
+
+// NewTaskExecutionContext wraps the catalog client and data store of tCtx so that the catalog uploads and the storage
+// writes and copies made through them are recorded in sink. It returns ctx carrying the identity of the task, to make
+// the calls with, along with the wrapped tCtx.
+func NewTaskExecutionContext(ctx context.Context, pluginID string, tCtx core.TaskExecutionContext, sink Sink) (
+	context.Context, core.TaskExecutionContext) {
+	return WithTask(ctx, TaskFromMetadata(pluginID, tCtx.TaskExecutionMetadata())),
+		taskExecutionContext{TaskExecutionContext: tCtx, sink: sink}
+}
@@ -0,0 +1,47 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/catalog"
+	catalogMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/catalog/mocks"
+	coreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+)
+
+func TestNewTaskExecutionContext(t *testing.T) {
+	sink := NewMemorySink()
+	ds, err := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+	assert.NoError(t, err)
+
+	c := &catalogMocks.AsyncClient{}
+	c.OnUploadMatch(mock.Anything, mock.Anything).Return(&catalogMocks.UploadFuture{}, nil)
+
+	tCtx := &coreMocks.TaskExecutionContext{}
+	tCtx.OnTaskExecutionMetadata().Return(dummyTaskExecMetadata(nil, "default"))
+	tCtx.OnCatalog().Return(c)
+	tCtx.OnDataStore().Return(ds)
+
+	ctx, audited := NewTaskExecutionContext(context.Background(), "my-plugin", tCtx, sink)
+	raw := []byte("hello")
+	assert.NoError(t, audited.DataStore().WriteRaw(ctx, "s3://bucket/raw", int64(len(raw)), storage.Options{},
+		bytes.NewReader(raw)))
+	_, err = audited.Catalog().Upload(ctx, catalog.UploadRequest{})
+	assert.NoError(t, err)
+
+	records := sink.Records()
+	if assert.Len(t, records, 2) {
+		assert.Equal(t, ActionStorageWrite, records[0].Action)
+		assert.Equal(t, ActionCatalogUpload, records[1].Action)
+		for _, r := range records {
+			assert.Equal(t, "exec-name-n0-0", r.ExecutionID)
+			assert.Equal(t, "my-plugin", r.PluginID)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+)
+
+// Redacted replaces the secrets redacted from records.
+const Redacted = "[REDACTED]"
+
+// defaultRedactions match bearer tokens, the values of parameters and headers commonly holding secrets, and the
+// passwords in URLs. The text matched by their groups is kept.
+var defaultRedactions = []redaction{
+	{regexp.MustCompile(`(?i)(bearer\s+)[^\s"',;]+`), "${1}" + Redacted},
+	{regexp.MustCompile(`(?i)((?:token|secret|password|passwd|api[_-]?key|credentials?|authorization)["']?\s*[:=]\s*["']?)[^\s"'&,;}]+`),
+		"${1}" + Redacted},
+	{regexp.MustCompile(`(://[^/:@\s]+:)[^/@\s]+(@)`), "${1}" + Redacted + "${2}"},
+}
+
+type redaction struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// Redactor redacts secrets from text.
+type Redactor struct {
+	redactions []redaction
+}
+
+// Redact returns s with the secrets it contains replaced by Redacted.
+func (r *Redactor) Redact(s string) string {
+	for _, red := range r.redactions {
+		s = red.pattern.ReplaceAllString(s, red.replacement)
+	}
+
+	return s
+}
+
+// NewRedactor creates a redactor replacing the matches of patterns, besides the secrets redacted by default. It fails
+// if a pattern is an invalid regular expression.
+func NewRedactor(patterns []string) (*Redactor, error) {
+	redactions := append([]redaction{}, defaultRedactions...)
+	for _, p := range patterns {
+		pattern, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redact pattern [%v]: %w", p, err)
+		}
+
+		redactions = append(redactions, redaction{pattern: pattern, replacement: Redacted})
+	}
+
+	return &Redactor{redactions: redactions}, nil
+}
+
+type redactingSink struct {
+	Sink
+	redactor *Redactor
+}
+
+func (s redactingSink) Record(ctx context.Context, record Record) error {
+	record.Target = s.redactor.Redact(record.Target)
+	record.Error = s.redactor.Redact(record.Error)
+	return s.Sink.Record(ctx, record)
+}
+
+// NewRedactingSink wraps sink so that secrets are redacted from the targets and errors of records.
+func NewRedactingSink(sink Sink, redactor *Redactor) Sink {
+	return redactingSink{Sink: sink, redactor: redactor}
+}
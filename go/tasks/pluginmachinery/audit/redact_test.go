@@ -0,0 +1,46 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactor_Redact(t *testing.T) {
+	r, err := NewRedactor([]string{`sk-[a-z0-9]+`})
+	assert.NoError(t, err)
+
+	for input, expected := range map[string]string{
+		"databricks/&{RunID:123}":                      "databricks/&{RunID:123}",
+		"https://host/api?token=abc123&run=1":          "https://host/api?token=[REDACTED]&run=1",
+		`{"password": "hunter2", "user": "me"}`:        `{"password": "[REDACTED]", "user": "me"}`,
+		"snowflake/&{QueryID:q1 Token:abc Account:a1}": "snowflake/&{QueryID:q1 Token:[REDACTED] Account:a1}",
+		"Authorization: Bearer eyJhbGciOi":             "Authorization: [REDACTED] [REDACTED]",
+		"api_key=xyz, api-key: xyz":                    "api_key=[REDACTED], api-key: [REDACTED]",
+		"s3://user:pa55@bucket/key":                    "s3://user:[REDACTED]@bucket/key",
+		"failed with key sk-0123abc":                   "failed with key [REDACTED]",
+	} {
+		t.Run(input, func(t *testing.T) {
+			assert.Equal(t, expected, r.Redact(input))
+		})
+	}
+
+	_, err = NewRedactor([]string{"("})
+	assert.Error(t, err)
+}
+
+func TestNewRedactingSink(t *testing.T) {
+	memory := NewMemorySink()
+	r, err := NewRedactor(nil)
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	Log(ctx, NewRedactingSink(memory, r), ActionWebAPICreate, "job?token=abc", fmt.Errorf("bad password=123"))
+	records := memory.Records()
+	if assert.Len(t, records, 1) {
+		assert.Equal(t, "job?token=[REDACTED]", records[0].Target)
+		assert.Equal(t, "bad password=[REDACTED]", records[0].Error)
+	}
+}
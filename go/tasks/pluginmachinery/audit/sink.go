@@ -0,0 +1,76 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+)
+
+type nopSink struct{}
+
+func (nopSink) Record(context.Context, Record) error {
+	return nil
+}
+
+// NopSink discards all records.
+var NopSink Sink = nopSink{}
+
+// MemorySink keeps records in memory, e.g. for tests or to inspect them through a debugging endpoint.
+type MemorySink struct {
+	lock    sync.Mutex
+	records []Record
+}
+
+func (s *MemorySink) Record(_ context.Context, record Record) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.records = append(s.records, record)
+	return nil
+}
+
+// Records returns a copy of the records kept so far.
+func (s *MemorySink) Records() []Record {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]Record(nil), s.records...)
+}
+
+// NewMemorySink creates an empty MemorySink.
+func NewMemorySink() *MemorySink {
+	return &MemorySink{}
+}
+
+// FileSink appends records to a file, one JSON object per line.
+type FileSink struct {
+	lock sync.Mutex
+	file *os.File
+}
+
+func (s *FileSink) Record(_ context.Context, record Record) error {
+	raw, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	_, err = s.file.Write(append(raw, '\n'))
+	return err
+}
+
+// Close closes the file.
+func (s *FileSink) Close() error {
+	return s.file.Close()
+}
+
+// NewFileSink creates a FileSink appending to the file at path, which is created, readable by its owner only, if it
+// doesn't exist.
+func NewFileSink(path string) (*FileSink, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileSink{file: file}, nil
+}
@@ -0,0 +1,60 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileSink(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	record := Record{
+		Time:    time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+		Task:    Task{ExecutionID: "exec-name-n0-0", Project: "flytesnacks", Principal: "default"},
+		Action:  ActionK8sCreate,
+		Target:  "Pod/ns/p1",
+		Outcome: OutcomeSuccess,
+	}
+
+	sink, err := NewFileSink(path)
+	assert.NoError(t, err)
+	assert.NoError(t, sink.Record(ctx, record))
+	assert.NoError(t, sink.Close())
+
+	// Records are appended to the existing file.
+	sink, err = NewFileSink(path)
+	assert.NoError(t, err)
+	assert.NoError(t, sink.Record(ctx, record))
+	assert.NoError(t, sink.Close())
+
+	raw, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(raw)), "\n")
+	assert.Len(t, lines, 2)
+	assert.JSONEq(t, `{"time": "2022-01-01T00:00:00Z", "taskExecutionId": "exec-name-n0-0", "project": "flytesnacks",
+		"principal": "default", "action": "k8s.create", "target": "Pod/ns/p1", "outcome": "success"}`, lines[0])
+
+	actual := Record{}
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &actual))
+	assert.Equal(t, record, actual)
+
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestMemorySink(t *testing.T) {
+	sink := NewMemorySink()
+	assert.Empty(t, sink.Records())
+	assert.NoError(t, sink.Record(context.Background(), Record{Target: "a"}))
+	records := sink.Records()
+	records[0].Target = "b"
+	assert.Equal(t, []Record{{Target: "a"}}, sink.Records())
+}
@@ -0,0 +1,47 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/golang/protobuf/proto"
+)
+
+type auditedProtobufStore struct {
+	storage.ComposedProtobufStore
+	sink Sink
+}
+
+func (s auditedProtobufStore) WriteRaw(ctx context.Context, reference storage.DataReference, size int64,
+	opts storage.Options, raw io.Reader) error {
+	err := s.ComposedProtobufStore.WriteRaw(ctx, reference, size, opts, raw)
+	Log(ctx, s.sink, ActionStorageWrite, string(reference), err)
+	return err
+}
+
+func (s auditedProtobufStore) WriteProtobuf(ctx context.Context, reference storage.DataReference, opts storage.Options,
+	msg proto.Message) error {
+	err := s.ComposedProtobufStore.WriteProtobuf(ctx, reference, opts, msg)
+	Log(ctx, s.sink, ActionStorageWrite, string(reference), err)
+	return err
+}
+
+func (s auditedProtobufStore) CopyRaw(ctx context.Context, source, destination storage.DataReference,
+	opts storage.Options) error {
+	err := s.ComposedProtobufStore.CopyRaw(ctx, source, destination, opts)
+	Log(ctx, s.sink, ActionStorageCopy, fmt.Sprintf("%v -> %v", source, destination), err)
+	return err
+}
+
+// NewDataStore wraps store so that the writes and copies made through it are recorded in sink, on behalf of the task
+// set in their context by WithTask.
+func NewDataStore(store *storage.DataStore, sink Sink) *storage.DataStore {
+	if store == nil {
+		return nil
+	}
+
+	return storage.NewCompositeDataStore(store.ReferenceConstructor,
+		auditedProtobufStore{ComposedProtobufStore: store.ComposedProtobufStore, sink: sink})
+}
@@ -0,0 +1,46 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/contextutils"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/promutils/labeled"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDataStore(t *testing.T) {
+	ctx := context.Background()
+	sink := NewMemorySink()
+	ds, err := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+	assert.NoError(t, err)
+
+	audited := NewDataStore(ds, sink)
+	raw := []byte("hello")
+	assert.NoError(t, audited.WriteRaw(ctx, "s3://bucket/raw", int64(len(raw)), storage.Options{}, bytes.NewReader(raw)))
+	assert.NoError(t, audited.WriteProtobuf(ctx, "s3://bucket/literals.pb", storage.Options{}, &idlCore.LiteralMap{}))
+	assert.NoError(t, audited.CopyRaw(ctx, "s3://bucket/raw", "s3://bucket/copy", storage.Options{}))
+
+	// Reads are not recorded.
+	_, err = audited.ReadRaw(ctx, "s3://bucket/copy")
+	assert.NoError(t, err)
+
+	records := sink.Records()
+	if assert.Len(t, records, 3) {
+		assert.Equal(t, Record{Time: records[0].Time, Action: ActionStorageWrite, Target: "s3://bucket/raw",
+			Outcome: OutcomeSuccess}, records[0])
+		assert.Equal(t, "s3://bucket/literals.pb", records[1].Target)
+		assert.Equal(t, ActionStorageCopy, records[2].Action)
+		assert.Equal(t, "s3://bucket/raw -> s3://bucket/copy", records[2].Target)
+	}
+
+	assert.Nil(t, NewDataStore(nil, sink))
+}
+
+func init() {
+	labeled.SetMetricKeys(contextutils.ExecIDKey)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/flyteorg/flytestdlib/logger"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tracing"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/workqueue"
 )
@@ -47,7 +48,19 @@ func (p ReaderProcessor) Process(ctx context.Context, workItem workqueue.WorkIte
 		return workqueue.WorkStatusNotDone, fmt.Errorf("wrong work item type. Received: %v", reflect.TypeOf(workItem))
 	}
 
+	ctx, span := tracing.StartSpan(ctx, "catalog.get", keyAttributes(wi.key)...)
 	op, err := p.catalogClient.Get(ctx, wi.key)
+	switch {
+	case err == nil:
+		span.SetAttributes(tracing.CatalogStatusKey.String(op.status.GetCacheStatus().String()))
+		tracing.EndSpan(span, nil)
+	case IsNotFound(err):
+		span.SetAttributes(tracing.CatalogStatusKey.String(core.CatalogCacheStatus_CACHE_MISS.String()))
+		tracing.EndSpan(span, nil)
+	default:
+		tracing.EndSpan(span, err)
+	}
+
 	if err != nil {
 		if IsNotFound(err) {
 			logger.Infof(ctx, "Artifact not found in Catalog. Key: %v", wi.key)
@@ -0,0 +1,15 @@
+package catalog
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tracing"
+)
+
+// keyAttributes returns the attributes annotating the spans of catalog calls for key.
+func keyAttributes(key Key) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		tracing.CatalogTaskKey.String(key.Identifier.GetName()),
+		tracing.CatalogVersionKey.String(key.CacheVersion),
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/flyteorg/flyteplugins/go/tasks/errors"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tracing"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/workqueue"
 )
 
@@ -38,7 +39,13 @@ func (p writerProcessor) Process(ctx context.Context, workItem workqueue.WorkIte
 		return workqueue.WorkStatusNotDone, fmt.Errorf("wrong work item type. Received: %v", reflect.TypeOf(workItem))
 	}
 
+	ctx, span := tracing.StartSpan(ctx, "catalog.put", keyAttributes(wi.key)...)
 	status, err := p.catalogClient.Put(ctx, wi.key, wi.data, wi.metadata)
+	if err == nil {
+		span.SetAttributes(tracing.CatalogStatusKey.String(status.GetCacheStatus().String()))
+	}
+
+	tracing.EndSpan(span, err)
 	if err != nil {
 		logger.Errorf(ctx, "Error putting to catalog [%s]", err)
 		return workqueue.WorkStatusNotDone, errors.Wrapf(errors.DownstreamSystemError, err,
@@ -5,12 +5,19 @@ import (
 	"time"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/golang/protobuf/proto"
 	structpb "github.com/golang/protobuf/ptypes/struct"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
 )
 
 const DefaultPhaseVersion = uint32(0)
 const SystemErrorCode = "SystemError"
 
+// SubReasonWaitingForResources is the sub-reason of tasks queued until the resources they need are allocated, e.g.
+// resource manager tokens.
+const SubReasonWaitingForResources = "WaitingForResources"
+
 //go:generate enumer -type=Phase
 
 type Phase int8
@@ -114,6 +121,10 @@ type PhaseInfo struct {
 	err *core.ExecutionError
 	// reason why the current phase exists.
 	reason string
+	// class of the failure, for failure phases.
+	class errors.Class
+	// subReason refines the phase, e.g. tells why the task is queued.
+	subReason string
 }
 
 func (p PhaseInfo) Phase() Phase {
@@ -124,10 +135,34 @@ func (p PhaseInfo) Version() uint32 {
 	return p.version
 }
 
+// WithVersion returns a copy of the phase info with the version set.
+func (p PhaseInfo) WithVersion(version uint32) PhaseInfo {
+	p.version = version
+	return p
+}
+
 func (p PhaseInfo) Reason() string {
 	return p.reason
 }
 
+// WithReason returns a copy of the phase info with the reason set.
+func (p PhaseInfo) WithReason(reason string) PhaseInfo {
+	p.reason = reason
+	return p
+}
+
+// SubReason returns the refinement of the phase, if any, e.g. why the task is queued or what it's initializing.
+func (p PhaseInfo) SubReason() string {
+	return p.subReason
+}
+
+// WithSubReason returns a copy of the phase info with the sub-reason set. Unlike reasons, sub-reasons are short values
+// from a small set (e.g. SubReasonWaitingForResources or the waiting reason of a container), as they label metrics.
+func (p PhaseInfo) WithSubReason(subReason string) PhaseInfo {
+	p.subReason = subReason
+	return p
+}
+
 func (p PhaseInfo) Info() *TaskInfo {
 	return p.info
 }
@@ -136,6 +171,65 @@ func (p PhaseInfo) Err() *core.ExecutionError {
 	return p.err
 }
 
+// Classification returns how a failure is classified. It's the zero value for other phases.
+func (p PhaseInfo) Classification() errors.Classification {
+	if !p.phase.IsFailure() {
+		return errors.Classification{}
+	}
+
+	return errors.Classification{Class: p.class, Retryable: p.phase == PhaseRetryableFailure}
+}
+
+// Classify applies the configured error classification rules matching target to a failure, which may change its
+// phase and the kind of its error. Other phases are returned as is.
+func (p PhaseInfo) Classify(target errors.Target) PhaseInfo {
+	if !p.phase.IsFailure() {
+		return p
+	}
+
+	return p.withClassification(errors.Classify(target, p.err.GetCode(), p.err.GetMessage(), p.Classification()))
+}
+
+func (p PhaseInfo) withClassification(c errors.Classification) PhaseInfo {
+	p.class = c.Class
+	p.phase = PhasePermanentFailure
+	if c.Retryable {
+		p.phase = PhaseRetryableFailure
+	}
+
+	// The error may be shared with the caller, e.g. when read from the plugin state, so it's copied before changing it.
+	if kind := errorKind(c.Class); p.err != nil && p.err.GetKind() != kind {
+		p.err = proto.Clone(p.err).(*core.ExecutionError)
+		p.err.Kind = kind
+	}
+
+	return p
+}
+
+// errorKind returns the kind of the errors of failures of the class.
+func errorKind(class errors.Class) core.ExecutionError_ErrorKind {
+	switch class {
+	case errors.ClassUnknown:
+		return core.ExecutionError_UNKNOWN
+	case errors.ClassUser:
+		return core.ExecutionError_USER
+	default:
+		return core.ExecutionError_SYSTEM
+	}
+}
+
+// errorClass returns the class of failures whose error is of the kind, when not classified otherwise.
+func errorClass(kind core.ExecutionError_ErrorKind) errors.Class {
+	switch kind {
+	case core.ExecutionError_USER:
+		return errors.ClassUser
+	case core.ExecutionError_SYSTEM:
+		return errors.ClassSystem
+	default:
+		return errors.ClassUnknown
+	}
+}
+
 func (p PhaseInfo) String() string {
 	if p.err != nil {
 		return fmt.Sprintf("Phase<%s:%d Error:%s>", p.phase, p.version, p.err)
@@ -202,6 +296,8 @@ func PhaseInfoInitializing(t time.Time, version uint32, reason string, info *Tas
 	return pi
 }
 
+// PhaseInfoFailed returns a failure with the error. The failure is classified by the kind of the error and whether the
+// phase is retryable, unless a configured error classification rule applies to it.
 func PhaseInfoFailed(p Phase, err *core.ExecutionError, info *TaskInfo) PhaseInfo {
 	if err == nil {
 		err = &core.ExecutionError{
@@ -209,7 +305,26 @@ func PhaseInfoFailed(p Phase, err *core.ExecutionError, info *TaskInfo) PhaseInf
 			Message: "Unknown error message",
 		}
 	}
-	return phaseInfo(p, DefaultPhaseVersion, err, info)
+
+	if !p.IsFailure() {
+		return phaseInfo(p, DefaultPhaseVersion, err, info)
+	}
+
+	return classifiedFailure(err, errors.Classification{
+		Class:     errorClass(err.GetKind()),
+		Retryable: p == PhaseRetryableFailure,
+	}, info)
+}
+
+// PhaseInfoClassifiedFailure returns a failure classified as c, unless a configured error classification rule applies
+// to it. Plugins should prefer it to the other failure helpers to report failures of classes other than user and
+// system, e.g. the classification of an HTTP status returned by errors.ClassifyHTTPStatus.
+func PhaseInfoClassifiedFailure(code, reason string, c errors.Classification, info *TaskInfo) PhaseInfo {
+	return classifiedFailure(&core.ExecutionError{Code: code, Message: reason, Kind: errorKind(c.Class)}, c, info)
+}
+
+func classifiedFailure(err *core.ExecutionError, c errors.Classification, info *TaskInfo) PhaseInfo {
+	return phaseInfo(PhasePermanentFailure, DefaultPhaseVersion, err, info).withClassification(c).Classify(errors.Target{})
 }
 
 func PhaseInfoRunning(version uint32, info *TaskInfo) PhaseInfo {
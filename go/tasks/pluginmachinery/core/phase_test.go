@@ -0,0 +1,76 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+)
+
+func TestPhaseInfo_Classification(t *testing.T) {
+	tests := []struct {
+		name     string
+		info     PhaseInfo
+		expected errors.Classification
+		kind     core.ExecutionError_ErrorKind
+	}{
+		{"failure", PhaseInfoFailure("code", "reason", nil), errors.Classification{Class: errors.ClassUser},
+			core.ExecutionError_USER},
+		{"retryable failure", PhaseInfoRetryableFailure("code", "reason", nil),
+			errors.Classification{Class: errors.ClassUser, Retryable: true}, core.ExecutionError_USER},
+		{"system failure", PhaseInfoSystemFailure("code", "reason", nil),
+			errors.Classification{Class: errors.ClassSystem}, core.ExecutionError_SYSTEM},
+		{"system retryable failure", PhaseInfoSystemRetryableFailure("code", "reason", nil),
+			errors.Classification{Class: errors.ClassSystem, Retryable: true}, core.ExecutionError_SYSTEM},
+		{"classified failure", PhaseInfoClassifiedFailure("code", "reason",
+			errors.Classification{Class: errors.ClassQuota, Retryable: true}, nil),
+			errors.Classification{Class: errors.ClassQuota, Retryable: true}, core.ExecutionError_SYSTEM},
+		{"unknown error", PhaseInfoFailed(PhasePermanentFailure, nil, nil), errors.Classification{},
+			core.ExecutionError_UNKNOWN},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.info.Classification())
+			assert.Equal(t, tt.kind, tt.info.Err().GetKind())
+		})
+	}
+
+	assert.Equal(t, errors.Classification{}, PhaseInfoRunning(0, nil).Classification())
+}
+
+func TestPhaseInfo_Classify(t *testing.T) {
+	defer func() { assert.NoError(t, errors.SetConfig(&errors.Config{})) }()
+
+	retryable := true
+	assert.NoError(t, errors.SetConfig(&errors.Config{Rules: []errors.Rule{
+		{Codes: []string{"Throttled"}, Class: errors.ClassQuota, Retryable: &retryable},
+		{Plugins: []string{"my-plugin"}, Codes: []string{"Flaky"}, Retryable: &retryable},
+	}}))
+
+	t.Run("applied when built", func(t *testing.T) {
+		err := &core.ExecutionError{Code: "Throttled", Kind: core.ExecutionError_USER}
+		info := PhaseInfoFailed(PhasePermanentFailure, err, nil)
+		assert.Equal(t, PhaseRetryableFailure, info.Phase())
+		assert.Equal(t, errors.Classification{Class: errors.ClassQuota, Retryable: true}, info.Classification())
+		assert.Equal(t, core.ExecutionError_SYSTEM, info.Err().GetKind())
+		assert.Equal(t, core.ExecutionError_USER, err.GetKind())
+	})
+
+	t.Run("applied for a target", func(t *testing.T) {
+		info := PhaseInfoFailure("Flaky", "reason", nil)
+		assert.Equal(t, PhasePermanentFailure, info.Phase())
+
+		info = info.Classify(errors.Target{PluginID: "my-plugin"})
+		assert.Equal(t, PhaseRetryableFailure, info.Phase())
+		assert.Equal(t, errors.Classification{Class: errors.ClassUser, Retryable: true}, info.Classification())
+		assert.Equal(t, core.ExecutionError_USER, info.Err().GetKind())
+	})
+
+	t.Run("other phases", func(t *testing.T) {
+		info := PhaseInfoRunning(0, nil)
+		assert.Equal(t, info, info.Classify(errors.Target{PluginID: "my-plugin"}))
+	})
+}
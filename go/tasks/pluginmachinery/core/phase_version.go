@@ -0,0 +1,119 @@
+package core
+
+import (
+	"fmt"
+	"hash/fnv"
+	"net/url"
+
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/golang/protobuf/proto"
+)
+
+// PhaseVersion tracks the version of the phases reported for a task. Frameworks keep it in the plugin state of the task
+// to version the phases reported by plugins that don't manage the version themselves. Only the webapi framework does so
+// for now: core plugins, including the array plugins, and the k8s plugin manager version the phases they report
+// themselves.
+type PhaseVersion struct {
+	Phase   Phase  `json:"phase,omitempty"`
+	Version uint32 `json:"version,omitempty"`
+	// Hash of the reason, logs, external resources and custom info last reported.
+	Hash uint64 `json:"hash,omitempty"`
+}
+
+// Update returns info with its version bumped if its reason, logs, external resources or custom info changed since the
+// last phase reported in the same phase. The version reported by the plugin is kept if higher, so plugins bumping it
+// by hand keep working. The query parameters of log links named in ignoredLogParams, e.g. the expiry and signature
+// appended by a tasklog.URLSigner, change every round and aren't considered.
+func (v *PhaseVersion) Update(info PhaseInfo, ignoredLogParams ...string) (PhaseInfo, error) {
+	hash, err := hashPhaseInfo(info, ignoredLogParams)
+	if err != nil {
+		return info, err
+	}
+
+	version := info.Version()
+	if v.Phase == info.Phase() {
+		current := v.Version
+		if hash != v.Hash {
+			current++
+		}
+
+		if current > version {
+			version = current
+		}
+	}
+
+	v.Phase, v.Version, v.Hash = info.Phase(), version, hash
+	return info.WithVersion(version), nil
+}
+
+// stableLog returns l with the ignored query parameters removed from its uri.
+func stableLog(l *idlCore.TaskLog, ignoredParams []string) *idlCore.TaskLog {
+	if len(ignoredParams) == 0 {
+		return l
+	}
+
+	u, err := url.Parse(l.Uri)
+	if err != nil {
+		return l
+	}
+
+	query := u.Query()
+	for _, p := range ignoredParams {
+		query.Del(p)
+	}
+
+	stable := proto.Clone(l).(*idlCore.TaskLog)
+	u.RawQuery = query.Encode()
+	stable.Uri = u.String()
+	return stable
+}
+
+func hashPhaseInfo(info PhaseInfo, ignoredLogParams []string) (uint64, error) {
+	h := fnv.New64a()
+	buf := proto.NewBuffer(nil)
+	buf.SetDeterministic(true)
+	write := func(m proto.Message) error {
+		buf.Reset()
+		if err := buf.Marshal(m); err != nil {
+			return err
+		}
+
+		// Values are prefixed with their length so consecutive values can't be mistaken for one another.
+		_, err := fmt.Fprintf(h, "%d:%s", len(buf.Bytes()), buf.Bytes())
+		return err
+	}
+
+	writeString := func(s string) {
+		_, _ = fmt.Fprintf(h, "%d:%s", len(s), s)
+	}
+
+	writeString(info.Reason())
+	writeString(info.SubReason())
+	if t := info.Info(); t != nil {
+		_, _ = fmt.Fprintf(h, "%d;", len(t.Logs))
+		for _, l := range t.Logs {
+			if err := write(stableLog(l, ignoredLogParams)); err != nil {
+				return 0, err
+			}
+		}
+
+		_, _ = fmt.Fprintf(h, "%d;", len(t.ExternalResources))
+		for _, r := range t.ExternalResources {
+			writeString(r.ExternalID)
+			_, _ = fmt.Fprintf(h, "%v:%v:%v:%v:%d;", r.CacheStatus, r.Index, r.RetryAttempt, r.Phase, len(r.Logs))
+			for _, l := range r.Logs {
+				if err := write(stableLog(l, ignoredLogParams)); err != nil {
+					return 0, err
+				}
+			}
+		}
+
+		if t.CustomInfo != nil {
+			if err := write(t.CustomInfo); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return h.Sum64(), nil
+}
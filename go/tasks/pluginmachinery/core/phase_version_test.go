@@ -0,0 +1,111 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhaseVersion_Update(t *testing.T) {
+	now := time.Now()
+	running := func(version uint32, logs ...string) PhaseInfo {
+		info := &TaskInfo{OccurredAt: &now}
+		for _, l := range logs {
+			info.Logs = append(info.Logs, &core.TaskLog{Name: l, Uri: "https://logs/" + l})
+		}
+
+		return PhaseInfoRunning(version, info)
+	}
+
+	v := PhaseVersion{}
+	update := func(info PhaseInfo) uint32 {
+		updated, err := v.Update(info)
+		assert.NoError(t, err)
+		assert.Equal(t, info.Phase(), updated.Phase())
+		return updated.Version()
+	}
+
+	assert.Equal(t, uint32(0), update(PhaseInfoQueued(now, DefaultPhaseVersion, "queued")))
+	assert.Equal(t, uint32(0), update(PhaseInfoQueued(now.Add(time.Second), DefaultPhaseVersion, "queued")))
+	assert.Equal(t, uint32(1), update(PhaseInfoQueued(now, DefaultPhaseVersion, "still queued")))
+
+	// The version starts over with each phase.
+	assert.Equal(t, uint32(0), update(running(DefaultPhaseVersion)))
+	assert.Equal(t, uint32(1), update(running(DefaultPhaseVersion, "driver")))
+	assert.Equal(t, uint32(1), update(running(DefaultPhaseVersion, "driver")))
+	assert.Equal(t, uint32(2), update(running(DefaultPhaseVersion, "driver", "executor")))
+
+	// Versions set by plugins are kept when higher.
+	assert.Equal(t, uint32(5), update(running(5, "driver", "executor")))
+	assert.Equal(t, uint32(6), update(running(5, "driver")))
+
+	t.Run("custom info", func(t *testing.T) {
+		v := PhaseVersion{}
+		withCustomInfo := func(fields map[string]string) PhaseInfo {
+			s := &structpb.Struct{Fields: map[string]*structpb.Value{}}
+			for k, val := range fields {
+				s.Fields[k] = &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: val}}
+			}
+
+			return PhaseInfoRunning(DefaultPhaseVersion, &TaskInfo{CustomInfo: s})
+		}
+
+		fields := map[string]string{"a": "1", "b": "2", "c": "3", "d": "4"}
+		for i := 0; i < 10; i++ {
+			updated, err := v.Update(withCustomInfo(fields))
+			assert.NoError(t, err)
+			assert.Equal(t, uint32(0), updated.Version())
+		}
+
+		fields["d"] = "5"
+		updated, err := v.Update(withCustomInfo(fields))
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(1), updated.Version())
+	})
+
+	t.Run("external resources", func(t *testing.T) {
+		v := PhaseVersion{}
+		withResource := func(phase Phase) PhaseInfo {
+			return PhaseInfoRunning(DefaultPhaseVersion, &TaskInfo{ExternalResources: []*ExternalResource{
+				{ExternalID: "job-1", Phase: phase},
+			}})
+		}
+
+		updated, err := v.Update(withResource(PhaseQueued))
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(0), updated.Version())
+
+		updated, err = v.Update(withResource(PhaseRunning))
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(1), updated.Version())
+	})
+
+	t.Run("signed logs", func(t *testing.T) {
+		v := PhaseVersion{}
+		withLog := func(uri string) PhaseInfo {
+			return PhaseInfoRunning(DefaultPhaseVersion, &TaskInfo{Logs: []*core.TaskLog{{Name: "driver", Uri: uri}}})
+		}
+
+		updated, err := v.Update(withLog("https://logs/view?pod=p1&expires=100&signature=a"), "signature", "expires")
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(0), updated.Version())
+
+		// A new signature and expiry don't change the version.
+		updated, err = v.Update(withLog("https://logs/view?pod=p1&expires=200&signature=b"), "signature", "expires")
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(0), updated.Version())
+		assert.Equal(t, "https://logs/view?pod=p1&expires=200&signature=b", updated.Info().Logs[0].Uri)
+
+		updated, err = v.Update(withLog("https://logs/view?pod=p2&expires=300&signature=c"), "signature", "expires")
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(1), updated.Version())
+
+		// Without ignored parameters, any change of the uri counts.
+		updated, err = v.Update(withLog("https://logs/view?pod=p2&expires=400&signature=d"))
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(2), updated.Version())
+	})
+}
@@ -3,6 +3,8 @@ package core
 import (
 	"context"
 	"fmt"
+
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 )
 
 //go:generate mockery -all -case=underscore
@@ -28,6 +30,9 @@ type PluginEntry struct {
 	// Boolean that indicates if this plugin can be used as the default for unknown task types. There can only be
 	// one default in the system
 	IsDefault bool
+	// Optional validator for the task templates handled by the plugin. Plugins are loaded lazily, the validator lets
+	// the system validate task templates without loading them.
+	Validator PluginValidator
 }
 
 // System level properties that this Plugin supports
@@ -55,6 +60,34 @@ type Plugin interface {
 	Finalize(ctx context.Context, tCtx TaskExecutionContext) error
 }
 
+// PluginValidator is an optional interface core, k8s and web API plugins implement to reject malformed task templates
+// ahead of their execution, e.g. when workflows are compiled. Validate is called without a task execution and must not
+// depend on one, nor on the systems the plugin talks to.
+type PluginValidator interface {
+	Validate(ctx context.Context, taskTemplate *idlCore.TaskTemplate) error
+}
+
+// PluginValidatorFunc adapts a function to a PluginValidator.
+type PluginValidatorFunc func(ctx context.Context, taskTemplate *idlCore.TaskTemplate) error
+
+func (f PluginValidatorFunc) Validate(ctx context.Context, taskTemplate *idlCore.TaskTemplate) error {
+	return f(ctx, taskTemplate)
+}
+
+// HealthChecker is an optional interface core, k8s and web API plugins implement to report whether they can currently
+// run tasks, e.g. by making a lightweight authenticated call to the service they talk to. CheckHealth is probed
+// periodically, outside of any task execution, and must return promptly once ctx is done.
+type HealthChecker interface {
+	CheckHealth(ctx context.Context) error
+}
+
+// HealthCheckerFunc adapts a function to a HealthChecker.
+type HealthCheckerFunc func(ctx context.Context) error
+
+func (f HealthCheckerFunc) CheckHealth(ctx context.Context) error {
+	return f(ctx)
+}
+
 // Loads and validates a plugin.
 func LoadPlugin(ctx context.Context, iCtx SetupContext, entry PluginEntry) (Plugin, error) {
 	plugin, err := entry.LoadPlugin(ctx, iCtx)
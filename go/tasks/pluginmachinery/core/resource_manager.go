@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"time"
 )
 
 //go:generate enumer -type=AllocationStatus -trimprefix=AllocationStatus
@@ -32,9 +33,25 @@ func (r ResourceNamespace) CreateSubNamespace(namespace ResourceNamespace) Resou
 }
 
 type ResourceRegistrar interface {
+	// RegisterResourceQuota caps the total weight of the tokens allocated in the namespace at any given time.
 	RegisterResourceQuota(ctx context.Context, namespace ResourceNamespace, quota int) error
 }
 
+// ResourceRateRegistrar is optionally implemented by ResourceRegistrars that support rates. Callers check for it with a
+// type assertion.
+type ResourceRateRegistrar interface {
+	// RegisterResourceRate caps the total weight of the tokens allocated in the namespace within any window of time, in
+	// addition to its quota. Released tokens keep counting until they leave the window.
+	RegisterResourceRate(ctx context.Context, namespace ResourceNamespace, rate ResourceRate) error
+}
+
+// ResourceRate caps the total weight of the tokens allocated within any window of time, e.g. for remote services
+// limiting requests per second rather than concurrent requests.
+type ResourceRate struct {
+	Value  int64
+	Window time.Duration
+}
+
 // ResourceManager Interface
 // 1. Terms and definitions
 //
@@ -48,7 +65,7 @@ type ResourceRegistrar interface {
 //
 //     2. Description
 //     ResourceManager provides a task-type-specific pooling system for Flyte Tasks. Plugin writers can optionally
-//     request for resources in their tasks, in single quantity.
+//     request for resources in their tasks, in single quantity or weighted by their cost (see ResourceConstraintsSpec).
 //
 //     3. Usage
 //     A Flyte plugin registers the resources and the desired quota of each resource with ResourceRegistrar at the
@@ -100,7 +117,21 @@ type ResourceConstraint struct {
 // Setting constraints in a ResourceConstraintsSpec to nil objects is valid, meaning there's no constraint at the corresponding level.
 // For example, a ResourceConstraintsSpec with nil ProjectScopeResourceConstraint and a non-nil NamespaceScopeResourceConstraint means
 // that it only poses a cap at the namespace level. A zero-value ResourceConstraintsSpec means there's no constraints posed at any level.
+//
+// Weight is the cost of the token being allocated, in the units of the quota, rate and constraints of the resource
+// namespace. It lets plugins account for allocations that don't cost the same, e.g. a large scan and a lookup. A
+// non-positive weight counts as 1.
 type ResourceConstraintsSpec struct {
 	ProjectScopeResourceConstraint   *ResourceConstraint
 	NamespaceScopeResourceConstraint *ResourceConstraint
+	Weight                           int64
+}
+
+// GetWeight returns the weight of the token being allocated, 1 if it's not set.
+func (s ResourceConstraintsSpec) GetWeight() int64 {
+	if s.Weight <= 0 {
+		return 1
+	}
+
+	return s.Weight
 }
@@ -0,0 +1,114 @@
+package core
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+)
+
+// StateMigration upgrades the plugin state written at one version to the next version.
+type StateMigration struct {
+	// NewState returns a pointer to the zero value of the state at the version the migration upgrades from. The stored
+	// state is decoded into it.
+	NewState func() interface{}
+	// Migrate upgrades the state returned by NewState, or by the migration from the previous version, and returns a
+	// pointer to the state at the next version.
+	Migrate func(state interface{}) (interface{}, error)
+}
+
+// StateMigrations is the registry of the migrations between the versions of the state of a plugin. Plugins read their
+// state through it so that state written by older versions of the plugin is upgraded to the current version, and
+// state written by newer versions is refused instead of being misread.
+//
+// The plugin state reader reports version 0 both for state written at version 0 and when there is no state yet.
+// Migrations from version 0 must therefore upgrade the zero value to the zero value of the next version.
+type StateMigrations struct {
+	currentVersion uint8
+	migrations     []StateMigration
+}
+
+// NewStateMigrations creates the migrations for a plugin whose state is at currentVersion. A migration must be
+// registered from each previous version.
+func NewStateMigrations(currentVersion uint8) *StateMigrations {
+	return &StateMigrations{
+		currentVersion: currentVersion,
+		migrations:     make([]StateMigration, currentVersion),
+	}
+}
+
+// Register registers the migration upgrading the state written at fromVersion to fromVersion+1. It panics if the
+// migration is incomplete, already registered or doesn't upgrade to a version up to the current one, as these are
+// programming errors.
+func (m *StateMigrations) Register(fromVersion uint8, migration StateMigration) *StateMigrations {
+	if fromVersion >= m.currentVersion {
+		panic(fmt.Sprintf("state migration from version [%v] upgrades past the current version [%v]", fromVersion,
+			m.currentVersion))
+	}
+
+	if migration.NewState == nil || migration.Migrate == nil {
+		panic(fmt.Sprintf("state migration from version [%v] is incomplete", fromVersion))
+	}
+
+	if m.migrations[fromVersion].Migrate != nil {
+		panic(fmt.Sprintf("state migration from version [%v] is already registered", fromVersion))
+	}
+
+	m.migrations[fromVersion] = migration
+	return m
+}
+
+// CurrentVersion returns the version plugin state is written at.
+func (m *StateMigrations) CurrentVersion() uint8 {
+	return m.currentVersion
+}
+
+// Get reads the plugin state into t, which must be a pointer to the state at the current version, upgrading state
+// written at older versions. It returns the version the state was written at.
+func (m *StateMigrations) Get(reader PluginStateReader, t interface{}) (stateVersion uint8, err error) {
+	stateVersion = reader.GetStateVersion()
+	if stateVersion > m.currentVersion {
+		return stateVersion, errors.Errorf(errors.UnsupportedStateVersion,
+			"plugin state version [%v] is newer than the current version [%v], it was likely written by a newer "+
+				"version of the plugin", stateVersion, m.currentVersion)
+	}
+
+	if stateVersion == m.currentVersion {
+		_, err = reader.Get(t)
+		return stateVersion, err
+	}
+
+	for v := stateVersion; v < m.currentVersion; v++ {
+		if m.migrations[v].Migrate == nil {
+			return stateVersion, errors.Errorf(errors.UnsupportedStateVersion,
+				"no state migration is registered from version [%v]", v)
+		}
+	}
+
+	state := m.migrations[stateVersion].NewState()
+	if _, err = reader.Get(state); err != nil {
+		return stateVersion, err
+	}
+
+	for v := stateVersion; v < m.currentVersion; v++ {
+		if state, err = m.migrations[v].Migrate(state); err != nil {
+			return stateVersion, errors.Wrapf(errors.CorruptedPluginState, err,
+				"failed to migrate plugin state from version [%v] to [%v]", v, v+1)
+		}
+	}
+
+	target, migrated := reflect.ValueOf(t), reflect.ValueOf(state)
+	if target.Kind() != reflect.Ptr || migrated.Kind() != reflect.Ptr ||
+		!migrated.Elem().Type().AssignableTo(target.Elem().Type()) {
+		return stateVersion, errors.Errorf(errors.CorruptedPluginState,
+			"plugin state migrated to [%T] can't be read into [%T]", state, t)
+	}
+
+	target.Elem().Set(migrated.Elem())
+	return stateVersion, nil
+}
+
+// Put writes the plugin state at the current version.
+func (m *StateMigrations) Put(writer PluginStateWriter, v interface{}) error {
+	return writer.Put(m.currentVersion, v)
+}
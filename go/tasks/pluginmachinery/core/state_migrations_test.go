@@ -0,0 +1,165 @@
+package core
+
+import (
+	"bytes"
+	"encoding/gob"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	stdErrors "github.com/flyteorg/flytestdlib/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+)
+
+var update = flag.Bool("update", false, "rewrite the serialized plugin states in testdata")
+
+// The state of a plugin across versions: v1 adds a name, v2 renames count to total.
+type stateV0 struct {
+	Count int
+}
+
+type stateV1 struct {
+	Count int
+	Name  string
+}
+
+type stateV2 struct {
+	Total int64
+	Name  string
+}
+
+// gobStateReader reads gob encoded state, as FlytePropeller does.
+type gobStateReader struct {
+	version uint8
+	state   []byte
+}
+
+func (r gobStateReader) GetStateVersion() uint8 {
+	return r.version
+}
+
+func (r gobStateReader) Get(t interface{}) (uint8, error) {
+	if len(r.state) == 0 {
+		return 0, nil
+	}
+
+	return r.version, gob.NewDecoder(bytes.NewReader(r.state)).Decode(t)
+}
+
+// Reads a serialized state from testdata, writing it first if -update is set.
+func readStateBlob(t *testing.T, name string, state interface{}) []byte {
+	path := filepath.Join("testdata", name)
+	if *update {
+		buf := &bytes.Buffer{}
+		assert.NoError(t, gob.NewEncoder(buf).Encode(state))
+		assert.NoError(t, os.WriteFile(path, buf.Bytes(), 0644))
+	}
+
+	blob, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	return blob
+}
+
+func newTestStateMigrations() *StateMigrations {
+	return NewStateMigrations(2).
+		Register(0, StateMigration{
+			NewState: func() interface{} { return &stateV0{} },
+			Migrate: func(state interface{}) (interface{}, error) {
+				return &stateV1{Count: state.(*stateV0).Count}, nil
+			},
+		}).
+		Register(1, StateMigration{
+			NewState: func() interface{} { return &stateV1{} },
+			Migrate: func(state interface{}) (interface{}, error) {
+				s := state.(*stateV1)
+				if s.Count < 0 {
+					return nil, fmt.Errorf("negative count")
+				}
+
+				return &stateV2{Total: int64(s.Count), Name: s.Name}, nil
+			},
+		})
+}
+
+func TestStateMigrations_Get(t *testing.T) {
+	migrations := newTestStateMigrations()
+	assert.Equal(t, uint8(2), migrations.CurrentVersion())
+
+	t.Run("no state", func(t *testing.T) {
+		state := stateV2{}
+		version, err := migrations.Get(gobStateReader{}, &state)
+		assert.NoError(t, err)
+		assert.Equal(t, uint8(0), version)
+		assert.Equal(t, stateV2{}, state)
+	})
+
+	t.Run("version 0", func(t *testing.T) {
+		blob := readStateBlob(t, "state_v0.gob", stateV0{Count: 3})
+		state := stateV2{}
+		version, err := migrations.Get(gobStateReader{version: 0, state: blob}, &state)
+		assert.NoError(t, err)
+		assert.Equal(t, uint8(0), version)
+		assert.Equal(t, stateV2{Total: 3}, state)
+	})
+
+	t.Run("version 1", func(t *testing.T) {
+		blob := readStateBlob(t, "state_v1.gob", stateV1{Count: 5, Name: "five"})
+		state := stateV2{}
+		version, err := migrations.Get(gobStateReader{version: 1, state: blob}, &state)
+		assert.NoError(t, err)
+		assert.Equal(t, uint8(1), version)
+		assert.Equal(t, stateV2{Total: 5, Name: "five"}, state)
+	})
+
+	t.Run("current version", func(t *testing.T) {
+		blob := readStateBlob(t, "state_v2.gob", stateV2{Total: 7, Name: "seven"})
+		state := stateV2{}
+		version, err := migrations.Get(gobStateReader{version: 2, state: blob}, &state)
+		assert.NoError(t, err)
+		assert.Equal(t, uint8(2), version)
+		assert.Equal(t, stateV2{Total: 7, Name: "seven"}, state)
+	})
+
+	t.Run("future version", func(t *testing.T) {
+		blob := readStateBlob(t, "state_v2.gob", stateV2{Total: 7, Name: "seven"})
+		_, err := migrations.Get(gobStateReader{version: 3, state: blob}, &stateV2{})
+		assert.Error(t, err)
+		code, _ := stdErrors.GetErrorCode(err)
+		assert.Equal(t, errors.UnsupportedStateVersion, code)
+	})
+
+	t.Run("failed migration", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		assert.NoError(t, gob.NewEncoder(buf).Encode(stateV1{Count: -1}))
+		_, err := migrations.Get(gobStateReader{version: 1, state: buf.Bytes()}, &stateV2{})
+		code, _ := stdErrors.GetErrorCode(err)
+		assert.Equal(t, errors.CorruptedPluginState, code)
+	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		blob := readStateBlob(t, "state_v1.gob", stateV1{Count: 5, Name: "five"})
+		_, err := migrations.Get(gobStateReader{version: 1, state: blob}, &stateV1{})
+		assert.Error(t, err)
+	})
+
+	t.Run("missing migration", func(t *testing.T) {
+		_, err := NewStateMigrations(1).Get(gobStateReader{}, &stateV1{})
+		code, _ := stdErrors.GetErrorCode(err)
+		assert.Equal(t, errors.UnsupportedStateVersion, code)
+	})
+}
+
+func TestStateMigrations_Register(t *testing.T) {
+	migration := StateMigration{
+		NewState: func() interface{} { return &stateV0{} },
+		Migrate:  func(state interface{}) (interface{}, error) { return state, nil },
+	}
+
+	assert.Panics(t, func() { NewStateMigrations(1).Register(1, migration) })
+	assert.Panics(t, func() { NewStateMigrations(1).Register(0, StateMigration{}) })
+	assert.Panics(t, func() { NewStateMigrations(1).Register(0, migration).Register(0, migration) })
+}
@@ -101,6 +101,25 @@ func Render(ctx context.Context, inputTemplate []string, params Parameters) ([]s
 	return res, nil
 }
 
+// ValidateInputs checks the inputs referenced by the templates, e.g. {{ .Inputs.myInput }}, are declared in inputs.
+// Plugins use it to reject templates, e.g. queries, that can't be rendered before executing them.
+func ValidateInputs(inputTemplate []string, inputs *idlCore.VariableMap) error {
+	var errs ErrorCollection
+	for _, t := range inputTemplate {
+		for _, matches := range inputVarRegex.FindAllStringSubmatch(t, -1) {
+			if _, exists := inputs.GetVariables()[matches[1]]; !exists {
+				errs.Errors = append(errs.Errors, fmt.Errorf("input template [%s] references an undeclared input", matches[0]))
+			}
+		}
+	}
+
+	if len(errs.Errors) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
 func render(ctx context.Context, inputTemplate string, params Parameters, perRetryKey string) (string, error) {
 
 	val := inputFileRegex.ReplaceAllString(inputTemplate, params.Inputs.GetInputPath().String())
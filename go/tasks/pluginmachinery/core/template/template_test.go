@@ -713,3 +713,21 @@ func TestSerializeLiteral(t *testing.T) {
 		assert.Equal(t, "s3://some-bucket/fdsa/x.parquet", interpolated)
 	})
 }
+
+func TestValidateInputs(t *testing.T) {
+	inputs := &core.VariableMap{Variables: map[string]*core.Variable{
+		"ds":    {Type: &core.LiteralType{Type: &core.LiteralType_Simple{Simple: core.SimpleType_STRING}}},
+		"limit": {Type: &core.LiteralType{Type: &core.LiteralType_Simple{Simple: core.SimpleType_INTEGER}}},
+	}}
+
+	assert.NoError(t, ValidateInputs([]string{
+		"select * from t where ds = '{{ .Inputs.ds }}' limit {{.inputs.limit}}",
+		"{{ .OutputPrefix }}",
+	}, inputs))
+
+	err := ValidateInputs([]string{"select * from t where ds = '{{ .Inputs.ds }}' and x = {{ .Inputs.x }}"}, inputs)
+	assert.EqualError(t, err, "0: input template [{{ .Inputs.x }}] references an undeclared input\r\n")
+
+	assert.Error(t, ValidateInputs([]string{"{{ .Inputs.ds }}"}, nil))
+	assert.NoError(t, ValidateInputs(nil, nil))
+}
@@ -0,0 +1,268 @@
+// Package dryrun provides a task execution context that doesn't need a task execution, so that plugins can exercise
+// the code paths building their resources, e.g. pod specs, to validate task templates ahead of their execution.
+package dryrun
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/storage"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/catalog"
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
+)
+
+const (
+	// Name is used in place of all the names derived from the task execution, e.g. the generated name.
+	Name = "dry-run"
+	// Namespace is the namespace of dry run task executions.
+	Namespace = "dry-run"
+	// PathPrefix prefixes all the storage paths of dry run task executions.
+	PathPrefix = storage.DataReference("dry-run://")
+)
+
+// ErrUnavailable is returned by the parts of the context that need a task execution, e.g. secrets.
+var ErrUnavailable = fmt.Errorf("not available in dry runs")
+
+type taskExecutionID struct {
+	id idlCore.TaskExecutionIdentifier
+}
+
+func (t taskExecutionID) GetGeneratedName() string {
+	return Name
+}
+
+func (t taskExecutionID) GetGeneratedNameWith(minLength, _ int) (string, error) {
+	if len(Name) >= minLength {
+		return Name, nil
+	}
+
+	return Name + strings.Repeat("0", minLength-len(Name)), nil
+}
+
+func (t taskExecutionID) GetID() idlCore.TaskExecutionIdentifier {
+	return t.id
+}
+
+type taskOverrides struct{}
+
+func (taskOverrides) GetResources() *v1.ResourceRequirements {
+	return &v1.ResourceRequirements{}
+}
+
+func (taskOverrides) GetConfig() *v1.ConfigMap {
+	return &v1.ConfigMap{}
+}
+
+type taskExecutionMetadata struct {
+	id taskExecutionID
+}
+
+func (m taskExecutionMetadata) GetOwnerID() types.NamespacedName {
+	return types.NamespacedName{Namespace: Namespace, Name: Name}
+}
+
+func (m taskExecutionMetadata) GetTaskExecutionID() pluginsCore.TaskExecutionID {
+	return m.id
+}
+
+func (m taskExecutionMetadata) GetNamespace() string {
+	return Namespace
+}
+
+func (m taskExecutionMetadata) GetOwnerReference() metav1.OwnerReference {
+	return metav1.OwnerReference{Name: Name}
+}
+
+func (m taskExecutionMetadata) GetOverrides() pluginsCore.TaskOverrides {
+	return taskOverrides{}
+}
+
+func (m taskExecutionMetadata) GetLabels() map[string]string {
+	return map[string]string{}
+}
+
+func (m taskExecutionMetadata) GetMaxAttempts() uint32 {
+	return 1
+}
+
+func (m taskExecutionMetadata) GetAnnotations() map[string]string {
+	return map[string]string{}
+}
+
+func (m taskExecutionMetadata) GetK8sServiceAccount() string {
+	return ""
+}
+
+func (m taskExecutionMetadata) GetSecurityContext() idlCore.SecurityContext {
+	return idlCore.SecurityContext{}
+}
+
+func (m taskExecutionMetadata) IsInterruptible() bool {
+	return false
+}
+
+func (m taskExecutionMetadata) GetPlatformResources() *v1.ResourceRequirements {
+	return &v1.ResourceRequirements{}
+}
+
+func (m taskExecutionMetadata) GetInterruptibleFailureThreshold() uint32 {
+	return 0
+}
+
+type taskReader struct {
+	taskTemplate *idlCore.TaskTemplate
+}
+
+func (r taskReader) Path(context.Context) (storage.DataReference, error) {
+	return PathPrefix + "task.pb", nil
+}
+
+func (r taskReader) Read(context.Context) (*idlCore.TaskTemplate, error) {
+	return r.taskTemplate, nil
+}
+
+// inputReader has no inputs, templates referencing inputs are left as is.
+type inputReader struct{}
+
+func (inputReader) GetInputPrefixPath() storage.DataReference {
+	return PathPrefix + "inputs"
+}
+
+func (inputReader) GetInputPath() storage.DataReference {
+	return PathPrefix + "inputs/inputs.pb"
+}
+
+func (inputReader) Get(context.Context) (*idlCore.LiteralMap, error) {
+	return nil, nil
+}
+
+type outputWriter struct{}
+
+func (outputWriter) GetRawOutputPrefix() storage.DataReference {
+	return PathPrefix + "raw"
+}
+
+func (outputWriter) GetPreviousCheckpointsPrefix() storage.DataReference {
+	return ""
+}
+
+func (outputWriter) GetCheckpointPrefix() storage.DataReference {
+	return PathPrefix + "checkpoint"
+}
+
+func (outputWriter) GetOutputPrefixPath() storage.DataReference {
+	return PathPrefix + "outputs"
+}
+
+func (outputWriter) GetOutputPath() storage.DataReference {
+	return PathPrefix + "outputs/outputs.pb"
+}
+
+func (outputWriter) GetDeckPath() storage.DataReference {
+	return PathPrefix + "outputs/deck.html"
+}
+
+func (outputWriter) GetErrorPath() storage.DataReference {
+	return PathPrefix + "outputs/error.pb"
+}
+
+func (outputWriter) Put(context.Context, io.OutputReader) error {
+	return ErrUnavailable
+}
+
+type secretManager struct{}
+
+func (secretManager) Get(context.Context, string) (string, error) {
+	return "", ErrUnavailable
+}
+
+type taskExecutionContext struct {
+	taskTemplate *idlCore.TaskTemplate
+	metadata     taskExecutionMetadata
+}
+
+func (c taskExecutionContext) ResourceManager() pluginsCore.ResourceManager {
+	return nil
+}
+
+func (c taskExecutionContext) SecretManager() pluginsCore.SecretManager {
+	return secretManager{}
+}
+
+func (c taskExecutionContext) TaskRefreshIndicator() pluginsCore.SignalAsync {
+	return func(context.Context) {}
+}
+
+func (c taskExecutionContext) MaxDatasetSizeBytes() int64 {
+	return 0
+}
+
+func (c taskExecutionContext) DataStore() *storage.DataStore {
+	return nil
+}
+
+func (c taskExecutionContext) PluginStateReader() pluginsCore.PluginStateReader {
+	return nil
+}
+
+func (c taskExecutionContext) TaskReader() pluginsCore.TaskReader {
+	return taskReader{taskTemplate: c.taskTemplate}
+}
+
+func (c taskExecutionContext) InputReader() io.InputReader {
+	return inputReader{}
+}
+
+func (c taskExecutionContext) TaskExecutionMetadata() pluginsCore.TaskExecutionMetadata {
+	return c.metadata
+}
+
+func (c taskExecutionContext) OutputWriter() io.OutputWriter {
+	return outputWriter{}
+}
+
+func (c taskExecutionContext) PluginStateWriter() pluginsCore.PluginStateWriter {
+	return nil
+}
+
+func (c taskExecutionContext) Catalog() catalog.AsyncClient {
+	return nil
+}
+
+func (c taskExecutionContext) EventsRecorder() pluginsCore.EventsRecorder {
+	return nil
+}
+
+// NewTaskExecutionContext returns a context for a dry run of the task. It has no inputs, resource manager, plugin state,
+// catalog nor events recorder, secrets aren't available and outputs can't be written.
+func NewTaskExecutionContext(taskTemplate *idlCore.TaskTemplate) pluginsCore.TaskExecutionContext {
+	taskID := taskTemplate.GetId()
+	return taskExecutionContext{
+		taskTemplate: taskTemplate,
+		metadata: taskExecutionMetadata{id: taskExecutionID{id: idlCore.TaskExecutionIdentifier{
+			TaskId: taskID,
+			NodeExecutionId: &idlCore.NodeExecutionIdentifier{
+				NodeId: Name,
+				ExecutionId: &idlCore.WorkflowExecutionIdentifier{
+					Project: taskID.GetProject(),
+					Domain:  taskID.GetDomain(),
+					Name:    Name,
+				},
+			},
+		}}},
+	}
+}
+
+// BuildResource builds the resource of a k8s plugin for the task, without creating it.
+func BuildResource(ctx context.Context, plugin k8s.Plugin, taskTemplate *idlCore.TaskTemplate) (client.Object, error) {
+	return plugin.BuildResource(ctx, NewTaskExecutionContext(taskTemplate))
+}
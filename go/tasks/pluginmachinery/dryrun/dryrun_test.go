@@ -0,0 +1,96 @@
+package dryrun
+
+import (
+	"context"
+	"testing"
+
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/template"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
+)
+
+// podPlugin builds a pod running the container of the task.
+type podPlugin struct {
+	k8s.Plugin
+}
+
+func (podPlugin) BuildResource(ctx context.Context, tCtx pluginsCore.TaskExecutionContext) (client.Object, error) {
+	taskTemplate, err := tCtx.TaskReader().Read(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	args, err := template.Render(ctx, taskTemplate.GetContainer().GetArgs(), template.Parameters{
+		TaskExecMetadata: tCtx.TaskExecutionMetadata(),
+		Inputs:           tCtx.InputReader(),
+		OutputPath:       tCtx.OutputWriter(),
+		Task:             tCtx.TaskReader(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName(),
+			Namespace: tCtx.TaskExecutionMetadata().GetNamespace(),
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{Image: taskTemplate.GetContainer().GetImage(), Args: args}},
+		},
+	}, nil
+}
+
+func TestBuildResource(t *testing.T) {
+	ctx := context.TODO()
+	taskTemplate := &idlCore.TaskTemplate{
+		Id: &idlCore.Identifier{Project: "project", Domain: "domain", Name: "task"},
+		Target: &idlCore.TaskTemplate_Container{Container: &idlCore.Container{
+			Image: "image",
+			Args:  []string{"--inputs", "{{ .Input }}", "--output-prefix", "{{ .OutputPrefix }}", "--x", "{{ .Inputs.x }}"},
+		}},
+	}
+
+	resource, err := BuildResource(ctx, podPlugin{}, taskTemplate)
+	assert.NoError(t, err)
+
+	pod := resource.(*v1.Pod)
+	assert.Equal(t, Name, pod.Name)
+	assert.Equal(t, Namespace, pod.Namespace)
+	assert.Equal(t, "image", pod.Spec.Containers[0].Image)
+	assert.Equal(t, []string{"--inputs", "dry-run://inputs/inputs.pb", "--output-prefix", "dry-run://outputs",
+		"--x", "{{ .Inputs.x }}"}, pod.Spec.Containers[0].Args)
+}
+
+func TestNewTaskExecutionContext(t *testing.T) {
+	ctx := context.TODO()
+	taskTemplate := &idlCore.TaskTemplate{Id: &idlCore.Identifier{Project: "project", Domain: "domain", Name: "task"}}
+	tCtx := NewTaskExecutionContext(taskTemplate)
+
+	read, err := tCtx.TaskReader().Read(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, taskTemplate, read)
+
+	id := tCtx.TaskExecutionMetadata().GetTaskExecutionID()
+	executionID := id.GetID()
+	assert.Equal(t, "project", executionID.GetNodeExecutionId().GetExecutionId().GetProject())
+	assert.Equal(t, "domain", executionID.GetNodeExecutionId().GetExecutionId().GetDomain())
+
+	name, err := id.GetGeneratedNameWith(32, 128)
+	assert.NoError(t, err)
+	assert.Len(t, name, 32)
+
+	_, err = tCtx.SecretManager().Get(ctx, "secret")
+	assert.ErrorIs(t, err, ErrUnavailable)
+	assert.ErrorIs(t, tCtx.OutputWriter().Put(ctx, nil), ErrUnavailable)
+
+	inputs, err := tCtx.InputReader().Get(ctx)
+	assert.NoError(t, err)
+	assert.Nil(t, inputs)
+}
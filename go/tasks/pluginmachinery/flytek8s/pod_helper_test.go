@@ -16,6 +16,7 @@ import (
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io"
 	pluginsIOMock "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tracing"
 
 	config1 "github.com/flyteorg/flytestdlib/config"
 	"github.com/flyteorg/flytestdlib/config/viper"
@@ -23,6 +24,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -530,6 +532,21 @@ func TestToK8sPod(t *testing.T) {
 		assert.Equal(t, val3, *p.DNSConfig.Options[3].Value)
 		assert.Equal(t, []string{"ns1.svc.cluster-domain.example", "my.dns.search.suffix"}, p.DNSConfig.Searches)
 	})
+
+	t.Run("trace-context", func(t *testing.T) {
+		assert.NoError(t, config.SetK8sPluginConfig(&config.K8sPluginConfig{}))
+		x := dummyExecContext(&v1.ResourceRequirements{})
+		_, objectMeta, err := ToK8sPodSpec(ctx, x)
+		assert.NoError(t, err)
+		assert.NotContains(t, objectMeta.Annotations, "traceparent")
+
+		spanCtx, span := sdktrace.NewTracerProvider().Tracer("test").Start(ctx, "test")
+		defer span.End()
+		_, objectMeta, err = ToK8sPodSpec(spanCtx, x)
+		assert.NoError(t, err)
+		assert.Equal(t, span.SpanContext().SpanID(),
+			tracing.SpanContextFromAnnotations(objectMeta.Annotations).SpanID())
+	})
 }
 
 func TestDemystifyPending(t *testing.T) {
@@ -552,6 +569,7 @@ func TestDemystifyPending(t *testing.T) {
 		taskStatus, err := DemystifyPending(s)
 		assert.NoError(t, err)
 		assert.Equal(t, pluginsCore.PhaseQueued, taskStatus.Phase())
+		assert.Equal(t, v1.PodReasonUnschedulable, taskStatus.SubReason())
 	})
 
 	t.Run("PodUnschedulable", func(t *testing.T) {
@@ -567,6 +585,7 @@ func TestDemystifyPending(t *testing.T) {
 		taskStatus, err := DemystifyPending(s)
 		assert.NoError(t, err)
 		assert.Equal(t, pluginsCore.PhaseQueued, taskStatus.Phase())
+		assert.Equal(t, v1.PodReasonUnschedulable, taskStatus.SubReason())
 	})
 
 	t.Run("PodNotScheduled", func(t *testing.T) {
@@ -582,6 +601,7 @@ func TestDemystifyPending(t *testing.T) {
 		taskStatus, err := DemystifyPending(s)
 		assert.NoError(t, err)
 		assert.Equal(t, pluginsCore.PhaseQueued, taskStatus.Phase())
+		assert.Equal(t, "Scheduling", taskStatus.SubReason())
 	})
 
 	t.Run("PodUnschedulable", func(t *testing.T) {
@@ -597,6 +617,7 @@ func TestDemystifyPending(t *testing.T) {
 		taskStatus, err := DemystifyPending(s)
 		assert.NoError(t, err)
 		assert.Equal(t, pluginsCore.PhaseQueued, taskStatus.Phase())
+		assert.Equal(t, v1.PodReasonUnschedulable, taskStatus.SubReason())
 	})
 
 	s := v1.PodStatus{
@@ -632,6 +653,7 @@ func TestDemystifyPending(t *testing.T) {
 		taskStatus, err := DemystifyPending(s)
 		assert.NoError(t, err)
 		assert.Equal(t, pluginsCore.PhaseInitializing, taskStatus.Phase())
+		assert.Equal(t, "ContainerCreating", taskStatus.SubReason())
 	})
 
 	t.Run("ErrImagePull", func(t *testing.T) {
@@ -649,6 +671,7 @@ func TestDemystifyPending(t *testing.T) {
 		taskStatus, err := DemystifyPending(s)
 		assert.NoError(t, err)
 		assert.Equal(t, pluginsCore.PhaseInitializing, taskStatus.Phase())
+		assert.Equal(t, "ErrImagePull", taskStatus.SubReason())
 	})
 
 	t.Run("PodInitializing", func(t *testing.T) {
@@ -666,6 +689,7 @@ func TestDemystifyPending(t *testing.T) {
 		taskStatus, err := DemystifyPending(s)
 		assert.NoError(t, err)
 		assert.Equal(t, pluginsCore.PhaseInitializing, taskStatus.Phase())
+		assert.Equal(t, "PodInitializing", taskStatus.SubReason())
 	})
 
 	t.Run("ImagePullBackOff", func(t *testing.T) {
@@ -683,6 +707,7 @@ func TestDemystifyPending(t *testing.T) {
 		taskStatus, err := DemystifyPending(s)
 		assert.NoError(t, err)
 		assert.Equal(t, pluginsCore.PhaseRetryableFailure, taskStatus.Phase())
+		assert.Equal(t, "ImagePullBackOff", taskStatus.SubReason())
 	})
 
 	t.Run("InvalidImageName", func(t *testing.T) {
@@ -700,6 +725,7 @@ func TestDemystifyPending(t *testing.T) {
 		taskStatus, err := DemystifyPending(s)
 		assert.NoError(t, err)
 		assert.Equal(t, pluginsCore.PhasePermanentFailure, taskStatus.Phase())
+		assert.Equal(t, "InvalidImageName", taskStatus.SubReason())
 	})
 
 	t.Run("RegistryUnavailable", func(t *testing.T) {
@@ -717,6 +743,7 @@ func TestDemystifyPending(t *testing.T) {
 		taskStatus, err := DemystifyPending(s)
 		assert.NoError(t, err)
 		assert.Equal(t, pluginsCore.PhaseRetryableFailure, taskStatus.Phase())
+		assert.Equal(t, "RegistryUnavailable", taskStatus.SubReason())
 	})
 
 	t.Run("RandomError", func(t *testing.T) {
@@ -734,6 +761,7 @@ func TestDemystifyPending(t *testing.T) {
 		taskStatus, err := DemystifyPending(s)
 		assert.NoError(t, err)
 		assert.Equal(t, pluginsCore.PhaseRetryableFailure, taskStatus.Phase())
+		assert.Equal(t, "RandomError", taskStatus.SubReason())
 	})
 
 	t.Run("CreateContainerConfigError", func(t *testing.T) {
@@ -751,6 +779,7 @@ func TestDemystifyPending(t *testing.T) {
 		taskStatus, err := DemystifyPending(s)
 		assert.NoError(t, err)
 		assert.Equal(t, pluginsCore.PhasePermanentFailure, taskStatus.Phase())
+		assert.Equal(t, "CreateContainerConfigError", taskStatus.SubReason())
 	})
 
 	t.Run("CreateContainerErrorWithinGracePeriod", func(t *testing.T) {
@@ -770,6 +799,7 @@ func TestDemystifyPending(t *testing.T) {
 		taskStatus, err := DemystifyPending(s2)
 		assert.NoError(t, err)
 		assert.Equal(t, pluginsCore.PhaseInitializing, taskStatus.Phase())
+		assert.Equal(t, "CreateContainerError", taskStatus.SubReason())
 	})
 
 	t.Run("CreateContainerErrorOutsideGracePeriod", func(t *testing.T) {
@@ -789,6 +819,7 @@ func TestDemystifyPending(t *testing.T) {
 		taskStatus, err := DemystifyPending(s2)
 		assert.NoError(t, err)
 		assert.Equal(t, pluginsCore.PhasePermanentFailure, taskStatus.Phase())
+		assert.Equal(t, "CreateContainerError", taskStatus.SubReason())
 	})
 }
 
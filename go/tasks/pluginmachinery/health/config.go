@@ -0,0 +1,38 @@
+package health
+
+import (
+	"time"
+
+	"github.com/flyteorg/flytestdlib/config"
+
+	pluginsConfig "github.com/flyteorg/flyteplugins/go/tasks/config"
+)
+
+//go:generate pflags Config --default-var=defaultConfig
+
+var (
+	defaultConfig = &Config{
+		Interval:         config.Duration{Duration: time.Minute},
+		Timeout:          config.Duration{Duration: 10 * time.Second},
+		FailureThreshold: 3,
+	}
+
+	cfgSection = pluginsConfig.MustRegisterSubSection("health", defaultConfig)
+)
+
+// Config sets how often the health of plugins is probed, and how many probes must fail in a row before a plugin is
+// considered unhealthy.
+type Config struct {
+	Interval         config.Duration `json:"interval" pflag:",Interval between two probes of the health of a plugin."`
+	Timeout          config.Duration `json:"timeout" pflag:",Time after which a probe of the health of a plugin is considered failed."`
+	FailureThreshold int             `json:"failureThreshold" pflag:",Number of consecutive failed probes after which a plugin is considered unhealthy."`
+}
+
+func GetConfig() *Config {
+	return cfgSection.GetConfig().(*Config)
+}
+
+// SetConfig should be used for unit testing only
+func SetConfig(cfg *Config) error {
+	return cfgSection.SetConfig(cfg)
+}
@@ -0,0 +1,30 @@
+package health
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+)
+
+// NewCRDChecker returns a checker failing while the kind of obj isn't served by the API server, as seen by the REST
+// mapper of the kube client, e.g. because the CRD of the operator running the resources of a k8s plugin isn't
+// installed. The k8s plugin manager registers it for the resources its plugins watch.
+func NewCRDChecker(kubeClient core.KubeClient, obj client.Object) core.HealthChecker {
+	return core.HealthCheckerFunc(func(ctx context.Context) error {
+		c := kubeClient.GetClient()
+		gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+		if err != nil {
+			return err
+		}
+
+		if _, err := c.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+			return fmt.Errorf("kind [%v] isn't served by the API server: %w", gvk, err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,25 @@
+package health
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+)
+
+func TestNewCRDChecker(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(v1.SchemeGroupVersion.WithKind("Pod"), meta.RESTScopeNamespace)
+	kubeClient := &mocks.KubeClient{}
+	kubeClient.OnGetClient().Return(fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRESTMapper(mapper).Build())
+
+	assert.NoError(t, NewCRDChecker(kubeClient, &v1.Pod{}).CheckHealth(context.TODO()))
+	assert.Error(t, NewCRDChecker(kubeClient, &batchv1.Job{}).CheckHealth(context.TODO()))
+}
@@ -0,0 +1,180 @@
+// Package health probes the health of plugins implementing core.HealthChecker, or of the checkers registered on their
+// behalf, e.g. the CRD presence check of k8s plugins. A plugin is considered unhealthy once the configured number of
+// probes in a row failed, and healthy again as soon as a probe succeeds. The health of plugins is published as
+// metrics, reported through the plugin registry and used by the health interceptor to hold new tasks while their plugin
+// is unhealthy.
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/utils/clock"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+)
+
+// Status is the health of a plugin as of its last probe.
+type Status struct {
+	Healthy bool `json:"healthy"`
+	// Error returned by the last failed probe, if the last probe failed.
+	Error               string    `json:"error,omitempty"`
+	LastChecked         time.Time `json:"lastChecked,omitempty"`
+	ConsecutiveFailures int       `json:"consecutiveFailures,omitempty"`
+}
+
+// Report is the health of all the plugins with a registered checker.
+type Report struct {
+	// Healthy is set if all the plugins are healthy.
+	Healthy bool              `json:"healthy"`
+	Plugins map[string]Status `json:"plugins"`
+}
+
+type metrics struct {
+	healthy       *prometheus.GaugeVec
+	probeFailures *prometheus.CounterVec
+}
+
+// Monitor periodically probes the health of the registered plugins.
+type Monitor struct {
+	lock     sync.RWMutex
+	checkers map[string]core.HealthChecker
+	statuses map[string]Status
+	clock    clock.WithTicker
+	start    sync.Once
+	metrics  metrics
+}
+
+// Register sets the checker probing the health of a plugin, replacing the one previously registered. The plugin is
+// considered healthy until probed. The periodic probes start with the first registered checker, the first one happening
+// one interval later.
+func (m *Monitor) Register(pluginID string, checker core.HealthChecker) {
+	m.lock.Lock()
+	m.checkers[pluginID] = checker
+	if _, found := m.statuses[pluginID]; !found {
+		m.statuses[pluginID] = Status{Healthy: true}
+		m.metrics.healthy.WithLabelValues(pluginID).Set(1)
+	}
+	m.lock.Unlock()
+
+	m.start.Do(func() {
+		if interval := GetConfig().Interval.Duration; interval > 0 {
+			go m.run(context.Background(), interval)
+		}
+	})
+}
+
+func (m *Monitor) run(ctx context.Context, interval time.Duration) {
+	ticker := m.clock.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+			m.Probe(ctx)
+		}
+	}
+}
+
+// Probe checks the health of all the registered plugins concurrently and returns once all the checks are done.
+func (m *Monitor) Probe(ctx context.Context) {
+	cfg := GetConfig()
+	m.lock.RLock()
+	checkers := make(map[string]core.HealthChecker, len(m.checkers))
+	for pluginID, checker := range m.checkers {
+		checkers[pluginID] = checker
+	}
+	m.lock.RUnlock()
+
+	wg := sync.WaitGroup{}
+	for pluginID, checker := range checkers {
+		wg.Add(1)
+		go func(pluginID string, checker core.HealthChecker) {
+			defer wg.Done()
+			m.probe(ctx, cfg, pluginID, checker)
+		}(pluginID, checker)
+	}
+
+	wg.Wait()
+}
+
+func (m *Monitor) probe(ctx context.Context, cfg *Config, pluginID string, checker core.HealthChecker) {
+	if cfg.Timeout.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.Timeout.Duration)
+		defer cancel()
+	}
+
+	err := checker.CheckHealth(ctx)
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	status := m.statuses[pluginID]
+	if err == nil {
+		if !status.Healthy {
+			logger.Infof(ctx, "Plugin [%v] is healthy again", pluginID)
+		}
+
+		m.statuses[pluginID] = Status{Healthy: true, LastChecked: m.clock.Now()}
+		m.metrics.healthy.WithLabelValues(pluginID).Set(1)
+		return
+	}
+
+	m.metrics.probeFailures.WithLabelValues(pluginID).Inc()
+	status.Error = err.Error()
+	status.LastChecked = m.clock.Now()
+	status.ConsecutiveFailures++
+	if status.Healthy && status.ConsecutiveFailures >= cfg.FailureThreshold {
+		logger.Warnf(ctx, "Plugin [%v] is unhealthy after [%v] failed probes. Error: %v", pluginID,
+			status.ConsecutiveFailures, err)
+		status.Healthy = false
+		m.metrics.healthy.WithLabelValues(pluginID).Set(0)
+	}
+
+	m.statuses[pluginID] = status
+}
+
+// Status returns the health of a plugin. Plugins without a registered checker are always healthy.
+func (m *Monitor) Status(pluginID string) Status {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	if status, found := m.statuses[pluginID]; found {
+		return status
+	}
+
+	return Status{Healthy: true}
+}
+
+// Report returns the health of all the plugins with a registered checker.
+func (m *Monitor) Report() Report {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	report := Report{Healthy: true, Plugins: make(map[string]Status, len(m.statuses))}
+	for pluginID, status := range m.statuses {
+		report.Plugins[pluginID] = status
+		report.Healthy = report.Healthy && status.Healthy
+	}
+
+	return report
+}
+
+// NewMonitor creates a monitor publishing its metrics under scope. Its configuration is read when the first checker is
+// registered and on every probe.
+func NewMonitor(scope promutils.Scope, c clock.WithTicker) *Monitor {
+	return &Monitor{
+		checkers: map[string]core.HealthChecker{},
+		statuses: map[string]Status{},
+		clock:    c,
+		metrics: metrics{
+			healthy: scope.MustNewGaugeVec("healthy",
+				"Whether plugins are healthy (1) or not (0), as of their last probe.", "plugin"),
+			probeFailures: scope.MustNewCounterVec("probe_failures", "Number of failed probes of the health of plugins.",
+				"plugin"),
+		},
+	}
+}
@@ -0,0 +1,73 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+	testingclock "k8s.io/utils/clock/testing"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+)
+
+func TestMonitor(t *testing.T) {
+	defer func() { assert.NoError(t, SetConfig(defaultConfig)) }()
+	assert.NoError(t, SetConfig(&Config{
+		Interval:         config.Duration{Duration: time.Minute},
+		Timeout:          config.Duration{Duration: time.Second},
+		FailureThreshold: 2,
+	}))
+
+	ctx := context.Background()
+	start := time.Now()
+	fakeClock := testingclock.NewFakeClock(start)
+	m := NewMonitor(promutils.NewTestScope(), fakeClock)
+
+	var probes, failing int32
+	m.Register("remote", core.HealthCheckerFunc(func(ctx context.Context) error {
+		atomic.AddInt32(&probes, 1)
+		if atomic.LoadInt32(&failing) == 1 {
+			return fmt.Errorf("unauthorized")
+		}
+
+		_, hasDeadline := ctx.Deadline()
+		assert.True(t, hasDeadline)
+		return nil
+	}))
+
+	t.Run("unknown plugins are healthy", func(t *testing.T) {
+		assert.Equal(t, Status{Healthy: true}, m.Status("other"))
+		assert.Equal(t, Report{Healthy: true, Plugins: map[string]Status{"remote": {Healthy: true}}}, m.Report())
+	})
+
+	t.Run("unhealthy past the failure threshold", func(t *testing.T) {
+		atomic.StoreInt32(&failing, 1)
+		m.Probe(ctx)
+		assert.True(t, m.Status("remote").Healthy)
+		assert.Equal(t, 1, m.Status("remote").ConsecutiveFailures)
+
+		m.Probe(ctx)
+		assert.Equal(t, Status{Error: "unauthorized", LastChecked: start, ConsecutiveFailures: 2}, m.Status("remote"))
+		assert.False(t, m.Report().Healthy)
+	})
+
+	t.Run("healthy again once a probe succeeds", func(t *testing.T) {
+		atomic.StoreInt32(&failing, 0)
+		m.Probe(ctx)
+		assert.Equal(t, Status{Healthy: true, LastChecked: start}, m.Status("remote"))
+		assert.True(t, m.Report().Healthy)
+	})
+
+	t.Run("probed periodically", func(t *testing.T) {
+		probed := atomic.LoadInt32(&probes)
+		assert.Eventually(t, func() bool {
+			fakeClock.Step(time.Minute)
+			return atomic.LoadInt32(&probes) > probed
+		}, time.Second, 10*time.Millisecond)
+	})
+}
@@ -0,0 +1,49 @@
+package interceptor
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/audit"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
+)
+
+// AuditName is the name the Audit interceptor is registered under.
+const AuditName = "audit"
+
+// Audit sets the identity of the task in the context of plugin calls, so that the side effects taken with that context
+// during the calls (e.g. by a kube client wrapped by audit.NewKubeClient) are recorded on behalf of the task. The
+// objects k8s plugins return from BuildResource are created by the k8s plugin manager after the call, with a context of
+// its own, so they aren't recorded.
+type Audit struct{}
+
+func withAuditedTask(ctx context.Context, pluginID string, taskExecMetadata core.TaskExecutionMetadata) context.Context {
+	return audit.WithTask(ctx, audit.TaskFromMetadata(pluginID, taskExecMetadata))
+}
+
+func (Audit) InterceptHandle(ctx context.Context, pluginID string, tCtx core.TaskExecutionContext,
+	next HandleFunc) (core.Transition, error) {
+	return next(withAuditedTask(ctx, pluginID, tCtx.TaskExecutionMetadata()), tCtx)
+}
+
+func (Audit) InterceptAbort(ctx context.Context, pluginID string, tCtx core.TaskExecutionContext,
+	next AbortFunc) error {
+	return next(withAuditedTask(ctx, pluginID, tCtx.TaskExecutionMetadata()), tCtx)
+}
+
+func (Audit) InterceptFinalize(ctx context.Context, pluginID string, tCtx core.TaskExecutionContext,
+	next FinalizeFunc) error {
+	return next(withAuditedTask(ctx, pluginID, tCtx.TaskExecutionMetadata()), tCtx)
+}
+
+func (Audit) InterceptBuildResource(ctx context.Context, pluginID string, tCtx core.TaskExecutionContext,
+	next BuildResourceFunc) (client.Object, error) {
+	return next(withAuditedTask(ctx, pluginID, tCtx.TaskExecutionMetadata()), tCtx)
+}
+
+func (Audit) InterceptGetTaskPhase(ctx context.Context, pluginID string, pluginContext k8s.PluginContext,
+	resource client.Object, next GetTaskPhaseFunc) (core.PhaseInfo, error) {
+	return next(withAuditedTask(ctx, pluginID, pluginContext.TaskExecutionMetadata()), pluginContext, resource)
+}
@@ -0,0 +1,69 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/audit"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	coreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	k8sMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s/mocks"
+)
+
+func TestAudit(t *testing.T) {
+	ctx := context.Background()
+	tMeta := dummyTaskExecMetadata()
+	tMeta.OnGetSecurityContext().Return(idlCore.SecurityContext{RunAs: &idlCore.Identity{K8SServiceAccount: "run-as"}})
+	tMeta.OnGetK8sServiceAccount().Return("default")
+	tCtx := &coreMocks.TaskExecutionContext{}
+	tCtx.OnTaskExecutionMetadata().Return(tMeta)
+
+	expected := audit.Task{
+		ExecutionID: "exec-name-n0-0",
+		Project:     "flytesnacks",
+		Domain:      "development",
+		Principal:   "run-as",
+		PluginID:    "my-plugin",
+	}
+
+	isAudited := func(pluginID string) interface{} {
+		task := expected
+		task.PluginID = pluginID
+		return mock.MatchedBy(func(ctx context.Context) bool { return audit.TaskFromContext(ctx) == task })
+	}
+
+	t.Run("core plugin", func(t *testing.T) {
+		plugin := &coreMocks.Plugin{}
+		plugin.OnGetID().Return("my-plugin")
+		plugin.OnHandleMatch(isAudited("my-plugin"), mock.Anything).Return(
+			core.DoTransition(core.PhaseInfoRunning(1, nil)), nil)
+		plugin.OnAbortMatch(isAudited("my-plugin"), mock.Anything).Return(nil)
+		plugin.OnFinalizeMatch(isAudited("my-plugin"), mock.Anything).Return(nil)
+
+		p := Chain{Audit{}}.WrapCorePlugin(plugin)
+		_, err := p.Handle(ctx, tCtx)
+		assert.NoError(t, err)
+		assert.NoError(t, p.Abort(ctx, tCtx))
+		assert.NoError(t, p.Finalize(ctx, tCtx))
+	})
+
+	t.Run("k8s plugin", func(t *testing.T) {
+		pluginContext := &k8sMocks.PluginContext{}
+		pluginContext.OnTaskExecutionMetadata().Return(tMeta)
+		plugin := &k8sMocks.Plugin{}
+		plugin.OnBuildResourceMatch(isAudited("my-k8s-plugin"), mock.Anything).Return(&v1.Pod{}, nil)
+		plugin.OnGetTaskPhaseMatch(isAudited("my-k8s-plugin"), mock.Anything, mock.Anything).Return(
+			core.PhaseInfoRunning(1, nil), nil)
+
+		p := Chain{Audit{}}.WrapK8sPlugin("my-k8s-plugin", plugin)
+		_, err := p.BuildResource(ctx, tCtx)
+		assert.NoError(t, err)
+		_, err = p.GetTaskPhase(ctx, pluginContext, &v1.Pod{})
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,49 @@
+package interceptor
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
+)
+
+// ErrorClassificationName is the name the ErrorClassification interceptor is registered under.
+const ErrorClassificationName = "error-classification"
+
+// ErrorClassification applies the error classification rules specific to plugins and projects to the failures reported
+// by Handle and GetTaskPhase. Rules applying to all plugins and projects are already applied when failures are built.
+type ErrorClassification struct {
+	PassThrough
+}
+
+func classificationTarget(pluginID string, taskExecMetadata core.TaskExecutionMetadata) errors.Target {
+	taskExecID := taskExecMetadata.GetTaskExecutionID().GetID()
+	return errors.Target{
+		PluginID: pluginID,
+		Project:  taskExecID.GetNodeExecutionId().GetExecutionId().GetProject(),
+	}
+}
+
+func (ErrorClassification) InterceptHandle(ctx context.Context, pluginID string, tCtx core.TaskExecutionContext,
+	next HandleFunc) (core.Transition, error) {
+	t, err := next(ctx, tCtx)
+	if err != nil || !t.Info().Phase().IsFailure() {
+		return t, err
+	}
+
+	info := t.Info().Classify(classificationTarget(pluginID, tCtx.TaskExecutionMetadata()))
+	return core.DoTransitionType(t.Type(), info), nil
+}
+
+func (ErrorClassification) InterceptGetTaskPhase(ctx context.Context, pluginID string,
+	pluginContext k8s.PluginContext, resource client.Object, next GetTaskPhaseFunc) (core.PhaseInfo, error) {
+	p, err := next(ctx, pluginContext, resource)
+	if err != nil || !p.Phase().IsFailure() {
+		return p, err
+	}
+
+	return p.Classify(classificationTarget(pluginID, pluginContext.TaskExecutionMetadata())), nil
+}
@@ -0,0 +1,67 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	coreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	k8sMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s/mocks"
+)
+
+func TestErrorClassification(t *testing.T) {
+	ctx := context.Background()
+	defer func() { assert.NoError(t, errors.SetConfig(&errors.Config{})) }()
+
+	retryable := true
+	assert.NoError(t, errors.SetConfig(&errors.Config{Rules: []errors.Rule{
+		{Plugins: []string{"my-plugin", "my-k8s-plugin"}, Projects: []string{"flytesnacks"}, Codes: []string{"Flaky"},
+			Class: errors.ClassInfrastructure, Retryable: &retryable},
+	}}))
+
+	tCtx := &coreMocks.TaskExecutionContext{}
+	tCtx.OnTaskExecutionMetadata().Return(dummyTaskExecMetadata())
+
+	t.Run("core plugin", func(t *testing.T) {
+		plugin := &coreMocks.Plugin{}
+		plugin.OnGetID().Return("my-plugin")
+		plugin.OnHandleMatch(mock.Anything, mock.Anything).Return(core.DoTransitionType(core.TransitionTypeBarrier,
+			core.PhaseInfoFailure("Flaky", "reason", nil)), nil).Once()
+		plugin.OnHandleMatch(mock.Anything, mock.Anything).Return(core.DoTransition(
+			core.PhaseInfoFailure("BadTaskSpecification", "reason", nil)), nil)
+
+		p := Chain{ErrorClassification{}}.WrapCorePlugin(plugin)
+		transition, err := p.Handle(ctx, tCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, core.TransitionTypeBarrier, transition.Type())
+		assert.Equal(t, core.PhaseRetryableFailure, transition.Info().Phase())
+		assert.Equal(t, errors.ClassInfrastructure, transition.Info().Classification().Class)
+
+		transition, err = p.Handle(ctx, tCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhasePermanentFailure, transition.Info().Phase())
+	})
+
+	t.Run("k8s plugin", func(t *testing.T) {
+		pluginContext := &k8sMocks.PluginContext{}
+		pluginContext.OnTaskExecutionMetadata().Return(dummyTaskExecMetadata())
+		plugin := &k8sMocks.Plugin{}
+		plugin.OnGetTaskPhaseMatch(mock.Anything, mock.Anything, mock.Anything).Return(
+			core.PhaseInfoFailure("Flaky", "reason", nil), nil)
+
+		phase, err := Chain{ErrorClassification{}}.WrapK8sPlugin("my-k8s-plugin", plugin).GetTaskPhase(ctx,
+			pluginContext, &v1.Pod{})
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhaseRetryableFailure, phase.Phase())
+
+		phase, err = Chain{ErrorClassification{}}.WrapK8sPlugin("other-plugin", plugin).GetTaskPhase(ctx,
+			pluginContext, &v1.Pod{})
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhasePermanentFailure, phase.Phase())
+	})
+}
@@ -0,0 +1,33 @@
+package interceptor
+
+import (
+	"github.com/flyteorg/flyteplugins/go/tasks/config"
+)
+
+//go:generate pflags Config --default-var=defaultConfig
+
+var (
+	defaultConfig = &Config{
+		Order: []string{ErrorClassificationName},
+	}
+
+	cfgSection = config.MustRegisterSubSection("interceptors", defaultConfig)
+)
+
+// Config enables interceptors registered with the plugin registry. Plugins fail to load, or to run tasks for k8s
+// plugins, if Order lists interceptors that aren't registered, or if it leaves out the error-classification interceptor
+// while error classification rules name plugins or projects. Only the error-classification interceptor is enabled by
+// default.
+type Config struct {
+	// Order lists the names of the interceptors to wrap plugins with, from the outermost to the innermost one.
+	Order []string `json:"order" pflag:",Names of the interceptors to wrap plugins with, from the outermost to the innermost one."`
+}
+
+func GetConfig() *Config {
+	return cfgSection.GetConfig().(*Config)
+}
+
+// SetConfig should be used for unit testing only
+func SetConfig(cfg *Config) error {
+	return cfgSection.SetConfig(cfg)
+}
@@ -0,0 +1,45 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/logger"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/health"
+)
+
+// HealthName is the name the Health interceptor is registered under.
+const HealthName = "health"
+
+// Health holds new tasks in PhaseWaitingForResources while their plugin is unhealthy, instead of letting them fail
+// and burn their retries. Tasks are considered new until their plugin writes plugin state at a version other than 0,
+// which only web API plugins guarantee: the web API framework writes its state at version 1 on the first round of
+// every task, while other core plugins, e.g. the array, hive and presto plugins, write theirs at version 0. Hence only
+// the tasks of the plugins Holds returns true for are held. Tasks of k8s plugins aren't held, the k8s plugin manager
+// can check the health of their plugin before creating their resources.
+type Health struct {
+	PassThrough
+	Monitor *health.Monitor
+	// Holds returns true if the new tasks of the plugin can be held. The plugin registry holds those of the web API
+	// plugins registered with it.
+	Holds func(pluginID string) bool
+}
+
+func (h Health) InterceptHandle(ctx context.Context, pluginID string, tCtx core.TaskExecutionContext,
+	next HandleFunc) (core.Transition, error) {
+	if h.Holds == nil || !h.Holds(pluginID) || tCtx.PluginStateReader().GetStateVersion() != 0 {
+		return next(ctx, tCtx)
+	}
+
+	status := h.Monitor.Status(pluginID)
+	if status.Healthy {
+		return next(ctx, tCtx)
+	}
+
+	logger.Infof(ctx, "Holding new task of unhealthy plugin [%v]", pluginID)
+	return core.DoTransition(core.PhaseInfoWaitingForResources(time.Now(), core.DefaultPhaseVersion,
+		fmt.Sprintf("Plugin [%v] is unhealthy: %v", pluginID, status.Error))), nil
+}
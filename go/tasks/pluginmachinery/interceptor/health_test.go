@@ -0,0 +1,78 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"k8s.io/utils/clock"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	coreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/health"
+)
+
+func TestHealth(t *testing.T) {
+	defer func(cfg health.Config) { assert.NoError(t, health.SetConfig(&cfg)) }(*health.GetConfig())
+	assert.NoError(t, health.SetConfig(&health.Config{FailureThreshold: 1}))
+
+	ctx := context.Background()
+	monitor := health.NewMonitor(promutils.NewTestScope(), clock.RealClock{})
+	monitor.Register("my-plugin", core.HealthCheckerFunc(func(context.Context) error {
+		return fmt.Errorf("unauthorized")
+	}))
+	monitor.Register("array", core.HealthCheckerFunc(func(context.Context) error {
+		return fmt.Errorf("unauthorized")
+	}))
+	monitor.Probe(ctx)
+	chain := Chain{Health{Monitor: monitor, Holds: func(pluginID string) bool { return pluginID != "array" }}}
+
+	newPlugin := func(id string) *coreMocks.Plugin {
+		plugin := &coreMocks.Plugin{}
+		plugin.OnGetID().Return(id)
+		plugin.OnHandleMatch(mock.Anything, mock.Anything).Return(core.DoTransition(core.PhaseInfoRunning(1, nil)), nil)
+		return plugin
+	}
+
+	newTask := func(stateVersion uint8) *coreMocks.TaskExecutionContext {
+		stateReader := &coreMocks.PluginStateReader{}
+		stateReader.OnGetStateVersion().Return(stateVersion)
+		tCtx := &coreMocks.TaskExecutionContext{}
+		tCtx.OnPluginStateReader().Return(stateReader)
+		return tCtx
+	}
+
+	t.Run("new task of an unhealthy plugin", func(t *testing.T) {
+		plugin := newPlugin("my-plugin")
+		tr, err := chain.WrapCorePlugin(plugin).Handle(ctx, newTask(0))
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhaseWaitingForResources, tr.Info().Phase())
+		assert.Equal(t, "Plugin [my-plugin] is unhealthy: unauthorized", tr.Info().Reason())
+		plugin.AssertNotCalled(t, "Handle", mock.Anything, mock.Anything)
+	})
+
+	t.Run("started task of an unhealthy plugin", func(t *testing.T) {
+		tr, err := chain.WrapCorePlugin(newPlugin("my-plugin")).Handle(ctx, newTask(1))
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhaseRunning, tr.Info().Phase())
+	})
+
+	t.Run("new task of a healthy plugin", func(t *testing.T) {
+		tr, err := chain.WrapCorePlugin(newPlugin("other")).Handle(ctx, newTask(0))
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhaseRunning, tr.Info().Phase())
+	})
+
+	t.Run("new task of an unhealthy plugin that isn't held", func(t *testing.T) {
+		tr, err := chain.WrapCorePlugin(newPlugin("array")).Handle(ctx, newTask(0))
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhaseRunning, tr.Info().Phase())
+
+		tr, err = Chain{Health{Monitor: monitor}}.WrapCorePlugin(newPlugin("my-plugin")).Handle(ctx, newTask(0))
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhaseRunning, tr.Info().Phase())
+	})
+}
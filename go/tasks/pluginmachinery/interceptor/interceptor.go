@@ -0,0 +1,297 @@
+// Package interceptor provides a chain of interceptors wrapping the calls the system makes to plugins. Interceptors
+// implement cross-cutting behavior (e.g. metrics, logging or panic recovery) once for all plugins. They are registered
+// with the plugin registry and enabled, in order, through config.
+package interceptor
+
+import (
+	"context"
+
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
+)
+
+// Operation identifies the plugin method an interceptor is invoked for.
+type Operation string
+
+const (
+	OperationHandle        Operation = "handle"
+	OperationAbort         Operation = "abort"
+	OperationFinalize      Operation = "finalize"
+	OperationBuildResource Operation = "build-resource"
+	OperationGetTaskPhase  Operation = "get-task-phase"
+)
+
+// HandleFunc invokes core.Plugin.Handle, or the next interceptor in the chain.
+type HandleFunc func(ctx context.Context, tCtx core.TaskExecutionContext) (core.Transition, error)
+
+// AbortFunc invokes core.Plugin.Abort, or the next interceptor in the chain.
+type AbortFunc func(ctx context.Context, tCtx core.TaskExecutionContext) error
+
+// FinalizeFunc invokes core.Plugin.Finalize, or the next interceptor in the chain.
+type FinalizeFunc func(ctx context.Context, tCtx core.TaskExecutionContext) error
+
+// BuildResourceFunc invokes k8s.Plugin.BuildResource, or the next interceptor in the chain.
+type BuildResourceFunc func(ctx context.Context, tCtx core.TaskExecutionContext) (client.Object, error)
+
+// GetTaskPhaseFunc invokes k8s.Plugin.GetTaskPhase, or the next interceptor in the chain.
+type GetTaskPhaseFunc func(ctx context.Context, pluginContext k8s.PluginContext, resource client.Object) (
+	core.PhaseInfo, error)
+
+// Interceptor wraps the calls made to plugins. Each method is given the id of the plugin being called and the next
+// function in the chain, which it's expected to call, unless it short-circuits the call. Interceptors are shared by
+// all plugins and must be safe for concurrent use. Embed PassThrough to only intercept some of the methods.
+type Interceptor interface {
+	InterceptHandle(ctx context.Context, pluginID string, tCtx core.TaskExecutionContext, next HandleFunc) (
+		core.Transition, error)
+	InterceptAbort(ctx context.Context, pluginID string, tCtx core.TaskExecutionContext, next AbortFunc) error
+	InterceptFinalize(ctx context.Context, pluginID string, tCtx core.TaskExecutionContext, next FinalizeFunc) error
+	InterceptBuildResource(ctx context.Context, pluginID string, tCtx core.TaskExecutionContext,
+		next BuildResourceFunc) (client.Object, error)
+	InterceptGetTaskPhase(ctx context.Context, pluginID string, pluginContext k8s.PluginContext,
+		resource client.Object, next GetTaskPhaseFunc) (core.PhaseInfo, error)
+}
+
+// PassThrough is an Interceptor that calls next for all methods.
+type PassThrough struct{}
+
+func (PassThrough) InterceptHandle(ctx context.Context, _ string, tCtx core.TaskExecutionContext, next HandleFunc) (
+	core.Transition, error) {
+	return next(ctx, tCtx)
+}
+
+func (PassThrough) InterceptAbort(ctx context.Context, _ string, tCtx core.TaskExecutionContext, next AbortFunc) error {
+	return next(ctx, tCtx)
+}
+
+func (PassThrough) InterceptFinalize(ctx context.Context, _ string, tCtx core.TaskExecutionContext,
+	next FinalizeFunc) error {
+	return next(ctx, tCtx)
+}
+
+func (PassThrough) InterceptBuildResource(ctx context.Context, _ string, tCtx core.TaskExecutionContext,
+	next BuildResourceFunc) (client.Object, error) {
+	return next(ctx, tCtx)
+}
+
+func (PassThrough) InterceptGetTaskPhase(ctx context.Context, _ string, pluginContext k8s.PluginContext,
+	resource client.Object, next GetTaskPhaseFunc) (core.PhaseInfo, error) {
+	return next(ctx, pluginContext, resource)
+}
+
+// Chain is an ordered list of interceptors. The first interceptor is the outermost one: it's invoked first and sees
+// the results of all the others.
+type Chain []Interceptor
+
+func (c Chain) handle(pluginID string, f HandleFunc) HandleFunc {
+	for i := len(c) - 1; i >= 0; i-- {
+		interceptor, next := c[i], f
+		f = func(ctx context.Context, tCtx core.TaskExecutionContext) (core.Transition, error) {
+			return interceptor.InterceptHandle(ctx, pluginID, tCtx, next)
+		}
+	}
+
+	return f
+}
+
+func (c Chain) abort(pluginID string, f AbortFunc) AbortFunc {
+	for i := len(c) - 1; i >= 0; i-- {
+		interceptor, next := c[i], f
+		f = func(ctx context.Context, tCtx core.TaskExecutionContext) error {
+			return interceptor.InterceptAbort(ctx, pluginID, tCtx, next)
+		}
+	}
+
+	return f
+}
+
+func (c Chain) finalize(pluginID string, f FinalizeFunc) FinalizeFunc {
+	for i := len(c) - 1; i >= 0; i-- {
+		interceptor, next := c[i], f
+		f = func(ctx context.Context, tCtx core.TaskExecutionContext) error {
+			return interceptor.InterceptFinalize(ctx, pluginID, tCtx, next)
+		}
+	}
+
+	return f
+}
+
+func (c Chain) buildResource(pluginID string, f BuildResourceFunc) BuildResourceFunc {
+	for i := len(c) - 1; i >= 0; i-- {
+		interceptor, next := c[i], f
+		f = func(ctx context.Context, tCtx core.TaskExecutionContext) (client.Object, error) {
+			return interceptor.InterceptBuildResource(ctx, pluginID, tCtx, next)
+		}
+	}
+
+	return f
+}
+
+func (c Chain) getTaskPhase(pluginID string, f GetTaskPhaseFunc) GetTaskPhaseFunc {
+	for i := len(c) - 1; i >= 0; i-- {
+		interceptor, next := c[i], f
+		f = func(ctx context.Context, pluginContext k8s.PluginContext, resource client.Object) (core.PhaseInfo, error) {
+			return interceptor.InterceptGetTaskPhase(ctx, pluginID, pluginContext, resource, next)
+		}
+	}
+
+	return f
+}
+
+func validate(ctx context.Context, plugin interface{}, taskTemplate *idlCore.TaskTemplate) error {
+	if validator, ok := plugin.(core.PluginValidator); ok {
+		return validator.Validate(ctx, taskTemplate)
+	}
+
+	return nil
+}
+
+type corePlugin struct {
+	core.Plugin
+	handle   HandleFunc
+	abort    AbortFunc
+	finalize FinalizeFunc
+}
+
+func (p corePlugin) Handle(ctx context.Context, tCtx core.TaskExecutionContext) (core.Transition, error) {
+	return p.handle(ctx, tCtx)
+}
+
+func (p corePlugin) Abort(ctx context.Context, tCtx core.TaskExecutionContext) error {
+	return p.abort(ctx, tCtx)
+}
+
+func (p corePlugin) Finalize(ctx context.Context, tCtx core.TaskExecutionContext) error {
+	return p.finalize(ctx, tCtx)
+}
+
+// Validate keeps the core.PluginValidator implementation of the wrapped plugin visible. Plugins that don't implement it
+// accept all task templates.
+func (p corePlugin) Validate(ctx context.Context, taskTemplate *idlCore.TaskTemplate) error {
+	return validate(ctx, p.Plugin, taskTemplate)
+}
+
+// corePluginWithHealthChecker keeps the core.HealthChecker implementation of the wrapped plugin visible.
+type corePluginWithHealthChecker struct {
+	corePlugin
+	core.HealthChecker
+}
+
+// WrapCorePlugin returns a plugin that runs the calls to Handle, Abort and Finalize through the chain. The wrapper
+// implements core.HealthChecker if the plugin does. The plugin is returned as is if the chain is empty.
+func (c Chain) WrapCorePlugin(plugin core.Plugin) core.Plugin {
+	if len(c) == 0 {
+		return plugin
+	}
+
+	id := plugin.GetID()
+	wrapped := corePlugin{
+		Plugin:   plugin,
+		handle:   c.handle(id, plugin.Handle),
+		abort:    c.abort(id, plugin.Abort),
+		finalize: c.finalize(id, plugin.Finalize),
+	}
+
+	if checker, ok := plugin.(core.HealthChecker); ok {
+		return corePluginWithHealthChecker{corePlugin: wrapped, HealthChecker: checker}
+	}
+
+	return wrapped
+}
+
+// WrapCorePluginEntry returns an entry whose loaded plugins are wrapped by the chain.
+func (c Chain) WrapCorePluginEntry(entry core.PluginEntry) core.PluginEntry {
+	if len(c) == 0 {
+		return entry
+	}
+
+	load := entry.LoadPlugin
+	entry.LoadPlugin = func(ctx context.Context, iCtx core.SetupContext) (core.Plugin, error) {
+		plugin, err := load(ctx, iCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		return c.WrapCorePlugin(plugin), nil
+	}
+
+	return entry
+}
+
+type k8sPlugin struct {
+	k8s.Plugin
+	buildResource BuildResourceFunc
+	getTaskPhase  GetTaskPhaseFunc
+}
+
+func (p k8sPlugin) BuildResource(ctx context.Context, tCtx core.TaskExecutionContext) (client.Object, error) {
+	return p.buildResource(ctx, tCtx)
+}
+
+func (p k8sPlugin) GetTaskPhase(ctx context.Context, pluginContext k8s.PluginContext, resource client.Object) (
+	core.PhaseInfo, error) {
+	return p.getTaskPhase(ctx, pluginContext, resource)
+}
+
+// Validate keeps the core.PluginValidator implementation of the wrapped plugin visible. Plugins that don't implement it
+// accept all task templates.
+func (p k8sPlugin) Validate(ctx context.Context, taskTemplate *idlCore.TaskTemplate) error {
+	return validate(ctx, p.Plugin, taskTemplate)
+}
+
+// k8sPluginWithAbortOverride keeps the k8s.PluginAbortOverride implementation of the wrapped plugin visible.
+type k8sPluginWithAbortOverride struct {
+	k8sPlugin
+	k8s.PluginAbortOverride
+}
+
+// k8sPluginWithHealthChecker keeps the core.HealthChecker implementation of the wrapped plugin visible.
+type k8sPluginWithHealthChecker struct {
+	k8sPlugin
+	core.HealthChecker
+}
+
+// k8sPluginWithAbortOverrideAndHealthChecker keeps both the k8s.PluginAbortOverride and core.HealthChecker
+// implementations of the wrapped plugin visible.
+type k8sPluginWithAbortOverrideAndHealthChecker struct {
+	k8sPlugin
+	k8s.PluginAbortOverride
+	core.HealthChecker
+}
+
+// WrapK8sPlugin returns a plugin that runs the calls to BuildResource and GetTaskPhase through the chain. The wrapper
+// implements core.HealthChecker and k8s.PluginAbortOverride if the plugin does. The plugin is returned as is if the
+// chain is empty.
+func (c Chain) WrapK8sPlugin(pluginID string, plugin k8s.Plugin) k8s.Plugin {
+	if len(c) == 0 {
+		return plugin
+	}
+
+	wrapped := k8sPlugin{
+		Plugin:        plugin,
+		buildResource: c.buildResource(pluginID, plugin.BuildResource),
+		getTaskPhase:  c.getTaskPhase(pluginID, plugin.GetTaskPhase),
+	}
+
+	abortOverride, isAbortOverride := plugin.(k8s.PluginAbortOverride)
+	checker, isHealthChecker := plugin.(core.HealthChecker)
+	switch {
+	case isAbortOverride && isHealthChecker:
+		return k8sPluginWithAbortOverrideAndHealthChecker{k8sPlugin: wrapped, PluginAbortOverride: abortOverride,
+			HealthChecker: checker}
+	case isAbortOverride:
+		return k8sPluginWithAbortOverride{k8sPlugin: wrapped, PluginAbortOverride: abortOverride}
+	case isHealthChecker:
+		return k8sPluginWithHealthChecker{k8sPlugin: wrapped, HealthChecker: checker}
+	default:
+		return wrapped
+	}
+}
+
+// WrapK8sPluginEntry returns an entry whose plugin is wrapped by the chain.
+func (c Chain) WrapK8sPluginEntry(entry k8s.PluginEntry) k8s.PluginEntry {
+	entry.Plugin = c.WrapK8sPlugin(entry.ID, entry.Plugin)
+	return entry
+}
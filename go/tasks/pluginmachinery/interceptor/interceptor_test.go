@@ -0,0 +1,229 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	coreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
+	k8sMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s/mocks"
+)
+
+// recorder appends its name to calls before and after calling next.
+type recorder struct {
+	PassThrough
+	name  string
+	calls *[]string
+}
+
+func (r recorder) InterceptHandle(ctx context.Context, pluginID string, tCtx core.TaskExecutionContext,
+	next HandleFunc) (core.Transition, error) {
+	*r.calls = append(*r.calls, r.name+":"+pluginID)
+	t, err := next(ctx, tCtx)
+	*r.calls = append(*r.calls, r.name)
+	return t, err
+}
+
+func (r recorder) InterceptGetTaskPhase(ctx context.Context, pluginID string, pluginContext k8s.PluginContext,
+	resource client.Object, next GetTaskPhaseFunc) (core.PhaseInfo, error) {
+	*r.calls = append(*r.calls, r.name+":"+pluginID)
+	p, err := next(ctx, pluginContext, resource)
+	*r.calls = append(*r.calls, r.name)
+	return p, err
+}
+
+// shortCircuit returns a fixed transition without calling next.
+type shortCircuit struct {
+	PassThrough
+}
+
+func (shortCircuit) InterceptHandle(context.Context, string, core.TaskExecutionContext, HandleFunc) (
+	core.Transition, error) {
+	return core.DoTransition(core.PhaseInfoWaitingForResourcesInfo(time.Time{}, core.DefaultPhaseVersion,
+		"throttled", nil)), nil
+}
+
+func newCorePlugin() *coreMocks.Plugin {
+	p := &coreMocks.Plugin{}
+	p.OnGetID().Return("my-plugin")
+	p.OnGetProperties().Return(core.PluginProperties{DisableNodeLevelCaching: true})
+	p.OnHandleMatch(mock.Anything, mock.Anything).Return(core.DoTransition(core.PhaseInfoRunning(1, nil)), nil)
+	p.OnAbortMatch(mock.Anything, mock.Anything).Return(nil)
+	p.OnFinalizeMatch(mock.Anything, mock.Anything).Return(nil)
+	return p
+}
+
+func TestChain_WrapCorePlugin(t *testing.T) {
+	ctx := context.Background()
+	tCtx := &coreMocks.TaskExecutionContext{}
+
+	t.Run("empty chain", func(t *testing.T) {
+		p := newCorePlugin()
+		assert.Equal(t, core.Plugin(p), Chain{}.WrapCorePlugin(p))
+	})
+
+	t.Run("order", func(t *testing.T) {
+		var calls []string
+		p := Chain{
+			recorder{name: "outer", calls: &calls},
+			recorder{name: "inner", calls: &calls},
+		}.WrapCorePlugin(newCorePlugin())
+
+		assert.Equal(t, "my-plugin", p.GetID())
+		assert.True(t, p.GetProperties().DisableNodeLevelCaching)
+
+		transition, err := p.Handle(ctx, tCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhaseRunning, transition.Info().Phase())
+		assert.Equal(t, []string{"outer:my-plugin", "inner:my-plugin", "inner", "outer"}, calls)
+
+		assert.NoError(t, p.Abort(ctx, tCtx))
+		assert.NoError(t, p.Finalize(ctx, tCtx))
+	})
+
+	t.Run("short circuit", func(t *testing.T) {
+		plugin := newCorePlugin()
+		p := Chain{shortCircuit{}}.WrapCorePlugin(plugin)
+
+		transition, err := p.Handle(ctx, tCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhaseWaitingForResources, transition.Info().Phase())
+		plugin.AssertNotCalled(t, "Handle", mock.Anything, mock.Anything)
+	})
+
+	t.Run("entry", func(t *testing.T) {
+		var calls []string
+		entry := Chain{recorder{name: "outer", calls: &calls}}.WrapCorePluginEntry(core.PluginEntry{
+			ID: "my-plugin",
+			LoadPlugin: func(ctx context.Context, iCtx core.SetupContext) (core.Plugin, error) {
+				return newCorePlugin(), nil
+			},
+		})
+
+		p, err := entry.LoadPlugin(ctx, nil)
+		assert.NoError(t, err)
+		_, err = p.Handle(ctx, tCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"outer:my-plugin", "outer"}, calls)
+	})
+
+	t.Run("health checker", func(t *testing.T) {
+		_, isHealthChecker := Chain{PassThrough{}}.WrapCorePlugin(newCorePlugin()).(core.HealthChecker)
+		assert.False(t, isHealthChecker)
+
+		checker := &coreMocks.HealthChecker{}
+		checker.OnCheckHealthMatch(mock.Anything).Return(errors.New("unhealthy"))
+		p := Chain{PassThrough{}}.WrapCorePlugin(corePluginWithChecker{Plugin: newCorePlugin(), HealthChecker: checker})
+
+		wrapped, isHealthChecker := p.(core.HealthChecker)
+		assert.True(t, isHealthChecker)
+		assert.EqualError(t, wrapped.CheckHealth(ctx), "unhealthy")
+	})
+}
+
+type corePluginWithChecker struct {
+	*coreMocks.Plugin
+	*coreMocks.HealthChecker
+}
+
+type k8sPluginWithOverride struct {
+	*k8sMocks.Plugin
+	*k8sMocks.PluginAbortOverride
+}
+
+type k8sPluginWithChecker struct {
+	*k8sMocks.Plugin
+	*coreMocks.HealthChecker
+}
+
+type k8sPluginWithOverrideAndChecker struct {
+	*k8sMocks.Plugin
+	*k8sMocks.PluginAbortOverride
+	*coreMocks.HealthChecker
+}
+
+func TestChain_WrapK8sPlugin(t *testing.T) {
+	ctx := context.Background()
+	pod := &v1.Pod{}
+
+	newK8sPlugin := func() *k8sMocks.Plugin {
+		p := &k8sMocks.Plugin{}
+		p.OnBuildResourceMatch(mock.Anything, mock.Anything).Return(pod, nil)
+		p.OnGetTaskPhaseMatch(mock.Anything, mock.Anything, mock.Anything).Return(core.PhaseInfoSuccess(nil), nil)
+		return p
+	}
+
+	t.Run("order", func(t *testing.T) {
+		var calls []string
+		entry := Chain{
+			recorder{name: "outer", calls: &calls},
+			recorder{name: "inner", calls: &calls},
+		}.WrapK8sPluginEntry(k8s.PluginEntry{ID: "my-k8s-plugin", Plugin: newK8sPlugin()})
+
+		o, err := entry.Plugin.BuildResource(ctx, &coreMocks.TaskExecutionContext{})
+		assert.NoError(t, err)
+		assert.Equal(t, pod, o)
+
+		phase, err := entry.Plugin.GetTaskPhase(ctx, &k8sMocks.PluginContext{}, pod)
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhaseSuccess, phase.Phase())
+		assert.Equal(t, []string{"outer:my-k8s-plugin", "inner:my-k8s-plugin", "inner", "outer"}, calls)
+
+		_, isAbortOverride := entry.Plugin.(k8s.PluginAbortOverride)
+		assert.False(t, isAbortOverride)
+	})
+
+	t.Run("abort override", func(t *testing.T) {
+		abortOverride := &k8sMocks.PluginAbortOverride{}
+		abortOverride.OnOnAbortMatch(mock.Anything, mock.Anything, mock.Anything).Return(
+			k8s.AbortBehaviorDeleteDefaultResource(), nil)
+
+		p := Chain{PassThrough{}}.WrapK8sPlugin("my-k8s-plugin", k8sPluginWithOverride{
+			Plugin:              newK8sPlugin(),
+			PluginAbortOverride: abortOverride,
+		})
+
+		wrapped, isAbortOverride := p.(k8s.PluginAbortOverride)
+		assert.True(t, isAbortOverride)
+		behavior, err := wrapped.OnAbort(ctx, &coreMocks.TaskExecutionContext{}, pod)
+		assert.NoError(t, err)
+		assert.True(t, behavior.DeleteResource)
+	})
+	t.Run("health checker", func(t *testing.T) {
+		checker := &coreMocks.HealthChecker{}
+		checker.OnCheckHealthMatch(mock.Anything).Return(nil)
+
+		p := Chain{PassThrough{}}.WrapK8sPlugin("my-k8s-plugin", k8sPluginWithChecker{
+			Plugin:        newK8sPlugin(),
+			HealthChecker: checker,
+		})
+
+		_, isAbortOverride := p.(k8s.PluginAbortOverride)
+		assert.False(t, isAbortOverride)
+		wrapped, isHealthChecker := p.(core.HealthChecker)
+		assert.True(t, isHealthChecker)
+		assert.NoError(t, wrapped.CheckHealth(ctx))
+		checker.AssertCalled(t, "CheckHealth", ctx)
+	})
+
+	t.Run("abort override and health checker", func(t *testing.T) {
+		p := Chain{PassThrough{}}.WrapK8sPlugin("my-k8s-plugin", k8sPluginWithOverrideAndChecker{
+			Plugin:              newK8sPlugin(),
+			PluginAbortOverride: &k8sMocks.PluginAbortOverride{},
+			HealthChecker:       &coreMocks.HealthChecker{},
+		})
+
+		_, isAbortOverride := p.(k8s.PluginAbortOverride)
+		assert.True(t, isAbortOverride)
+		_, isHealthChecker := p.(core.HealthChecker)
+		assert.True(t, isHealthChecker)
+	})
+}
@@ -0,0 +1,64 @@
+package interceptor
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
+	"github.com/flyteorg/flytestdlib/contextutils"
+	"github.com/flyteorg/flytestdlib/logger"
+)
+
+// TransitionLoggingName is the name the TransitionLogging interceptor is registered under.
+const TransitionLoggingName = "transition-logging"
+
+// TransitionLogging logs the phase reported by each call to Handle and GetTaskPhase. Plugins are called every round
+// whether or not tasks progress, so phases are logged at debug level. Failed calls are logged as warnings. The phase is
+// added to the log fields, along with the project, domain, workflow, node and execution of the task.
+type TransitionLogging struct {
+	PassThrough
+}
+
+func withTaskFields(ctx context.Context, taskExecMetadata core.TaskExecutionMetadata, phase core.Phase) context.Context {
+	taskExecID := taskExecMetadata.GetTaskExecutionID().GetID()
+	nodeExecID := taskExecID.GetNodeExecutionId()
+	execID := nodeExecID.GetExecutionId()
+	ctx = contextutils.WithProjectDomain(ctx, execID.GetProject(), execID.GetDomain())
+	ctx = contextutils.WithExecutionID(ctx, execID.GetName())
+	ctx = contextutils.WithNodeID(ctx, nodeExecID.GetNodeId())
+	return contextutils.WithPhase(ctx, phase.String())
+}
+
+func (TransitionLogging) InterceptHandle(ctx context.Context, pluginID string, tCtx core.TaskExecutionContext,
+	next HandleFunc) (core.Transition, error) {
+	t, err := next(ctx, tCtx)
+	if err != nil {
+		logger.Warnf(withTaskFields(ctx, tCtx.TaskExecutionMetadata(), core.PhaseUndefined),
+			"Plugin [%v] failed to handle task: %v", pluginID, err)
+		return t, err
+	}
+
+	info := t.Info()
+	logger.Debugf(withTaskFields(ctx, tCtx.TaskExecutionMetadata(), info.Phase()),
+		"Plugin [%v] transitioned task to phase [%v], version [%v], transition [%v], reason [%v]", pluginID,
+		info.Phase(), info.Version(), t.Type(), info.Reason())
+	return t, err
+}
+
+func (TransitionLogging) InterceptGetTaskPhase(ctx context.Context, pluginID string, pluginContext k8s.PluginContext,
+	resource client.Object, next GetTaskPhaseFunc) (core.PhaseInfo, error) {
+	p, err := next(ctx, pluginContext, resource)
+	if err != nil {
+		logger.Warnf(withTaskFields(ctx, pluginContext.TaskExecutionMetadata(), core.PhaseUndefined),
+			"Plugin [%v] failed to get the phase of [%v/%v]: %v", pluginID, resource.GetNamespace(),
+			resource.GetName(), err)
+		return p, err
+	}
+
+	logger.Debugf(withTaskFields(ctx, pluginContext.TaskExecutionMetadata(), p.Phase()),
+		"Plugin [%v] reported phase [%v], version [%v], reason [%v] for [%v/%v]", pluginID, p.Phase(), p.Version(),
+		p.Reason(), resource.GetNamespace(), resource.GetName())
+	return p, err
+}
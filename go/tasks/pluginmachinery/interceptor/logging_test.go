@@ -0,0 +1,67 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	coreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	k8sMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s/mocks"
+)
+
+func dummyTaskExecMetadata() *coreMocks.TaskExecutionMetadata {
+	tID := &coreMocks.TaskExecutionID{}
+	tID.OnGetID().Return(idlCore.TaskExecutionIdentifier{
+		NodeExecutionId: &idlCore.NodeExecutionIdentifier{
+			NodeId: "n0",
+			ExecutionId: &idlCore.WorkflowExecutionIdentifier{
+				Project: "flytesnacks",
+				Domain:  "development",
+				Name:    "exec-name",
+			},
+		},
+	})
+	tID.OnGetGeneratedName().Return("exec-name-n0-0")
+
+	tMeta := &coreMocks.TaskExecutionMetadata{}
+	tMeta.OnGetTaskExecutionID().Return(tID)
+	return tMeta
+}
+
+func TestTransitionLogging(t *testing.T) {
+	ctx := context.Background()
+	tCtx := &coreMocks.TaskExecutionContext{}
+	tCtx.OnTaskExecutionMetadata().Return(dummyTaskExecMetadata())
+
+	plugin := &coreMocks.Plugin{}
+	plugin.OnGetID().Return("my-plugin")
+	plugin.OnHandleMatch(mock.Anything, mock.Anything).Return(core.DoTransition(core.PhaseInfoRunning(1, nil)), nil).Once()
+	plugin.OnHandleMatch(mock.Anything, mock.Anything).Return(core.UnknownTransition, fmt.Errorf("failed"))
+	plugin.OnAbortMatch(mock.Anything, mock.Anything).Return(nil)
+
+	p := Chain{TransitionLogging{}}.WrapCorePlugin(plugin)
+	transition, err := p.Handle(ctx, tCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, core.PhaseRunning, transition.Info().Phase())
+
+	_, err = p.Handle(ctx, tCtx)
+	assert.EqualError(t, err, "failed")
+	assert.NoError(t, p.Abort(ctx, tCtx))
+
+	pluginContext := &k8sMocks.PluginContext{}
+	pluginContext.OnTaskExecutionMetadata().Return(dummyTaskExecMetadata())
+	k8sPlugin := &k8sMocks.Plugin{}
+	k8sPlugin.OnGetTaskPhaseMatch(mock.Anything, mock.Anything, mock.Anything).Return(core.PhaseInfoSuccess(nil), nil)
+
+	phase, err := Chain{TransitionLogging{}}.WrapK8sPlugin("my-k8s-plugin", k8sPlugin).GetTaskPhase(ctx,
+		pluginContext, &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "ns"}})
+	assert.NoError(t, err)
+	assert.Equal(t, core.PhaseSuccess, phase.Phase())
+}
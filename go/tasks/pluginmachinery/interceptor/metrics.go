@@ -0,0 +1,96 @@
+package interceptor
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
+	"github.com/flyteorg/flytestdlib/promutils"
+)
+
+// LatencyName is the name the Latency interceptor is registered under.
+const LatencyName = "latency"
+
+const (
+	// phaseLabelError is the phase label of calls that returned an error.
+	phaseLabelError = "Error"
+	// phaseLabelNone is the phase label of successful calls of methods that don't return a phase.
+	phaseLabelNone = "None"
+)
+
+// Latency records the latency of plugin calls in a histogram labeled by plugin, operation and resulting phase.
+type Latency struct {
+	latency *prometheus.HistogramVec
+}
+
+func (l Latency) observe(pluginID string, op Operation, phase string, start time.Time) {
+	l.latency.WithLabelValues(pluginID, string(op), phase).Observe(time.Since(start).Seconds())
+}
+
+func phaseLabel(phase core.Phase, err error) string {
+	if err != nil {
+		return phaseLabelError
+	}
+
+	return phase.String()
+}
+
+func errLabel(err error) string {
+	if err != nil {
+		return phaseLabelError
+	}
+
+	return phaseLabelNone
+}
+
+func (l Latency) InterceptHandle(ctx context.Context, pluginID string, tCtx core.TaskExecutionContext,
+	next HandleFunc) (core.Transition, error) {
+	start := time.Now()
+	t, err := next(ctx, tCtx)
+	l.observe(pluginID, OperationHandle, phaseLabel(t.Info().Phase(), err), start)
+	return t, err
+}
+
+func (l Latency) InterceptAbort(ctx context.Context, pluginID string, tCtx core.TaskExecutionContext,
+	next AbortFunc) error {
+	start := time.Now()
+	err := next(ctx, tCtx)
+	l.observe(pluginID, OperationAbort, errLabel(err), start)
+	return err
+}
+
+func (l Latency) InterceptFinalize(ctx context.Context, pluginID string, tCtx core.TaskExecutionContext,
+	next FinalizeFunc) error {
+	start := time.Now()
+	err := next(ctx, tCtx)
+	l.observe(pluginID, OperationFinalize, errLabel(err), start)
+	return err
+}
+
+func (l Latency) InterceptBuildResource(ctx context.Context, pluginID string, tCtx core.TaskExecutionContext,
+	next BuildResourceFunc) (client.Object, error) {
+	start := time.Now()
+	o, err := next(ctx, tCtx)
+	l.observe(pluginID, OperationBuildResource, errLabel(err), start)
+	return o, err
+}
+
+func (l Latency) InterceptGetTaskPhase(ctx context.Context, pluginID string, pluginContext k8s.PluginContext,
+	resource client.Object, next GetTaskPhaseFunc) (core.PhaseInfo, error) {
+	start := time.Now()
+	p, err := next(ctx, pluginContext, resource)
+	l.observe(pluginID, OperationGetTaskPhase, phaseLabel(p.Phase(), err), start)
+	return p, err
+}
+
+// NewLatency creates a Latency interceptor publishing its histogram, in seconds, under scope.
+func NewLatency(scope promutils.Scope) Latency {
+	return Latency{
+		latency: scope.MustNewHistogramVec("latency_seconds", "Latency of plugin calls by plugin, operation and phase.",
+			"plugin", "operation", "phase"),
+	}
+}
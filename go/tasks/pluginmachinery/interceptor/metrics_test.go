@@ -0,0 +1,55 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	coreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	k8sMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s/mocks"
+)
+
+func sampleCount(t *testing.T, l Latency, labels ...string) uint64 {
+	m := &dto.Metric{}
+	assert.NoError(t, l.latency.WithLabelValues(labels...).(prometheus.Metric).Write(m))
+	return m.GetHistogram().GetSampleCount()
+}
+
+func TestLatency(t *testing.T) {
+	ctx := context.Background()
+	tCtx := &coreMocks.TaskExecutionContext{}
+	l := NewLatency(promutils.NewTestScope())
+
+	plugin := &coreMocks.Plugin{}
+	plugin.OnGetID().Return("my-plugin")
+	plugin.OnHandleMatch(mock.Anything, mock.Anything).Return(core.DoTransition(core.PhaseInfoRunning(1, nil)), nil).Once()
+	plugin.OnHandleMatch(mock.Anything, mock.Anything).Return(core.UnknownTransition, fmt.Errorf("failed"))
+	plugin.OnAbortMatch(mock.Anything, mock.Anything).Return(nil)
+	plugin.OnFinalizeMatch(mock.Anything, mock.Anything).Return(nil)
+
+	p := Chain{l}.WrapCorePlugin(plugin)
+	_, err := p.Handle(ctx, tCtx)
+	assert.NoError(t, err)
+	_, err = p.Handle(ctx, tCtx)
+	assert.Error(t, err)
+	assert.NoError(t, p.Abort(ctx, tCtx))
+
+	assert.Equal(t, uint64(1), sampleCount(t, l, "my-plugin", "handle", "PhaseRunning"))
+	assert.Equal(t, uint64(1), sampleCount(t, l, "my-plugin", "handle", "Error"))
+	assert.Equal(t, uint64(1), sampleCount(t, l, "my-plugin", "abort", "None"))
+	assert.Equal(t, uint64(0), sampleCount(t, l, "my-plugin", "finalize", "None"))
+
+	k8sPlugin := &k8sMocks.Plugin{}
+	k8sPlugin.OnGetTaskPhaseMatch(mock.Anything, mock.Anything, mock.Anything).Return(core.PhaseInfoSuccess(nil), nil)
+	_, err = Chain{l}.WrapK8sPlugin("my-k8s-plugin", k8sPlugin).GetTaskPhase(ctx, &k8sMocks.PluginContext{}, &v1.Pod{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), sampleCount(t, l, "my-k8s-plugin", "get-task-phase", "PhaseSuccess"))
+}
@@ -0,0 +1,57 @@
+package interceptor
+
+import (
+	"context"
+	"runtime/debug"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
+	"github.com/flyteorg/flytestdlib/logger"
+)
+
+// PanicRecoveryName is the name the PanicRecovery interceptor is registered under.
+const PanicRecoveryName = "panic-recovery"
+
+// PanicRecovery recovers from panics in plugins and returns them as errors.PluginPanicked system errors, so that a
+// single misbehaving task can't crash the process.
+type PanicRecovery struct{}
+
+func recoverPanic(ctx context.Context, pluginID string, op Operation, err *error) {
+	if r := recover(); r != nil {
+		logger.Errorf(ctx, "Plugin [%v] panicked in [%v]: %v\n%s", pluginID, op, r, debug.Stack())
+		*err = errors.Errorf(errors.PluginPanicked, "plugin [%v] panicked in [%v]: %v", pluginID, op, r)
+	}
+}
+
+func (PanicRecovery) InterceptHandle(ctx context.Context, pluginID string, tCtx core.TaskExecutionContext,
+	next HandleFunc) (t core.Transition, err error) {
+	defer recoverPanic(ctx, pluginID, OperationHandle, &err)
+	return next(ctx, tCtx)
+}
+
+func (PanicRecovery) InterceptAbort(ctx context.Context, pluginID string, tCtx core.TaskExecutionContext,
+	next AbortFunc) (err error) {
+	defer recoverPanic(ctx, pluginID, OperationAbort, &err)
+	return next(ctx, tCtx)
+}
+
+func (PanicRecovery) InterceptFinalize(ctx context.Context, pluginID string, tCtx core.TaskExecutionContext,
+	next FinalizeFunc) (err error) {
+	defer recoverPanic(ctx, pluginID, OperationFinalize, &err)
+	return next(ctx, tCtx)
+}
+
+func (PanicRecovery) InterceptBuildResource(ctx context.Context, pluginID string, tCtx core.TaskExecutionContext,
+	next BuildResourceFunc) (o client.Object, err error) {
+	defer recoverPanic(ctx, pluginID, OperationBuildResource, &err)
+	return next(ctx, tCtx)
+}
+
+func (PanicRecovery) InterceptGetTaskPhase(ctx context.Context, pluginID string, pluginContext k8s.PluginContext,
+	resource client.Object, next GetTaskPhaseFunc) (p core.PhaseInfo, err error) {
+	defer recoverPanic(ctx, pluginID, OperationGetTaskPhase, &err)
+	return next(ctx, pluginContext, resource)
+}
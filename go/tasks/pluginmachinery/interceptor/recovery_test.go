@@ -0,0 +1,58 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flytestdlib/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	v1 "k8s.io/api/core/v1"
+
+	pluginErrors "github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	coreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	k8sMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s/mocks"
+)
+
+func assertPanicked(t *testing.T, err error) {
+	code, isCoded := errors.GetErrorCode(err)
+	assert.True(t, isCoded)
+	assert.Equal(t, pluginErrors.PluginPanicked, code)
+}
+
+func TestPanicRecovery(t *testing.T) {
+	ctx := context.Background()
+	tCtx := &coreMocks.TaskExecutionContext{}
+	panics := func(mock.Arguments) { panic("boom") }
+
+	t.Run("core plugin", func(t *testing.T) {
+		plugin := &coreMocks.Plugin{}
+		plugin.OnGetID().Return("my-plugin")
+		plugin.OnHandleMatch(mock.Anything, mock.Anything).Run(panics).Return(core.UnknownTransition, nil)
+		plugin.OnAbortMatch(mock.Anything, mock.Anything).Run(panics).Return(nil)
+		plugin.OnFinalizeMatch(mock.Anything, mock.Anything).Return(nil)
+
+		p := Chain{PanicRecovery{}}.WrapCorePlugin(plugin)
+		_, err := p.Handle(ctx, tCtx)
+		assertPanicked(t, err)
+		assert.Contains(t, err.Error(), "boom")
+
+		assertPanicked(t, p.Abort(ctx, tCtx))
+		assert.NoError(t, p.Finalize(ctx, tCtx))
+	})
+
+	t.Run("k8s plugin", func(t *testing.T) {
+		plugin := &k8sMocks.Plugin{}
+		plugin.OnBuildResourceMatch(mock.Anything, mock.Anything).Run(panics).Return(nil, nil)
+		plugin.OnGetTaskPhaseMatch(mock.Anything, mock.Anything, mock.Anything).Return(core.PhaseInfoSuccess(nil), nil)
+
+		p := Chain{PanicRecovery{}}.WrapK8sPlugin("my-k8s-plugin", plugin)
+		_, err := p.BuildResource(ctx, tCtx)
+		assertPanicked(t, err)
+
+		phase, err := p.GetTaskPhase(ctx, &k8sMocks.PluginContext{}, &v1.Pod{})
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhaseSuccess, phase.Phase())
+	})
+}
@@ -0,0 +1,75 @@
+package interceptor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tracing"
+)
+
+// TracingName is the name the Tracing interceptor is registered under.
+const TracingName = "tracing"
+
+// Tracing wraps each plugin call in an OpenTelemetry span named plugin.<operation>. Spans are annotated with the
+// plugin id, the task execution and, for Handle and GetTaskPhase, the resulting phase.
+type Tracing struct{}
+
+func startPluginSpan(ctx context.Context, pluginID string, op Operation,
+	taskExecMetadata core.TaskExecutionMetadata) (context.Context, trace.Span) {
+	attrs := append([]attribute.KeyValue{tracing.PluginAttribute(pluginID)},
+		tracing.TaskAttributes(taskExecMetadata.GetTaskExecutionID())...)
+	return tracing.StartSpan(ctx, "plugin."+string(op), attrs...)
+}
+
+func (Tracing) InterceptHandle(ctx context.Context, pluginID string, tCtx core.TaskExecutionContext,
+	next HandleFunc) (core.Transition, error) {
+	ctx, span := startPluginSpan(ctx, pluginID, OperationHandle, tCtx.TaskExecutionMetadata())
+	t, err := next(ctx, tCtx)
+	if err == nil {
+		span.SetAttributes(tracing.PhaseAttribute(t.Info().Phase()))
+	}
+
+	tracing.EndSpan(span, err)
+	return t, err
+}
+
+func (Tracing) InterceptAbort(ctx context.Context, pluginID string, tCtx core.TaskExecutionContext,
+	next AbortFunc) error {
+	ctx, span := startPluginSpan(ctx, pluginID, OperationAbort, tCtx.TaskExecutionMetadata())
+	err := next(ctx, tCtx)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (Tracing) InterceptFinalize(ctx context.Context, pluginID string, tCtx core.TaskExecutionContext,
+	next FinalizeFunc) error {
+	ctx, span := startPluginSpan(ctx, pluginID, OperationFinalize, tCtx.TaskExecutionMetadata())
+	err := next(ctx, tCtx)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (Tracing) InterceptBuildResource(ctx context.Context, pluginID string, tCtx core.TaskExecutionContext,
+	next BuildResourceFunc) (client.Object, error) {
+	ctx, span := startPluginSpan(ctx, pluginID, OperationBuildResource, tCtx.TaskExecutionMetadata())
+	o, err := next(ctx, tCtx)
+	tracing.EndSpan(span, err)
+	return o, err
+}
+
+func (Tracing) InterceptGetTaskPhase(ctx context.Context, pluginID string, pluginContext k8s.PluginContext,
+	resource client.Object, next GetTaskPhaseFunc) (core.PhaseInfo, error) {
+	ctx, span := startPluginSpan(ctx, pluginID, OperationGetTaskPhase, pluginContext.TaskExecutionMetadata())
+	p, err := next(ctx, pluginContext, resource)
+	if err == nil {
+		span.SetAttributes(tracing.PhaseAttribute(p.Phase()))
+	}
+
+	tracing.EndSpan(span, err)
+	return p, err
+}
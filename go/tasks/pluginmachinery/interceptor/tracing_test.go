@@ -0,0 +1,82 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	coreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	k8sMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tracing"
+)
+
+func withSpanRecorder(t *testing.T) *tracetest.SpanRecorder {
+	sr := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+	return sr
+}
+
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	res := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes() {
+		res[kv.Key] = kv.Value
+	}
+
+	return res
+}
+
+func TestTracing(t *testing.T) {
+	sr := withSpanRecorder(t)
+	ctx := context.Background()
+	tCtx := &coreMocks.TaskExecutionContext{}
+	tCtx.OnTaskExecutionMetadata().Return(dummyTaskExecMetadata())
+
+	plugin := &coreMocks.Plugin{}
+	plugin.OnGetID().Return("my-plugin")
+	plugin.OnHandleMatch(mock.Anything, mock.Anything).Return(core.DoTransition(core.PhaseInfoRunning(1, nil)), nil)
+	plugin.OnAbortMatch(mock.Anything, mock.Anything).Return(fmt.Errorf("failed"))
+
+	p := Chain{Tracing{}}.WrapCorePlugin(plugin)
+	_, err := p.Handle(ctx, tCtx)
+	assert.NoError(t, err)
+	assert.EqualError(t, p.Abort(ctx, tCtx), "failed")
+
+	pluginContext := &k8sMocks.PluginContext{}
+	pluginContext.OnTaskExecutionMetadata().Return(dummyTaskExecMetadata())
+	k8sPlugin := &k8sMocks.Plugin{}
+	k8sPlugin.OnGetTaskPhaseMatch(mock.Anything, mock.Anything, mock.Anything).Return(core.PhaseInfoSuccess(nil), nil)
+	_, err = Chain{Tracing{}}.WrapK8sPlugin("my-k8s-plugin", k8sPlugin).GetTaskPhase(ctx, pluginContext, &v1.Pod{})
+	assert.NoError(t, err)
+
+	spans := sr.Ended()
+	if assert.Len(t, spans, 3) {
+		assert.Equal(t, "plugin.handle", spans[0].Name())
+		attrs := spanAttributes(spans[0])
+		assert.Equal(t, "my-plugin", attrs[tracing.PluginIDKey].AsString())
+		assert.Equal(t, "PhaseRunning", attrs[tracing.PhaseKey].AsString())
+		assert.Equal(t, "exec-name-n0-0", attrs[tracing.TaskExecutionIDKey].AsString())
+		assert.Equal(t, "flytesnacks", attrs[tracing.ProjectKey].AsString())
+		assert.Equal(t, codes.Unset, spans[0].Status().Code)
+
+		assert.Equal(t, "plugin.abort", spans[1].Name())
+		assert.Equal(t, codes.Error, spans[1].Status().Code)
+		assert.Equal(t, "failed", spans[1].Status().Description)
+
+		assert.Equal(t, "plugin.get-task-phase", spans[2].Name())
+		attrs = spanAttributes(spans[2])
+		assert.Equal(t, "my-k8s-plugin", attrs[tracing.PluginIDKey].AsString())
+		assert.Equal(t, "PhaseSuccess", attrs[tracing.PhaseKey].AsString())
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/flyteorg/flytestdlib/logger"
 
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
@@ -25,8 +26,8 @@ func newTokenAllocator(c clock.Clock) tokenAllocator {
 
 func (a tokenAllocator) allocateToken(ctx context.Context, p webapi.AsyncPlugin, tCtx core.TaskExecutionContext, state *State, metrics Metrics) (
 	newState *State, phaseInfo core.PhaseInfo, err error) {
-	if len(p.GetConfig().ResourceQuotas) == 0 {
-		// No quota, return success
+	if !p.GetConfig().AllocatesTokens() {
+		// No quota nor rate, return success
 		return &State{
 			AllocationTokenRequestStartTime: a.clock.Now(),
 			Phase:                           PhaseAllocationTokenAcquired,
@@ -39,6 +40,19 @@ func (a tokenAllocator) allocateToken(ctx context.Context, p webapi.AsyncPlugin,
 		return nil, core.PhaseInfo{}, err
 	}
 
+	// A token weighing more than the quota or the rate would wait forever.
+	if quota, found := p.GetConfig().ResourceQuotas[ns]; found && constraints.GetWeight() > int64(quota) {
+		return state, core.PhaseInfoFailure(string(errors.BadTaskSpecification), fmt.Sprintf(
+			"task weight [%v] exceeds the quota [%v] of resource namespace [%v]", constraints.GetWeight(), quota, ns),
+			nil), nil
+	}
+
+	if rate, found := p.GetConfig().ResourceRates[ns]; found && constraints.GetWeight() > rate.Value {
+		return state, core.PhaseInfoFailure(string(errors.BadTaskSpecification), fmt.Sprintf(
+			"task weight [%v] exceeds the rate [%v per %v] of resource namespace [%v]", constraints.GetWeight(),
+			rate.Value, rate.Window.Duration, ns), nil), nil
+	}
+
 	token := tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName()
 	allocationStatus, err := tCtx.ResourceManager().AllocateResource(ctx, ns, token, constraints)
 	if err != nil {
@@ -67,7 +81,8 @@ func (a tokenAllocator) allocateToken(ctx context.Context, p webapi.AsyncPlugin,
 				AllocationTokenRequestStartTime: startTime,
 				Phase:                           PhaseNotStarted,
 			}, core.PhaseInfoQueued(
-				a.clock.Now(), 0, "Quota for task has exceeded. The request is enqueued."), nil
+				a.clock.Now(), 0, "Quota for task has exceeded. The request is enqueued.").
+				WithSubReason(core.SubReasonWaitingForResources), nil
 	}
 
 	return nil, core.PhaseInfo{}, fmt.Errorf("allocation status undefined [%v]", allocationStatus)
@@ -14,6 +14,7 @@ import (
 
 	testing2 "k8s.io/utils/clock/testing"
 
+	"github.com/flyteorg/flytestdlib/config"
 	"github.com/flyteorg/flytestdlib/contextutils"
 	"github.com/flyteorg/flytestdlib/promutils/labeled"
 
@@ -115,6 +116,52 @@ func Test_allocateToken(t *testing.T) {
 			t.Errorf("allocateToken() gotNewState = %v, Diff: %v", gotNewState, diff)
 		}
 	})
+
+	t.Run("Weight exceeds quota", func(t *testing.T) {
+		p := newPluginWithProperties(webapi.PluginConfig{
+			ResourceQuotas: map[core.ResourceNamespace]int{
+				"ns": 5,
+			},
+		})
+		p.OnResourceRequirements(ctx, tCtx).Return("ns", core.ResourceConstraintsSpec{Weight: 6}, nil)
+		a := newTokenAllocator(clck)
+		gotNewState, phaseInfo, err := a.allocateToken(ctx, p, tCtx, state, metrics)
+		assert.NoError(t, err)
+		assert.Equal(t, state, gotNewState)
+		assert.Equal(t, core.PhasePermanentFailure, phaseInfo.Phase())
+		assert.Equal(t, "task weight [6] exceeds the quota [5] of resource namespace [ns]", phaseInfo.Err().GetMessage())
+	})
+
+	t.Run("Weight exceeds rate", func(t *testing.T) {
+		p := newPluginWithProperties(webapi.PluginConfig{
+			ResourceRates: webapi.ResourceRates{
+				"ns": {Value: 5, Window: config.Duration{Duration: time.Second}},
+			},
+		})
+		p.OnResourceRequirements(ctx, tCtx).Return("ns", core.ResourceConstraintsSpec{Weight: 6}, nil)
+		a := newTokenAllocator(clck)
+		gotNewState, phaseInfo, err := a.allocateToken(ctx, p, tCtx, state, metrics)
+		assert.NoError(t, err)
+		assert.Equal(t, state, gotNewState)
+		assert.Equal(t, core.PhasePermanentFailure, phaseInfo.Phase())
+		assert.Equal(t, "task weight [6] exceeds the rate [5 per 1s] of resource namespace [ns]",
+			phaseInfo.Err().GetMessage())
+	})
+
+	t.Run("Rate without quota", func(t *testing.T) {
+		p := newPluginWithProperties(webapi.PluginConfig{
+			ResourceRates: webapi.ResourceRates{
+				"ns": {Value: 10, Window: config.Duration{Duration: time.Second}},
+			},
+		})
+		p.OnResourceRequirements(ctx, tCtx).Return("ns", core.ResourceConstraintsSpec{Weight: 6}, nil)
+		a := newTokenAllocator(clck)
+		gotNewState, _, err := a.allocateToken(ctx, p, tCtx, state, metrics)
+		assert.NoError(t, err)
+		assert.Equal(t, PhaseAllocationTokenAcquired, gotNewState.Phase)
+		rm.AssertCalled(t, "AllocateResource", ctx, core.ResourceNamespace("ns"), "abc",
+			core.ResourceConstraintsSpec{Weight: 6})
+	})
 }
 
 func Test_releaseToken(t *testing.T) {
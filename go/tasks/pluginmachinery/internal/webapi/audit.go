@@ -0,0 +1,53 @@
+package webapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/audit"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+)
+
+// auditedPlugin records the resources an AsyncPlugin creates and deletes in its remote service.
+type auditedPlugin struct {
+	webapi.AsyncPlugin
+	id   string
+	sink audit.Sink
+}
+
+// withTask sets the identity of the task of tCtx in ctx, if it's known.
+func (p auditedPlugin) withTask(ctx context.Context, tCtx interface{}) context.Context {
+	switch t := tCtx.(type) {
+	case pluginContext:
+		if t.TaskExecutionContext != nil {
+			return audit.WithTask(ctx, audit.TaskFromMetadata(p.id, t.TaskExecutionMetadata()))
+		}
+	case webapi.TaskExecutionContextReader:
+		return audit.WithTask(ctx, audit.TaskFromMetadata(p.id, t.TaskExecutionMetadata()))
+	}
+
+	return ctx
+}
+
+// target identifies the remote resource by the plugin and its metadata. Field names are kept so that secrets in the
+// metadata are matched by the redaction patterns.
+func (p auditedPlugin) target(resourceMeta webapi.ResourceMeta) string {
+	if resourceMeta == nil {
+		return p.id
+	}
+
+	return fmt.Sprintf("%v/%+v", p.id, resourceMeta)
+}
+
+func (p auditedPlugin) Create(ctx context.Context, tCtx webapi.TaskExecutionContextReader) (
+	resourceMeta webapi.ResourceMeta, optionalResource webapi.Resource, err error) {
+	resourceMeta, optionalResource, err = p.AsyncPlugin.Create(ctx, tCtx)
+	audit.Log(p.withTask(ctx, tCtx), p.sink, audit.ActionWebAPICreate, p.target(resourceMeta), err)
+	return resourceMeta, optionalResource, err
+}
+
+func (p auditedPlugin) Delete(ctx context.Context, tCtx webapi.DeleteContext) error {
+	err := p.AsyncPlugin.Delete(ctx, tCtx)
+	audit.Log(p.withTask(ctx, tCtx), p.sink, audit.ActionWebAPIDelete, p.target(tCtx.ResourceMeta()), err)
+	return err
+}
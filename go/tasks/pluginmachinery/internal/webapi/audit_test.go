@@ -0,0 +1,72 @@
+package webapi
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/audit"
+	coreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/mocks"
+)
+
+type dummyResourceMeta struct {
+	RunID string
+	Token string
+}
+
+func TestAuditedPlugin(t *testing.T) {
+	ctx := context.Background()
+	tID := &coreMocks.TaskExecutionID{}
+	tID.OnGetGeneratedName().Return("exec-name-n0-0")
+	tID.OnGetID().Return(idlCore.TaskExecutionIdentifier{
+		NodeExecutionId: &idlCore.NodeExecutionIdentifier{
+			ExecutionId: &idlCore.WorkflowExecutionIdentifier{Project: "flytesnacks", Domain: "development"},
+		},
+	})
+	tMeta := &coreMocks.TaskExecutionMetadata{}
+	tMeta.OnGetTaskExecutionID().Return(tID)
+	tMeta.OnGetSecurityContext().Return(idlCore.SecurityContext{})
+	tMeta.OnGetK8sServiceAccount().Return("default")
+	tCtx := &mocks.TaskExecutionContext{}
+	tCtx.OnTaskExecutionMetadata().Return(tMeta)
+
+	resourceMeta := &dummyResourceMeta{RunID: "123", Token: "abc"}
+	plugin := &mocks.AsyncPlugin{}
+	plugin.OnCreateMatch(mock.Anything, mock.Anything).Return(resourceMeta, nil, nil)
+	plugin.OnDeleteMatch(mock.Anything, mock.Anything).Return(fmt.Errorf("not found"))
+
+	memory := audit.NewMemorySink()
+	redactor, err := audit.NewRedactor(nil)
+	assert.NoError(t, err)
+
+	p := auditedPlugin{AsyncPlugin: plugin, id: "my-plugin", sink: audit.NewRedactingSink(memory, redactor)}
+	_, _, err = p.Create(ctx, tCtx)
+	assert.NoError(t, err)
+	assert.Error(t, p.Delete(ctx, newPluginContext(resourceMeta, nil, "Aborted", tCtx)))
+
+	task := audit.Task{
+		ExecutionID: "exec-name-n0-0",
+		Project:     "flytesnacks",
+		Domain:      "development",
+		Principal:   "default",
+		PluginID:    "my-plugin",
+	}
+
+	records := memory.Records()
+	if assert.Len(t, records, 2) {
+		assert.Equal(t, task, records[0].Task)
+		assert.Equal(t, audit.ActionWebAPICreate, records[0].Action)
+		assert.Equal(t, "my-plugin/&{RunID:123 Token:[REDACTED]}", records[0].Target)
+		assert.Equal(t, audit.OutcomeSuccess, records[0].Outcome)
+
+		assert.Equal(t, task, records[1].Task)
+		assert.Equal(t, audit.ActionWebAPIDelete, records[1].Action)
+		assert.Equal(t, audit.OutcomeFailure, records[1].Outcome)
+		assert.Equal(t, "not found", records[1].Error)
+	}
+}
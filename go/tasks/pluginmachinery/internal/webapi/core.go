@@ -13,9 +13,15 @@ import (
 	"github.com/flyteorg/flytestdlib/cache"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flyteplugins/go/tasks/logs"
 	"github.com/flyteorg/flytestdlib/logger"
 
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/audit"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/secretmanager"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tasklog"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/timeline"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/watchdog"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
 )
 
@@ -39,6 +45,15 @@ type CorePlugin struct {
 	cache          cache.AutoRefresh
 	tokenAllocator tokenAllocator
 	metrics        Metrics
+	clock          clock.Clock
+	watchdog       *watchdog.Watchdog
+	// ignoredLogParams are the query parameters the log signer of the plugin appends to log links. They change every
+	// round and are ignored when versioning phases.
+	ignoredLogParams []string
+	// secretsRequirer is the plugin, if it implements webapi.SecretsRequirer. p wraps the plugin and doesn't.
+	secretsRequirer webapi.SecretsRequirer
+	// secrets reads the structured secret references of the plugin, see secretmanager.Manager.Wrap.
+	secrets secretmanager.Manager
 }
 
 func (c CorePlugin) unmarshalState(ctx context.Context, stateReader core.PluginStateReader) (State, error) {
@@ -47,7 +62,7 @@ func (c CorePlugin) unmarshalState(ctx context.Context, stateReader core.PluginS
 
 	// We assume here that the first time this function is called, the custom state we get back is whatever we passed in,
 	// namely the zero-value of our struct.
-	if _, err := stateReader.Get(&existingState); err != nil {
+	if _, err := stateMigrations.Get(stateReader, &existingState); err != nil {
 		c.metrics.FailedUnmarshalState.Inc(ctx)
 		logger.Errorf(ctx, "AsyncPlugin [%v] failed to unmarshal custom state. Error: %v",
 			c.GetID(), err)
@@ -69,6 +84,7 @@ func (c CorePlugin) GetProperties() core.PluginProperties {
 }
 
 func (c CorePlugin) Handle(ctx context.Context, tCtx core.TaskExecutionContext) (core.Transition, error) {
+	tCtx = secretsTaskExecutionContext{TaskExecutionContext: tCtx, secrets: c.secrets}
 	incomingState, err := c.unmarshalState(ctx, tCtx.PluginStateReader())
 	if err != nil {
 		return core.UnknownTransition, err
@@ -78,13 +94,13 @@ func (c CorePlugin) Handle(ctx context.Context, tCtx core.TaskExecutionContext)
 	var phaseInfo core.PhaseInfo
 	switch incomingState.Phase {
 	case PhaseNotStarted:
-		if len(c.p.GetConfig().ResourceQuotas) > 0 {
+		if c.p.GetConfig().AllocatesTokens() {
 			nextState, phaseInfo, err = c.tokenAllocator.allocateToken(ctx, c.p, tCtx, &incomingState, c.metrics)
 		} else {
-			nextState, phaseInfo, err = launch(ctx, c.p, tCtx, c.cache, &incomingState)
+			nextState, phaseInfo, err = launch(ctx, c.p, c.secretsRequirer, tCtx, c.cache, &incomingState)
 		}
 	case PhaseAllocationTokenAcquired:
-		nextState, phaseInfo, err = launch(ctx, c.p, tCtx, c.cache, &incomingState)
+		nextState, phaseInfo, err = launch(ctx, c.p, c.secretsRequirer, tCtx, c.cache, &incomingState)
 	case PhaseResourcesCreated:
 		nextState, phaseInfo, err = monitor(ctx, tCtx, c.p, c.cache, &incomingState)
 	}
@@ -93,14 +109,92 @@ func (c CorePlugin) Handle(ctx context.Context, tCtx core.TaskExecutionContext)
 		return core.UnknownTransition, err
 	}
 
-	if err := tCtx.PluginStateWriter().Put(pluginStateVersion, nextState); err != nil {
+	if c.watchdog != nil {
+		nextState.Watchdog, phaseInfo, err = c.watch(ctx, tCtx, incomingState.Watchdog, nextState, phaseInfo)
+		if err != nil {
+			return core.UnknownTransition, err
+		}
+	}
+
+	if !c.p.GetConfig().ManagesPhaseVersion {
+		nextState.PhaseVersion, phaseInfo = c.versionPhase(ctx, incomingState.PhaseVersion, phaseInfo)
+	}
+
+	nextState.Timeline = c.recordPhase(ctx, incomingState.Timeline, phaseInfo)
+	if err := stateMigrations.Put(tCtx.PluginStateWriter(), nextState); err != nil {
 		return core.UnknownTransition, err
 	}
 
 	return core.DoTransitionType(core.TransitionTypeBarrier, phaseInfo), nil
 }
 
+// watch observes the phase reported by the plugin and applies the verdict of the watchdog. The remote resource of the
+// tasks the watchdog fails is deleted.
+func (c CorePlugin) watch(ctx context.Context, tCtx core.TaskExecutionContext, s watchdog.State, state *State,
+	phaseInfo core.PhaseInfo) (watchdog.State, core.PhaseInfo, error) {
+	wasStuck := s.Stuck
+	verdict, err := c.watchdog.Observe(c.id, &s, phaseInfo, c.clock.Now(), c.ignoredLogParams...)
+	if err != nil {
+		logger.Warnf(ctx, "Failed to observe the phase of the task for the watchdog. Error: %v", err)
+		return s, phaseInfo, nil
+	}
+
+	if verdict.Action == watchdog.ActionNone {
+		return s, phaseInfo, nil
+	}
+
+	taskExecID := tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName()
+	if !wasStuck {
+		logger.Warnf(ctx, "Task [%v] saw no change in %v for over %v, taking action [%v].", taskExecID,
+			phaseInfo.Phase(), verdict.Threshold, verdict.Action)
+	}
+
+	if verdict.Action != watchdog.ActionWarn && state.Phase == PhaseResourcesCreated {
+		err = c.p.Delete(ctx, newPluginContext(state.ResourceMeta, nil, "Stuck", tCtx))
+		if err != nil {
+			logger.Errorf(ctx, "Failed to delete the resources of stuck task [%v]. Error: %v", taskExecID, err)
+			return s, phaseInfo, err
+		}
+
+		if err = c.cache.DeleteDelayed(taskExecID); err != nil {
+			logger.Warnf(ctx, "Failed to queue item for deletion in the cache with Item Id: [%v]. Error: %v",
+				taskExecID, err)
+		}
+	}
+
+	return s, verdict.Apply(phaseInfo), nil
+}
+
+// versionPhase bumps the version of the phase if what's reported changed since the last round within the same phase.
+// It must run before recordPhase, the timeline added to the task info changes every round.
+func (c CorePlugin) versionPhase(ctx context.Context, v core.PhaseVersion, phaseInfo core.PhaseInfo) (
+	core.PhaseVersion, core.PhaseInfo) {
+	versioned, err := v.Update(phaseInfo, c.ignoredLogParams...)
+	if err != nil {
+		logger.Warnf(ctx, "Failed to compute the phase version, keeping the one reported by the plugin. Error: %v", err)
+		return v, phaseInfo
+	}
+
+	return v, versioned
+}
+
+// recordPhase records the phase in the timeline of the task, observing how long the task was in the phase it left, and
+// adds the timeline to the task info.
+func (c CorePlugin) recordPhase(ctx context.Context, t timeline.Timeline, phaseInfo core.PhaseInfo) timeline.Timeline {
+	now := c.clock.Now()
+	if left, duration := t.Record(phaseInfo, now); left != nil {
+		c.metrics.PhaseDurations.Observe(c.id, *left, duration)
+	}
+
+	if err := t.AddToTaskInfo(phaseInfo.Info(), now); err != nil {
+		logger.Warnf(ctx, "Failed to add the phase timeline to the task info. Error: %v", err)
+	}
+
+	return t
+}
+
 func (c CorePlugin) Abort(ctx context.Context, tCtx core.TaskExecutionContext) error {
+	tCtx = secretsTaskExecutionContext{TaskExecutionContext: tCtx, secrets: c.secrets}
 	incomingState, err := c.unmarshalState(ctx, tCtx.PluginStateReader())
 	if err != nil {
 		return err
@@ -119,8 +213,9 @@ func (c CorePlugin) Abort(ctx context.Context, tCtx core.TaskExecutionContext) e
 }
 
 func (c CorePlugin) Finalize(ctx context.Context, tCtx core.TaskExecutionContext) error {
-	if len(c.p.GetConfig().ResourceQuotas) == 0 {
-		// If there are no defined quotas, there is nothing to cleanup.
+	tCtx = secretsTaskExecutionContext{TaskExecutionContext: tCtx, secrets: c.secrets}
+	if !c.p.GetConfig().AllocatesTokens() {
+		// If there are no defined quotas nor rates, there is nothing to cleanup.
 		return nil
 	}
 
@@ -155,6 +250,24 @@ func validateConfig(cfg webapi.PluginConfig) error {
 	errs.Append(validateRangeInt("read qps", minQPS, maxQPS, cfg.ReadRateLimiter.QPS))
 	errs.Append(validateRangeInt("write burst", minBurst, maxBurst, cfg.WriteRateLimiter.Burst))
 	errs.Append(validateRangeInt("write qps", minQPS, maxQPS, cfg.WriteRateLimiter.QPS))
+	for _, logCfg := range cfg.Logs {
+		errs.Append(tasklog.ValidateTemplateURIs(logCfg.TemplateURIs))
+	}
+
+	for _, override := range cfg.LogOverrides {
+		overlay, err := override.Config.ApplyTo(logs.LogConfig{})
+		errs.Append(err)
+		for _, logCfg := range overlay.Templates {
+			errs.Append(tasklog.ValidateTemplateURIs(logCfg.TemplateURIs))
+		}
+	}
+
+	for ns, rate := range cfg.ResourceRates {
+		if rate.Window.Duration <= 0 {
+			errs.Append(fmt.Errorf("window of resource rate [%v] is expected to be positive. Provided value is %v", ns,
+				rate.Window.Duration))
+		}
+	}
 
 	return errs.ErrorOrDefault()
 }
@@ -163,13 +276,30 @@ func createRemotePlugin(pluginEntry webapi.PluginEntry, c clock.Clock) core.Plug
 	return core.PluginEntry{
 		ID:                  pluginEntry.ID,
 		RegisteredTaskTypes: pluginEntry.SupportedTaskTypes,
+		Validator:           pluginEntry.Validator,
 		LoadPlugin: func(ctx context.Context, iCtx core.SetupContext) (
 			core.Plugin, error) {
-			p, err := pluginEntry.PluginLoader(ctx, iCtx)
+			secrets, err := secretmanager.NewManagerFromConfig(secretmanager.GetConfig())
+			if err != nil {
+				return nil, fmt.Errorf("secrets config validation failed. Error: %w", err)
+			}
+
+			p, err := pluginEntry.PluginLoader(ctx, secretsSetupContext{SetupContext: iCtx, secrets: secrets})
 			if err != nil {
 				return nil, err
 			}
 
+			// The plugin is wrapped below, its health checker and required secrets must be looked up first.
+			checker, _ := p.(core.HealthChecker)
+			requirer, _ := p.(webapi.SecretsRequirer)
+
+			sink, err := audit.GetSink()
+			if err != nil {
+				return nil, fmt.Errorf("audit config validation failed. Error: %w", err)
+			}
+
+			p = auditedPlugin{AsyncPlugin: tracedPlugin{AsyncPlugin: p, id: pluginEntry.ID}, id: pluginEntry.ID,
+				sink: sink}
 			err = validateConfig(p.GetConfig())
 			if err != nil {
 				return nil, fmt.Errorf("config validation failed. Error: %w", err)
@@ -190,6 +320,23 @@ func createRemotePlugin(pluginEntry webapi.PluginEntry, c clock.Clock) core.Plug
 				}
 			}
 
+			if rates := p.GetConfig().ResourceRates; len(rates) > 0 {
+				rateRegistrar, ok := iCtx.ResourceRegistrar().(core.ResourceRateRegistrar)
+				if !ok {
+					return nil, fmt.Errorf("resource rates are configured but the resource registrar doesn't support them")
+				}
+
+				for ns, rate := range rates {
+					err := rateRegistrar.RegisterResourceRate(ctx, ns, core.ResourceRate{
+						Value:  rate.Value,
+						Window: rate.Window.Duration,
+					})
+					if err != nil {
+						return nil, err
+					}
+				}
+			}
+
 			resourceCache, err := NewResourceCache(ctx, pluginEntry.ID, p, p.GetConfig().Caching,
 				iCtx.MetricsScope().NewSubScope("cache"))
 
@@ -202,13 +349,29 @@ func createRemotePlugin(pluginEntry webapi.PluginEntry, c clock.Clock) core.Plug
 				return nil, err
 			}
 
-			return CorePlugin{
-				id:             pluginEntry.ID,
-				p:              p,
-				cache:          resourceCache,
-				metrics:        newMetrics(iCtx.MetricsScope()),
-				tokenAllocator: newTokenAllocator(c),
-			}, nil
+			w, err := watchdog.NewWatchdog(watchdog.GetConfig(), iCtx.MetricsScope().NewSubScope("watchdog"))
+			if err != nil {
+				return nil, fmt.Errorf("watchdog config validation failed. Error: %w", err)
+			}
+
+			corePlugin := CorePlugin{
+				id:               pluginEntry.ID,
+				p:                p,
+				cache:            resourceCache,
+				metrics:          newMetrics(iCtx.MetricsScope()),
+				tokenAllocator:   newTokenAllocator(c),
+				clock:            c,
+				watchdog:         w,
+				ignoredLogParams: p.GetConfig().LogSigner.QueryParams(),
+				secretsRequirer:  requirer,
+				secrets:          secrets,
+			}
+
+			if checker != nil {
+				return healthCheckedPlugin{CorePlugin: corePlugin, checker: checker}, nil
+			}
+
+			return corePlugin, nil
 		},
 	}
 }
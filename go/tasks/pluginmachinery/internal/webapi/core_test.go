@@ -2,13 +2,23 @@ package webapi
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tasklog"
 
+	flyteIdlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	cacheMocks "github.com/flyteorg/flytestdlib/cache/mocks"
+	"github.com/flyteorg/flytestdlib/promutils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	testing2 "k8s.io/utils/clock/testing"
 
+	mocks2 "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/watchdog"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
 	"github.com/flyteorg/flytestdlib/config"
 )
@@ -77,6 +87,63 @@ func Test_validateConfig(t *testing.T) {
 		assert.Error(t, err)
 		assert.Equal(t, "\ncache size is expected to be between 10 and 500000. Provided value is 1000000000\nworkers count is expected to be between 1 and 100. Provided value is 1000000000\nresync interval is expected to be between 5 and 3600. Provided value is 3.6e+07\nread burst is expected to be between 5 and 10000. Provided value is 1000000\nwrite burst is expected to be between 5 and 10000. Provided value is 1000000", err.Error())
 	})
+
+	t.Run("Invalid log template", func(t *testing.T) {
+		cfg := webapi.PluginConfig{
+			ReadRateLimiter: webapi.RateLimiterConfig{
+				QPS:   10,
+				Burst: 100,
+			},
+			WriteRateLimiter: webapi.RateLimiterConfig{
+				QPS:   10,
+				Burst: 100,
+			},
+			Caching: webapi.CachingConfig{
+				Size:           10,
+				ResyncInterval: config.Duration{Duration: 10 * time.Second},
+				Workers:        10,
+			},
+			Logs: []tasklog.TemplateLogPluginConfig{
+				{
+					DisplayName:  "Console",
+					TemplateURIs: []string{"https://example.com/{{ .resourceId "},
+				},
+			},
+		}
+
+		assert.Error(t, validateConfig(cfg))
+
+		cfg.Logs = nil
+		assert.NoError(t, validateConfig(cfg))
+
+		assert.NoError(t, json.Unmarshal([]byte(`[
+			{"project": "flytesnacks", "config": {"templates": [{"displayName": "Console", "templateUris": ["https://example.com/{{ .resourceId "]}]}}
+		]`), &cfg.LogOverrides))
+
+		assert.Error(t, validateConfig(cfg))
+	})
+
+	t.Run("Resource rates", func(t *testing.T) {
+		cfg := webapi.PluginConfig{
+			ReadRateLimiter:  webapi.RateLimiterConfig{QPS: 10, Burst: 100},
+			WriteRateLimiter: webapi.RateLimiterConfig{QPS: 10, Burst: 100},
+			Caching: webapi.CachingConfig{
+				Size:           10,
+				ResyncInterval: config.Duration{Duration: 10 * time.Second},
+				Workers:        10,
+			},
+			ResourceRates: webapi.ResourceRates{
+				"ns": {Value: 10, Window: config.Duration{Duration: time.Second}},
+			},
+		}
+
+		assert.NoError(t, validateConfig(cfg))
+
+		cfg.ResourceRates["ns"] = webapi.ResourceRate{Value: 10}
+		err := validateConfig(cfg)
+		assert.Error(t, err)
+		assert.Equal(t, "\nwindow of resource rate [ns] is expected to be positive. Provided value is 0s", err.Error())
+	})
 }
 
 func TestCreateRemotePlugin(t *testing.T) {
@@ -94,3 +161,166 @@ func TestCreateRemotePlugin(t *testing.T) {
 		DefaultForTaskTypes: []core.TaskType{"test-task"},
 	})
 }
+
+func TestCreateRemotePlugin_ResourceRates(t *testing.T) {
+	cfg := webapi.PluginConfig{
+		ReadRateLimiter:  webapi.RateLimiterConfig{QPS: 10, Burst: 100},
+		WriteRateLimiter: webapi.RateLimiterConfig{QPS: 10, Burst: 100},
+		Caching: webapi.CachingConfig{
+			Size:           10,
+			ResyncInterval: config.Duration{Duration: 10 * time.Second},
+			Workers:        10,
+		},
+		ResourceRates: webapi.ResourceRates{
+			"ns": {Value: 10, Window: config.Duration{Duration: time.Second}},
+		},
+	}
+
+	entry := createRemotePlugin(webapi.PluginEntry{
+		ID:                 "rate-limited",
+		SupportedTaskTypes: []core.TaskType{"test-task"},
+		PluginLoader: func(ctx context.Context, iCtx webapi.PluginSetupContext) (webapi.AsyncPlugin, error) {
+			return newPluginWithProperties(cfg), nil
+		},
+	}, testing2.NewFakeClock(time.Now()))
+
+	// The registrar doesn't support rates, they can't be enforced.
+	iCtx := &mocks2.SetupContext{}
+	iCtx.OnMetricsScope().Return(promutils.NewTestScope())
+	iCtx.OnResourceRegistrar().Return(&mocks2.ResourceRegistrar{})
+	_, err := entry.LoadPlugin(context.TODO(), iCtx)
+	assert.Error(t, err)
+}
+
+func TestCreateRemotePlugin_RequiredSecrets(t *testing.T) {
+	ctx := context.Background()
+	plgn := requiringSecrets{AsyncPlugin: newPluginWithProperties(webapi.PluginConfig{
+		ReadRateLimiter:  webapi.RateLimiterConfig{QPS: 10, Burst: 100},
+		WriteRateLimiter: webapi.RateLimiterConfig{QPS: 10, Burst: 100},
+		Caching: webapi.CachingConfig{
+			Size:           10,
+			ResyncInterval: config.Duration{Duration: 10 * time.Second},
+			Workers:        10,
+		},
+	}), secrets: []string{"token"}}
+
+	entry := CreateRemotePlugin(webapi.PluginEntry{
+		ID:                 "requiring-secrets",
+		SupportedTaskTypes: []core.TaskType{"test-task"},
+		PluginLoader: func(ctx context.Context, iCtx webapi.PluginSetupContext) (webapi.AsyncPlugin, error) {
+			return plgn, nil
+		},
+	})
+
+	iCtx := &mocks2.SetupContext{}
+	iCtx.OnMetricsScope().Return(promutils.NewTestScope())
+	loaded, err := entry.LoadPlugin(ctx, iCtx)
+	assert.NoError(t, err)
+
+	sm := &mocks2.SecretManager{}
+	sm.OnGetMatch(mock.Anything, "token").Return("", fmt.Errorf("not found"))
+	stateReader := &mocks2.PluginStateReader{}
+	stateReader.OnGetStateVersion().Return(0)
+	stateReader.OnGetMatch(mock.Anything).Return(0, nil)
+	stateWriter := &mocks2.PluginStateWriter{}
+	stateWriter.OnPutMatch(mock.Anything, mock.Anything).Return(nil)
+	tCtx := &mocks2.TaskExecutionContext{}
+	tCtx.OnSecretManager().Return(sm)
+	tCtx.OnPluginStateReader().Return(stateReader)
+	tCtx.OnPluginStateWriter().Return(stateWriter)
+
+	transition, err := loaded.Handle(ctx, tCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, core.PhasePermanentFailure, transition.Info().Phase())
+	assert.Contains(t, transition.Info().Err().GetMessage(), "token")
+	plgn.AsyncPlugin.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestCorePlugin_versionPhase(t *testing.T) {
+	ctx := context.Background()
+	start := time.Now()
+	fakeClock := testing2.NewFakeClock(start)
+	c := CorePlugin{
+		id:      "MyTestPlugin",
+		metrics: newMetrics(promutils.NewTestScope()),
+		clock:   fakeClock,
+	}
+
+	running := func(logs ...string) core.PhaseInfo {
+		info := &core.TaskInfo{}
+		for _, l := range logs {
+			info.Logs = append(info.Logs, &flyteIdlCore.TaskLog{Name: l})
+		}
+
+		return core.PhaseInfoRunning(core.DefaultPhaseVersion, info)
+	}
+
+	state := State{}
+	handle := func(phaseInfo core.PhaseInfo) uint32 {
+		state.PhaseVersion, phaseInfo = c.versionPhase(ctx, state.PhaseVersion, phaseInfo)
+		state.Timeline = c.recordPhase(ctx, state.Timeline, phaseInfo)
+		fakeClock.Step(time.Minute)
+		return phaseInfo.Version()
+	}
+
+	// The timeline added to the task info changes every round, it must not bump the version.
+	assert.Equal(t, uint32(0), handle(running()))
+	assert.Equal(t, uint32(0), handle(running()))
+	assert.Equal(t, uint32(1), handle(running("driver")))
+	assert.Equal(t, uint32(1), handle(running("driver")))
+	assert.Equal(t, uint32(0), handle(core.PhaseInfoSuccess(&core.TaskInfo{})))
+}
+
+func TestCorePlugin_watch(t *testing.T) {
+	ctx := context.Background()
+	start := time.Now()
+	fakeClock := testing2.NewFakeClock(start)
+	w, err := watchdog.NewWatchdog(&watchdog.Config{NearLimitRatio: 0.8, Rules: []watchdog.Rule{
+		{Phases: []string{"PhaseQueued"}, Threshold: config.Duration{Duration: time.Minute}, Action: watchdog.ActionWarn},
+		{Phases: []string{"PhaseRunning"}, Threshold: config.Duration{Duration: time.Hour}, Action: watchdog.ActionAbort},
+	}}, promutils.NewTestScope())
+	assert.NoError(t, err)
+
+	tID := &mocks2.TaskExecutionID{}
+	tID.OnGetGeneratedName().Return("abc")
+	tMeta := &mocks2.TaskExecutionMetadata{}
+	tMeta.OnGetTaskExecutionID().Return(tID)
+	tCtx := &mocks2.TaskExecutionContext{}
+	tCtx.OnTaskExecutionMetadata().Return(tMeta)
+
+	p := newPluginWithProperties(webapi.PluginConfig{})
+	p.OnDeleteMatch(mock.Anything, mock.Anything).Return(nil)
+	resourceCache := &cacheMocks.AutoRefresh{}
+	resourceCache.OnDeleteDelayed("abc").Return(nil)
+	c := CorePlugin{id: "MyTestPlugin", p: p, cache: resourceCache, clock: fakeClock, watchdog: w}
+
+	t.Run("warn", func(t *testing.T) {
+		queued := core.PhaseInfoQueued(start, core.DefaultPhaseVersion, "queued")
+		s, phaseInfo, err := c.watch(ctx, tCtx, watchdog.State{}, &State{}, queued)
+		assert.NoError(t, err)
+		assert.Equal(t, queued, phaseInfo)
+
+		fakeClock.Step(time.Minute)
+		s, phaseInfo, err = c.watch(ctx, tCtx, s, &State{}, queued)
+		assert.NoError(t, err)
+		assert.True(t, s.Stuck)
+		assert.Equal(t, core.PhaseQueued, phaseInfo.Phase())
+		assert.Equal(t, "queued (no change observed for over 1m0s)", phaseInfo.Reason())
+		p.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("abort", func(t *testing.T) {
+		running := core.PhaseInfoRunning(core.DefaultPhaseVersion, nil)
+		state := &State{Phase: PhaseResourcesCreated, ResourceMeta: "job-1"}
+		s, _, err := c.watch(ctx, tCtx, watchdog.State{}, state, running)
+		assert.NoError(t, err)
+
+		fakeClock.Step(time.Hour)
+		_, phaseInfo, err := c.watch(ctx, tCtx, s, state, running)
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhasePermanentFailure, phaseInfo.Phase())
+		assert.Equal(t, watchdog.StuckTaskErrorCode, phaseInfo.Err().GetCode())
+		p.AssertCalled(t, "Delete", mock.Anything, mock.Anything)
+		resourceCache.AssertCalled(t, "DeleteDelayed", "abc")
+	})
+}
@@ -0,0 +1,19 @@
+package webapi
+
+import (
+	"context"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+)
+
+// healthCheckedPlugin keeps the core.HealthChecker implementation of an AsyncPlugin visible on the CorePlugin running
+// it, so that its health is probed once loaded. CorePlugin writes plugin state on the first round of every task, which
+// lets the health interceptor hold its new tasks while the plugin is unhealthy.
+type healthCheckedPlugin struct {
+	CorePlugin
+	checker core.HealthChecker
+}
+
+func (p healthCheckedPlugin) CheckHealth(ctx context.Context) error {
+	return p.checker.CheckHealth(ctx)
+}
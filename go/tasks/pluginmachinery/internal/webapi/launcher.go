@@ -5,16 +5,37 @@ import (
 	"time"
 
 	"github.com/flyteorg/flytestdlib/cache"
+	stdErrors "github.com/flyteorg/flytestdlib/errors"
 	"github.com/flyteorg/flytestdlib/logger"
 
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/secretmanager"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
 )
 
-func launch(ctx context.Context, p webapi.AsyncPlugin, tCtx core.TaskExecutionContext, cache cache.AutoRefresh,
-	state *State) (newState *State, phaseInfo core.PhaseInfo, err error) {
+// launch creates the resource of the task. If requirer isn't nil, the secrets it requires are checked first so that
+// tasks missing them fail before anything is created.
+func launch(ctx context.Context, p webapi.AsyncPlugin, requirer webapi.SecretsRequirer, tCtx core.TaskExecutionContext,
+	cache cache.AutoRefresh, state *State) (newState *State, phaseInfo core.PhaseInfo, err error) {
+	if requirer != nil {
+		if err := secretmanager.Require(ctx, tCtx.SecretManager(), requirer.RequiredSecrets(tCtx)...); err != nil {
+			if stdErrors.IsCausedBy(err, errors.SecretNotFound) {
+				logger.Errorf(ctx, "Required secrets are missing. Error: %v", err)
+				return state, core.PhaseInfoSystemFailure(string(errors.SecretNotFound), err.Error(), nil), nil
+			}
+
+			return nil, core.PhaseInfo{}, err
+		}
+	}
+
 	rMeta, r, err := p.Create(ctx, tCtx)
 	if err != nil {
+		if stdErrors.IsCausedBy(err, errors.SecretNotFound) {
+			logger.Errorf(ctx, "Secrets to create the resource are missing. Error: %v", err)
+			return state, core.PhaseInfoSystemFailure(string(errors.SecretNotFound), err.Error(), nil), nil
+		}
+
 		logger.Errorf(ctx, "Failed to create resource. Error: %v", err)
 		return nil, core.PhaseInfo{}, err
 	}
@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+	webapiMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/mocks"
 	mocks2 "github.com/flyteorg/flytestdlib/cache/mocks"
 	"github.com/stretchr/testify/assert"
 )
@@ -33,7 +35,7 @@ func Test_launch(t *testing.T) {
 		plgn := newPluginWithProperties(webapi.PluginConfig{})
 		plgn.OnCreate(ctx, tCtx).Return("abc", nil, nil)
 		plgn.OnStatus(ctx, newPluginContext("abc", nil, "", tCtx)).Return(core.PhaseInfoSuccess(nil), nil)
-		newS, phaseInfo, err := launch(ctx, plgn, tCtx, c, &s)
+		newS, phaseInfo, err := launch(ctx, plgn, nil, tCtx, c, &s)
 		assert.NoError(t, err)
 		assert.NotNil(t, newS)
 		assert.NotNil(t, phaseInfo)
@@ -57,7 +59,7 @@ func Test_launch(t *testing.T) {
 		plgn := newPluginWithProperties(webapi.PluginConfig{})
 		plgn.OnCreate(ctx, tCtx).Return("abc", "abc-r", nil)
 		plgn.OnStatus(ctx, newPluginContext("abc", "abc-r", "", tCtx)).Return(core.PhaseInfoSuccess(nil), nil)
-		newS, phaseInfo, err := launch(ctx, plgn, tCtx, c, &s)
+		newS, phaseInfo, err := launch(ctx, plgn, nil, tCtx, c, &s)
 		assert.NoError(t, err)
 		assert.NotNil(t, newS)
 		assert.NotNil(t, phaseInfo)
@@ -79,7 +81,7 @@ func Test_launch(t *testing.T) {
 
 		plgn := newPluginWithProperties(webapi.PluginConfig{})
 		plgn.OnCreate(ctx, tCtx).Return("", nil, fmt.Errorf("error creating"))
-		_, _, err := launch(ctx, plgn, tCtx, c, &s)
+		_, _, err := launch(ctx, plgn, nil, tCtx, c, &s)
 		assert.Error(t, err)
 	})
 
@@ -102,7 +104,60 @@ func Test_launch(t *testing.T) {
 		plgn := newPluginWithProperties(webapi.PluginConfig{})
 		plgn.OnCreate(ctx, tCtx).Return("my-id", nil, nil)
 		plgn.OnStatus(ctx, newPluginContext("my-id", nil, "", tCtx)).Return(core.PhaseInfoRunning(0, nil), nil)
-		_, _, err := launch(ctx, plgn, tCtx, c, &s)
+		_, _, err := launch(ctx, plgn, nil, tCtx, c, &s)
 		assert.Error(t, err)
 	})
+	t.Run("Missing required secrets", func(t *testing.T) {
+		ctx := context.Background()
+		sm := &mocks.SecretManager{}
+		sm.OnGet(ctx, "token").Return("token", nil)
+		sm.OnGet(ctx, "password").Return("", fmt.Errorf("not found"))
+		tCtx := &mocks.TaskExecutionContext{}
+		tCtx.OnSecretManager().Return(sm)
+
+		plgn := requiringSecrets{AsyncPlugin: newPluginWithProperties(webapi.PluginConfig{}), secrets: []string{"token", "password"}}
+		s := State{}
+		newS, phaseInfo, err := launch(ctx, plgn, plgn, tCtx, &mocks2.AutoRefresh{}, &s)
+		assert.NoError(t, err)
+		assert.Equal(t, &s, newS)
+		assert.Equal(t, core.PhasePermanentFailure, phaseInfo.Phase())
+		assert.Contains(t, phaseInfo.Err().GetMessage(), "password")
+		plgn.AsyncPlugin.AssertNotCalled(t, "Create", ctx, tCtx)
+	})
+
+	t.Run("Required secrets unavailable", func(t *testing.T) {
+		ctx := context.Background()
+		sm := &mocks.SecretManager{}
+		sm.OnGet(ctx, "token").Return("", errors.Errorf(errors.DownstreamSystemError, "vault unreachable"))
+		tCtx := &mocks.TaskExecutionContext{}
+		tCtx.OnSecretManager().Return(sm)
+
+		plgn := requiringSecrets{AsyncPlugin: newPluginWithProperties(webapi.PluginConfig{}), secrets: []string{"token"}}
+		_, _, err := launch(ctx, plgn, plgn, tCtx, &mocks2.AutoRefresh{}, &State{})
+		assert.Error(t, err)
+	})
+
+	t.Run("Missing secrets to create resource", func(t *testing.T) {
+		ctx := context.Background()
+		tCtx := &mocks.TaskExecutionContext{}
+
+		plgn := newPluginWithProperties(webapi.PluginConfig{})
+		plgn.OnCreate(ctx, tCtx).Return(nil, nil, errors.Wrapf(errors.RuntimeFailure,
+			errors.Errorf(errors.SecretNotFound, "secret [token] not found"), "failed to create client"))
+		s := State{}
+		newS, phaseInfo, err := launch(ctx, plgn, nil, tCtx, &mocks2.AutoRefresh{}, &s)
+		assert.NoError(t, err)
+		assert.Equal(t, &s, newS)
+		assert.Equal(t, core.PhasePermanentFailure, phaseInfo.Phase())
+		assert.Contains(t, phaseInfo.Err().GetMessage(), "token")
+	})
+}
+
+type requiringSecrets struct {
+	*webapiMocks.AsyncPlugin
+	secrets []string
+}
+
+func (p requiringSecrets) RequiredSecrets(webapi.TaskExecutionContextReader) []string {
+	return p.secrets
 }
@@ -6,6 +6,8 @@ import (
 	"github.com/flyteorg/flytestdlib/promutils"
 	"github.com/flyteorg/flytestdlib/promutils/labeled"
 	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/timeline"
 )
 
 type Metrics struct {
@@ -17,6 +19,7 @@ type Metrics struct {
 	ResourceWaitTime        prometheus.Summary
 	SucceededUnmarshalState labeled.StopWatch
 	FailedUnmarshalState    labeled.Counter
+	PhaseDurations          timeline.Metrics
 }
 
 var (
@@ -40,5 +43,6 @@ func newMetrics(scope promutils.Scope) Metrics {
 			time.Millisecond, scope),
 		FailedUnmarshalState: labeled.NewCounter("unmarshal_state_failed",
 			"Failed to unmarshal state", scope, labeled.EmitUnlabeledMetric),
+		PhaseDurations: timeline.NewMetrics(scope),
 	}
 }
@@ -0,0 +1,27 @@
+package webapi
+
+import (
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/secretmanager"
+)
+
+// secretsTaskExecutionContext replaces the secret manager of the task execution by one also reading structured secret
+// references from the configured backends, see secretmanager.Manager.Wrap.
+type secretsTaskExecutionContext struct {
+	core.TaskExecutionContext
+	secrets secretmanager.Manager
+}
+
+func (c secretsTaskExecutionContext) SecretManager() core.SecretManager {
+	return c.secrets.Wrap(c.TaskExecutionContext.SecretManager())
+}
+
+// secretsSetupContext does the same for the setup context plugins are loaded with.
+type secretsSetupContext struct {
+	core.SetupContext
+	secrets secretmanager.Manager
+}
+
+func (c secretsSetupContext) SecretManager() core.SecretManager {
+	return c.secrets.Wrap(c.SetupContext.SecretManager())
+}
@@ -3,6 +3,9 @@ package webapi
 import (
 	"time"
 
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/timeline"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/watchdog"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
 )
 
@@ -56,4 +59,21 @@ type State struct {
 
 	// The time the execution first requests for an allocation token
 	AllocationTokenRequestStartTime time.Time `json:"allocationTokenRequestStartTime,omitempty"`
+
+	// Timeline of the phases the task went through.
+	Timeline timeline.Timeline `json:"timeline,omitempty"`
+
+	// PhaseVersion of the last phase reported, unless the plugin manages phase versions itself.
+	PhaseVersion core.PhaseVersion `json:"phaseVersion,omitempty"`
+
+	// Watchdog state of the task, to tell whether it's stuck.
+	Watchdog watchdog.State `json:"watchdog,omitempty"`
 }
+
+// stateMigrations upgrades the persisted State of older versions of the plugin. Version 0 is only ever reported when
+// there is no state yet, its zero value is that of the current State.
+var stateMigrations = core.NewStateMigrations(pluginStateVersion).
+	Register(0, core.StateMigration{
+		NewState: func() interface{} { return &State{} },
+		Migrate:  func(state interface{}) (interface{}, error) { return state, nil },
+	})
@@ -1,6 +1,21 @@
 package webapi
 
-import "testing"
+import (
+	"bytes"
+	"encoding/gob"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	stdErrors "github.com/flyteorg/flytestdlib/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+)
+
+var update = flag.Bool("update", false, "rewrite the serialized plugin states in testdata")
 
 func TestPhase_IsTerminal(t *testing.T) {
 	tests := []struct {
@@ -22,3 +37,66 @@ func TestPhase_IsTerminal(t *testing.T) {
 		})
 	}
 }
+
+// gobStateReader reads gob encoded state, as FlytePropeller does.
+type gobStateReader struct {
+	version uint8
+	state   []byte
+}
+
+func (r gobStateReader) GetStateVersion() uint8 {
+	return r.version
+}
+
+func (r gobStateReader) Get(t interface{}) (uint8, error) {
+	if len(r.state) == 0 {
+		return 0, nil
+	}
+
+	return r.version, gob.NewDecoder(bytes.NewReader(r.state)).Decode(t)
+}
+
+func TestStateMigrations(t *testing.T) {
+	v1 := State{
+		Phase:                           PhaseResourcesCreated,
+		ResourceMeta:                    "query-id",
+		SyncFailureCount:                1,
+		CreationFailureCount:            2,
+		AllocationTokenRequestStartTime: time.Date(2023, 4, 5, 6, 7, 8, 0, time.UTC),
+	}
+
+	path := filepath.Join("testdata", "state_v1.gob")
+	if *update {
+		buf := &bytes.Buffer{}
+		assert.NoError(t, gob.NewEncoder(buf).Encode(v1))
+		assert.NoError(t, os.WriteFile(path, buf.Bytes(), 0644))
+	}
+
+	blob, err := os.ReadFile(path)
+	assert.NoError(t, err)
+
+	t.Run("no state", func(t *testing.T) {
+		state := State{}
+		_, err := stateMigrations.Get(gobStateReader{}, &state)
+		assert.NoError(t, err)
+		assert.Equal(t, State{}, state)
+	})
+
+	t.Run("version 1", func(t *testing.T) {
+		state := State{}
+		version, err := stateMigrations.Get(gobStateReader{version: 1, state: blob}, &state)
+		assert.NoError(t, err)
+		assert.Equal(t, uint8(1), version)
+		assert.Equal(t, v1.Phase, state.Phase)
+		assert.Equal(t, v1.ResourceMeta, state.ResourceMeta)
+		assert.Equal(t, v1.SyncFailureCount, state.SyncFailureCount)
+		assert.Equal(t, v1.CreationFailureCount, state.CreationFailureCount)
+		assert.True(t, v1.AllocationTokenRequestStartTime.Equal(state.AllocationTokenRequestStartTime))
+	})
+
+	t.Run("future version", func(t *testing.T) {
+		_, err := stateMigrations.Get(gobStateReader{version: pluginStateVersion + 1, state: blob}, &State{})
+		assert.Error(t, err)
+		assert.True(t, stdErrors.IsCausedBy(err, errors.UnsupportedStateVersion))
+	})
+}
@@ -0,0 +1,50 @@
+package webapi
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tracing"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+)
+
+// tracedPlugin traces the calls an AsyncPlugin makes to its remote service.
+type tracedPlugin struct {
+	webapi.AsyncPlugin
+	id string
+}
+
+func (p tracedPlugin) attributes(tCtx interface{}) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{tracing.PluginAttribute(p.id)}
+	switch t := tCtx.(type) {
+	case pluginContext:
+		// Contexts built by the cache sync loop don't have a task execution context.
+		if t.TaskExecutionContext != nil {
+			attrs = append(attrs, tracing.TaskAttributes(t.TaskExecutionMetadata().GetTaskExecutionID())...)
+		}
+	case webapi.TaskExecutionContextReader:
+		attrs = append(attrs, tracing.TaskAttributes(t.TaskExecutionMetadata().GetTaskExecutionID())...)
+	}
+
+	return attrs
+}
+
+func (p tracedPlugin) Create(ctx context.Context, tCtx webapi.TaskExecutionContextReader) (
+	resourceMeta webapi.ResourceMeta, optionalResource webapi.Resource, err error) {
+	ctx, span := tracing.StartSpan(ctx, "webapi.create", p.attributes(tCtx)...)
+	defer func() { tracing.EndSpan(span, err) }()
+	return p.AsyncPlugin.Create(ctx, tCtx)
+}
+
+func (p tracedPlugin) Get(ctx context.Context, tCtx webapi.GetContext) (latest webapi.Resource, err error) {
+	ctx, span := tracing.StartSpan(ctx, "webapi.get", p.attributes(tCtx)...)
+	defer func() { tracing.EndSpan(span, err) }()
+	return p.AsyncPlugin.Get(ctx, tCtx)
+}
+
+func (p tracedPlugin) Delete(ctx context.Context, tCtx webapi.DeleteContext) (err error) {
+	ctx, span := tracing.StartSpan(ctx, "webapi.delete", p.attributes(tCtx)...)
+	defer func() { tracing.EndSpan(span, err) }()
+	return p.AsyncPlugin.Delete(ctx, tCtx)
+}
@@ -0,0 +1,59 @@
+package webapi
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	coreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tracing"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/mocks"
+)
+
+func TestTracedPlugin(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)))
+	defer otel.SetTracerProvider(prev)
+
+	ctx := context.Background()
+	tID := &coreMocks.TaskExecutionID{}
+	tID.OnGetGeneratedName().Return("exec-name-n0-0")
+	tID.OnGetID().Return(idlCore.TaskExecutionIdentifier{})
+	tMeta := &coreMocks.TaskExecutionMetadata{}
+	tMeta.OnGetTaskExecutionID().Return(tID)
+	tCtx := &mocks.TaskExecutionContext{}
+	tCtx.OnTaskExecutionMetadata().Return(tMeta)
+
+	plugin := &mocks.AsyncPlugin{}
+	plugin.OnCreateMatch(mock.Anything, mock.Anything).Return("meta", nil, nil)
+	plugin.OnGetMatch(mock.Anything, mock.Anything).Return(nil, fmt.Errorf("failed"))
+
+	p := tracedPlugin{AsyncPlugin: plugin, id: "my-plugin"}
+	_, _, err := p.Create(ctx, tCtx)
+	assert.NoError(t, err)
+
+	// Contexts built by the cache sync loop don't have a task execution context.
+	_, err = p.Get(ctx, newPluginContext("meta", nil, "", nil))
+	assert.EqualError(t, err, "failed")
+
+	spans := sr.Ended()
+	if assert.Len(t, spans, 2) {
+		assert.Equal(t, "webapi.create", spans[0].Name())
+		assert.Contains(t, spans[0].Attributes(), tracing.PluginIDKey.String("my-plugin"))
+		assert.Contains(t, spans[0].Attributes(), tracing.TaskExecutionIDKey.String("exec-name-n0-0"))
+
+		assert.Equal(t, "webapi.get", spans[1].Name())
+		assert.Equal(t, []attribute.KeyValue{tracing.PluginIDKey.String("my-plugin")}, spans[1].Attributes())
+		assert.Equal(t, codes.Error, spans[1].Status().Code)
+	}
+}
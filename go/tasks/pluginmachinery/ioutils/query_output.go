@@ -0,0 +1,78 @@
+package ioutils
+
+import (
+	"fmt"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/golang/protobuf/proto"
+)
+
+// Well-known formats of the files produced by query engines. They are used to fill in the format of a
+// StructuredDataset literal when the task interface does not declare one.
+const (
+	StructuredDatasetFormatParquet = "parquet"
+	StructuredDatasetFormatCSV     = "csv"
+)
+
+// NewQueryResultLiteral builds the literal pointing to the results of an externally executed query stored at uri.
+// If the declared output type is a StructuredDataset, a StructuredDataset literal is built carrying the declared
+// columns and format (defaultFormat is used if the interface leaves the format empty). If the declared output type is
+// a Schema, a legacy Schema literal is built instead.
+func NewQueryResultLiteral(outputType *core.LiteralType, uri string, defaultFormat string) (*core.Literal, error) {
+	if sdType := outputType.GetStructuredDatasetType(); sdType != nil {
+		sdType = proto.Clone(sdType).(*core.StructuredDatasetType)
+		if len(sdType.Format) == 0 {
+			sdType.Format = defaultFormat
+		}
+
+		return &core.Literal{
+			Value: &core.Literal_Scalar{
+				Scalar: &core.Scalar{
+					Value: &core.Scalar_StructuredDataset{
+						StructuredDataset: &core.StructuredDataset{
+							Uri: uri,
+							Metadata: &core.StructuredDatasetMetadata{
+								StructuredDatasetType: sdType,
+							},
+						},
+					},
+				},
+			},
+		}, nil
+	}
+
+	if schemaType := outputType.GetSchema(); schemaType != nil {
+		return &core.Literal{
+			Value: &core.Literal_Scalar{
+				Scalar: &core.Scalar{
+					Value: &core.Scalar_Schema{
+						Schema: &core.Schema{
+							Uri:  uri,
+							Type: schemaType,
+						},
+					},
+				},
+			},
+		}, nil
+	}
+
+	return nil, fmt.Errorf("query results can only be written to a StructuredDataset or Schema output, found [%v]",
+		outputType)
+}
+
+// NewQueryResultOutputReader builds an in-memory OutputReader with a single output, named outputName, that points to
+// the results of an externally executed query. See NewQueryResultLiteral.
+func NewQueryResultOutputReader(outputName string, outputType *core.LiteralType, uri string, defaultFormat string) (
+	InMemoryOutputReader, error) {
+
+	lit, err := NewQueryResultLiteral(outputType, uri, defaultFormat)
+	if err != nil {
+		return InMemoryOutputReader{}, err
+	}
+
+	return NewInMemoryOutputReader(&core.LiteralMap{
+		Literals: map[string]*core.Literal{
+			outputName: lit,
+		},
+	}, nil, nil), nil
+}
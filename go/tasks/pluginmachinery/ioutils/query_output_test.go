@@ -0,0 +1,73 @@
+package ioutils
+
+import (
+	"context"
+	"testing"
+
+	flyteIdlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewQueryResultLiteral(t *testing.T) {
+	columns := []*flyteIdlCore.StructuredDatasetType_DatasetColumn{
+		{
+			Name:        "col1",
+			LiteralType: &flyteIdlCore.LiteralType{Type: &flyteIdlCore.LiteralType_Simple{Simple: flyteIdlCore.SimpleType_INTEGER}},
+		},
+	}
+
+	t.Run("structured dataset with default format", func(t *testing.T) {
+		sdType := &flyteIdlCore.StructuredDatasetType{Columns: columns}
+		lit, err := NewQueryResultLiteral(&flyteIdlCore.LiteralType{
+			Type: &flyteIdlCore.LiteralType_StructuredDatasetType{StructuredDatasetType: sdType},
+		}, "s3://bucket/key", StructuredDatasetFormatCSV)
+		assert.NoError(t, err)
+
+		sd := lit.GetScalar().GetStructuredDataset()
+		assert.Equal(t, "s3://bucket/key", sd.GetUri())
+		assert.Equal(t, StructuredDatasetFormatCSV, sd.GetMetadata().GetStructuredDatasetType().GetFormat())
+		assert.Equal(t, columns, sd.GetMetadata().GetStructuredDatasetType().GetColumns())
+		// The declared type must not be mutated.
+		assert.Empty(t, sdType.GetFormat())
+	})
+
+	t.Run("structured dataset with declared format", func(t *testing.T) {
+		lit, err := NewQueryResultLiteral(&flyteIdlCore.LiteralType{
+			Type: &flyteIdlCore.LiteralType_StructuredDatasetType{StructuredDatasetType: &flyteIdlCore.StructuredDatasetType{
+				Format: StructuredDatasetFormatParquet,
+			}},
+		}, "s3://bucket/key", StructuredDatasetFormatCSV)
+		assert.NoError(t, err)
+		assert.Equal(t, StructuredDatasetFormatParquet,
+			lit.GetScalar().GetStructuredDataset().GetMetadata().GetStructuredDatasetType().GetFormat())
+	})
+
+	t.Run("schema", func(t *testing.T) {
+		schemaType := &flyteIdlCore.SchemaType{}
+		lit, err := NewQueryResultLiteral(&flyteIdlCore.LiteralType{
+			Type: &flyteIdlCore.LiteralType_Schema{Schema: schemaType},
+		}, "s3://bucket/key", StructuredDatasetFormatParquet)
+		assert.NoError(t, err)
+		assert.Equal(t, "s3://bucket/key", lit.GetScalar().GetSchema().GetUri())
+		assert.Equal(t, schemaType, lit.GetScalar().GetSchema().GetType())
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		_, err := NewQueryResultLiteral(&flyteIdlCore.LiteralType{
+			Type: &flyteIdlCore.LiteralType_Simple{Simple: flyteIdlCore.SimpleType_STRING},
+		}, "s3://bucket/key", StructuredDatasetFormatParquet)
+		assert.Error(t, err)
+	})
+}
+
+func TestNewQueryResultOutputReader(t *testing.T) {
+	or, err := NewQueryResultOutputReader("results", &flyteIdlCore.LiteralType{
+		Type: &flyteIdlCore.LiteralType_StructuredDatasetType{StructuredDatasetType: &flyteIdlCore.StructuredDatasetType{}},
+	}, "s3://bucket/key", StructuredDatasetFormatParquet)
+	assert.NoError(t, err)
+
+	literalMap, executionErr, err := or.Read(context.TODO())
+	assert.NoError(t, err)
+	assert.Nil(t, executionErr)
+	assert.Equal(t, "s3://bucket/key", literalMap.GetLiterals()["results"].GetScalar().GetStructuredDataset().GetUri())
+}
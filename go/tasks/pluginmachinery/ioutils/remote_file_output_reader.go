@@ -34,7 +34,7 @@ func (r RemoteFileOutputReader) IsError(ctx context.Context) (bool, error) {
 
 func (r RemoteFileOutputReader) ReadError(ctx context.Context) (io.ExecutionError, error) {
 	errorDoc := &core.ErrorDocument{}
-	err := r.store.ReadProtobuf(ctx, r.outPath.GetErrorPath(), errorDoc)
+	err := readProtobuf(ctx, r.store, r.outPath.GetErrorPath(), errorDoc)
 	if err != nil {
 		if storage.IsNotFound(err) {
 			return io.ExecutionError{
@@ -92,7 +92,7 @@ func (r RemoteFileOutputReader) Exists(ctx context.Context) (bool, error) {
 func (r RemoteFileOutputReader) Read(ctx context.Context) (*core.LiteralMap, *io.ExecutionError, error) {
 
 	d := &core.LiteralMap{}
-	if err := r.store.ReadProtobuf(ctx, r.outPath.GetOutputPath(), d); err != nil {
+	if err := readProtobuf(ctx, r.store, r.outPath.GetOutputPath(), d); err != nil {
 		// TODO change flytestdlib to return protobuf unmarshal errors separately. As this can indicate malformed output and we should catch that
 		return nil, nil, fmt.Errorf("failed to read data from dataDir [%v]. Error: %v", r.outPath.GetOutputPath(), err)
 	}
@@ -74,11 +74,11 @@ func (w RemoteFileOutputWriter) Put(ctx context.Context, reader io.OutputReader)
 			},
 		}
 
-		return w.store.WriteProtobuf(ctx, w.GetErrorPath(), storage.Options{}, errDoc)
+		return writeProtobuf(ctx, w.store, w.GetErrorPath(), errDoc)
 	}
 
 	if literals != nil {
-		return w.store.WriteProtobuf(ctx, w.GetOutputPath(), storage.Options{}, literals)
+		return writeProtobuf(ctx, w.store, w.GetOutputPath(), literals)
 	}
 
 	return fmt.Errorf("no data found to write")
@@ -0,0 +1,28 @@
+package ioutils
+
+import (
+	"context"
+
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/golang/protobuf/proto"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tracing"
+)
+
+// readProtobuf reads msg from reference within a storage.read span.
+func readProtobuf(ctx context.Context, store storage.ProtobufStore, reference storage.DataReference,
+	msg proto.Message) error {
+	ctx, span := tracing.StartSpan(ctx, "storage.read", tracing.StoragePathKey.String(reference.String()))
+	err := store.ReadProtobuf(ctx, reference, msg)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+// writeProtobuf writes msg to reference within a storage.write span.
+func writeProtobuf(ctx context.Context, store storage.ProtobufStore, reference storage.DataReference,
+	msg proto.Message) error {
+	ctx, span := tracing.StartSpan(ctx, "storage.write", tracing.StoragePathKey.String(reference.String()))
+	err := store.WriteProtobuf(ctx, reference, storage.Options{}, msg)
+	tracing.EndSpan(span, err)
+	return err
+}
@@ -70,6 +70,22 @@ type PluginContext interface {
 	TaskExecutionMetadata() pluginsCore.TaskExecutionMetadata
 }
 
+// K8sReaderProvider is optionally implemented by PluginContexts that can read k8s objects. Plugins of custom resources
+// use it to read the pods their resources run, e.g. to build log links for all the containers of the pods.
+type K8sReaderProvider interface {
+	// Returns a reader of k8s objects, preferably backed by the informer cache.
+	K8sReader() client.Reader
+}
+
+// GetK8sReader returns the reader of k8s objects of pluginContext, or nil if it doesn't implement K8sReaderProvider.
+func GetK8sReader(pluginContext PluginContext) client.Reader {
+	if provider, ok := pluginContext.(K8sReaderProvider); ok {
+		return provider.K8sReader()
+	}
+
+	return nil
+}
+
 // Defines a simplified interface to author plugins for k8s resources.
 type Plugin interface {
 	// Defines a func to create a query object (typically just object and type meta portions) that's used to query k8s
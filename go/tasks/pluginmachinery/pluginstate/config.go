@@ -0,0 +1,32 @@
+package pluginstate
+
+import (
+	"github.com/flyteorg/flyteplugins/go/tasks/config"
+)
+
+//go:generate pflags Config --default-var=defaultConfig
+
+var (
+	defaultConfig = &Config{
+		CompressionThreshold: 64 * 1024,
+		OffloadThreshold:     512 * 1024,
+	}
+
+	cfgSection = config.MustRegisterSubSection("pluginState", defaultConfig)
+)
+
+// Config configures when plugin state is compressed and offloaded to blob storage. Both thresholds apply to the size of
+// the serialized state, a non-positive threshold disables the step.
+type Config struct {
+	CompressionThreshold int `json:"compressionThreshold" pflag:",Size in bytes above which plugin state is compressed."`
+	OffloadThreshold     int `json:"offloadThreshold" pflag:",Size in bytes of the compressed plugin state above which it's offloaded to blob storage."`
+}
+
+func GetConfig() *Config {
+	return cfgSection.GetConfig().(*Config)
+}
+
+// SetConfig should be used for unit testing only
+func SetConfig(cfg *Config) error {
+	return cfgSection.SetConfig(cfg)
+}
@@ -0,0 +1,274 @@
+// Package pluginstate keeps large plugin state within the size limits of the workflow CRD. Plugin state above a
+// threshold is compressed, and above a second threshold offloaded to blob storage under the output prefix of the task
+// (see io.OutputWriter.GetOutputPrefixPath), with only a reference to it kept inline. State below the thresholds is
+// stored as is, so plugins can opt in without migrating their existing state. Compressed or offloaded state is stored
+// at a state version with envelopeVersionBit set, so that binaries unaware of the envelope report it as written by a
+// newer version of the plugin rather than misreading it.
+//
+// Offloaded state is deleted once superseded by the state of later rounds, so at most the state of the last two rounds
+// is kept per task attempt. The state of the last rounds isn't deleted when the task completes, as it's read again to
+// abort or finalize the task, possibly more than once. It's retained along with the outputs of the task, under the same
+// prefix, and is removed by whatever removes those, e.g. the lifecycle rules of the bucket.
+package pluginstate
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"io"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/storage"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+)
+
+// Encoding is the encoding of the serialized plugin state in an envelope.
+type Encoding string
+
+const (
+	// EncodingGob is the gob encoded state.
+	EncodingGob Encoding = "gob"
+	// EncodingGzip is the gob encoded state, compressed with gzip.
+	EncodingGzip Encoding = "gob+gzip"
+)
+
+// offloadedStateDir is the directory of the offloaded states under the output prefix of the task.
+const offloadedStateDir = "plugin-state"
+
+// envelopeVersionBit is set on the state version of the envelopes. Plugins' own state versions must leave it unset.
+const envelopeVersionBit uint8 = 0x80
+
+// envelope is stored in place of compressed or offloaded state.
+type envelope struct {
+	PluginStateEncoding  Encoding
+	PluginStateData      []byte
+	PluginStateReference storage.DataReference
+	// PreviousReference is the offloaded state of the previous round. It's deleted once this envelope is itself
+	// superseded, as the previous round's state is no longer needed past that point.
+	PreviousReference storage.DataReference
+}
+
+// writer compresses and offloads plugin state above the configured thresholds before writing it.
+type writer struct {
+	ctx  context.Context
+	tCtx core.TaskExecutionContext
+	cfg  *Config
+}
+
+func (w writer) Put(stateVersion uint8, v interface{}) error {
+	if stateVersion&envelopeVersionBit != 0 {
+		return errors.Errorf(errors.UnsupportedStateVersion, "plugin state version [%v] is above the maximum [%v]",
+			stateVersion, envelopeVersionBit-1)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := gob.NewEncoder(buf).Encode(v); err != nil {
+		return errors.Wrapf(errors.CorruptedPluginState, err, "failed to encode plugin state")
+	}
+
+	env := envelope{PluginStateEncoding: EncodingGob, PluginStateData: buf.Bytes()}
+	if w.cfg.CompressionThreshold > 0 && len(env.PluginStateData) > w.cfg.CompressionThreshold {
+		compressed, err := compress(env.PluginStateData)
+		if err != nil {
+			return errors.Wrapf(errors.CorruptedPluginState, err, "failed to compress plugin state")
+		}
+
+		env = envelope{PluginStateEncoding: EncodingGzip, PluginStateData: compressed}
+	}
+
+	if w.cfg.OffloadThreshold > 0 && len(env.PluginStateData) > w.cfg.OffloadThreshold {
+		ref, err := w.offload(env.PluginStateData)
+		if err != nil {
+			return err
+		}
+
+		env.PluginStateData, env.PluginStateReference = nil, ref
+	}
+
+	// The stored envelope is the state of the previous round, which was persisted. The state it superseded, two rounds
+	// ago, isn't needed anymore. The state of the previous round is kept until this round's state is persisted too.
+	stored := w.stored()
+	w.delete(stored.PreviousReference, env.PluginStateReference, stored.PluginStateReference)
+	if stored.PluginStateReference != env.PluginStateReference {
+		env.PreviousReference = stored.PluginStateReference
+	}
+
+	if env.PluginStateEncoding == EncodingGob && len(env.PluginStateReference) == 0 && len(env.PreviousReference) == 0 {
+		return w.tCtx.PluginStateWriter().Put(stateVersion, v)
+	}
+
+	return w.tCtx.PluginStateWriter().Put(stateVersion|envelopeVersionBit, env)
+}
+
+// Returns the envelope stored by the previous round, or an empty one if the state was stored as is.
+func (w writer) stored() envelope {
+	env := envelope{}
+	if w.tCtx.PluginStateReader().GetStateVersion()&envelopeVersionBit == 0 {
+		return env
+	}
+
+	if _, err := w.tCtx.PluginStateReader().Get(&env); err != nil {
+		logger.Warnf(w.ctx, "Failed to read the stored plugin state envelope. Error: %v", err)
+		return envelope{}
+	}
+
+	return env
+}
+
+// Deletes the offloaded state at ref, unless it's empty or one of the references still in use. Failures are only
+// logged, a leftover offloaded state doesn't affect the task.
+func (w writer) delete(ref storage.DataReference, inUse ...storage.DataReference) {
+	if len(ref) == 0 {
+		return
+	}
+
+	for _, r := range inUse {
+		if r == ref {
+			return
+		}
+	}
+
+	logger.Debugf(w.ctx, "Deleting superseded offloaded plugin state [%v]", ref)
+	if err := w.tCtx.DataStore().Delete(w.ctx, ref); err != nil {
+		logger.Warnf(w.ctx, "Failed to delete superseded offloaded plugin state [%v]. Error: %v", ref, err)
+	}
+}
+
+// Writes the state under a name derived from its content. The state of a failed round must remain readable, it's
+// never overwritten.
+func (w writer) offload(data []byte) (storage.DataReference, error) {
+	sum := sha256.Sum256(data)
+	ref, err := w.tCtx.DataStore().ConstructReference(w.ctx, w.tCtx.OutputWriter().GetOutputPrefixPath(),
+		offloadedStateDir, hex.EncodeToString(sum[:]))
+	if err != nil {
+		return "", errors.Wrapf(errors.MetadataAccessFailed, err, "failed to construct the offloaded plugin state path")
+	}
+
+	logger.Debugf(w.ctx, "Offloading plugin state of [%v] bytes to [%v]", len(data), ref)
+	if err := w.tCtx.DataStore().WriteRaw(w.ctx, ref, int64(len(data)), storage.Options{}, bytes.NewReader(data)); err != nil {
+		return "", errors.Wrapf(errors.MetadataAccessFailed, err, "failed to offload plugin state to [%v]", ref)
+	}
+
+	return ref, nil
+}
+
+func (w writer) Reset() error {
+	return w.tCtx.PluginStateWriter().Reset()
+}
+
+// reader reads plugin state written by writer, whether it was stored as is, compressed or offloaded.
+type reader struct {
+	ctx  context.Context
+	tCtx core.TaskExecutionContext
+}
+
+func (r reader) GetStateVersion() uint8 {
+	return r.tCtx.PluginStateReader().GetStateVersion() &^ envelopeVersionBit
+}
+
+func (r reader) Get(t interface{}) (stateVersion uint8, err error) {
+	if r.tCtx.PluginStateReader().GetStateVersion()&envelopeVersionBit == 0 {
+		// The state was stored as is.
+		return r.tCtx.PluginStateReader().Get(t)
+	}
+
+	env := envelope{}
+	if stateVersion, err = r.tCtx.PluginStateReader().Get(&env); err != nil {
+		return stateVersion &^ envelopeVersionBit, errors.Wrapf(errors.CorruptedPluginState, err,
+			"failed to read plugin state envelope")
+	}
+
+	stateVersion &^= envelopeVersionBit
+	data := env.PluginStateData
+	if len(env.PluginStateReference) > 0 {
+		if data, err = r.read(env.PluginStateReference); err != nil {
+			return stateVersion, err
+		}
+	}
+
+	switch env.PluginStateEncoding {
+	case EncodingGob:
+	case EncodingGzip:
+		if data, err = decompress(data); err != nil {
+			return stateVersion, errors.Wrapf(errors.CorruptedPluginState, err, "failed to decompress plugin state")
+		}
+	default:
+		return stateVersion, errors.Errorf(errors.CorruptedPluginState, "unknown plugin state encoding [%v]",
+			env.PluginStateEncoding)
+	}
+
+	if err = gob.NewDecoder(bytes.NewReader(data)).Decode(t); err != nil {
+		return stateVersion, errors.Wrapf(errors.CorruptedPluginState, err, "failed to decode plugin state")
+	}
+
+	return stateVersion, nil
+}
+
+func (r reader) read(ref storage.DataReference) ([]byte, error) {
+	rc, err := r.tCtx.DataStore().ReadRaw(r.ctx, ref)
+	if err != nil {
+		return nil, errors.Wrapf(errors.MetadataAccessFailed, err, "failed to read offloaded plugin state from [%v]", ref)
+	}
+
+	defer func() {
+		if err := rc.Close(); err != nil {
+			logger.Warnf(r.ctx, "Failed to close offloaded plugin state [%v]. Error: %v", ref, err)
+		}
+	}()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, errors.Wrapf(errors.MetadataAccessFailed, err, "failed to read offloaded plugin state from [%v]", ref)
+	}
+
+	return data, nil
+}
+
+func compress(data []byte) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	zw := gzip.NewWriter(buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func decompress(data []byte) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	defer zr.Close()
+	return io.ReadAll(zr)
+}
+
+type taskExecutionContext struct {
+	core.TaskExecutionContext
+	ctx context.Context
+	cfg *Config
+}
+
+func (c taskExecutionContext) PluginStateReader() core.PluginStateReader {
+	return reader{ctx: c.ctx, tCtx: c.TaskExecutionContext}
+}
+
+func (c taskExecutionContext) PluginStateWriter() core.PluginStateWriter {
+	return writer{ctx: c.ctx, tCtx: c.TaskExecutionContext, cfg: c.cfg}
+}
+
+// NewTaskExecutionContext wraps the plugin state reader and writer of tCtx to compress and offload large state, as
+// configured. State is offloaded under the output prefix of the task.
+func NewTaskExecutionContext(ctx context.Context, tCtx core.TaskExecutionContext) core.TaskExecutionContext {
+	return taskExecutionContext{TaskExecutionContext: tCtx, ctx: ctx, cfg: GetConfig()}
+}
@@ -0,0 +1,260 @@
+package pluginstate
+
+import (
+	"context"
+	"math/rand"
+	"os"
+	"testing"
+
+	"github.com/flyteorg/flytestdlib/contextutils"
+	stdErrors "github.com/flyteorg/flytestdlib/errors"
+	"github.com/flyteorg/flytestdlib/promutils/labeled"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/plugintest"
+)
+
+type state struct {
+	Phase    int
+	Attempts []byte
+}
+
+func TestMain(m *testing.M) {
+	labeled.SetMetricKeys(contextutils.NamespaceKey)
+	os.Exit(m.Run())
+}
+
+// Returns a state whose serialized size is roughly size bytes. Random attempts don't compress, zeroes do.
+func newState(size int, random bool) state {
+	attempts := make([]byte, size)
+	if random {
+		rand.New(rand.NewSource(0)).Read(attempts) // #nosec
+	}
+
+	return state{Phase: 3, Attempts: attempts}
+}
+
+// Writes the state through a context wrapped with cfg and commits the round.
+func put(t *testing.T, tCtx *plugintest.TaskContext, cfg *Config, s state) {
+	wrapped := taskExecutionContext{TaskExecutionContext: tCtx, ctx: context.Background(), cfg: cfg}
+	assert.NoError(t, wrapped.PluginStateWriter().Put(2, s))
+	tCtx.EndRound(false)
+}
+
+func get(tCtx *plugintest.TaskContext) (state, uint8, error) {
+	s := state{}
+	version, err := NewTaskExecutionContext(context.Background(), tCtx).PluginStateReader().Get(&s)
+	return s, version, err
+}
+
+func TestPluginState(t *testing.T) {
+	cfg := &Config{CompressionThreshold: 1024, OffloadThreshold: 4096}
+
+	t.Run("below the thresholds", func(t *testing.T) {
+		tCtx := plugintest.NewTaskContextBuilder(t).Build()
+		expected := newState(100, true)
+		put(t, tCtx, cfg, expected)
+
+		// The state is stored as is.
+		stored := state{}
+		_, err := tCtx.PluginStateReader().Get(&stored)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, stored)
+
+		actual, version, err := get(tCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, uint8(2), version)
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("compressed", func(t *testing.T) {
+		tCtx := plugintest.NewTaskContextBuilder(t).Build()
+		expected := newState(100000, false)
+		put(t, tCtx, cfg, expected)
+
+		env := envelope{}
+		_, err := tCtx.PluginStateReader().Get(&env)
+		assert.NoError(t, err)
+		assert.Equal(t, EncodingGzip, env.PluginStateEncoding)
+		assert.NotEmpty(t, env.PluginStateData)
+		assert.Less(t, len(env.PluginStateData), cfg.OffloadThreshold)
+		assert.Empty(t, env.PluginStateReference)
+		assert.Equal(t, 2|envelopeVersionBit, tCtx.PluginStateReader().GetStateVersion())
+
+		actual, version, err := get(tCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, uint8(2), version)
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("offloaded", func(t *testing.T) {
+		tCtx := plugintest.NewTaskContextBuilder(t).Build()
+		expected := newState(10000, true)
+		put(t, tCtx, cfg, expected)
+
+		env := envelope{}
+		_, err := tCtx.PluginStateReader().Get(&env)
+		assert.NoError(t, err)
+		assert.Equal(t, EncodingGzip, env.PluginStateEncoding)
+		assert.Empty(t, env.PluginStateData)
+		assert.Contains(t, env.PluginStateReference.String(),
+			tCtx.OutputWriter().GetOutputPrefixPath().String()+"/"+offloadedStateDir+"/")
+
+		actual, version, err := get(tCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, uint8(2), version)
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("offloaded without compression", func(t *testing.T) {
+		tCtx := plugintest.NewTaskContextBuilder(t).Build()
+		expected := newState(10000, true)
+		put(t, tCtx, &Config{OffloadThreshold: 4096}, expected)
+
+		env := envelope{}
+		_, err := tCtx.PluginStateReader().Get(&env)
+		assert.NoError(t, err)
+		assert.Equal(t, EncodingGob, env.PluginStateEncoding)
+		assert.NotEmpty(t, env.PluginStateReference)
+
+		actual, _, err := get(tCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("no state", func(t *testing.T) {
+		tCtx := plugintest.NewTaskContextBuilder(t).Build()
+		actual, version, err := get(tCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, uint8(0), version)
+		assert.Equal(t, state{}, actual)
+	})
+
+	t.Run("missing offloaded state", func(t *testing.T) {
+		tCtx := plugintest.NewTaskContextBuilder(t).Build()
+		assert.NoError(t, tCtx.PluginStateWriter().Put(2|envelopeVersionBit, envelope{
+			PluginStateEncoding:  EncodingGzip,
+			PluginStateReference: storage.DataReference("mem://bucket/missing"),
+		}))
+		tCtx.EndRound(false)
+
+		_, _, err := get(tCtx)
+		code, _ := stdErrors.GetErrorCode(err)
+		assert.Equal(t, errors.MetadataAccessFailed, code)
+	})
+
+	t.Run("unknown encoding", func(t *testing.T) {
+		tCtx := plugintest.NewTaskContextBuilder(t).Build()
+		assert.NoError(t, tCtx.PluginStateWriter().Put(2|envelopeVersionBit,
+			envelope{PluginStateEncoding: "zstd", PluginStateData: []byte{1}}))
+		tCtx.EndRound(false)
+
+		_, _, err := get(tCtx)
+		code, _ := stdErrors.GetErrorCode(err)
+		assert.Equal(t, errors.CorruptedPluginState, code)
+	})
+
+	t.Run("below the thresholds after offloaded state", func(t *testing.T) {
+		tCtx := plugintest.NewTaskContextBuilder(t).Build()
+		put(t, tCtx, cfg, newState(10000, true))
+		expected := newState(100, true)
+		put(t, tCtx, cfg, expected)
+
+		// The envelope is kept for one more round, to delete the offloaded state once the new state is persisted.
+		env := envelope{}
+		_, err := tCtx.PluginStateReader().Get(&env)
+		assert.NoError(t, err)
+		assert.Equal(t, EncodingGob, env.PluginStateEncoding)
+		assert.NotEmpty(t, env.PreviousReference)
+
+		actual, version, err := get(tCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, uint8(2), version)
+		assert.Equal(t, expected, actual)
+
+		put(t, tCtx, cfg, expected)
+		assert.Equal(t, uint8(2), tCtx.PluginStateReader().GetStateVersion())
+		_, err = tCtx.DataStore().ReadRaw(context.Background(), env.PreviousReference)
+		assert.Error(t, err)
+	})
+
+	t.Run("superseded offloaded state", func(t *testing.T) {
+		tCtx := plugintest.NewTaskContextBuilder(t).Build()
+		refs := make([]storage.DataReference, 0, 3)
+		for i := 0; i < 3; i++ {
+			s := newState(10000, true)
+			s.Phase = i
+			put(t, tCtx, cfg, s)
+
+			env := envelope{}
+			_, err := tCtx.PluginStateReader().Get(&env)
+			assert.NoError(t, err)
+			refs = append(refs, env.PluginStateReference)
+		}
+
+		// The state of the first round is deleted, the one of the previous round is kept in case this round fails.
+		_, err := tCtx.DataStore().ReadRaw(context.Background(), refs[0])
+		assert.Error(t, err)
+		for _, ref := range refs[1:] {
+			rc, err := tCtx.DataStore().ReadRaw(context.Background(), ref)
+			if assert.NoError(t, err) {
+				assert.NoError(t, rc.Close())
+			}
+		}
+
+		actual, _, err := get(tCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, actual.Phase)
+	})
+
+	t.Run("version above the maximum", func(t *testing.T) {
+		tCtx := plugintest.NewTaskContextBuilder(t).Build()
+		wrapped := NewTaskExecutionContext(context.Background(), tCtx)
+		err := wrapped.PluginStateWriter().Put(envelopeVersionBit, newState(100, true))
+		code, _ := stdErrors.GetErrorCode(err)
+		assert.Equal(t, errors.UnsupportedStateVersion, code)
+	})
+
+	t.Run("read without the envelope", func(t *testing.T) {
+		tCtx := plugintest.NewTaskContextBuilder(t).Build()
+		put(t, tCtx, cfg, newState(100000, false))
+
+		// Binaries unaware of the envelope read the state as written by a newer version of the plugin.
+		_, err := core.NewStateMigrations(2).Get(tCtx.PluginStateReader(), &state{})
+		code, _ := stdErrors.GetErrorCode(err)
+		assert.Equal(t, errors.UnsupportedStateVersion, code)
+
+		actual := state{}
+		reader := NewTaskExecutionContext(context.Background(), tCtx).PluginStateReader()
+		version, err := core.NewStateMigrations(2).Get(reader, &actual)
+		assert.NoError(t, err)
+		assert.Equal(t, uint8(2), version)
+		assert.Equal(t, newState(100000, false), actual)
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		tCtx := plugintest.NewTaskContextBuilder(t).Build()
+		put(t, tCtx, cfg, newState(100000, false))
+		assert.NoError(t, NewTaskExecutionContext(context.Background(), tCtx).PluginStateWriter().Reset())
+		tCtx.EndRound(false)
+
+		actual, _, err := get(tCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, state{}, actual)
+	})
+}
+
+func TestNewTaskExecutionContext(t *testing.T) {
+	tCtx := plugintest.NewTaskContextBuilder(t).Build()
+	wrapped := NewTaskExecutionContext(context.Background(), tCtx)
+
+	// Everything but the plugin state is the one of the wrapped context.
+	assert.Equal(t, tCtx.TaskExecutionMetadata(), wrapped.TaskExecutionMetadata())
+	assert.Equal(t, tCtx.DataStore(), wrapped.DataStore())
+	assert.Equal(t, GetConfig(), wrapped.(taskExecutionContext).cfg)
+	assert.Implements(t, (*core.PluginStateReader)(nil), wrapped.PluginStateReader())
+	assert.IsType(t, writer{}, wrapped.PluginStateWriter())
+}
@@ -0,0 +1,28 @@
+package cluster
+
+import (
+	sparkOp "github.com/GoogleCloudPlatform/spark-on-k8s-operator/pkg/apis/sparkoperator.k8s.io/v1beta2"
+	daskAPI "github.com/bstadlbauer/dask-k8s-operator-go-client/pkg/apis/kubernetes.dask.org/v1"
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+// Scheme returns a new scheme with the client-go types and the custom resources of all the shipped simulators.
+func Scheme() *runtime.Scheme {
+	s := runtime.NewScheme()
+	for _, addToScheme := range []func(*runtime.Scheme) error{
+		clientgoscheme.AddToScheme,
+		sparkOp.AddToScheme,
+		rayv1alpha1.AddToScheme,
+		daskAPI.AddToScheme,
+		kubeflowv1.AddToScheme,
+	} {
+		if err := addToScheme(s); err != nil {
+			panic(err)
+		}
+	}
+
+	return s
+}
@@ -0,0 +1,97 @@
+package cluster
+
+import (
+	"context"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/plugintest"
+)
+
+// Simulation moves the resource created by a k8s plugin through a sequence of stages, one per round, and optionally
+// writes the outputs or the error of the task, as the task's container would.
+type Simulation struct {
+	tCtx        *plugintest.TaskContext
+	stages      []Stage
+	simulator   Simulator
+	outputs     *core.LiteralMap
+	errorOutput *core.ContainerError
+}
+
+// WithSimulator overrides the simulator registered for the kind of the resource.
+func (s *Simulation) WithSimulator(simulator Simulator) *Simulation {
+	s.simulator = simulator
+	return s
+}
+
+// WithOutputs sets the outputs written for the task when the resource succeeds.
+func (s *Simulation) WithOutputs(outputs *core.LiteralMap) *Simulation {
+	s.outputs = outputs
+	return s
+}
+
+// WithErrorOutput sets the error written for the task when the resource fails or is evicted.
+func (s *Simulation) WithErrorOutput(errorOutput *core.ContainerError) *Simulation {
+	s.errorOutput = errorOutput
+	return s
+}
+
+// Hook returns a cluster hook setting the status of the resource to the next stage each time it's invoked. The status
+// is left as is once all the stages have been simulated.
+func (s *Simulation) Hook() plugintest.ClusterHook {
+	next := 0
+	return func(ctx context.Context, c client.Client, resource client.Object) error {
+		if next >= len(s.stages) {
+			return nil
+		}
+
+		stage := s.stages[next]
+		next++
+
+		simulator := s.simulator
+		if simulator == nil {
+			var err error
+			if simulator, err = SimulatorFor(resource); err != nil {
+				return err
+			}
+		}
+
+		observed := resource.DeepCopyObject().(client.Object)
+		if err := c.Get(ctx, client.ObjectKeyFromObject(resource), observed); err != nil {
+			return err
+		}
+
+		if err := simulator.SetStatus(observed, stage); err != nil {
+			return err
+		}
+
+		if err := c.Update(ctx, observed); err != nil {
+			return err
+		}
+
+		switch {
+		case stage == StageSucceeded && s.outputs != nil:
+			return s.tCtx.WriteOutputs(ctx, s.outputs)
+		case (stage == StageFailed || stage == StageEvicted) && s.errorOutput != nil:
+			return s.tCtx.WriteError(ctx, s.errorOutput)
+		}
+
+		return nil
+	}
+}
+
+// Runner returns a runner driving plugin through the simulated stages. The run fails if the task doesn't reach a
+// terminal phase by the last stage.
+func (s *Simulation) Runner(t plugintest.T, plugin k8s.Plugin) *plugintest.K8sPluginRunner {
+	return plugintest.NewK8sPluginRunner(t, plugin, s.tCtx).
+		WithClusterHook(s.Hook()).
+		WithMaxRounds(len(s.stages))
+}
+
+// Simulate creates a simulation of the given stages for the resource created for tCtx. The fake cluster of tCtx must
+// know the kind of the resource, see Scheme.
+func Simulate(tCtx *plugintest.TaskContext, stages ...Stage) *Simulation {
+	return &Simulation{tCtx: tCtx, stages: stages}
+}
@@ -0,0 +1,83 @@
+// Package cluster simulates the controllers and operators of a Kubernetes cluster so that k8s plugins can be tested
+// end to end against the controller-runtime fake client: the resource built by a plugin is created in the fake
+// cluster, and its status is moved through a sequence of stages by a Simulator for its kind.
+package cluster
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Stage is a step of the lifecycle of a resource, independent of its kind.
+type Stage int
+
+const (
+	// StagePending is a resource accepted by the cluster, waiting to be scheduled.
+	StagePending Stage = iota
+	// StageRunning is a resource whose workload is running.
+	StageRunning
+	// StageSucceeded is a resource whose workload completed successfully.
+	StageSucceeded
+	// StageFailed is a resource whose workload failed.
+	StageFailed
+	// StageEvicted is a resource whose workload was killed by the cluster, e.g. because its node ran out of memory.
+	StageEvicted
+)
+
+func (s Stage) String() string {
+	switch s {
+	case StagePending:
+		return "Pending"
+	case StageRunning:
+		return "Running"
+	case StageSucceeded:
+		return "Succeeded"
+	case StageFailed:
+		return "Failed"
+	case StageEvicted:
+		return "Evicted"
+	}
+
+	return fmt.Sprintf("Stage(%d)", int(s))
+}
+
+// Simulator sets the status a controller would report for a resource at a given stage.
+type Simulator interface {
+	SetStatus(resource client.Object, stage Stage) error
+}
+
+// SimulatorFunc adapts a function to a Simulator.
+type SimulatorFunc func(resource client.Object, stage Stage) error
+
+// SetStatus calls f.
+func (f SimulatorFunc) SetStatus(resource client.Object, stage Stage) error {
+	return f(resource, stage)
+}
+
+var (
+	simulatorsLock sync.RWMutex
+	simulators     = map[reflect.Type]Simulator{}
+)
+
+// RegisterSimulator sets the simulator used for resources of the same type as resource, replacing the existing one if
+// any.
+func RegisterSimulator(resource client.Object, simulator Simulator) {
+	simulatorsLock.Lock()
+	defer simulatorsLock.Unlock()
+	simulators[reflect.TypeOf(resource)] = simulator
+}
+
+// SimulatorFor returns the simulator registered for the type of resource.
+func SimulatorFor(resource client.Object) (Simulator, error) {
+	simulatorsLock.RLock()
+	defer simulatorsLock.RUnlock()
+	simulator, found := simulators[reflect.TypeOf(resource)]
+	if !found {
+		return nil, fmt.Errorf("no simulator registered for resources of type [%T]", resource)
+	}
+
+	return simulator, nil
+}
@@ -0,0 +1,226 @@
+package cluster
+
+import (
+	"fmt"
+
+	sparkOp "github.com/GoogleCloudPlatform/spark-on-k8s-operator/pkg/apis/sparkoperator.k8s.io/v1beta2"
+	daskAPI "github.com/bstadlbauer/dask-k8s-operator-go-client/pkg/apis/kubernetes.dask.org/v1"
+	commonOp "github.com/kubeflow/common/pkg/apis/common/v1"
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	evictedReason  = "Evicted"
+	evictedMessage = "The node was low on resource: memory."
+	failedMessage  = "Job failed with exit code 1."
+	sigkillCode    = 137
+)
+
+// PodSimulator simulates the kubelet. Running pods have all their containers running, succeeded pods have all their
+// containers terminated with exit code 0 and failed pods have them terminated with exit code 1. Evicted pods fail with
+// the Evicted reason and their containers are killed.
+var PodSimulator = SimulatorFunc(func(resource client.Object, stage Stage) error {
+	pod, ok := resource.(*v1.Pod)
+	if !ok {
+		return fmt.Errorf("expected a pod, got [%T]", resource)
+	}
+
+	now := metav1.Now()
+	containerState := func(exitCode int32, reason string) v1.ContainerState {
+		return v1.ContainerState{Terminated: &v1.ContainerStateTerminated{
+			ExitCode: exitCode, Reason: reason, StartedAt: now, FinishedAt: now}}
+	}
+
+	pod.Status = v1.PodStatus{Phase: v1.PodPending}
+	var state v1.ContainerState
+	switch stage {
+	case StagePending:
+		return nil
+	case StageRunning:
+		pod.Status.Phase = v1.PodRunning
+		state = v1.ContainerState{Running: &v1.ContainerStateRunning{StartedAt: now}}
+	case StageSucceeded:
+		pod.Status.Phase = v1.PodSucceeded
+		state = containerState(0, "Completed")
+	case StageFailed:
+		pod.Status.Phase = v1.PodFailed
+		state = containerState(1, "Error")
+	case StageEvicted:
+		pod.Status.Phase = v1.PodFailed
+		pod.Status.Reason = evictedReason
+		pod.Status.Message = evictedMessage
+		state = containerState(sigkillCode, "Error")
+	default:
+		return fmt.Errorf("unsupported stage [%v]", stage)
+	}
+
+	pod.Status.StartTime = &now
+	started := state.Running != nil
+	for _, c := range pod.Spec.Containers {
+		pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, v1.ContainerStatus{
+			Name:    c.Name,
+			Image:   c.Image,
+			Ready:   started,
+			State:   state,
+			Started: &started,
+		})
+	}
+
+	return nil
+})
+
+// SparkApplicationSimulator simulates the Spark operator. The driver pod is named after the application.
+var SparkApplicationSimulator = SimulatorFunc(func(resource client.Object, stage Stage) error {
+	app, ok := resource.(*sparkOp.SparkApplication)
+	if !ok {
+		return fmt.Errorf("expected a SparkApplication, got [%T]", resource)
+	}
+
+	app.Status.SparkApplicationID = "spark-" + string(app.GetUID())
+	app.Status.AppState.ErrorMessage = ""
+	switch stage {
+	case StagePending:
+		app.Status.AppState.State = sparkOp.SubmittedState
+		return nil
+	case StageRunning:
+		app.Status.AppState.State = sparkOp.RunningState
+	case StageSucceeded:
+		app.Status.AppState.State = sparkOp.CompletedState
+	case StageFailed:
+		app.Status.AppState.State = sparkOp.FailedState
+		app.Status.AppState.ErrorMessage = "driver container failed with ExitCode: 1, Reason: Error"
+	case StageEvicted:
+		app.Status.AppState.State = sparkOp.FailedState
+		app.Status.AppState.ErrorMessage = "driver pod was evicted: " + evictedMessage
+	default:
+		return fmt.Errorf("unsupported stage [%v]", stage)
+	}
+
+	app.Status.DriverInfo.PodName = app.GetName() + "-driver"
+	return nil
+})
+
+// RayJobSimulator simulates the KubeRay operator.
+var RayJobSimulator = SimulatorFunc(func(resource client.Object, stage Stage) error {
+	job, ok := resource.(*rayv1alpha1.RayJob)
+	if !ok {
+		return fmt.Errorf("expected a RayJob, got [%T]", resource)
+	}
+
+	job.Status.RayClusterName = job.GetName() + "-raycluster"
+	job.Status.Message = ""
+	switch stage {
+	case StagePending:
+		job.Status.JobStatus = rayv1alpha1.JobStatusPending
+		job.Status.JobDeploymentStatus = rayv1alpha1.JobDeploymentStatusInitializing
+	case StageRunning:
+		job.Status.JobStatus = rayv1alpha1.JobStatusRunning
+		job.Status.JobDeploymentStatus = rayv1alpha1.JobDeploymentStatusRunning
+	case StageSucceeded:
+		job.Status.JobStatus = rayv1alpha1.JobStatusSucceeded
+		job.Status.JobDeploymentStatus = rayv1alpha1.JobDeploymentStatusComplete
+	case StageFailed:
+		job.Status.JobStatus = rayv1alpha1.JobStatusFailed
+		job.Status.JobDeploymentStatus = rayv1alpha1.JobDeploymentStatusComplete
+		job.Status.Message = failedMessage
+	case StageEvicted:
+		job.Status.JobStatus = rayv1alpha1.JobStatusFailed
+		job.Status.JobDeploymentStatus = rayv1alpha1.JobDeploymentStatusFailedToGetJobStatus
+		job.Status.Message = evictedMessage
+	default:
+		return fmt.Errorf("unsupported stage [%v]", stage)
+	}
+
+	return nil
+})
+
+// DaskJobSimulator simulates the Dask operator. The job runner pod is named after the job.
+var DaskJobSimulator = SimulatorFunc(func(resource client.Object, stage Stage) error {
+	job, ok := resource.(*daskAPI.DaskJob)
+	if !ok {
+		return fmt.Errorf("expected a DaskJob, got [%T]", resource)
+	}
+
+	job.Status.ClusterName = job.GetName()
+	switch stage {
+	case StagePending:
+		job.Status.JobStatus = daskAPI.DaskJobCreated
+		return nil
+	case StageRunning:
+		job.Status.JobStatus = daskAPI.DaskJobRunning
+		job.Status.StartTime = metav1.Now()
+	case StageSucceeded:
+		job.Status.JobStatus = daskAPI.DaskJobSuccessful
+		job.Status.EndTime = metav1.Now()
+	case StageFailed, StageEvicted:
+		job.Status.JobStatus = daskAPI.DaskJobFailed
+		job.Status.EndTime = metav1.Now()
+	default:
+		return fmt.Errorf("unsupported stage [%v]", stage)
+	}
+
+	job.Status.JobRunnerPodName = job.GetName() + "-runner"
+	return nil
+})
+
+// KubeflowJobSimulator simulates the Kubeflow training operator for PyTorchJobs, TFJobs and MPIJobs. Each stage adds a
+// condition to the job and only the last one is true.
+var KubeflowJobSimulator = SimulatorFunc(func(resource client.Object, stage Stage) error {
+	var status *commonOp.JobStatus
+	switch job := resource.(type) {
+	case *kubeflowv1.PyTorchJob:
+		status = &job.Status
+	case *kubeflowv1.TFJob:
+		status = &job.Status
+	case *kubeflowv1.MPIJob:
+		status = &job.Status
+	default:
+		return fmt.Errorf("expected a kubeflow job, got [%T]", resource)
+	}
+
+	condition := commonOp.JobCondition{
+		Status:             v1.ConditionTrue,
+		LastUpdateTime:     metav1.Now(),
+		LastTransitionTime: metav1.Now(),
+	}
+
+	switch stage {
+	case StagePending:
+		condition.Type, condition.Reason = commonOp.JobCreated, "JobCreated"
+	case StageRunning:
+		condition.Type, condition.Reason = commonOp.JobRunning, "JobRunning"
+	case StageSucceeded:
+		condition.Type, condition.Reason = commonOp.JobSucceeded, "JobSucceeded"
+	case StageFailed:
+		condition.Type, condition.Reason, condition.Message = commonOp.JobFailed, "JobFailed", failedMessage
+	case StageEvicted:
+		condition.Type, condition.Reason, condition.Message = commonOp.JobFailed, evictedReason, evictedMessage
+	default:
+		return fmt.Errorf("unsupported stage [%v]", stage)
+	}
+
+	for i := range status.Conditions {
+		status.Conditions[i].Status = v1.ConditionFalse
+	}
+
+	status.Conditions = append(status.Conditions, condition)
+	if status.StartTime == nil {
+		status.StartTime = &condition.LastTransitionTime
+	}
+
+	return nil
+})
+
+func init() {
+	RegisterSimulator(&v1.Pod{}, PodSimulator)
+	RegisterSimulator(&sparkOp.SparkApplication{}, SparkApplicationSimulator)
+	RegisterSimulator(&rayv1alpha1.RayJob{}, RayJobSimulator)
+	RegisterSimulator(&daskAPI.DaskJob{}, DaskJobSimulator)
+	RegisterSimulator(&kubeflowv1.PyTorchJob{}, KubeflowJobSimulator)
+	RegisterSimulator(&kubeflowv1.TFJob{}, KubeflowJobSimulator)
+	RegisterSimulator(&kubeflowv1.MPIJob{}, KubeflowJobSimulator)
+}
@@ -0,0 +1,115 @@
+package cluster
+
+import (
+	"testing"
+
+	sparkOp "github.com/GoogleCloudPlatform/spark-on-k8s-operator/pkg/apis/sparkoperator.k8s.io/v1beta2"
+	daskAPI "github.com/bstadlbauer/dask-k8s-operator-go-client/pkg/apis/kubernetes.dask.org/v1"
+	commonOp "github.com/kubeflow/common/pkg/apis/common/v1"
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func setStatus(t *testing.T, resource client.Object, stages ...Stage) {
+	simulator, err := SimulatorFor(resource)
+	assert.NoError(t, err)
+	for _, stage := range stages {
+		assert.NoError(t, simulator.SetStatus(resource, stage))
+	}
+}
+
+func TestPodSimulator(t *testing.T) {
+	newPod := func() *v1.Pod {
+		return &v1.Pod{Spec: v1.PodSpec{Containers: []v1.Container{{Name: "a"}, {Name: "b"}}}}
+	}
+
+	pod := newPod()
+	setStatus(t, pod, StagePending)
+	assert.Equal(t, v1.PodPending, pod.Status.Phase)
+	assert.Empty(t, pod.Status.ContainerStatuses)
+
+	setStatus(t, pod, StageRunning)
+	assert.Equal(t, v1.PodRunning, pod.Status.Phase)
+	assert.Len(t, pod.Status.ContainerStatuses, 2)
+	assert.NotNil(t, pod.Status.ContainerStatuses[1].State.Running)
+
+	setStatus(t, pod, StageSucceeded)
+	assert.Equal(t, v1.PodSucceeded, pod.Status.Phase)
+	assert.Len(t, pod.Status.ContainerStatuses, 2)
+	assert.Equal(t, int32(0), pod.Status.ContainerStatuses[0].State.Terminated.ExitCode)
+
+	pod = newPod()
+	setStatus(t, pod, StageFailed)
+	assert.Equal(t, v1.PodFailed, pod.Status.Phase)
+	assert.Equal(t, int32(1), pod.Status.ContainerStatuses[0].State.Terminated.ExitCode)
+
+	pod = newPod()
+	setStatus(t, pod, StageEvicted)
+	assert.Equal(t, v1.PodFailed, pod.Status.Phase)
+	assert.Equal(t, "Evicted", pod.Status.Reason)
+
+	assert.EqualError(t, PodSimulator.SetStatus(&v1.ConfigMap{}, StageRunning), "expected a pod, got [*v1.ConfigMap]")
+	assert.EqualError(t, PodSimulator.SetStatus(newPod(), Stage(42)), "unsupported stage [Stage(42)]")
+}
+
+func TestCustomResourceSimulators(t *testing.T) {
+	app := &sparkOp.SparkApplication{ObjectMeta: metav1.ObjectMeta{Name: "app"}}
+	setStatus(t, app, StagePending, StageRunning)
+	assert.Equal(t, sparkOp.RunningState, app.Status.AppState.State)
+	assert.Equal(t, "app-driver", app.Status.DriverInfo.PodName)
+	setStatus(t, app, StageEvicted)
+	assert.Equal(t, sparkOp.FailedState, app.Status.AppState.State)
+	assert.NotEmpty(t, app.Status.AppState.ErrorMessage)
+
+	rayJob := &rayv1alpha1.RayJob{}
+	setStatus(t, rayJob, StagePending)
+	assert.Equal(t, rayv1alpha1.JobStatusPending, rayJob.Status.JobStatus)
+	setStatus(t, rayJob, StageSucceeded)
+	assert.Equal(t, rayv1alpha1.JobStatusSucceeded, rayJob.Status.JobStatus)
+
+	daskJob := &daskAPI.DaskJob{ObjectMeta: metav1.ObjectMeta{Name: "job"}}
+	setStatus(t, daskJob, StagePending)
+	assert.Equal(t, daskAPI.DaskJobCreated, daskJob.Status.JobStatus)
+	assert.Empty(t, daskJob.Status.JobRunnerPodName)
+	setStatus(t, daskJob, StageFailed)
+	assert.Equal(t, daskAPI.DaskJobFailed, daskJob.Status.JobStatus)
+	assert.Equal(t, "job-runner", daskJob.Status.JobRunnerPodName)
+
+	for _, job := range []client.Object{&kubeflowv1.PyTorchJob{}, &kubeflowv1.TFJob{}, &kubeflowv1.MPIJob{}} {
+		setStatus(t, job, StagePending, StageRunning, StageFailed)
+		var conditions []commonOp.JobCondition
+		switch j := job.(type) {
+		case *kubeflowv1.PyTorchJob:
+			conditions = j.Status.Conditions
+		case *kubeflowv1.TFJob:
+			conditions = j.Status.Conditions
+		case *kubeflowv1.MPIJob:
+			conditions = j.Status.Conditions
+		}
+
+		if assert.Len(t, conditions, 3) {
+			assert.Equal(t, v1.ConditionFalse, conditions[0].Status)
+			assert.Equal(t, v1.ConditionFalse, conditions[1].Status)
+			assert.Equal(t, commonOp.JobFailed, conditions[2].Type)
+			assert.Equal(t, v1.ConditionTrue, conditions[2].Status)
+		}
+	}
+}
+
+func TestSimulatorFor(t *testing.T) {
+	_, err := SimulatorFor(&v1.ConfigMap{})
+	assert.EqualError(t, err, "no simulator registered for resources of type [*v1.ConfigMap]")
+
+	called := false
+	RegisterSimulator(&v1.ConfigMap{}, SimulatorFunc(func(client.Object, Stage) error {
+		called = true
+		return nil
+	}))
+
+	setStatus(t, &v1.ConfigMap{}, StageRunning)
+	assert.True(t, called)
+}
@@ -0,0 +1,155 @@
+package plugintest
+
+import (
+	"context"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+
+	pluginCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io"
+)
+
+// FailurePoint identifies a call made by plugins to the task execution context that can be made to fail.
+type FailurePoint string
+
+const (
+	// FailInputRead fails reading the task inputs.
+	FailInputRead FailurePoint = "input-read"
+	// FailOutputWrite fails writing the task outputs.
+	FailOutputWrite FailurePoint = "output-write"
+	// FailSecretGet fails reading secrets.
+	FailSecretGet FailurePoint = "secret-get"
+	// FailResourceAllocation fails allocating resources from the resource manager.
+	FailResourceAllocation FailurePoint = "resource-allocation"
+	// FailPluginStateWrite fails writing the plugin state.
+	FailPluginStateWrite FailurePoint = "plugin-state-write"
+	// FailK8sCreate fails creating objects in the fake cluster.
+	FailK8sCreate FailurePoint = "k8s-create"
+	// FailK8sGet fails getting objects from the fake cluster.
+	FailK8sGet FailurePoint = "k8s-get"
+	// FailK8sDelete fails deleting objects from the fake cluster.
+	FailK8sDelete FailurePoint = "k8s-delete"
+)
+
+type injectedFailure struct {
+	err error
+	// remaining is the number of calls left to fail, calls fail indefinitely if negative.
+	remaining int
+}
+
+// failureInjector returns the errors injected for each failure point.
+type failureInjector struct {
+	m        sync.Mutex
+	failures map[FailurePoint]*injectedFailure
+}
+
+func (f *failureInjector) inject(point FailurePoint, err error, times int) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	if f.failures == nil {
+		f.failures = map[FailurePoint]*injectedFailure{}
+	}
+
+	f.failures[point] = &injectedFailure{err: err, remaining: times}
+}
+
+// check returns the error injected for point, if any calls are left to fail.
+func (f *failureInjector) check(point FailurePoint) error {
+	f.m.Lock()
+	defer f.m.Unlock()
+	failure, found := f.failures[point]
+	if !found || failure.remaining == 0 {
+		return nil
+	}
+
+	if failure.remaining > 0 {
+		failure.remaining--
+	}
+
+	return failure.err
+}
+
+type failingInputReader struct {
+	io.InputReader
+	failures *failureInjector
+}
+
+func (r failingInputReader) Get(ctx context.Context) (*core.LiteralMap, error) {
+	if err := r.failures.check(FailInputRead); err != nil {
+		return nil, err
+	}
+
+	return r.InputReader.Get(ctx)
+}
+
+type failingOutputWriter struct {
+	io.OutputWriter
+	failures *failureInjector
+}
+
+func (w failingOutputWriter) Put(ctx context.Context, reader io.OutputReader) error {
+	if err := w.failures.check(FailOutputWrite); err != nil {
+		return err
+	}
+
+	return w.OutputWriter.Put(ctx, reader)
+}
+
+type failingSecretManager struct {
+	pluginCore.SecretManager
+	failures *failureInjector
+}
+
+func (s failingSecretManager) Get(ctx context.Context, key string) (string, error) {
+	if err := s.failures.check(FailSecretGet); err != nil {
+		return "", err
+	}
+
+	return s.SecretManager.Get(ctx, key)
+}
+
+type failingResourceManager struct {
+	pluginCore.ResourceManager
+	failures *failureInjector
+}
+
+func (r failingResourceManager) AllocateResource(ctx context.Context, namespace pluginCore.ResourceNamespace,
+	allocationToken string, constraintsSpec pluginCore.ResourceConstraintsSpec) (pluginCore.AllocationStatus, error) {
+	if err := r.failures.check(FailResourceAllocation); err != nil {
+		return pluginCore.AllocationUndefined, err
+	}
+
+	return r.ResourceManager.AllocateResource(ctx, namespace, allocationToken, constraintsSpec)
+}
+
+type failingClient struct {
+	client.Client
+	failures *failureInjector
+}
+
+func (c failingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if err := c.failures.check(FailK8sCreate); err != nil {
+		return err
+	}
+
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c failingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	if err := c.failures.check(FailK8sGet); err != nil {
+		return err
+	}
+
+	return c.Client.Get(ctx, key, obj)
+}
+
+func (c failingClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if err := c.failures.check(FailK8sDelete); err != nil {
+		return err
+	}
+
+	return c.Client.Delete(ctx, obj, opts...)
+}
@@ -0,0 +1,293 @@
+package plugintest
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/datacatalog"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/catalog"
+	pluginCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/ioutils"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/resourcemanager"
+)
+
+// ownerResourceManager allocates the tokens of a task for its owner in the resource manager of the harness, so that
+// project and namespace constraints apply. Like the default resource manager of FlytePropeller, it grants allocations
+// in the namespaces without a quota nor a rate: they're registered an unbounded quota on first use.
+type ownerResourceManager struct {
+	pluginCore.ResourceManager
+	memoryResourceManager *resourcemanager.Manager
+}
+
+func (r ownerResourceManager) AllocateResource(ctx context.Context, namespace pluginCore.ResourceNamespace,
+	allocationToken string, constraintsSpec pluginCore.ResourceConstraintsSpec) (pluginCore.AllocationStatus, error) {
+	if !r.memoryResourceManager.Registered(namespace) {
+		if err := r.memoryResourceManager.RegisterResourceQuota(ctx, namespace, math.MaxInt32); err != nil {
+			return pluginCore.AllocationUndefined, err
+		}
+	}
+
+	return r.ResourceManager.AllocateResource(ctx, namespace, allocationToken, constraintsSpec)
+}
+
+func (r ownerResourceManager) ReleaseResource(ctx context.Context, namespace pluginCore.ResourceNamespace,
+	allocationToken string) error {
+	if !r.memoryResourceManager.Registered(namespace) {
+		return nil
+	}
+
+	return r.ResourceManager.ReleaseResource(ctx, namespace, allocationToken)
+}
+
+type secretManager map[string]string
+
+func (s secretManager) Get(_ context.Context, key string) (string, error) {
+	value, found := s[key]
+	if !found {
+		return "", fmt.Errorf("secret [%v] not found", key)
+	}
+
+	return value, nil
+}
+
+type eventsRecorder struct {
+	m      sync.Mutex
+	events []pluginCore.PhaseInfo
+}
+
+func (r *eventsRecorder) RecordRaw(_ context.Context, ev pluginCore.PhaseInfo) error {
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.events = append(r.events, ev)
+	return nil
+}
+
+func (r *eventsRecorder) recorded() []pluginCore.PhaseInfo {
+	r.m.Lock()
+	defer r.m.Unlock()
+	return append([]pluginCore.PhaseInfo{}, r.events...)
+}
+
+// pluginState stores plugin state gob encoded, as FlytePropeller does. State written during a round only becomes
+// visible once the round is committed.
+type pluginState struct {
+	m              sync.Mutex
+	failures       *failureInjector
+	version        uint8
+	state          []byte
+	pendingVersion uint8
+	pending        []byte
+	written        bool
+}
+
+func (s *pluginState) Put(stateVersion uint8, v interface{}) error {
+	if err := s.failures.check(FailPluginStateWrite); err != nil {
+		return err
+	}
+
+	buf := &bytes.Buffer{}
+	if err := gob.NewEncoder(buf).Encode(v); err != nil {
+		return fmt.Errorf("failed to encode plugin state: %w", err)
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.pendingVersion, s.pending, s.written = stateVersion, buf.Bytes(), true
+	return nil
+}
+
+func (s *pluginState) Reset() error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.pendingVersion, s.pending, s.written = 0, nil, true
+	return nil
+}
+
+func (s *pluginState) GetStateVersion() uint8 {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.version
+}
+
+func (s *pluginState) Get(t interface{}) (stateVersion uint8, err error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if len(s.state) == 0 {
+		return 0, nil
+	}
+
+	if err = gob.NewDecoder(bytes.NewReader(s.state)).Decode(t); err != nil {
+		return 0, fmt.Errorf("failed to decode plugin state: %w", err)
+	}
+
+	return s.version, nil
+}
+
+// commit makes the state written during the round visible. Discarding it instead mimics a failed round.
+func (s *pluginState) commit(discard bool) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.written && !discard {
+		s.version, s.state = s.pendingVersion, s.pending
+	}
+
+	s.pendingVersion, s.pending, s.written = 0, nil, false
+}
+
+type taskReader struct {
+	template *core.TaskTemplate
+	path     storage.DataReference
+}
+
+func (r taskReader) Path(context.Context) (storage.DataReference, error) {
+	return r.path, nil
+}
+
+func (r taskReader) Read(context.Context) (*core.TaskTemplate, error) {
+	return r.template, nil
+}
+
+// catalogClient is an in-memory catalog.Client. Artifacts are keyed by task identifier, cache version and inputs.
+type catalogClient struct {
+	m         sync.Mutex
+	artifacts map[string]*core.LiteralMap
+}
+
+func (c *catalogClient) key(ctx context.Context, key catalog.Key) (string, error) {
+	inputs := proto.NewBuffer(nil)
+	if key.InputReader != nil {
+		literals, err := key.InputReader.Get(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		inputs.SetDeterministic(true)
+		if err = inputs.Marshal(literals); err != nil {
+			return "", err
+		}
+	}
+
+	return fmt.Sprintf("%v:%v:%x", key.Identifier.String(), key.CacheVersion, inputs.Bytes()), nil
+}
+
+func (c *catalogClient) Get(ctx context.Context, key catalog.Key) (catalog.Entry, error) {
+	k, err := c.key(ctx, key)
+	if err != nil {
+		return catalog.Entry{}, err
+	}
+
+	c.m.Lock()
+	defer c.m.Unlock()
+	literals, found := c.artifacts[k]
+	if !found {
+		return catalog.Entry{}, status.Error(codes.NotFound, "no artifact found for key")
+	}
+
+	return catalog.NewCatalogEntry(ioutils.NewInMemoryOutputReader(literals, nil, nil),
+		catalog.NewStatus(core.CatalogCacheStatus_CACHE_HIT, nil)), nil
+}
+
+func (c *catalogClient) GetOrExtendReservation(_ context.Context, _ catalog.Key, ownerID string,
+	heartbeatInterval time.Duration) (*datacatalog.Reservation, error) {
+	return &datacatalog.Reservation{OwnerId: ownerID}, nil
+}
+
+func (c *catalogClient) Put(ctx context.Context, key catalog.Key, reader io.OutputReader, _ catalog.Metadata) (
+	catalog.Status, error) {
+	k, err := c.key(ctx, key)
+	if err != nil {
+		return catalog.Status{}, err
+	}
+
+	literals, executionErr, err := reader.Read(ctx)
+	if err != nil {
+		return catalog.Status{}, err
+	} else if executionErr != nil {
+		return catalog.Status{}, fmt.Errorf("failed outputs can't be cached: %v", executionErr)
+	}
+
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.artifacts[k] = literals
+	return catalog.NewStatus(core.CatalogCacheStatus_CACHE_POPULATED, nil), nil
+}
+
+func (c *catalogClient) Update(ctx context.Context, key catalog.Key, reader io.OutputReader,
+	metadata catalog.Metadata) (catalog.Status, error) {
+	return c.Put(ctx, key, reader, metadata)
+}
+
+func (c *catalogClient) ReleaseReservation(context.Context, catalog.Key, string) error {
+	return nil
+}
+
+type kubeClient struct {
+	client client.Client
+}
+
+func (k kubeClient) GetClient() client.Client {
+	return k.client
+}
+
+func (k kubeClient) GetCache() cache.Cache {
+	return nil
+}
+
+type setupContext struct {
+	kubeClient            pluginCore.KubeClient
+	secretManager         pluginCore.SecretManager
+	memoryResourceManager *resourcemanager.Manager
+	scope                 promutils.Scope
+}
+
+func (s setupContext) EnqueueOwner() pluginCore.EnqueueOwner {
+	return func(types.NamespacedName) error { return nil }
+}
+
+func (s setupContext) OwnerKind() string {
+	return "FlyteWorkflow"
+}
+
+func (s setupContext) MetricsScope() promutils.Scope {
+	return s.scope
+}
+
+func (s setupContext) KubeClient() pluginCore.KubeClient {
+	return s.kubeClient
+}
+
+func (s setupContext) SecretManager() pluginCore.SecretManager {
+	return s.secretManager
+}
+
+// ResourceRegistrar returns the resource manager of the harness, which is also a ResourceRateRegistrar, or a registrar
+// ignoring registrations if the task context's resource manager was replaced.
+func (s setupContext) ResourceRegistrar() pluginCore.ResourceRegistrar {
+	if s.memoryResourceManager != nil {
+		return s.memoryResourceManager
+	}
+
+	return noopResourceRegistrar{}
+}
+
+type noopResourceRegistrar struct{}
+
+func (noopResourceRegistrar) RegisterResourceQuota(context.Context, pluginCore.ResourceNamespace, int) error {
+	return nil
+}
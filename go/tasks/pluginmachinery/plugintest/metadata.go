@@ -0,0 +1,126 @@
+package plugintest
+
+import (
+	"strings"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	pluginCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/encoding"
+)
+
+type taskExecutionID struct {
+	id            core.TaskExecutionIdentifier
+	generatedName string
+}
+
+func (t taskExecutionID) GetGeneratedName() string {
+	return t.generatedName
+}
+
+// GetGeneratedNameWith pads or hashes the generated name the way FlytePropeller does.
+func (t taskExecutionID) GetGeneratedNameWith(minLength, maxLength int) (string, error) {
+	length := len(t.generatedName)
+	if length > maxLength {
+		return encoding.FixedLengthUniqueID(t.generatedName, maxLength)
+	}
+
+	if length < minLength {
+		return t.generatedName + strings.Repeat("0", minLength-length), nil
+	}
+
+	return t.generatedName, nil
+}
+
+func (t taskExecutionID) GetID() core.TaskExecutionIdentifier {
+	return t.id
+}
+
+type taskOverrides struct {
+	resources *v1.ResourceRequirements
+	config    *v1.ConfigMap
+}
+
+func (o taskOverrides) GetResources() *v1.ResourceRequirements {
+	return o.resources
+}
+
+func (o taskOverrides) GetConfig() *v1.ConfigMap {
+	return o.config
+}
+
+type taskExecutionMetadata struct {
+	taskExecID                    taskExecutionID
+	namespace                     string
+	overrides                     taskOverrides
+	labels                        map[string]string
+	annotations                   map[string]string
+	maxAttempts                   uint32
+	serviceAccount                string
+	securityContext               core.SecurityContext
+	interruptible                 bool
+	platformResources             *v1.ResourceRequirements
+	interruptibleFailureThreshold uint32
+}
+
+func (m taskExecutionMetadata) GetOwnerID() types.NamespacedName {
+	return types.NamespacedName{
+		Namespace: m.namespace,
+		Name:      m.taskExecID.id.GetNodeExecutionId().GetExecutionId().GetName(),
+	}
+}
+
+func (m taskExecutionMetadata) GetTaskExecutionID() pluginCore.TaskExecutionID {
+	return m.taskExecID
+}
+
+func (m taskExecutionMetadata) GetNamespace() string {
+	return m.namespace
+}
+
+func (m taskExecutionMetadata) GetOwnerReference() metav1.OwnerReference {
+	return metav1.OwnerReference{
+		APIVersion: "flyte.lyft.com/v1alpha1",
+		Kind:       "FlyteWorkflow",
+		Name:       m.GetOwnerID().Name,
+	}
+}
+
+func (m taskExecutionMetadata) GetOverrides() pluginCore.TaskOverrides {
+	return m.overrides
+}
+
+func (m taskExecutionMetadata) GetLabels() map[string]string {
+	return m.labels
+}
+
+func (m taskExecutionMetadata) GetMaxAttempts() uint32 {
+	return m.maxAttempts
+}
+
+func (m taskExecutionMetadata) GetAnnotations() map[string]string {
+	return m.annotations
+}
+
+func (m taskExecutionMetadata) GetK8sServiceAccount() string {
+	return m.serviceAccount
+}
+
+func (m taskExecutionMetadata) GetSecurityContext() core.SecurityContext {
+	return m.securityContext
+}
+
+func (m taskExecutionMetadata) IsInterruptible() bool {
+	return m.interruptible
+}
+
+func (m taskExecutionMetadata) GetPlatformResources() *v1.ResourceRequirements {
+	return m.platformResources
+}
+
+func (m taskExecutionMetadata) GetInterruptibleFailureThreshold() uint32 {
+	return m.interruptibleFailureThreshold
+}
@@ -0,0 +1,101 @@
+package plugintest
+
+import (
+	"context"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pluginCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+)
+
+// Result records a run of a plugin.
+type Result struct {
+	t    T
+	tCtx *TaskContext
+
+	// Phases lists the phase reported by each round, in order.
+	Phases []pluginCore.PhaseInfo
+	// Err is the error that stopped the run, if any.
+	Err error
+	// Aborted is set if the task was aborted before reaching a terminal phase.
+	Aborted bool
+	// AbortErr is the error returned when aborting the task.
+	AbortErr error
+	// FinalizeErr is the error returned when finalizing the task.
+	FinalizeErr error
+	// Resource is the last observed state of the resource created by a k8s plugin.
+	Resource client.Object
+}
+
+// Phase returns the last phase reported by the plugin.
+func (r *Result) Phase() pluginCore.PhaseInfo {
+	if len(r.Phases) == 0 {
+		return pluginCore.PhaseInfoUndefined
+	}
+
+	return r.Phases[len(r.Phases)-1]
+}
+
+// AssertPhases asserts the plugin went through the given phases, in order. Repeated phases are only listed once, e.g.
+// Queued, Running, Running, Success is asserted with Queued, Running, Success.
+func (r *Result) AssertPhases(expected ...pluginCore.Phase) bool {
+	r.t.Helper()
+	actual := make([]pluginCore.Phase, 0, len(r.Phases))
+	for _, p := range r.Phases {
+		if len(actual) == 0 || actual[len(actual)-1] != p.Phase() {
+			actual = append(actual, p.Phase())
+		}
+	}
+
+	return assert.Equal(r.t, expected, actual)
+}
+
+// AssertSuccess asserts the run completed without errors in a successful phase.
+func (r *Result) AssertSuccess() bool {
+	r.t.Helper()
+	return assert.NoError(r.t, r.Err) && assert.True(r.t, r.Phase().Phase().IsSuccess(),
+		"expected a successful phase, got [%v]", r.Phase().Phase())
+}
+
+// AssertFailure asserts the run completed without errors in a failed phase with the given error code.
+func (r *Result) AssertFailure(code string) bool {
+	r.t.Helper()
+	return assert.NoError(r.t, r.Err) &&
+		assert.True(r.t, r.Phase().Phase().IsFailure(), "expected a failed phase, got [%v]", r.Phase().Phase()) &&
+		assert.Equal(r.t, code, r.Phase().Err().GetCode())
+}
+
+// AssertLogs asserts the last phase reported by the plugin links to logs with the given names, in order.
+func (r *Result) AssertLogs(names ...string) bool {
+	r.t.Helper()
+	var actual []string
+	if info := r.Phase().Info(); info != nil {
+		for _, l := range info.Logs {
+			actual = append(actual, l.GetName())
+		}
+	}
+
+	return assert.Equal(r.t, names, actual)
+}
+
+// AssertOutputs asserts the outputs written for the task are equal to expected.
+func (r *Result) AssertOutputs(ctx context.Context, expected *core.LiteralMap) bool {
+	r.t.Helper()
+	outputs, executionErr, err := r.tCtx.OutputReader().Read(ctx)
+	if !assert.NoError(r.t, err) || !assert.Nil(r.t, executionErr) {
+		return false
+	}
+
+	return assert.True(r.t, proto.Equal(expected, outputs), "expected outputs %v, got %v", expected, outputs)
+}
+
+// AssertErrorOutput asserts the error written for the task has the given code and message.
+func (r *Result) AssertErrorOutput(ctx context.Context, code, message string) bool {
+	r.t.Helper()
+	executionErr, err := r.tCtx.OutputReader().ReadError(ctx)
+	return assert.NoError(r.t, err) && assert.Equal(r.t, code, executionErr.GetCode()) &&
+		assert.Equal(r.t, message, executionErr.GetMessage())
+}
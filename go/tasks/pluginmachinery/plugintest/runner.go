@@ -0,0 +1,278 @@
+package plugintest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pluginCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
+)
+
+const defaultRunTimeout = time.Minute
+
+// RoundHook is invoked after each round, e.g. to advance the state of the external system a plugin is waiting on.
+type RoundHook func(ctx context.Context, tCtx pluginCore.TaskExecutionContext) error
+
+// ClusterHook is invoked before each round with the fake cluster and the resource created by a k8s plugin, e.g. to
+// update the status of the resource.
+type ClusterHook func(ctx context.Context, c client.Client, resource client.Object) error
+
+// runSettings are shared by the runners.
+type runSettings struct {
+	t          T
+	tCtx       *TaskContext
+	timeout    time.Duration
+	maxRounds  int
+	abortAfter int
+}
+
+// done returns an error if the run must stop after the given number of rounds.
+func (s runSettings) done(rounds int, start time.Time) error {
+	if s.maxRounds > 0 && rounds >= s.maxRounds {
+		return fmt.Errorf("task didn't reach a terminal phase after [%v] rounds", rounds)
+	}
+
+	if time.Since(start) > s.timeout {
+		return fmt.Errorf("task didn't reach a terminal phase within [%v]", s.timeout)
+	}
+
+	return nil
+}
+
+func (s runSettings) abortNow(rounds int) bool {
+	return s.abortAfter >= 0 && rounds >= s.abortAfter
+}
+
+// record appends p to the phases of res unless it's the same phase and version as the last one.
+func record(res *Result, p pluginCore.PhaseInfo) {
+	if last := res.Phase(); len(res.Phases) > 0 && last.Phase() == p.Phase() && last.Version() == p.Version() {
+		res.Phases[len(res.Phases)-1] = p
+		return
+	}
+
+	res.Phases = append(res.Phases, p)
+}
+
+// CorePluginRunner calls Handle on a core.Plugin until the task reaches a terminal phase, optionally aborts it, and
+// finalizes it, as FlytePropeller would.
+type CorePluginRunner struct {
+	runSettings
+	plugin pluginCore.Plugin
+	hook   RoundHook
+}
+
+// WithRoundHook sets a hook invoked after each call to Handle.
+func (r *CorePluginRunner) WithRoundHook(hook RoundHook) *CorePluginRunner {
+	r.hook = hook
+	return r
+}
+
+// WithTimeout bounds the duration of the run. Defaults to a minute.
+func (r *CorePluginRunner) WithTimeout(timeout time.Duration) *CorePluginRunner {
+	r.timeout = timeout
+	return r
+}
+
+// WithMaxRounds bounds the number of calls to Handle. Unbounded by default.
+func (r *CorePluginRunner) WithMaxRounds(maxRounds int) *CorePluginRunner {
+	r.maxRounds = maxRounds
+	return r
+}
+
+// AbortAfter aborts the task after the given number of calls to Handle, unless it reached a terminal phase before.
+func (r *CorePluginRunner) AbortAfter(rounds int) *CorePluginRunner {
+	r.abortAfter = rounds
+	return r
+}
+
+// Run drives the plugin. Errors returned by the plugin stop the run and are reported in the result, like errors of the
+// hook and timeouts.
+func (r *CorePluginRunner) Run(ctx context.Context) *Result {
+	res := &Result{t: r.t, tCtx: r.tCtx}
+	start := time.Now()
+	for rounds := 0; !res.Phase().Phase().IsTerminal(); rounds++ {
+		if r.abortNow(rounds) {
+			res.Aborted = true
+			res.AbortErr = r.plugin.Abort(ctx, r.tCtx)
+			break
+		}
+
+		if res.Err = r.done(rounds, start); res.Err != nil {
+			break
+		}
+
+		var transition pluginCore.Transition
+		transition, res.Err = r.plugin.Handle(ctx, r.tCtx)
+		r.tCtx.EndRound(res.Err != nil)
+		if res.Err != nil {
+			break
+		}
+
+		record(res, transition.Info())
+		if r.hook != nil {
+			if res.Err = r.hook(ctx, r.tCtx); res.Err != nil {
+				break
+			}
+		}
+	}
+
+	res.FinalizeErr = r.plugin.Finalize(ctx, r.tCtx)
+	return res
+}
+
+// NewCorePluginRunner creates a runner driving plugin with tCtx.
+func NewCorePluginRunner(t T, plugin pluginCore.Plugin, tCtx *TaskContext) *CorePluginRunner {
+	return &CorePluginRunner{
+		runSettings: runSettings{t: t, tCtx: tCtx, timeout: defaultRunTimeout, abortAfter: -1},
+		plugin:      plugin,
+	}
+}
+
+// K8sPluginRunner builds the resource of a k8s.Plugin, creates it in the fake cluster and calls GetTaskPhase until the
+// task reaches a terminal phase. It then optionally aborts the task, applying the plugin's abort behavior, and
+// finalizes it by deleting the resource, as the FlytePropeller plugin manager would.
+type K8sPluginRunner struct {
+	runSettings
+	plugin k8s.Plugin
+	hook   ClusterHook
+}
+
+// WithClusterHook sets a hook invoked before each call to GetTaskPhase.
+func (r *K8sPluginRunner) WithClusterHook(hook ClusterHook) *K8sPluginRunner {
+	r.hook = hook
+	return r
+}
+
+// WithTimeout bounds the duration of the run. Defaults to a minute.
+func (r *K8sPluginRunner) WithTimeout(timeout time.Duration) *K8sPluginRunner {
+	r.timeout = timeout
+	return r
+}
+
+// WithMaxRounds bounds the number of calls to GetTaskPhase. Unbounded by default.
+func (r *K8sPluginRunner) WithMaxRounds(maxRounds int) *K8sPluginRunner {
+	r.maxRounds = maxRounds
+	return r
+}
+
+// AbortAfter aborts the task after the given number of calls to GetTaskPhase, unless it reached a terminal phase
+// before.
+func (r *K8sPluginRunner) AbortAfter(rounds int) *K8sPluginRunner {
+	r.abortAfter = rounds
+	return r
+}
+
+// addObjectMetadata names the resource after the task execution and adds the labels, annotations and owner reference
+// of the task execution to it.
+func addObjectMetadata(taskExecMetadata pluginCore.TaskExecutionMetadata, o client.Object) {
+	o.SetNamespace(taskExecMetadata.GetNamespace())
+	o.SetName(taskExecMetadata.GetTaskExecutionID().GetGeneratedName())
+	o.SetLabels(utils.UnionMaps(o.GetLabels(), taskExecMetadata.GetLabels()))
+	o.SetAnnotations(utils.UnionMaps(o.GetAnnotations(), taskExecMetadata.GetAnnotations()))
+	o.SetOwnerReferences([]metav1.OwnerReference{taskExecMetadata.GetOwnerReference()})
+}
+
+func (r *K8sPluginRunner) abort(ctx context.Context, c client.Client, resource client.Object) error {
+	behavior := k8s.AbortBehaviorDeleteDefaultResource()
+	if abortOverride, ok := r.plugin.(k8s.PluginAbortOverride); ok {
+		var err error
+		if behavior, err = abortOverride.OnAbort(ctx, r.tCtx, resource); err != nil {
+			return err
+		}
+	}
+
+	if behavior.Resource != nil {
+		resource = behavior.Resource
+	}
+
+	var err error
+	switch {
+	case behavior.Update != nil:
+		err = c.Update(ctx, resource, behavior.Update.Options...)
+	case behavior.Patch != nil:
+		err = c.Patch(ctx, resource, behavior.Patch.Patch, behavior.Patch.Options...)
+	}
+
+	if behavior.DeleteResource || (err != nil && behavior.DeleteOnErr) {
+		err = client.IgnoreNotFound(c.Delete(ctx, resource))
+	}
+
+	return err
+}
+
+// Run drives the plugin. Errors returned by the plugin or the fake cluster stop the run and are reported in the result,
+// like errors of the hook and timeouts.
+func (r *K8sPluginRunner) Run(ctx context.Context) *Result {
+	res := &Result{t: r.t, tCtx: r.tCtx}
+	c := r.tCtx.KubeClient().GetClient()
+	taskExecMetadata := r.tCtx.TaskExecutionMetadata()
+
+	resource, err := r.plugin.BuildResource(ctx, r.tCtx)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+
+	addObjectMetadata(taskExecMetadata, resource)
+	if res.Err = c.Create(ctx, resource); res.Err != nil {
+		return res
+	}
+
+	res.Resource = resource
+	start := time.Now()
+	for rounds := 0; !res.Phase().Phase().IsTerminal(); rounds++ {
+		if r.abortNow(rounds) {
+			res.Aborted = true
+			res.AbortErr = r.abort(ctx, c, res.Resource)
+			break
+		}
+
+		if res.Err = r.done(rounds, start); res.Err != nil {
+			break
+		}
+
+		if r.hook != nil {
+			if res.Err = r.hook(ctx, c, res.Resource); res.Err != nil {
+				break
+			}
+		}
+
+		observed, err := r.plugin.BuildIdentityResource(ctx, taskExecMetadata)
+		if err != nil {
+			res.Err = err
+			break
+		}
+
+		if res.Err = c.Get(ctx, client.ObjectKeyFromObject(resource), observed); res.Err != nil {
+			break
+		}
+
+		res.Resource = observed
+		var p pluginCore.PhaseInfo
+		if p, res.Err = r.plugin.GetTaskPhase(ctx, r.tCtx, observed); res.Err != nil {
+			break
+		}
+
+		record(res, p)
+	}
+
+	if err := c.Delete(ctx, res.Resource); err != nil && !k8serrors.IsNotFound(err) {
+		res.FinalizeErr = err
+	}
+
+	return res
+}
+
+// NewK8sPluginRunner creates a runner driving plugin with tCtx.
+func NewK8sPluginRunner(t T, plugin k8s.Plugin, tCtx *TaskContext) *K8sPluginRunner {
+	return &K8sPluginRunner{
+		runSettings: runSettings{t: t, tCtx: tCtx, timeout: defaultRunTimeout, abortAfter: -1},
+		plugin:      plugin,
+	}
+}
@@ -0,0 +1,386 @@
+package plugintest_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/clients/go/coreutils"
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/contextutils"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/promutils/labeled"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pluginCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/ioutils"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/plugintest"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/resourcemanager"
+)
+
+const resourceNamespace = pluginCore.ResourceNamespace("echo")
+
+type echoState struct {
+	Rounds int
+}
+
+// echoPlugin allocates a token, checks a secret, then copies its inputs to its outputs on its third round.
+type echoPlugin struct {
+	aborted   bool
+	finalized bool
+}
+
+func (p *echoPlugin) GetID() string {
+	return "echo"
+}
+
+func (p *echoPlugin) GetProperties() pluginCore.PluginProperties {
+	return pluginCore.PluginProperties{}
+}
+
+func (p *echoPlugin) Handle(ctx context.Context, tCtx pluginCore.TaskExecutionContext) (pluginCore.Transition, error) {
+	token := tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName()
+	status, err := tCtx.ResourceManager().AllocateResource(ctx, resourceNamespace, token,
+		pluginCore.ResourceConstraintsSpec{})
+	if err != nil {
+		return pluginCore.UnknownTransition, err
+	} else if status != pluginCore.AllocationStatusGranted {
+		return pluginCore.DoTransition(pluginCore.PhaseInfoWaitingForResourcesInfo(
+			metav1.Now().Time, pluginCore.DefaultPhaseVersion, "throttled", nil)), nil
+	}
+
+	if _, err = tCtx.SecretManager().Get(ctx, "token"); err != nil {
+		return pluginCore.UnknownTransition, err
+	}
+
+	state := echoState{}
+	if _, err = tCtx.PluginStateReader().Get(&state); err != nil {
+		return pluginCore.UnknownTransition, err
+	}
+
+	state.Rounds++
+	if err = tCtx.PluginStateWriter().Put(0, &state); err != nil {
+		return pluginCore.UnknownTransition, err
+	}
+
+	switch state.Rounds {
+	case 1:
+		return pluginCore.DoTransition(pluginCore.PhaseInfoQueued(metav1.Now().Time, 0, "")), nil
+	case 2:
+		return pluginCore.DoTransition(pluginCore.PhaseInfoRunning(0, nil)), nil
+	}
+
+	inputs, err := tCtx.InputReader().Get(ctx)
+	if err != nil {
+		return pluginCore.UnknownTransition, err
+	}
+
+	if inputs.GetLiterals()["fail"] != nil {
+		return pluginCore.DoTransition(pluginCore.PhaseInfoFailure("BadInput", "asked to fail", nil)), nil
+	}
+
+	if err = tCtx.OutputWriter().Put(ctx, ioutils.NewInMemoryOutputReader(inputs, nil, nil)); err != nil {
+		return pluginCore.UnknownTransition, err
+	}
+
+	return pluginCore.DoTransition(pluginCore.PhaseInfoSuccess(nil)), nil
+}
+
+func (p *echoPlugin) Abort(context.Context, pluginCore.TaskExecutionContext) error {
+	p.aborted = true
+	return nil
+}
+
+func (p *echoPlugin) Finalize(ctx context.Context, tCtx pluginCore.TaskExecutionContext) error {
+	p.finalized = true
+	return tCtx.ResourceManager().ReleaseResource(ctx, resourceNamespace,
+		tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName())
+}
+
+func TestMain(m *testing.M) {
+	labeled.SetMetricKeys(contextutils.NamespaceKey)
+	os.Exit(m.Run())
+}
+
+func TestCorePluginRunner(t *testing.T) {
+	ctx := context.Background()
+	inputs := coreutils.MustMakeLiteral(map[string]interface{}{"x": 5}).GetMap()
+
+	t.Run("success", func(t *testing.T) {
+		p := &echoPlugin{}
+		rm, err := resourcemanager.NewManager(resourcemanager.GetConfig(), promutils.NewTestScope())
+		assert.NoError(t, err)
+		tCtx := plugintest.NewTaskContextBuilder(t).
+			WithInputs(inputs).
+			WithSecret("token", "secret").
+			WithMemoryResourceManager(rm).
+			Build()
+
+		res := plugintest.NewCorePluginRunner(t, p, tCtx).Run(ctx)
+		res.AssertSuccess()
+		res.AssertPhases(pluginCore.PhaseQueued, pluginCore.PhaseRunning, pluginCore.PhaseSuccess)
+		res.AssertOutputs(ctx, inputs)
+		assert.False(t, p.aborted)
+		assert.True(t, p.finalized)
+		assert.Empty(t, rm.Allocations(resourceNamespace))
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		tCtx := plugintest.NewTaskContextBuilder(t).
+			WithInputs(coreutils.MustMakeLiteral(map[string]interface{}{"fail": true}).GetMap()).
+			WithSecret("token", "secret").
+			Build()
+
+		res := plugintest.NewCorePluginRunner(t, &echoPlugin{}, tCtx).Run(ctx)
+		res.AssertFailure("BadInput")
+	})
+
+	t.Run("resource quota and abort", func(t *testing.T) {
+		p := &echoPlugin{}
+		tCtx := plugintest.NewTaskContextBuilder(t).
+			WithResourceQuota(resourceNamespace, 0).
+			Build()
+
+		res := plugintest.NewCorePluginRunner(t, p, tCtx).AbortAfter(3).Run(ctx)
+		assert.NoError(t, res.Err)
+		res.AssertPhases(pluginCore.PhaseWaitingForResources)
+		assert.True(t, res.Aborted)
+		assert.True(t, p.aborted)
+		assert.True(t, p.finalized)
+	})
+
+	t.Run("injected failures", func(t *testing.T) {
+		tCtx := plugintest.NewTaskContextBuilder(t).
+			WithSecret("token", "secret").
+			WithFailureTimes(plugintest.FailPluginStateWrite, fmt.Errorf("state failure"), 1).
+			Build()
+
+		// The failed round's state is discarded, the second round starts over.
+		res := plugintest.NewCorePluginRunner(t, &echoPlugin{}, tCtx).Run(ctx)
+		assert.EqualError(t, res.Err, "state failure")
+
+		res = plugintest.NewCorePluginRunner(t, &echoPlugin{}, tCtx).Run(ctx)
+		res.AssertSuccess()
+		res.AssertPhases(pluginCore.PhaseQueued, pluginCore.PhaseRunning, pluginCore.PhaseSuccess)
+
+		tCtx = plugintest.NewTaskContextBuilder(t).
+			WithFailure(plugintest.FailSecretGet, fmt.Errorf("secret failure")).
+			Build()
+		res = plugintest.NewCorePluginRunner(t, &echoPlugin{}, tCtx).Run(ctx)
+		assert.EqualError(t, res.Err, "secret failure")
+	})
+
+	t.Run("max rounds", func(t *testing.T) {
+		tCtx := plugintest.NewTaskContextBuilder(t).WithResourceQuota(resourceNamespace, 0).Build()
+		res := plugintest.NewCorePluginRunner(t, &echoPlugin{}, tCtx).WithMaxRounds(5).Run(ctx)
+		assert.EqualError(t, res.Err, "task didn't reach a terminal phase after [5] rounds")
+	})
+}
+
+// podPlugin runs tasks in a pod whose phase is reported as is.
+type podPlugin struct {
+	abortBehavior *k8s.AbortBehavior
+}
+
+func (p podPlugin) BuildIdentityResource(context.Context, pluginCore.TaskExecutionMetadata) (client.Object, error) {
+	return &v1.Pod{}, nil
+}
+
+func (p podPlugin) BuildResource(context.Context, pluginCore.TaskExecutionContext) (client.Object, error) {
+	return &v1.Pod{Spec: v1.PodSpec{Containers: []v1.Container{{Name: "main", Image: "img1"}}}}, nil
+}
+
+func (p podPlugin) GetTaskPhase(_ context.Context, _ k8s.PluginContext, resource client.Object) (
+	pluginCore.PhaseInfo, error) {
+	switch resource.(*v1.Pod).Status.Phase {
+	case v1.PodRunning:
+		return pluginCore.PhaseInfoRunning(0, nil), nil
+	case v1.PodSucceeded:
+		return pluginCore.PhaseInfoSuccess(nil), nil
+	case v1.PodFailed:
+		return pluginCore.PhaseInfoRetryableFailure("PodFailed", "pod failed", nil), nil
+	}
+
+	return pluginCore.PhaseInfoQueued(metav1.Now().Time, 0, ""), nil
+}
+
+func (p podPlugin) GetProperties() k8s.PluginProperties {
+	return k8s.PluginProperties{}
+}
+
+type podPluginWithAbortOverride struct {
+	podPlugin
+}
+
+func (p podPluginWithAbortOverride) OnAbort(context.Context, pluginCore.TaskExecutionContext, client.Object) (
+	k8s.AbortBehavior, error) {
+	return *p.abortBehavior, nil
+}
+
+// advancePod moves the pod through the given phases, one per round.
+func advancePod(phases ...v1.PodPhase) plugintest.ClusterHook {
+	return func(ctx context.Context, c client.Client, resource client.Object) error {
+		pod := &v1.Pod{}
+		if err := c.Get(ctx, client.ObjectKeyFromObject(resource), pod); err != nil {
+			return err
+		}
+
+		if len(phases) > 0 {
+			pod.Status.Phase, phases = phases[0], phases[1:]
+		}
+
+		return c.Update(ctx, pod)
+	}
+}
+
+func TestK8sPluginRunner(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		tCtx := plugintest.NewTaskContextBuilder(t).WithLabels(map[string]string{"l": "v"}).Build()
+		res := plugintest.NewK8sPluginRunner(t, podPlugin{}, tCtx).
+			WithClusterHook(advancePod(v1.PodPending, v1.PodRunning, v1.PodRunning, v1.PodSucceeded)).
+			Run(ctx)
+
+		res.AssertSuccess()
+		res.AssertPhases(pluginCore.PhaseQueued, pluginCore.PhaseRunning, pluginCore.PhaseSuccess)
+
+		taskExecID := tCtx.TaskExecutionMetadata().GetTaskExecutionID()
+		assert.Equal(t, taskExecID.GetGeneratedName(), res.Resource.GetName())
+		assert.Equal(t, plugintest.DefaultNamespace, res.Resource.GetNamespace())
+		assert.Equal(t, "v", res.Resource.GetLabels()["l"])
+
+		err := tCtx.KubeClient().GetClient().Get(ctx, client.ObjectKeyFromObject(res.Resource), &v1.Pod{})
+		assert.True(t, k8serrors.IsNotFound(err), "the pod should be deleted on finalize")
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		tCtx := plugintest.NewTaskContextBuilder(t).Build()
+		res := plugintest.NewK8sPluginRunner(t, podPlugin{}, tCtx).
+			WithClusterHook(advancePod(v1.PodRunning, v1.PodFailed)).
+			Run(ctx)
+
+		res.AssertPhases(pluginCore.PhaseRunning, pluginCore.PhaseRetryableFailure)
+		res.AssertFailure("PodFailed")
+	})
+
+	t.Run("abort override", func(t *testing.T) {
+		behavior := k8s.AbortBehaviorPatchDefaultResource(k8s.PatchResourceOperation{
+			Patch: client.RawPatch("application/merge-patch+json", []byte(`{"metadata":{"labels":{"aborted":"true"}}}`)),
+		}, false)
+
+		var aborted *v1.Pod
+		tCtx := plugintest.NewTaskContextBuilder(t).Build()
+		res := plugintest.NewK8sPluginRunner(t, podPluginWithAbortOverride{podPlugin{abortBehavior: &behavior}}, tCtx).
+			WithClusterHook(func(ctx context.Context, c client.Client, resource client.Object) error {
+				aborted = &v1.Pod{}
+				return c.Get(ctx, client.ObjectKeyFromObject(resource), aborted)
+			}).
+			AbortAfter(1).
+			Run(ctx)
+
+		assert.NoError(t, res.Err)
+		assert.NoError(t, res.AbortErr)
+		assert.True(t, res.Aborted)
+		assert.Equal(t, "true", res.Resource.GetLabels()["aborted"])
+		assert.NotNil(t, aborted)
+	})
+
+	t.Run("injected create failure", func(t *testing.T) {
+		tCtx := plugintest.NewTaskContextBuilder(t).
+			WithFailure(plugintest.FailK8sCreate, fmt.Errorf("quota exceeded")).
+			Build()
+
+		res := plugintest.NewK8sPluginRunner(t, podPlugin{}, tCtx).Run(ctx)
+		assert.EqualError(t, res.Err, "quota exceeded")
+	})
+
+	t.Run("existing objects", func(t *testing.T) {
+		cm := &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: plugintest.DefaultNamespace}}
+		tCtx := plugintest.NewTaskContextBuilder(t).WithK8sObjects(cm).Build()
+		assert.NoError(t, tCtx.KubeClient().GetClient().Get(ctx, client.ObjectKeyFromObject(cm), &v1.ConfigMap{}))
+		assert.NotNil(t, tCtx.SetupContext().KubeClient())
+	})
+}
+
+func TestTaskContext(t *testing.T) {
+	ctx := context.Background()
+	tCtx := plugintest.NewTaskContextBuilder(t).
+		WithTaskExecutionID(idlCore.TaskExecutionIdentifier{
+			NodeExecutionId: &idlCore.NodeExecutionIdentifier{
+				NodeId: "n0",
+				ExecutionId: &idlCore.WorkflowExecutionIdentifier{
+					Project: "flytesnacks",
+					Domain:  "development",
+					Name:    "exec-name",
+				},
+			},
+			RetryAttempt: 1,
+		}).
+		Build()
+
+	taskExecID := tCtx.TaskExecutionMetadata().GetTaskExecutionID()
+	assert.Equal(t, "exec-name-n0-1", taskExecID.GetGeneratedName())
+	assert.Equal(t, "flytesnacks", taskExecID.GetID().TaskId.Project)
+	name, err := taskExecID.GetGeneratedNameWith(20, 30)
+	assert.NoError(t, err)
+	assert.Equal(t, "exec-name-n0-1000000", name)
+	name, err = taskExecID.GetGeneratedNameWith(1, 8)
+	assert.NoError(t, err)
+	assert.Len(t, name, 8)
+
+	template, err := tCtx.TaskReader().Read(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, plugintest.DefaultTaskTemplate(), template)
+
+	tCtx.TaskRefreshIndicator()(ctx)
+	assert.Equal(t, 1, tCtx.RefreshCount())
+
+	assert.NoError(t, tCtx.EventsRecorder().RecordRaw(ctx, pluginCore.PhaseInfoRunning(1, nil)))
+	assert.Len(t, tCtx.RecordedEvents(), 1)
+
+	assert.NoError(t, tCtx.WriteError(ctx, &idlCore.ContainerError{Code: "OOM", Message: "out of memory"}))
+	isErr, err := tCtx.OutputReader().IsError(ctx)
+	assert.NoError(t, err)
+	assert.True(t, isErr)
+
+	_, err = tCtx.SecretManager().Get(ctx, "missing")
+	assert.EqualError(t, err, "secret [missing] not found")
+}
+
+func TestTaskContextBuilder_ResourceConstraints(t *testing.T) {
+	ctx := context.Background()
+	rm, err := resourcemanager.NewManager(resourcemanager.GetConfig(), promutils.NewTestScope())
+	assert.NoError(t, err)
+	assert.NoError(t, rm.RegisterResourceQuota(ctx, resourceNamespace, 10))
+
+	// Tokens are allocated for the project and domain of the task, its constraints apply.
+	constraints := pluginCore.ResourceConstraintsSpec{
+		ProjectScopeResourceConstraint: &pluginCore.ResourceConstraint{Value: 1},
+	}
+	allocate := func(project, name string) pluginCore.AllocationStatus {
+		tCtx := plugintest.NewTaskContextBuilder(t).
+			WithMemoryResourceManager(rm).
+			WithTaskExecutionID(idlCore.TaskExecutionIdentifier{NodeExecutionId: &idlCore.NodeExecutionIdentifier{
+				NodeId:      "node1",
+				ExecutionId: &idlCore.WorkflowExecutionIdentifier{Project: project, Domain: "development", Name: name},
+			}}).
+			Build()
+
+		status, err := tCtx.ResourceManager().AllocateResource(ctx, resourceNamespace,
+			tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName(), constraints)
+		assert.NoError(t, err)
+		return status
+	}
+
+	assert.Equal(t, pluginCore.AllocationStatusGranted, allocate("flytesnacks", "exec1"))
+	assert.Equal(t, pluginCore.AllocationStatusExhausted, allocate("flytesnacks", "exec2"))
+	assert.Equal(t, pluginCore.AllocationStatusGranted, allocate("flyteexamples", "exec3"))
+	assert.Len(t, rm.Allocations(resourceNamespace), 2)
+}
@@ -0,0 +1,8 @@
+package plugintest
+
+// T is the subset of *testing.T used by the harness, so that it can be used from any test framework.
+type T interface {
+	Errorf(format string, args ...interface{})
+	FailNow()
+	Helper()
+}
@@ -0,0 +1,512 @@
+// Package plugintest provides a harness to test plugins end to end without running FlytePropeller. A TaskContextBuilder
+// assembles a task execution context backed by in-memory storage, catalog and plugin state, a fake secret manager, the
+// in-memory resource manager of the resourcemanager package and a fake cluster. A CorePluginRunner or a K8sPluginRunner
+// then drives a plugin through its lifecycle using that context and records the phases it reports, for assertions.
+//
+// The in-memory storage records labeled metrics, tests using the harness must call labeled.SetMetricKeys first.
+package plugintest
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/catalog"
+	pluginCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/ioutils"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/resourcemanager"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/workqueue"
+)
+
+const (
+	// DefaultNamespace is the namespace tasks run in unless set otherwise.
+	DefaultNamespace = "fake-development"
+
+	defaultMaxDatasetSizeBytes = 1000000
+	defaultMaxAttempts         = 2
+)
+
+// DefaultTaskTemplate returns the template of a container task whose arguments reference its inputs.
+func DefaultTaskTemplate() *core.TaskTemplate {
+	return &core.TaskTemplate{
+		Type: "container",
+		Target: &core.TaskTemplate_Container{
+			Container: &core.Container{
+				Command: []string{"cmd"},
+				Args:    []string{"{{$inputPrefix}}"},
+				Image:   "img1",
+			},
+		},
+	}
+}
+
+// TaskContextBuilder builds a TaskContext. All settings are optional, by default the task runs the DefaultTaskTemplate
+// without inputs in a randomly named execution, so that contexts built by different tests don't collide.
+type TaskContextBuilder struct {
+	t                     T
+	template              *core.TaskTemplate
+	inputs                *core.LiteralMap
+	taskExecID            core.TaskExecutionIdentifier
+	namespace             string
+	serviceAccount        string
+	labels                map[string]string
+	annotations           map[string]string
+	resources             *v1.ResourceRequirements
+	platformResources     *v1.ResourceRequirements
+	config                map[string]string
+	interruptible         bool
+	maxAttempts           uint32
+	maxDatasetSizeBytes   int64
+	secrets               secretManager
+	secretManager         pluginCore.SecretManager
+	memoryResourceManager *resourcemanager.Manager
+	resourceManager       pluginCore.ResourceManager
+	scheme                *runtime.Scheme
+	k8sObjects            []client.Object
+	failures              *failureInjector
+}
+
+// WithTaskTemplate sets the template of the task.
+func (b *TaskContextBuilder) WithTaskTemplate(template *core.TaskTemplate) *TaskContextBuilder {
+	b.template = template
+	return b
+}
+
+// WithInputs sets the inputs of the task.
+func (b *TaskContextBuilder) WithInputs(inputs *core.LiteralMap) *TaskContextBuilder {
+	b.inputs = inputs
+	return b
+}
+
+// WithTaskExecutionID sets the id of the task execution. The generated name of the execution is derived from it.
+func (b *TaskContextBuilder) WithTaskExecutionID(id core.TaskExecutionIdentifier) *TaskContextBuilder {
+	b.taskExecID = id
+	return b
+}
+
+// WithNamespace sets the k8s namespace the task runs in.
+func (b *TaskContextBuilder) WithNamespace(namespace string) *TaskContextBuilder {
+	b.namespace = namespace
+	return b
+}
+
+// WithServiceAccount sets the k8s service account the task runs as.
+func (b *TaskContextBuilder) WithServiceAccount(serviceAccount string) *TaskContextBuilder {
+	b.serviceAccount = serviceAccount
+	return b
+}
+
+// WithLabels sets the labels of the task execution.
+func (b *TaskContextBuilder) WithLabels(labels map[string]string) *TaskContextBuilder {
+	b.labels = labels
+	return b
+}
+
+// WithAnnotations sets the annotations of the task execution.
+func (b *TaskContextBuilder) WithAnnotations(annotations map[string]string) *TaskContextBuilder {
+	b.annotations = annotations
+	return b
+}
+
+// WithResources sets the resources overrides of the task.
+func (b *TaskContextBuilder) WithResources(resources *v1.ResourceRequirements) *TaskContextBuilder {
+	b.resources = resources
+	return b
+}
+
+// WithPlatformResources sets the platform resources of the task.
+func (b *TaskContextBuilder) WithPlatformResources(resources *v1.ResourceRequirements) *TaskContextBuilder {
+	b.platformResources = resources
+	return b
+}
+
+// WithConfig sets the config overrides of the task.
+func (b *TaskContextBuilder) WithConfig(config map[string]string) *TaskContextBuilder {
+	b.config = config
+	return b
+}
+
+// WithInterruptible marks the task as interruptible.
+func (b *TaskContextBuilder) WithInterruptible(interruptible bool) *TaskContextBuilder {
+	b.interruptible = interruptible
+	return b
+}
+
+// WithMaxAttempts sets the number of attempts the task is allowed.
+func (b *TaskContextBuilder) WithMaxAttempts(maxAttempts uint32) *TaskContextBuilder {
+	b.maxAttempts = maxAttempts
+	return b
+}
+
+// WithSecret makes a secret readable through the secret manager of the task and of the setup context.
+func (b *TaskContextBuilder) WithSecret(key, value string) *TaskContextBuilder {
+	b.secrets[key] = value
+	return b
+}
+
+// WithSecretManager replaces the default secret manager, which serves the secrets added with WithSecret.
+func (b *TaskContextBuilder) WithSecretManager(secretManager pluginCore.SecretManager) *TaskContextBuilder {
+	b.secretManager = secretManager
+	return b
+}
+
+// WithMemoryResourceManager replaces the default resource manager, e.g. to share it between task contexts. Tokens are
+// allocated for the owner of the task, see resourcemanager.Manager.ForOwner.
+func (b *TaskContextBuilder) WithMemoryResourceManager(resourceManager *resourcemanager.Manager) *TaskContextBuilder {
+	b.memoryResourceManager = resourceManager
+	return b
+}
+
+// WithResourceManager replaces the default resource manager by one that isn't a resourcemanager.Manager.
+func (b *TaskContextBuilder) WithResourceManager(resourceManager pluginCore.ResourceManager) *TaskContextBuilder {
+	b.resourceManager = resourceManager
+	return b
+}
+
+// WithResourceQuota limits the total weight of the tokens that can be allocated in namespace by the default resource
+// manager. A quota of 0 rejects all allocations.
+func (b *TaskContextBuilder) WithResourceQuota(namespace pluginCore.ResourceNamespace, quota int) *TaskContextBuilder {
+	if b.resourceManager != nil {
+		b.t.Errorf("resource quotas can only be set on the default resource manager")
+		return b
+	}
+
+	require.NoError(b.t, b.memoryResourceManager.RegisterResourceQuota(context.Background(), namespace, quota))
+	return b
+}
+
+// WithResourceRate limits the total weight of the tokens that can be allocated in namespace within any window of time
+// by the default resource manager.
+func (b *TaskContextBuilder) WithResourceRate(namespace pluginCore.ResourceNamespace,
+	rate pluginCore.ResourceRate) *TaskContextBuilder {
+	if b.resourceManager != nil {
+		b.t.Errorf("resource rates can only be set on the default resource manager")
+		return b
+	}
+
+	require.NoError(b.t, b.memoryResourceManager.RegisterResourceRate(context.Background(), namespace, rate))
+	return b
+}
+
+// WithScheme sets the scheme of the fake cluster. Plugins creating custom resources must register their types.
+// Defaults to the client-go scheme.
+func (b *TaskContextBuilder) WithScheme(scheme *runtime.Scheme) *TaskContextBuilder {
+	b.scheme = scheme
+	return b
+}
+
+// WithK8sObjects adds objects to the fake cluster.
+func (b *TaskContextBuilder) WithK8sObjects(objects ...client.Object) *TaskContextBuilder {
+	b.k8sObjects = append(b.k8sObjects, objects...)
+	return b
+}
+
+// WithFailure makes all calls to point return err.
+func (b *TaskContextBuilder) WithFailure(point FailurePoint, err error) *TaskContextBuilder {
+	return b.WithFailureTimes(point, err, -1)
+}
+
+// WithFailureTimes makes the next times calls to point return err. Calls fail indefinitely if times is negative.
+func (b *TaskContextBuilder) WithFailureTimes(point FailurePoint, err error, times int) *TaskContextBuilder {
+	b.failures.inject(point, err, times)
+	return b
+}
+
+func (b *TaskContextBuilder) buildTaskExecutionID() taskExecutionID {
+	id := b.taskExecID
+	if id.NodeExecutionId == nil {
+		id.NodeExecutionId = &core.NodeExecutionIdentifier{NodeId: "node1"}
+	}
+
+	if id.NodeExecutionId.ExecutionId == nil {
+		id.NodeExecutionId.ExecutionId = &core.WorkflowExecutionIdentifier{
+			Project: "project",
+			Domain:  "development",
+			Name:    "exec" + rand.String(6),
+		}
+	}
+
+	if id.TaskId == nil {
+		id.TaskId = &core.Identifier{
+			ResourceType: core.ResourceType_TASK,
+			Project:      id.NodeExecutionId.ExecutionId.Project,
+			Domain:       id.NodeExecutionId.ExecutionId.Domain,
+			Name:         "task",
+			Version:      "v1",
+		}
+	}
+
+	return taskExecutionID{
+		id: id,
+		generatedName: fmt.Sprintf("%v-%v-%v", id.NodeExecutionId.ExecutionId.Name, id.NodeExecutionId.NodeId,
+			id.RetryAttempt),
+	}
+}
+
+// Build creates the task context. Inputs are written to in-memory storage and the catalog client is started.
+func (b *TaskContextBuilder) Build() *TaskContext {
+	b.t.Helper()
+	ctx := context.Background()
+
+	ds, err := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+	require.NoError(b.t, err)
+
+	taskExecID := b.buildTaskExecutionID()
+	prefix := storage.DataReference(fmt.Sprintf("mem://bucket/%v", taskExecID.generatedName))
+
+	inputs := b.inputs
+	if inputs == nil {
+		inputs = &core.LiteralMap{}
+	}
+
+	inputPaths := ioutils.NewInputFilePaths(ctx, ds, prefix+"/inputs")
+	require.NoError(b.t, ds.WriteProtobuf(ctx, inputPaths.GetInputPath(), storage.Options{}, inputs))
+
+	template := b.template
+	if template == nil {
+		template = DefaultTaskTemplate()
+	}
+
+	outputPaths := ioutils.NewCheckpointRemoteFilePaths(ctx, ds, prefix+"/outputs",
+		ioutils.NewRawOutputPaths(ctx, prefix+"/raw"), "")
+
+	asyncCatalog, err := catalog.NewAsyncClient(&catalogClient{artifacts: map[string]*core.LiteralMap{}},
+		catalog.Config{
+			ReaderWorkqueueConfig: workqueue.Config{Workers: 2, IndexCacheMaxItems: 100},
+			WriterWorkqueueConfig: workqueue.Config{Workers: 2, IndexCacheMaxItems: 100},
+		}, promutils.NewTestScope())
+	require.NoError(b.t, err)
+	require.NoError(b.t, asyncCatalog.Start(ctx))
+
+	s := b.scheme
+	if s == nil {
+		s = scheme.Scheme
+	}
+
+	resources := b.resources
+	if resources == nil {
+		resources = &v1.ResourceRequirements{Requests: v1.ResourceList{}, Limits: v1.ResourceList{}}
+	}
+
+	platformResources := b.platformResources
+	if platformResources == nil {
+		platformResources = &v1.ResourceRequirements{}
+	}
+
+	var secrets pluginCore.SecretManager = b.secrets
+	if b.secretManager != nil {
+		secrets = b.secretManager
+	}
+
+	memoryResourceManager, resourceManager := b.memoryResourceManager, b.resourceManager
+	if resourceManager == nil {
+		owner := resourcemanager.OwnerFromTaskExecutionMetadata(taskExecutionMetadata{taskExecID: taskExecID})
+		resourceManager = ownerResourceManager{ResourceManager: memoryResourceManager.ForOwner(owner),
+			memoryResourceManager: memoryResourceManager}
+	} else {
+		memoryResourceManager = nil
+	}
+
+	tCtx := &TaskContext{
+		dataStore:   ds,
+		inputReader: ioutils.NewRemoteFileInputReader(ctx, ds, inputPaths),
+		outputWriter: failingOutputWriter{
+			OutputWriter: ioutils.NewRemoteFileOutputWriter(ctx, ds, outputPaths),
+			failures:     b.failures,
+		},
+		outputReader:          ioutils.NewRemoteFileOutputReader(ctx, ds, outputPaths, b.maxDatasetSizeBytes),
+		taskReader:            taskReader{template: template, path: prefix + "/task.pb"},
+		pluginState:           &pluginState{failures: b.failures},
+		catalog:               asyncCatalog,
+		eventsRecorder:        &eventsRecorder{},
+		memoryResourceManager: memoryResourceManager,
+		resourceManager:       resourceManager,
+		secretManager:         secrets,
+		kubeClient: kubeClient{client: failingClient{
+			Client:   fake.NewClientBuilder().WithScheme(s).WithObjects(b.k8sObjects...).Build(),
+			failures: b.failures,
+		}},
+		maxDatasetSizeBytes: b.maxDatasetSizeBytes,
+		failures:            b.failures,
+		metadata: taskExecutionMetadata{
+			taskExecID: taskExecID,
+			namespace:  b.namespace,
+			overrides: taskOverrides{
+				resources: resources,
+				config:    &v1.ConfigMap{Data: b.config},
+			},
+			labels:         b.labels,
+			annotations:    b.annotations,
+			maxAttempts:    b.maxAttempts,
+			serviceAccount: b.serviceAccount,
+			securityContext: core.SecurityContext{
+				RunAs: &core.Identity{K8SServiceAccount: b.serviceAccount},
+			},
+			interruptible:                 b.interruptible,
+			platformResources:             platformResources,
+			interruptibleFailureThreshold: b.maxAttempts,
+		},
+	}
+
+	return tCtx
+}
+
+// NewTaskContextBuilder creates a builder reporting errors to t.
+func NewTaskContextBuilder(t T) *TaskContextBuilder {
+	memoryResourceManager, err := resourcemanager.NewManager(resourcemanager.GetConfig(), promutils.NewTestScope())
+	require.NoError(t, err)
+
+	return &TaskContextBuilder{
+		t:                     t,
+		namespace:             DefaultNamespace,
+		serviceAccount:        "default",
+		labels:                map[string]string{},
+		annotations:           map[string]string{},
+		config:                map[string]string{},
+		maxAttempts:           defaultMaxAttempts,
+		maxDatasetSizeBytes:   defaultMaxDatasetSizeBytes,
+		secrets:               secretManager{},
+		memoryResourceManager: memoryResourceManager,
+		failures:              &failureInjector{},
+	}
+}
+
+// TaskContext is an in-memory pluginCore.TaskExecutionContext, which is also a k8s.PluginContext. It additionally
+// gives tests access to the fake cluster, the recorded events and the outputs of the task.
+type TaskContext struct {
+	dataStore             *storage.DataStore
+	inputReader           io.InputReader
+	outputWriter          io.OutputWriter
+	outputReader          io.OutputReader
+	taskReader            taskReader
+	pluginState           *pluginState
+	catalog               catalog.AsyncClient
+	eventsRecorder        *eventsRecorder
+	memoryResourceManager *resourcemanager.Manager
+	resourceManager       pluginCore.ResourceManager
+	secretManager         pluginCore.SecretManager
+	kubeClient            kubeClient
+	maxDatasetSizeBytes   int64
+	failures              *failureInjector
+	metadata              taskExecutionMetadata
+	refreshes             int32
+}
+
+func (c *TaskContext) ResourceManager() pluginCore.ResourceManager {
+	return failingResourceManager{ResourceManager: c.resourceManager, failures: c.failures}
+}
+
+func (c *TaskContext) SecretManager() pluginCore.SecretManager {
+	return failingSecretManager{SecretManager: c.secretManager, failures: c.failures}
+}
+
+func (c *TaskContext) TaskRefreshIndicator() pluginCore.SignalAsync {
+	return func(ctx context.Context) {
+		atomic.AddInt32(&c.refreshes, 1)
+	}
+}
+
+func (c *TaskContext) MaxDatasetSizeBytes() int64 {
+	return c.maxDatasetSizeBytes
+}
+
+func (c *TaskContext) DataStore() *storage.DataStore {
+	return c.dataStore
+}
+
+func (c *TaskContext) PluginStateReader() pluginCore.PluginStateReader {
+	return c.pluginState
+}
+
+func (c *TaskContext) TaskReader() pluginCore.TaskReader {
+	return c.taskReader
+}
+
+func (c *TaskContext) InputReader() io.InputReader {
+	return failingInputReader{InputReader: c.inputReader, failures: c.failures}
+}
+
+func (c *TaskContext) TaskExecutionMetadata() pluginCore.TaskExecutionMetadata {
+	return c.metadata
+}
+
+func (c *TaskContext) OutputWriter() io.OutputWriter {
+	return c.outputWriter
+}
+
+func (c *TaskContext) PluginStateWriter() pluginCore.PluginStateWriter {
+	return c.pluginState
+}
+
+func (c *TaskContext) Catalog() catalog.AsyncClient {
+	return c.catalog
+}
+
+func (c *TaskContext) EventsRecorder() pluginCore.EventsRecorder {
+	return c.eventsRecorder
+}
+
+// KubeClient returns a client to the fake cluster.
+func (c *TaskContext) KubeClient() pluginCore.KubeClient {
+	return c.kubeClient
+}
+
+// K8sReader returns a reader of the fake cluster. See k8s.K8sReaderProvider.
+func (c *TaskContext) K8sReader() client.Reader {
+	return c.kubeClient.GetClient()
+}
+
+// SetupContext returns a context to load plugins with, sharing the fake cluster, secrets and resource manager of the
+// task context.
+func (c *TaskContext) SetupContext() pluginCore.SetupContext {
+	return setupContext{
+		kubeClient:            c.kubeClient,
+		secretManager:         c.SecretManager(),
+		memoryResourceManager: c.memoryResourceManager,
+		scope:                 promutils.NewTestScope(),
+	}
+}
+
+// RecordedEvents returns the events recorded by the plugin through the EventsRecorder.
+func (c *TaskContext) RecordedEvents() []pluginCore.PhaseInfo {
+	return c.eventsRecorder.recorded()
+}
+
+// RefreshCount returns the number of times the plugin signaled the task had updates.
+func (c *TaskContext) RefreshCount() int {
+	return int(atomic.LoadInt32(&c.refreshes))
+}
+
+// EndRound makes the plugin state written since the last round visible to the next one. Runners end rounds after each
+// call to the plugin, the state is discarded if the call failed.
+func (c *TaskContext) EndRound(failed bool) {
+	c.pluginState.commit(failed)
+}
+
+// WriteOutputs writes the outputs of the task, as a container running it would.
+func (c *TaskContext) WriteOutputs(ctx context.Context, outputs *core.LiteralMap) error {
+	return c.dataStore.WriteProtobuf(ctx, c.outputWriter.GetOutputPath(), storage.Options{}, outputs)
+}
+
+// WriteError writes the error document of the task, as a container running it would.
+func (c *TaskContext) WriteError(ctx context.Context, err *core.ContainerError) error {
+	return c.dataStore.WriteProtobuf(ctx, c.outputWriter.GetErrorPath(), storage.Options{},
+		&core.ErrorDocument{Error: err})
+}
+
+// OutputReader returns a reader of the outputs, or error, written for the task.
+func (c *TaskContext) OutputReader() io.OutputReader {
+	return c.outputReader
+}
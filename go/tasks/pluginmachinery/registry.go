@@ -2,30 +2,71 @@ package pluginmachinery
 
 import (
 	"context"
+	"fmt"
 	"sync"
 
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+
 	internalRemote "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/internal/webapi"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
 
 	"github.com/flyteorg/flytestdlib/logger"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/health"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/interceptor"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
+	"github.com/flyteorg/flytestdlib/promutils"
 )
 
 type taskPluginRegistry struct {
-	m          sync.Mutex
-	k8sPlugin  []k8s.PluginEntry
-	corePlugin []core.PluginEntry
+	m            sync.Mutex
+	k8sPlugin    []k8s.PluginEntry
+	corePlugin   []core.PluginEntry
+	interceptors map[string]interceptor.Interceptor
+	monitor      *health.Monitor
+	// IDs of the registered web API plugins, whose new tasks the health interceptor holds.
+	remotePlugins map[string]bool
+	// IDs of the k8s plugins whose health checker has been registered with the monitor.
+	healthCheckedK8sPlugins map[string]bool
 }
 
+var healthMonitor = health.NewMonitor(promutils.NewScope("plugins:health"), clock.RealClock{})
+
 // A singleton variable that maintains a registry of all plugins. The framework uses this to access all plugins
-var pluginRegistry = &taskPluginRegistry{}
+var pluginRegistry = &taskPluginRegistry{
+	monitor: healthMonitor,
+	interceptors: map[string]interceptor.Interceptor{
+		interceptor.PanicRecoveryName:       interceptor.PanicRecovery{},
+		interceptor.LatencyName:             interceptor.NewLatency(promutils.NewScope("plugins:interceptor")),
+		interceptor.TransitionLoggingName:   interceptor.TransitionLogging{},
+		interceptor.TracingName:             interceptor.Tracing{},
+		interceptor.ErrorClassificationName: interceptor.ErrorClassification{},
+		interceptor.AuditName:               interceptor.Audit{},
+	},
+}
+
+func init() {
+	pluginRegistry.interceptors[interceptor.HealthName] = interceptor.Health{
+		Monitor: healthMonitor,
+		Holds:   pluginRegistry.isRemotePlugin,
+	}
+}
 
 func PluginRegistry() TaskPluginRegistry {
 	return pluginRegistry
 }
 
+// ExtendedPluginRegistry returns the same registry as PluginRegistry, to register interceptors and health checkers,
+// validate task templates and report the health of plugins.
+func ExtendedPluginRegistry() ExtendedTaskPluginRegistry {
+	return pluginRegistry
+}
+
 func (p *taskPluginRegistry) RegisterRemotePlugin(info webapi.PluginEntry) {
 	ctx := context.Background()
 	if info.ID == "" {
@@ -42,9 +83,22 @@ func (p *taskPluginRegistry) RegisterRemotePlugin(info webapi.PluginEntry) {
 
 	p.m.Lock()
 	defer p.m.Unlock()
+	if p.remotePlugins == nil {
+		p.remotePlugins = map[string]bool{}
+	}
+
+	p.remotePlugins[info.ID] = true
 	p.corePlugin = append(p.corePlugin, internalRemote.CreateRemotePlugin(info))
 }
 
+// Returns true if the plugin was registered using RegisterRemotePlugin. The web API framework writes plugin state on
+// the first round of every task, which tells the health interceptor its new tasks apart.
+func (p *taskPluginRegistry) isRemotePlugin(pluginID string) bool {
+	p.m.Lock()
+	defer p.m.Unlock()
+	return p.remotePlugins[pluginID]
+}
+
 func CreateRemotePlugin(pluginEntry webapi.PluginEntry) core.PluginEntry {
 	return internalRemote.CreateRemotePlugin(pluginEntry)
 }
@@ -89,18 +143,230 @@ func (p *taskPluginRegistry) RegisterCorePlugin(info core.PluginEntry) {
 	p.corePlugin = append(p.corePlugin, info)
 }
 
-// Returns a snapshot of all the registered core plugins.
+// Use this method to register interceptors. Registered interceptors wrap plugins once they are listed in the
+// interceptors config. The panic-recovery, latency, transition-logging, tracing, error-classification, audit and
+// health interceptors are registered by default.
+func (p *taskPluginRegistry) RegisterInterceptor(name string, i interceptor.Interceptor) {
+	if name == "" {
+		logger.Panicf(context.TODO(), "Name is required attribute for interceptor")
+	}
+
+	if i == nil {
+		logger.Panicf(context.TODO(), "Interceptor [%v] cannot be nil", name)
+	}
+
+	p.m.Lock()
+	defer p.m.Unlock()
+	if _, exists := p.interceptors[name]; exists {
+		logger.Panicf(context.TODO(), "Interceptor [%v] is already registered", name)
+	}
+
+	p.interceptors[name] = i
+}
+
+// Returns the chain of interceptors listed in the interceptors config, or an error if any of them isn't registered or
+// if error classification rules naming plugins or projects can't be applied. Must be called with the lock held.
+func (p *taskPluginRegistry) interceptorChain() (interceptor.Chain, error) {
+	order := interceptor.GetConfig().Order
+	chain := make(interceptor.Chain, 0, len(order))
+	classifies := false
+	for _, name := range order {
+		i, exists := p.interceptors[name]
+		if !exists {
+			err := fmt.Errorf("interceptor [%v] is configured but isn't registered", name)
+			logger.Error(context.TODO(), err)
+			return nil, err
+		}
+
+		classifies = classifies || name == interceptor.ErrorClassificationName
+		chain = append(chain, i)
+	}
+
+	if !classifies && errors.GetConfig().HasTargetedRules() {
+		err := fmt.Errorf("error classification rules name plugins or projects but the [%v] interceptor isn't "+
+			"configured", interceptor.ErrorClassificationName)
+		logger.Error(context.TODO(), err)
+		return nil, err
+	}
+
+	return chain, nil
+}
+
+// misconfigured is an interceptor failing the calls that run tasks with err, used in place of the configured chain
+// when it can't be built. Tasks can still be aborted and finalized.
+type misconfigured struct {
+	interceptor.PassThrough
+	err error
+}
+
+func (m misconfigured) InterceptHandle(context.Context, string, core.TaskExecutionContext, interceptor.HandleFunc) (
+	core.Transition, error) {
+	return core.UnknownTransition, m.err
+}
+
+func (m misconfigured) InterceptBuildResource(context.Context, string, core.TaskExecutionContext,
+	interceptor.BuildResourceFunc) (client.Object, error) {
+	return nil, m.err
+}
+
+func (m misconfigured) InterceptGetTaskPhase(context.Context, string, k8s.PluginContext, client.Object,
+	interceptor.GetTaskPhaseFunc) (core.PhaseInfo, error) {
+	return core.PhaseInfoUndefined, m.err
+}
+
+// Returns a snapshot of all the registered core plugins, wrapped by the configured interceptors. Loading the plugins
+// fails if the interceptors config lists interceptors that aren't registered.
 func (p *taskPluginRegistry) GetCorePlugins() []core.PluginEntry {
 	p.m.Lock()
 	defer p.m.Unlock()
-	return append(p.corePlugin[:0:0], p.corePlugin...)
+	chain, err := p.interceptorChain()
+	entries := make([]core.PluginEntry, 0, len(p.corePlugin))
+	for _, entry := range p.corePlugin {
+		if err != nil {
+			entry.LoadPlugin = func(context.Context, core.SetupContext) (core.Plugin, error) {
+				return nil, err
+			}
+		}
+
+		entries = append(entries, chain.WrapCorePluginEntry(p.withHealthChecker(entry)))
+	}
+
+	return entries
 }
 
-// Returns a snapshot of all registered K8s plugins
+// Returns an entry whose loaded plugin has its health probed, if it implements core.HealthChecker.
+func (p *taskPluginRegistry) withHealthChecker(entry core.PluginEntry) core.PluginEntry {
+	load := entry.LoadPlugin
+	entry.LoadPlugin = func(ctx context.Context, iCtx core.SetupContext) (core.Plugin, error) {
+		plugin, err := load(ctx, iCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		if checker, ok := plugin.(core.HealthChecker); ok {
+			p.monitor.Register(plugin.GetID(), checker)
+		}
+
+		return plugin, nil
+	}
+
+	return entry
+}
+
+// Returns a snapshot of all registered K8s plugins, wrapped by the configured interceptors. If the interceptors config
+// lists interceptors that aren't registered, the plugins fail to build resources and to get their phase.
 func (p *taskPluginRegistry) GetK8sPlugins() []k8s.PluginEntry {
 	p.m.Lock()
 	defer p.m.Unlock()
-	return append(p.k8sPlugin[:0:0], p.k8sPlugin...)
+	chain, err := p.interceptorChain()
+	if err != nil {
+		chain = interceptor.Chain{misconfigured{err: err}}
+	}
+
+	entries := make([]k8s.PluginEntry, 0, len(p.k8sPlugin))
+	for _, entry := range p.k8sPlugin {
+		if checker, ok := entry.Plugin.(core.HealthChecker); ok && !p.healthCheckedK8sPlugins[entry.ID] {
+			if p.healthCheckedK8sPlugins == nil {
+				p.healthCheckedK8sPlugins = map[string]bool{}
+			}
+
+			p.monitor.Register(entry.ID, checker)
+			p.healthCheckedK8sPlugins[entry.ID] = true
+		}
+
+		entries = append(entries, chain.WrapK8sPluginEntry(entry))
+	}
+
+	return entries
+}
+
+// Use this method to have the health of a plugin probed by a checker it doesn't implement itself, e.g. the k8s plugin
+// manager registers health.NewCRDChecker for the resources its plugins watch. Core and k8s plugins implementing
+// core.HealthChecker are probed once loaded, or first listed for k8s plugins, without being registered.
+func (p *taskPluginRegistry) RegisterHealthChecker(pluginID string, checker core.HealthChecker) {
+	if pluginID == "" {
+		logger.Panicf(context.TODO(), "Plugin ID is required attribute for health checker")
+	}
+
+	if checker == nil {
+		logger.Panicf(context.TODO(), "Health checker of plugin [%v] cannot be nil", pluginID)
+	}
+
+	p.monitor.Register(pluginID, checker)
+}
+
+// Returns the health of the plugins whose health is probed, as of their last probe.
+func (p *taskPluginRegistry) GetHealth() health.Report {
+	return p.monitor.Report()
+}
+
+// A validator of a plugin, nil if the plugin doesn't validate task templates.
+type pluginValidator struct {
+	pluginID  string
+	validator core.PluginValidator
+}
+
+func handles(taskTypes []core.TaskType, taskType core.TaskType) bool {
+	for _, t := range taskTypes {
+		if t == taskType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns the validators of the plugins registered for the task type, or of the default plugins if there are none.
+// Must be called with the lock held.
+func (p *taskPluginRegistry) validators(taskType core.TaskType) []pluginValidator {
+	var matching, defaults []pluginValidator
+	for _, entry := range p.k8sPlugin {
+		validator, _ := entry.Plugin.(core.PluginValidator)
+		if handles(entry.RegisteredTaskTypes, taskType) {
+			matching = append(matching, pluginValidator{pluginID: entry.ID, validator: validator})
+		} else if entry.IsDefault {
+			defaults = append(defaults, pluginValidator{pluginID: entry.ID, validator: validator})
+		}
+	}
+
+	for _, entry := range p.corePlugin {
+		if handles(entry.RegisteredTaskTypes, taskType) {
+			matching = append(matching, pluginValidator{pluginID: entry.ID, validator: entry.Validator})
+		} else if entry.IsDefault {
+			defaults = append(defaults, pluginValidator{pluginID: entry.ID, validator: entry.Validator})
+		}
+	}
+
+	if len(matching) > 0 {
+		return matching
+	}
+
+	return defaults
+}
+
+// Validates a task template with the plugins registered for its task type, or with the default plugins if there are
+// none. This doesn't require a task execution, nor loading the plugins, so that tooling can reject malformed task
+// templates when workflows are compiled. Plugins that don't implement core.PluginValidator accept all task templates.
+func (p *taskPluginRegistry) Validate(ctx context.Context, taskTemplate *idlCore.TaskTemplate) error {
+	p.m.Lock()
+	validators := p.validators(taskTemplate.GetType())
+	p.m.Unlock()
+
+	if len(validators) == 0 {
+		return fmt.Errorf("no plugin is registered for task type [%v]", taskTemplate.GetType())
+	}
+
+	for _, v := range validators {
+		if v.validator == nil {
+			continue
+		}
+
+		if err := v.validator.Validate(ctx, taskTemplate); err != nil {
+			return fmt.Errorf("plugin [%v] rejected the task template: %w", v.pluginID, err)
+		}
+	}
+
+	return nil
 }
 
 type TaskPluginRegistry interface {
@@ -110,3 +376,13 @@ type TaskPluginRegistry interface {
 	GetCorePlugins() []core.PluginEntry
 	GetK8sPlugins() []k8s.PluginEntry
 }
+
+// ExtendedTaskPluginRegistry is the registry returned by ExtendedPluginRegistry. It's kept apart from
+// TaskPluginRegistry so that other implementations of TaskPluginRegistry don't have to implement it.
+type ExtendedTaskPluginRegistry interface {
+	TaskPluginRegistry
+	RegisterInterceptor(name string, i interceptor.Interceptor)
+	RegisterHealthChecker(pluginID string, checker core.HealthChecker)
+	Validate(ctx context.Context, taskTemplate *idlCore.TaskTemplate) error
+	GetHealth() health.Report
+}
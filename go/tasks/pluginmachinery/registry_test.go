@@ -0,0 +1,283 @@
+package pluginmachinery
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/utils/clock"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	coreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/health"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/interceptor"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
+	k8sMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+)
+
+// panicking is a core plugin whose Handle panics.
+type panicking struct {
+	*coreMocks.Plugin
+}
+
+func (panicking) Handle(context.Context, core.TaskExecutionContext) (core.Transition, error) {
+	panic("boom")
+}
+
+func TestTaskPluginRegistry_Interceptors(t *testing.T) {
+	defer func() { assert.NoError(t, interceptor.SetConfig(&interceptor.Config{})) }()
+
+	registry := &taskPluginRegistry{interceptors: map[string]interceptor.Interceptor{}}
+	registry.RegisterInterceptor(interceptor.PanicRecoveryName, interceptor.PanicRecovery{})
+	assert.Panics(t, func() { registry.RegisterInterceptor(interceptor.PanicRecoveryName, interceptor.PanicRecovery{}) })
+	assert.Panics(t, func() { registry.RegisterInterceptor("", interceptor.PanicRecovery{}) })
+	assert.Panics(t, func() { registry.RegisterInterceptor("nil", nil) })
+
+	corePlugin := &coreMocks.Plugin{}
+	corePlugin.OnGetID().Return("core")
+	registry.RegisterCorePlugin(core.PluginEntry{
+		ID:                  "core",
+		RegisteredTaskTypes: []core.TaskType{"core"},
+		LoadPlugin: func(ctx context.Context, iCtx core.SetupContext) (core.Plugin, error) {
+			return panicking{Plugin: corePlugin}, nil
+		},
+	})
+
+	k8sPlugin := &k8sMocks.Plugin{}
+	k8sPlugin.OnBuildResourceMatch(mock.Anything, mock.Anything).Run(func(mock.Arguments) { panic("boom") }).Return(nil, nil)
+	registry.RegisterK8sPlugin(k8s.PluginEntry{
+		ID:                  "k8s",
+		RegisteredTaskTypes: []core.TaskType{"k8s"},
+		ResourceToWatch:     &v1.Pod{},
+		Plugin:              k8sPlugin,
+	})
+
+	t.Run("not configured", func(t *testing.T) {
+		assert.NoError(t, interceptor.SetConfig(&interceptor.Config{}))
+		assert.Equal(t, k8sPlugin, registry.GetK8sPlugins()[0].Plugin)
+
+		p, err := registry.GetCorePlugins()[0].LoadPlugin(context.TODO(), nil)
+		assert.NoError(t, err)
+		assert.Panics(t, func() { _, _ = p.Handle(context.TODO(), nil) })
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		assert.NoError(t, interceptor.SetConfig(&interceptor.Config{Order: []string{interceptor.PanicRecoveryName}}))
+
+		p, err := registry.GetCorePlugins()[0].LoadPlugin(context.TODO(), nil)
+		assert.NoError(t, err)
+		_, err = p.Handle(context.TODO(), nil)
+		assert.Error(t, err)
+
+		_, err = registry.GetK8sPlugins()[0].Plugin.BuildResource(context.TODO(), nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("not registered", func(t *testing.T) {
+		assert.NoError(t, interceptor.SetConfig(&interceptor.Config{Order: []string{"unknown"}}))
+		_, err := registry.GetCorePlugins()[0].LoadPlugin(context.TODO(), nil)
+		assert.EqualError(t, err, "interceptor [unknown] is configured but isn't registered")
+
+		plugin := registry.GetK8sPlugins()[0].Plugin
+		_, err = plugin.BuildResource(context.TODO(), nil)
+		assert.EqualError(t, err, "interceptor [unknown] is configured but isn't registered")
+		_, err = plugin.GetTaskPhase(context.TODO(), nil, &v1.Pod{})
+		assert.EqualError(t, err, "interceptor [unknown] is configured but isn't registered")
+	})
+
+	t.Run("classification rules naming plugins", func(t *testing.T) {
+		defer func() { assert.NoError(t, errors.SetConfig(&errors.Config{})) }()
+		assert.NoError(t, errors.SetConfig(&errors.Config{Rules: []errors.Rule{
+			{Plugins: []string{"core"}, Class: errors.ClassUser},
+		}}))
+		assert.NoError(t, interceptor.SetConfig(&interceptor.Config{Order: []string{interceptor.PanicRecoveryName}}))
+
+		_, err := registry.GetCorePlugins()[0].LoadPlugin(context.TODO(), nil)
+		assert.EqualError(t, err, "error classification rules name plugins or projects but the "+
+			"[error-classification] interceptor isn't configured")
+
+		registry.RegisterInterceptor(interceptor.ErrorClassificationName, interceptor.ErrorClassification{})
+		assert.NoError(t, interceptor.SetConfig(&interceptor.Config{Order: []string{interceptor.PanicRecoveryName,
+			interceptor.ErrorClassificationName}}))
+		_, err = registry.GetCorePlugins()[0].LoadPlugin(context.TODO(), nil)
+		assert.NoError(t, err)
+	})
+}
+
+func TestPluginRegistry_BuiltinInterceptors(t *testing.T) {
+	for _, name := range []string{interceptor.PanicRecoveryName, interceptor.LatencyName,
+		interceptor.TransitionLoggingName, interceptor.HealthName, interceptor.ErrorClassificationName} {
+		assert.Contains(t, pluginRegistry.interceptors, name)
+	}
+}
+
+// validating is a k8s plugin that validates task templates.
+type validating struct {
+	*k8sMocks.Plugin
+	*coreMocks.PluginValidator
+}
+
+func TestTaskPluginRegistry_Validate(t *testing.T) {
+	ctx := context.TODO()
+	registry := &taskPluginRegistry{interceptors: map[string]interceptor.Interceptor{}}
+
+	k8sValidator := &coreMocks.PluginValidator{}
+	k8sValidator.OnValidateMatch(mock.Anything, mock.Anything).Return(fmt.Errorf("invalid"))
+	registry.RegisterK8sPlugin(k8s.PluginEntry{
+		ID:                  "k8s",
+		RegisteredTaskTypes: []core.TaskType{"k8s"},
+		ResourceToWatch:     &v1.Pod{},
+		Plugin:              validating{Plugin: &k8sMocks.Plugin{}, PluginValidator: k8sValidator},
+	})
+
+	coreValidator := &coreMocks.PluginValidator{}
+	coreValidator.OnValidateMatch(mock.Anything, mock.Anything).Return(nil)
+	registry.RegisterCorePlugin(core.PluginEntry{
+		ID:                  "core",
+		RegisteredTaskTypes: []core.TaskType{"core"},
+		IsDefault:           true,
+		LoadPlugin: func(ctx context.Context, iCtx core.SetupContext) (core.Plugin, error) {
+			return &coreMocks.Plugin{}, nil
+		},
+		Validator: coreValidator,
+	})
+
+	registry.RegisterK8sPlugin(k8s.PluginEntry{
+		ID:                  "no-validator",
+		RegisteredTaskTypes: []core.TaskType{"no-validator"},
+		ResourceToWatch:     &v1.Pod{},
+		Plugin:              &k8sMocks.Plugin{},
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		err := registry.Validate(ctx, &idlCore.TaskTemplate{Type: "k8s"})
+		assert.EqualError(t, err, "plugin [k8s] rejected the task template: invalid")
+	})
+
+	t.Run("accepted", func(t *testing.T) {
+		assert.NoError(t, registry.Validate(ctx, &idlCore.TaskTemplate{Type: "core"}))
+	})
+
+	t.Run("no validator", func(t *testing.T) {
+		assert.NoError(t, registry.Validate(ctx, &idlCore.TaskTemplate{Type: "no-validator"}))
+	})
+
+	t.Run("default", func(t *testing.T) {
+		assert.NoError(t, registry.Validate(ctx, &idlCore.TaskTemplate{Type: "unknown"}))
+		coreValidator.AssertNumberOfCalls(t, "Validate", 2)
+	})
+
+	t.Run("not registered", func(t *testing.T) {
+		empty := &taskPluginRegistry{}
+		assert.Error(t, empty.Validate(ctx, &idlCore.TaskTemplate{Type: "unknown"}))
+	})
+}
+
+// checked is a plugin that checks its health.
+type checked struct {
+	*coreMocks.Plugin
+	*coreMocks.HealthChecker
+}
+
+// checkedK8s is a k8s plugin that checks its health.
+type checkedK8s struct {
+	*k8sMocks.Plugin
+	*coreMocks.HealthChecker
+}
+
+func TestTaskPluginRegistry_Health(t *testing.T) {
+	defer func(cfg health.Config) { assert.NoError(t, health.SetConfig(&cfg)) }(*health.GetConfig())
+	assert.NoError(t, health.SetConfig(&health.Config{FailureThreshold: 1}))
+
+	ctx := context.TODO()
+	registry := &taskPluginRegistry{
+		interceptors: map[string]interceptor.Interceptor{},
+		monitor:      health.NewMonitor(promutils.NewTestScope(), clock.RealClock{}),
+	}
+
+	coreChecker := &coreMocks.HealthChecker{}
+	coreChecker.OnCheckHealthMatch(mock.Anything).Return(fmt.Errorf("unauthorized"))
+	corePlugin := &coreMocks.Plugin{}
+	corePlugin.OnGetID().Return("core")
+	registry.RegisterCorePlugin(core.PluginEntry{
+		ID:                  "core",
+		RegisteredTaskTypes: []core.TaskType{"core"},
+		LoadPlugin: func(ctx context.Context, iCtx core.SetupContext) (core.Plugin, error) {
+			return checked{Plugin: corePlugin, HealthChecker: coreChecker}, nil
+		},
+	})
+
+	k8sChecker := &coreMocks.HealthChecker{}
+	k8sChecker.OnCheckHealthMatch(mock.Anything).Return(nil)
+	registry.RegisterK8sPlugin(k8s.PluginEntry{
+		ID:                  "k8s",
+		RegisteredTaskTypes: []core.TaskType{"k8s"},
+		ResourceToWatch:     &v1.Pod{},
+		Plugin:              checkedK8s{Plugin: &k8sMocks.Plugin{}, HealthChecker: k8sChecker},
+	})
+
+	crdChecker := &coreMocks.HealthChecker{}
+	crdChecker.OnCheckHealthMatch(mock.Anything).Return(nil)
+	registry.RegisterHealthChecker("operator", crdChecker)
+	assert.Panics(t, func() { registry.RegisterHealthChecker("", crdChecker) })
+	assert.Panics(t, func() { registry.RegisterHealthChecker("nil", nil) })
+
+	_, err := registry.GetCorePlugins()[0].LoadPlugin(ctx, nil)
+	assert.NoError(t, err)
+	registry.GetK8sPlugins()
+
+	// Listing k8s plugins again doesn't replace the checker registered since.
+	unhealthy := &coreMocks.HealthChecker{}
+	unhealthy.OnCheckHealthMatch(mock.Anything).Return(fmt.Errorf("missing CRD"))
+	registry.RegisterHealthChecker("k8s", unhealthy)
+	registry.GetK8sPlugins()
+	registry.monitor.Probe(ctx)
+
+	report := registry.GetHealth()
+	assert.False(t, report.Healthy)
+	assert.Equal(t, health.Status{Error: "unauthorized", ConsecutiveFailures: 1},
+		withoutLastChecked(report.Plugins["core"]))
+	assert.Equal(t, "missing CRD", report.Plugins["k8s"].Error)
+	assert.True(t, report.Plugins["operator"].Healthy)
+	k8sChecker.AssertNotCalled(t, "CheckHealth", mock.Anything)
+}
+
+func withoutLastChecked(status health.Status) health.Status {
+	status.LastChecked = time.Time{}
+	return status
+}
+
+func TestExtendedPluginRegistry(t *testing.T) {
+	assert.Same(t, pluginRegistry, ExtendedPluginRegistry())
+}
+
+func TestTaskPluginRegistry_RegisterRemotePlugin(t *testing.T) {
+	registry := &taskPluginRegistry{interceptors: map[string]interceptor.Interceptor{}}
+	registry.RegisterRemotePlugin(webapi.PluginEntry{
+		ID:                 "remote",
+		SupportedTaskTypes: []core.TaskType{"remote"},
+		PluginLoader: func(ctx context.Context, iCtx webapi.PluginSetupContext) (webapi.AsyncPlugin, error) {
+			return nil, fmt.Errorf("not loaded")
+		},
+	})
+
+	registry.RegisterCorePlugin(core.PluginEntry{
+		ID:                  "core",
+		RegisteredTaskTypes: []core.TaskType{"core"},
+		LoadPlugin: func(ctx context.Context, iCtx core.SetupContext) (core.Plugin, error) {
+			return &coreMocks.Plugin{}, nil
+		},
+	})
+
+	assert.Len(t, registry.GetCorePlugins(), 2)
+	assert.True(t, registry.isRemotePlugin("remote"))
+	assert.False(t, registry.isRemotePlugin("core"))
+}
@@ -0,0 +1,52 @@
+package resourcemanager
+
+import (
+	"time"
+
+	"github.com/flyteorg/flytestdlib/config"
+
+	pluginsConfig "github.com/flyteorg/flyteplugins/go/tasks/config"
+)
+
+//go:generate pflags Config --default-var=defaultConfig
+
+// Ordering is the order in which waiting allocations are granted once resources are released.
+type Ordering = string
+
+const (
+	// OrderingNone grants allocations to whichever retries first.
+	OrderingNone Ordering = "none"
+
+	// OrderingFIFO grants allocations in the order they were first requested.
+	OrderingFIFO Ordering = "fifo"
+
+	// OrderingFairShare grants allocations to the projects holding the fewest tokens first, and then in the order they
+	// were first requested.
+	OrderingFairShare Ordering = "fairShare"
+)
+
+var (
+	defaultConfig = &Config{
+		TokenTTL:  config.Duration{Duration: 24 * time.Hour},
+		WaiterTTL: config.Duration{Duration: 5 * time.Minute},
+		Ordering:  OrderingNone,
+	}
+
+	cfgSection = pluginsConfig.MustRegisterSubSection("resourceManager", defaultConfig)
+)
+
+// Config configures the in-memory resource manager.
+type Config struct {
+	TokenTTL  config.Duration `json:"tokenTTL" pflag:",Duration after which allocated tokens are considered leaked and released. Allocating a token again extends it."`
+	WaiterTTL config.Duration `json:"waiterTTL" pflag:",Duration after which waiting allocations that aren't retried lose their place in the queue."`
+	Ordering  Ordering        `json:"ordering" pflag:",Order in which waiting allocations are granted: none, fifo or fairShare."`
+}
+
+func GetConfig() *Config {
+	return cfgSection.GetConfig().(*Config)
+}
+
+// SetConfig should be used for unit testing only
+func SetConfig(cfg *Config) error {
+	return cfgSection.SetConfig(cfg)
+}
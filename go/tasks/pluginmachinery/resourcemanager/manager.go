@@ -0,0 +1,435 @@
+// Package resourcemanager provides an in-memory ResourceManager. It enforces the quotas and rates registered for
+// resource namespaces, weighing tokens by their cost, and the project and namespace constraints of the allocations made
+// through Manager.ForOwner. Allocation tokens are opaque, the project and domain they're allocated for can't be told
+// from them. It also expires leaked tokens and optionally grants waiting allocations in order. Its state is neither
+// persisted nor shared between processes, so it's meant for unit tests and single node deployments.
+package resourcemanager
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/utils/clock"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+)
+
+// Reasons allocations are rejected for, as reported by the metrics.
+const (
+	reasonQuota     = "quota"
+	reasonProject   = "project"
+	reasonNamespace = "namespace"
+	reasonRate      = "rate"
+	reasonQueued    = "queued"
+)
+
+// Owner identifies the project and the domain tokens are allocated for. Project scope constraints apply to the tokens
+// of the project, namespace scope constraints to the tokens of the project and domain.
+type Owner struct {
+	Project string
+	Domain  string
+}
+
+// OwnerFromTaskExecutionMetadata returns the owner of the tokens allocated for the task execution.
+func OwnerFromTaskExecutionMetadata(metadata core.TaskExecutionMetadata) Owner {
+	id := metadata.GetTaskExecutionID().GetID()
+	executionID := id.GetNodeExecutionId().GetExecutionId()
+	return Owner{
+		Project: executionID.GetProject(),
+		Domain:  executionID.GetDomain(),
+	}
+}
+
+type allocation struct {
+	owner     Owner
+	weight    int64
+	expiresAt time.Time
+}
+
+// grant records when a token was allocated, for rates to apply.
+type grant struct {
+	at     time.Time
+	weight int64
+}
+
+type waiter struct {
+	owner       Owner
+	constraints core.ResourceConstraintsSpec
+	// seq orders waiters by when they first requested an allocation.
+	seq       uint64
+	since     time.Time
+	expiresAt time.Time
+}
+
+// pool holds the tokens allocated in, and the allocations waiting for, a resource namespace. Quotas, rates and
+// constraints apply to the weights of the tokens.
+type pool struct {
+	quota       int64
+	rate        *core.ResourceRate
+	grants      []grant
+	allocations map[string]allocation
+	waiters     map[string]*waiter
+}
+
+func newPool() *pool {
+	return &pool{
+		quota:       math.MaxInt64,
+		allocations: map[string]allocation{},
+		waiters:     map[string]*waiter{},
+	}
+}
+
+func (p *pool) weight(match func(Owner) bool) int64 {
+	weight := int64(0)
+	for _, a := range p.allocations {
+		if match(a.owner) {
+			weight += a.weight
+		}
+	}
+
+	return weight
+}
+
+func (p *pool) projectWeight(project string) int64 {
+	return p.weight(func(o Owner) bool { return o.Project == project })
+}
+
+// available returns the weight that can be allocated now, as allowed by the quota and the rate.
+func (p *pool) available() int64 {
+	available := p.quota - p.weight(func(Owner) bool { return true })
+	if p.rate != nil {
+		granted := int64(0)
+		for _, g := range p.grants {
+			granted += g.weight
+		}
+
+		if p.rate.Value-granted < available {
+			available = p.rate.Value - granted
+		}
+	}
+
+	return available
+}
+
+// rejection returns the reason an allocation for owner, with the constraints, can't be granted now, or an empty
+// string if it can. Project and namespace constraints don't apply to the allocations without an owner.
+func (p *pool) rejection(owner Owner, constraints core.ResourceConstraintsSpec) string {
+	weight := constraints.GetWeight()
+	if p.quota-p.weight(func(Owner) bool { return true }) < weight {
+		return reasonQuota
+	}
+
+	if p.available() < weight {
+		return reasonRate
+	}
+
+	if owner == (Owner{}) {
+		return ""
+	}
+
+	if c := constraints.ProjectScopeResourceConstraint; c != nil && p.projectWeight(owner.Project)+weight > c.Value {
+		return reasonProject
+	}
+
+	if c := constraints.NamespaceScopeResourceConstraint; c != nil &&
+		p.weight(func(o Owner) bool { return o == owner })+weight > c.Value {
+		return reasonNamespace
+	}
+
+	return ""
+}
+
+func (p *pool) before(a, b *waiter, ordering Ordering) bool {
+	if ordering == OrderingFairShare {
+		if weightA, weightB := p.projectWeight(a.owner.Project), p.projectWeight(b.owner.Project); weightA != weightB {
+			return weightA < weightB
+		}
+	}
+
+	return a.seq < b.seq
+}
+
+// queuedAhead returns the total weight of the waiters to grant allocations to before w that could be granted one now.
+func (p *pool) queuedAhead(w *waiter, ordering Ordering) int64 {
+	if ordering == OrderingNone {
+		return 0
+	}
+
+	ahead := int64(0)
+	for _, other := range p.waiters {
+		if other != w && p.before(other, w, ordering) && len(p.rejection(other.owner, other.constraints)) == 0 {
+			ahead += other.constraints.GetWeight()
+		}
+	}
+
+	return ahead
+}
+
+type metrics struct {
+	allocated *prometheus.GaugeVec
+	waiting   *prometheus.GaugeVec
+	granted   *prometheus.CounterVec
+	rejected  *prometheus.CounterVec
+	expired   *prometheus.CounterVec
+	waitTime  *prometheus.SummaryVec
+}
+
+func newMetrics(scope promutils.Scope) metrics {
+	return metrics{
+		allocated: scope.MustNewGaugeVec("allocated_tokens", "Number of tokens allocated per resource namespace.",
+			"namespace"),
+		waiting: scope.MustNewGaugeVec("waiting_allocations",
+			"Number of allocations waiting to be granted per resource namespace.", "namespace"),
+		granted: scope.MustNewCounterVec("allocations_granted", "Number of allocations granted per resource namespace.",
+			"namespace"),
+		rejected: scope.MustNewCounterVec("allocations_rejected",
+			"Number of allocations rejected per resource namespace and reason.", "namespace", "reason"),
+		expired: scope.MustNewCounterVec("tokens_expired",
+			"Number of leaked tokens released once expired per resource namespace.", "namespace"),
+		waitTime: scope.MustNewSummaryVec("allocation_wait_time_ms",
+			"Time allocations waited before being granted, in milliseconds.", "namespace"),
+	}
+}
+
+// Manager is an in-memory ResourceManager, ResourceRegistrar and ResourceRateRegistrar. Allocating in a resource
+// namespace without a quota or a rate registered fails.
+type Manager struct {
+	cfg     Config
+	clock   clock.PassiveClock
+	metrics metrics
+
+	m     sync.Mutex
+	seq   uint64
+	pools map[core.ResourceNamespace]*pool
+}
+
+func (m *Manager) GetID() string {
+	return "memory"
+}
+
+// RegisterResourceQuota sets the maximum total weight of the tokens allocated in the namespace at once.
+func (m *Manager) RegisterResourceQuota(ctx context.Context, namespace core.ResourceNamespace, quota int) error {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	m.registeredPool(namespace).quota = int64(quota)
+	logger.Infof(ctx, "Registered quota [%v] for resource namespace [%v]", quota, namespace)
+	return nil
+}
+
+// RegisterResourceRate sets the maximum total weight of the tokens allocated in the namespace within any window of
+// time.
+func (m *Manager) RegisterResourceRate(ctx context.Context, namespace core.ResourceNamespace,
+	rate core.ResourceRate) error {
+	if rate.Window <= 0 {
+		return errors.Errorf(errors.ResourceManagerFailure, "invalid window [%v] for resource namespace [%v]",
+			rate.Window, namespace)
+	}
+
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	m.registeredPool(namespace).rate = &rate
+	logger.Infof(ctx, "Registered rate [%v per %v] for resource namespace [%v]", rate.Value, rate.Window, namespace)
+	return nil
+}
+
+func (m *Manager) registeredPool(namespace core.ResourceNamespace) *pool {
+	p, found := m.pools[namespace]
+	if !found {
+		p = newPool()
+		m.pools[namespace] = p
+	}
+
+	return p
+}
+
+// AllocateResource allocates a token that belongs to no project. Only the quota and the rate of the namespace apply to
+// it, the project and namespace constraints are ignored. Use ForOwner for them to apply.
+func (m *Manager) AllocateResource(ctx context.Context, namespace core.ResourceNamespace, allocationToken string,
+	constraintsSpec core.ResourceConstraintsSpec) (core.AllocationStatus, error) {
+	return m.allocate(ctx, Owner{}, namespace, allocationToken, constraintsSpec)
+}
+
+func (m *Manager) ReleaseResource(ctx context.Context, namespace core.ResourceNamespace, allocationToken string) error {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	p, err := m.pool(namespace)
+	if err != nil {
+		return err
+	}
+
+	delete(p.allocations, allocationToken)
+	delete(p.waiters, allocationToken)
+	m.updateGauges(namespace, p)
+	logger.Debugf(ctx, "Released token [%v] in resource namespace [%v]", allocationToken, namespace)
+	return nil
+}
+
+// ForOwner returns a ResourceManager allocating tokens for the owner, sharing the quotas and the tokens of m. The
+// project and namespace constraints of its allocations apply to the tokens of the owner, see
+// OwnerFromTaskExecutionMetadata.
+func (m *Manager) ForOwner(owner Owner) core.ResourceManager {
+	return ownerResourceManager{Manager: m, owner: owner}
+}
+
+// Registered returns true if a quota or a rate is registered for the namespace.
+func (m *Manager) Registered(namespace core.ResourceNamespace) bool {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	_, found := m.pools[namespace]
+	return found
+}
+
+// Allocations returns the tokens currently allocated in the namespace, sorted.
+func (m *Manager) Allocations(namespace core.ResourceNamespace) []string {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	p, found := m.pools[namespace]
+	if !found {
+		return nil
+	}
+
+	tokens := make([]string, 0, len(p.allocations))
+	for token := range p.allocations {
+		tokens = append(tokens, token)
+	}
+
+	sort.Strings(tokens)
+	return tokens
+}
+
+func (m *Manager) pool(namespace core.ResourceNamespace) (*pool, error) {
+	p, found := m.pools[namespace]
+	if !found {
+		return nil, errors.Errorf(errors.ResourceManagerFailure,
+			"no quota nor rate registered for resource namespace [%v]", namespace)
+	}
+
+	return p, nil
+}
+
+func (m *Manager) allocate(ctx context.Context, owner Owner, namespace core.ResourceNamespace, token string,
+	constraints core.ResourceConstraintsSpec) (core.AllocationStatus, error) {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	p, err := m.pool(namespace)
+	if err != nil {
+		return core.AllocationUndefined, err
+	}
+
+	now := m.clock.Now()
+	m.expire(ctx, namespace, p, now)
+	if a, allocated := p.allocations[token]; allocated {
+		a.expiresAt = now.Add(m.cfg.TokenTTL.Duration)
+		p.allocations[token] = a
+		return core.AllocationStatusGranted, nil
+	}
+
+	w, waiting := p.waiters[token]
+	if !waiting {
+		m.seq++
+		w = &waiter{seq: m.seq, since: now}
+		p.waiters[token] = w
+	}
+
+	w.owner, w.constraints, w.expiresAt = owner, constraints, now.Add(m.cfg.WaiterTTL.Duration)
+	reason := p.rejection(owner, constraints)
+	if len(reason) == 0 && p.queuedAhead(w, m.cfg.Ordering)+constraints.GetWeight() > p.available() {
+		reason = reasonQueued
+	}
+
+	if len(reason) > 0 {
+		m.metrics.rejected.WithLabelValues(string(namespace), reason).Inc()
+		m.updateGauges(namespace, p)
+		logger.Debugf(ctx, "Rejected allocation of token [%v] in resource namespace [%v], reason [%v]", token,
+			namespace, reason)
+		return core.AllocationStatusExhausted, nil
+	}
+
+	delete(p.waiters, token)
+	p.allocations[token] = allocation{
+		owner:     owner,
+		weight:    constraints.GetWeight(),
+		expiresAt: now.Add(m.cfg.TokenTTL.Duration),
+	}
+	if p.rate != nil {
+		p.grants = append(p.grants, grant{at: now, weight: constraints.GetWeight()})
+	}
+
+	m.metrics.granted.WithLabelValues(string(namespace)).Inc()
+	m.metrics.waitTime.WithLabelValues(string(namespace)).Observe(float64(now.Sub(w.since).Milliseconds()))
+	m.updateGauges(namespace, p)
+	return core.AllocationStatusGranted, nil
+}
+
+// expire releases the tokens that weren't allocated again nor released in time, drops the waiters that stopped
+// retrying and forgets the grants that left the rate window.
+func (m *Manager) expire(ctx context.Context, namespace core.ResourceNamespace, p *pool, now time.Time) {
+	if p.rate != nil {
+		recent := p.grants[:0]
+		for _, g := range p.grants {
+			if now.Sub(g.at) < p.rate.Window {
+				recent = append(recent, g)
+			}
+		}
+
+		p.grants = recent
+	}
+
+	for token, a := range p.allocations {
+		if !now.Before(a.expiresAt) {
+			logger.Warnf(ctx, "Releasing leaked token [%v] in resource namespace [%v]", token, namespace)
+			delete(p.allocations, token)
+			m.metrics.expired.WithLabelValues(string(namespace)).Inc()
+		}
+	}
+
+	for token, w := range p.waiters {
+		if !now.Before(w.expiresAt) {
+			delete(p.waiters, token)
+		}
+	}
+}
+
+func (m *Manager) updateGauges(namespace core.ResourceNamespace, p *pool) {
+	m.metrics.allocated.WithLabelValues(string(namespace)).Set(float64(len(p.allocations)))
+	m.metrics.waiting.WithLabelValues(string(namespace)).Set(float64(len(p.waiters)))
+}
+
+type ownerResourceManager struct {
+	*Manager
+	owner Owner
+}
+
+func (r ownerResourceManager) AllocateResource(ctx context.Context, namespace core.ResourceNamespace,
+	allocationToken string, constraintsSpec core.ResourceConstraintsSpec) (core.AllocationStatus, error) {
+	return r.allocate(ctx, r.owner, namespace, allocationToken, constraintsSpec)
+}
+
+// NewManager creates a resource manager without any quota registered.
+func NewManager(cfg *Config, scope promutils.Scope) (*Manager, error) {
+	switch cfg.Ordering {
+	case OrderingNone, OrderingFIFO, OrderingFairShare:
+	default:
+		return nil, errors.Errorf(errors.PluginInitializationFailed, "unknown ordering [%v]", cfg.Ordering)
+	}
+
+	return &Manager{
+		cfg:     *cfg,
+		clock:   clock.RealClock{},
+		metrics: newMetrics(scope),
+		pools:   map[core.ResourceNamespace]*pool{},
+	}, nil
+}
@@ -0,0 +1,283 @@
+package resourcemanager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/config"
+	stdErrors "github.com/flyteorg/flytestdlib/errors"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	testclock "k8s.io/utils/clock/testing"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+)
+
+const namespace = core.ResourceNamespace("cluster")
+
+func newTestManager(t *testing.T, ordering Ordering, quota int) (*Manager, *testclock.FakePassiveClock) {
+	m, err := NewManager(&Config{
+		TokenTTL:  config.Duration{Duration: time.Hour},
+		WaiterTTL: config.Duration{Duration: time.Minute},
+		Ordering:  ordering,
+	}, promutils.NewTestScope())
+	assert.NoError(t, err)
+
+	fakeClock := testclock.NewFakePassiveClock(time.Now())
+	m.clock = fakeClock
+	assert.NoError(t, m.RegisterResourceQuota(context.TODO(), namespace, quota))
+	return m, fakeClock
+}
+
+func assertAllocation(t *testing.T, rm core.ResourceManager, token string, constraints core.ResourceConstraintsSpec,
+	expected core.AllocationStatus) {
+	t.Helper()
+	status, err := rm.AllocateResource(context.TODO(), namespace, token, constraints)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, status, token)
+}
+
+func TestManager_Quota(t *testing.T) {
+	ctx := context.TODO()
+	m, _ := newTestManager(t, OrderingNone, 2)
+	none := core.ResourceConstraintsSpec{}
+
+	assertAllocation(t, m, "a", none, core.AllocationStatusGranted)
+	assertAllocation(t, m, "b", none, core.AllocationStatusGranted)
+	assertAllocation(t, m, "a", none, core.AllocationStatusGranted)
+	assertAllocation(t, m, "c", none, core.AllocationStatusExhausted)
+	assert.Equal(t, []string{"a", "b"}, m.Allocations(namespace))
+
+	assert.NoError(t, m.ReleaseResource(ctx, namespace, "a"))
+	assert.NoError(t, m.ReleaseResource(ctx, namespace, "unknown"))
+	assertAllocation(t, m, "c", none, core.AllocationStatusGranted)
+	assert.Equal(t, []string{"b", "c"}, m.Allocations(namespace))
+
+	assert.Equal(t, float64(3), testutil.ToFloat64(m.metrics.granted.WithLabelValues(string(namespace))))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.metrics.rejected.WithLabelValues(string(namespace), reasonQuota)))
+	assert.Equal(t, float64(2), testutil.ToFloat64(m.metrics.allocated.WithLabelValues(string(namespace))))
+	assert.Equal(t, float64(0), testutil.ToFloat64(m.metrics.waiting.WithLabelValues(string(namespace))))
+
+	t.Run("unregistered namespace", func(t *testing.T) {
+		_, err := m.AllocateResource(ctx, "unknown", "a", none)
+		assert.True(t, stdErrors.IsCausedBy(err, errors.ResourceManagerFailure))
+		assert.True(t, stdErrors.IsCausedBy(m.ReleaseResource(ctx, "unknown", "a"), errors.ResourceManagerFailure))
+		assert.Nil(t, m.Allocations("unknown"))
+		assert.False(t, m.Registered("unknown"))
+		assert.True(t, m.Registered(namespace))
+	})
+}
+
+func TestManager_Constraints(t *testing.T) {
+	m, _ := newTestManager(t, OrderingNone, 10)
+	constraints := core.ResourceConstraintsSpec{
+		ProjectScopeResourceConstraint:   &core.ResourceConstraint{Value: 3},
+		NamespaceScopeResourceConstraint: &core.ResourceConstraint{Value: 2},
+	}
+	development := m.ForOwner(Owner{Project: "flytesnacks", Domain: "development"})
+	production := m.ForOwner(Owner{Project: "flytesnacks", Domain: "production"})
+	other := m.ForOwner(Owner{Project: "flyteexamples", Domain: "development"})
+
+	assertAllocation(t, development, "d1", constraints, core.AllocationStatusGranted)
+	assertAllocation(t, development, "d2", constraints, core.AllocationStatusGranted)
+	assertAllocation(t, development, "d3", constraints, core.AllocationStatusExhausted)
+	assertAllocation(t, production, "p1", constraints, core.AllocationStatusGranted)
+	assertAllocation(t, production, "p2", constraints, core.AllocationStatusExhausted)
+	assertAllocation(t, other, "o1", constraints, core.AllocationStatusGranted)
+	assertAllocation(t, development, "d3", core.ResourceConstraintsSpec{}, core.AllocationStatusGranted)
+
+	rejected := func(reason string) float64 {
+		return testutil.ToFloat64(m.metrics.rejected.WithLabelValues(string(namespace), reason))
+	}
+	assert.Equal(t, float64(1), rejected(reasonNamespace))
+	assert.Equal(t, float64(1), rejected(reasonProject))
+
+	t.Run("from task execution metadata", func(t *testing.T) {
+		tID := &mocks.TaskExecutionID{}
+		tID.OnGetID().Return(idlCore.TaskExecutionIdentifier{
+			NodeExecutionId: &idlCore.NodeExecutionIdentifier{
+				ExecutionId: &idlCore.WorkflowExecutionIdentifier{Project: "flytesnacks", Domain: "development"},
+			},
+		})
+		tMeta := &mocks.TaskExecutionMetadata{}
+		tMeta.OnGetTaskExecutionID().Return(tID)
+
+		owner := OwnerFromTaskExecutionMetadata(tMeta)
+		assert.Equal(t, Owner{Project: "flytesnacks", Domain: "development"}, owner)
+		assertAllocation(t, m.ForOwner(owner), "d4", constraints, core.AllocationStatusExhausted)
+	})
+
+	t.Run("without an owner", func(t *testing.T) {
+		// Only the quota applies, the tokens can't be told apart by project.
+		for _, token := range []string{"n1", "n2", "n3", "n4", "n5"} {
+			assertAllocation(t, m, token, constraints, core.AllocationStatusGranted)
+		}
+
+		assertAllocation(t, m, "n6", constraints, core.AllocationStatusExhausted)
+		assert.Equal(t, float64(1), rejected(reasonQuota))
+	})
+}
+
+func TestManager_Expiry(t *testing.T) {
+	m, fakeClock := newTestManager(t, OrderingNone, 2)
+	none := core.ResourceConstraintsSpec{}
+
+	assertAllocation(t, m, "leaked", none, core.AllocationStatusGranted)
+	assertAllocation(t, m, "extended", none, core.AllocationStatusGranted)
+	fakeClock.SetTime(fakeClock.Now().Add(30 * time.Minute))
+	assertAllocation(t, m, "extended", none, core.AllocationStatusGranted)
+	assertAllocation(t, m, "waiting", none, core.AllocationStatusExhausted)
+
+	fakeClock.SetTime(fakeClock.Now().Add(30 * time.Minute))
+	assertAllocation(t, m, "waiting", none, core.AllocationStatusGranted)
+	assert.Equal(t, []string{"extended", "waiting"}, m.Allocations(namespace))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.metrics.expired.WithLabelValues(string(namespace))))
+}
+
+func TestManager_Ordering(t *testing.T) {
+	ctx := context.TODO()
+	none := core.ResourceConstraintsSpec{}
+
+	t.Run("none", func(t *testing.T) {
+		m, _ := newTestManager(t, OrderingNone, 1)
+		assertAllocation(t, m, "a", none, core.AllocationStatusGranted)
+		assertAllocation(t, m, "b", none, core.AllocationStatusExhausted)
+		assertAllocation(t, m, "c", none, core.AllocationStatusExhausted)
+
+		assert.NoError(t, m.ReleaseResource(ctx, namespace, "a"))
+		assertAllocation(t, m, "c", none, core.AllocationStatusGranted)
+	})
+
+	t.Run("fifo", func(t *testing.T) {
+		m, _ := newTestManager(t, OrderingFIFO, 1)
+		assertAllocation(t, m, "a", none, core.AllocationStatusGranted)
+		assertAllocation(t, m, "b", none, core.AllocationStatusExhausted)
+		assertAllocation(t, m, "c", none, core.AllocationStatusExhausted)
+		assert.Equal(t, float64(2), testutil.ToFloat64(m.metrics.waiting.WithLabelValues(string(namespace))))
+
+		assert.NoError(t, m.ReleaseResource(ctx, namespace, "a"))
+		assertAllocation(t, m, "c", none, core.AllocationStatusExhausted)
+		assertAllocation(t, m, "b", none, core.AllocationStatusGranted)
+		assert.Equal(t, float64(1), testutil.ToFloat64(m.metrics.rejected.WithLabelValues(string(namespace), reasonQueued)))
+
+		assert.NoError(t, m.ReleaseResource(ctx, namespace, "b"))
+		assertAllocation(t, m, "c", none, core.AllocationStatusGranted)
+	})
+
+	t.Run("fifo skips waiters that can't be granted", func(t *testing.T) {
+		m, _ := newTestManager(t, OrderingFIFO, 2)
+		limited := core.ResourceConstraintsSpec{ProjectScopeResourceConstraint: &core.ResourceConstraint{Value: 1}}
+		flytesnacks := m.ForOwner(Owner{Project: "flytesnacks"})
+		assertAllocation(t, flytesnacks, "a", limited, core.AllocationStatusGranted)
+		assertAllocation(t, flytesnacks, "b", limited, core.AllocationStatusExhausted)
+		assertAllocation(t, m, "c", none, core.AllocationStatusGranted)
+	})
+
+	t.Run("fifo forgets waiters that stopped retrying", func(t *testing.T) {
+		m, fakeClock := newTestManager(t, OrderingFIFO, 1)
+		assertAllocation(t, m, "a", none, core.AllocationStatusGranted)
+		assertAllocation(t, m, "b", none, core.AllocationStatusExhausted)
+		assertAllocation(t, m, "c", none, core.AllocationStatusExhausted)
+
+		assert.NoError(t, m.ReleaseResource(ctx, namespace, "a"))
+		fakeClock.SetTime(fakeClock.Now().Add(time.Minute))
+		assertAllocation(t, m, "c", none, core.AllocationStatusGranted)
+	})
+
+	t.Run("fair share", func(t *testing.T) {
+		m, _ := newTestManager(t, OrderingFairShare, 2)
+		flytesnacks := m.ForOwner(Owner{Project: "flytesnacks"})
+		flyteexamples := m.ForOwner(Owner{Project: "flyteexamples"})
+		assertAllocation(t, flytesnacks, "a", none, core.AllocationStatusGranted)
+		assertAllocation(t, flytesnacks, "b", none, core.AllocationStatusGranted)
+		assertAllocation(t, flytesnacks, "c", none, core.AllocationStatusExhausted)
+		assertAllocation(t, flyteexamples, "d", none, core.AllocationStatusExhausted)
+
+		assert.NoError(t, m.ReleaseResource(ctx, namespace, "a"))
+		assertAllocation(t, flytesnacks, "c", none, core.AllocationStatusExhausted)
+		assertAllocation(t, flyteexamples, "d", none, core.AllocationStatusGranted)
+
+		assert.NoError(t, m.ReleaseResource(ctx, namespace, "b"))
+		assertAllocation(t, flytesnacks, "c", none, core.AllocationStatusGranted)
+	})
+}
+
+func TestManager_Weights(t *testing.T) {
+	ctx := context.TODO()
+	m, _ := newTestManager(t, OrderingFIFO, 10)
+	constraints := func(weight int64) core.ResourceConstraintsSpec {
+		return core.ResourceConstraintsSpec{
+			ProjectScopeResourceConstraint: &core.ResourceConstraint{Value: 8},
+			Weight:                         weight,
+		}
+	}
+	flytesnacks := m.ForOwner(Owner{Project: "flytesnacks"})
+	flyteexamples := m.ForOwner(Owner{Project: "flyteexamples"})
+
+	assertAllocation(t, flytesnacks, "scan", constraints(6), core.AllocationStatusGranted)
+	assertAllocation(t, flytesnacks, "large", constraints(3), core.AllocationStatusExhausted)
+	// Tokens weigh at least 1.
+	assertAllocation(t, flytesnacks, "lookup", constraints(0), core.AllocationStatusGranted)
+	assertAllocation(t, flyteexamples, "huge", constraints(5), core.AllocationStatusExhausted)
+	assertAllocation(t, flyteexamples, "small", constraints(2), core.AllocationStatusGranted)
+
+	assert.NoError(t, m.ReleaseResource(ctx, namespace, "scan"))
+	assertAllocation(t, flyteexamples, "huge", constraints(5), core.AllocationStatusExhausted)
+	assertAllocation(t, flytesnacks, "large", constraints(3), core.AllocationStatusGranted)
+	assertAllocation(t, flyteexamples, "huge", constraints(5), core.AllocationStatusExhausted)
+	assert.NoError(t, m.ReleaseResource(ctx, namespace, "small"))
+	assertAllocation(t, flyteexamples, "huge", constraints(5), core.AllocationStatusGranted)
+
+	rejected := func(reason string) float64 {
+		return testutil.ToFloat64(m.metrics.rejected.WithLabelValues(string(namespace), reason))
+	}
+	assert.Equal(t, float64(1), rejected(reasonProject))
+	assert.Equal(t, float64(2), rejected(reasonQuota))
+	assert.Equal(t, float64(1), rejected(reasonQueued))
+}
+
+func TestManager_Rate(t *testing.T) {
+	ctx := context.TODO()
+	m, fakeClock := newTestManager(t, OrderingNone, 10)
+	assert.NoError(t, m.RegisterResourceRate(ctx, namespace, core.ResourceRate{Value: 3, Window: time.Second}))
+	assert.NoError(t, m.RegisterResourceRate(ctx, "unlimited", core.ResourceRate{Value: 1, Window: time.Second}))
+	none := core.ResourceConstraintsSpec{}
+
+	assertAllocation(t, m, "a", core.ResourceConstraintsSpec{Weight: 2}, core.AllocationStatusGranted)
+	assertAllocation(t, m, "b", none, core.AllocationStatusGranted)
+	assertAllocation(t, m, "c", none, core.AllocationStatusExhausted)
+	assertAllocation(t, m, "a", none, core.AllocationStatusGranted)
+
+	// Released tokens keep counting until they leave the window.
+	assert.NoError(t, m.ReleaseResource(ctx, namespace, "a"))
+	fakeClock.SetTime(fakeClock.Now().Add(500 * time.Millisecond))
+	assertAllocation(t, m, "c", none, core.AllocationStatusExhausted)
+	fakeClock.SetTime(fakeClock.Now().Add(500 * time.Millisecond))
+	assertAllocation(t, m, "c", none, core.AllocationStatusGranted)
+	assert.Equal(t, float64(2), testutil.ToFloat64(m.metrics.rejected.WithLabelValues(string(namespace), reasonRate)))
+
+	t.Run("rate without quota", func(t *testing.T) {
+		status, err := m.AllocateResource(ctx, "unlimited", "a", none)
+		assert.NoError(t, err)
+		assert.Equal(t, core.AllocationStatusGranted, status)
+	})
+
+	t.Run("invalid window", func(t *testing.T) {
+		err := m.RegisterResourceRate(ctx, namespace, core.ResourceRate{Value: 1})
+		assert.True(t, stdErrors.IsCausedBy(err, errors.ResourceManagerFailure))
+	})
+}
+
+func TestNewManager(t *testing.T) {
+	_, err := NewManager(&Config{Ordering: "random"}, promutils.NewTestScope())
+	assert.True(t, stdErrors.IsCausedBy(err, errors.PluginInitializationFailed))
+
+	m, err := NewManager(GetConfig(), promutils.NewTestScope())
+	assert.NoError(t, err)
+	assert.Equal(t, "memory", m.GetID())
+}
@@ -0,0 +1,77 @@
+package secretmanager
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Backend reads secrets from a secret store. It returns ErrNotFound if the store doesn't have the secret. The field of
+// the reference is selected by the Manager, backends can ignore it.
+type Backend interface {
+	// Name identifies the backend in errors and logs.
+	Name() string
+	Get(ctx context.Context, ref SecretRef) ([]byte, error)
+}
+
+// FileBackend reads secrets mounted as files, at <root>/<group>/<key>, or <root>/<key> for secrets without a group.
+// It doesn't support versions, versioned secrets are looked up in the next backends.
+type FileBackend struct {
+	Root string
+}
+
+func (b FileBackend) Name() string {
+	return "file"
+}
+
+func (b FileBackend) Get(_ context.Context, ref SecretRef) ([]byte, error) {
+	if len(ref.Version) > 0 {
+		return nil, fmt.Errorf("the file backend doesn't support secret versions, got [%v]: %w", ref, ErrNotFound)
+	}
+
+	value, err := os.ReadFile(filepath.Join(b.Root, ref.Group, ref.Key))
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secret [%v]: %w", ref, err)
+	}
+
+	return value, nil
+}
+
+// EnvBackend reads secrets from environment variables named <prefix><GROUP>_<KEY>, or <prefix><KEY> for secrets
+// without a group. It doesn't support versions, versioned secrets are looked up in the next backends.
+type EnvBackend struct {
+	Prefix string
+}
+
+func (b EnvBackend) Name() string {
+	return "env"
+}
+
+// VariableName returns the name of the environment variable holding the secret.
+func (b EnvBackend) VariableName(ref SecretRef) string {
+	name := ref.Key
+	if len(ref.Group) > 0 {
+		name = ref.Group + "_" + name
+	}
+
+	return b.Prefix + strings.ToUpper(name)
+}
+
+func (b EnvBackend) Get(_ context.Context, ref SecretRef) ([]byte, error) {
+	if len(ref.Version) > 0 {
+		return nil, fmt.Errorf("the env backend doesn't support secret versions, got [%v]: %w", ref, ErrNotFound)
+	}
+
+	value, found := os.LookupEnv(b.VariableName(ref))
+	if !found {
+		return nil, ErrNotFound
+	}
+
+	return []byte(value), nil
+}
@@ -0,0 +1,54 @@
+package secretmanager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileBackend(t *testing.T) {
+	ctx := context.TODO()
+	root := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "snowflake"), 0700))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "snowflake", "token"), []byte("grouped"), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "TOKEN"), []byte("plain"), 0600))
+	backend := FileBackend{Root: root}
+
+	value, err := backend.Get(ctx, SecretRef{Group: "snowflake", Key: "token"})
+	assert.NoError(t, err)
+	assert.Equal(t, "grouped", string(value))
+
+	value, err = backend.Get(ctx, SecretRef{Key: "TOKEN"})
+	assert.NoError(t, err)
+	assert.Equal(t, "plain", string(value))
+
+	_, err = backend.Get(ctx, SecretRef{Group: "snowflake", Key: "missing"})
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = backend.Get(ctx, SecretRef{Group: "snowflake", Key: "token", Version: "1"})
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestEnvBackend(t *testing.T) {
+	ctx := context.TODO()
+	t.Setenv("_FSEC_SNOWFLAKE_TOKEN", "grouped")
+	t.Setenv("_FSEC_TOKEN", "plain")
+	backend := EnvBackend{Prefix: "_FSEC_"}
+
+	value, err := backend.Get(ctx, SecretRef{Group: "snowflake", Key: "token"})
+	assert.NoError(t, err)
+	assert.Equal(t, "grouped", string(value))
+
+	value, err = backend.Get(ctx, SecretRef{Key: "token"})
+	assert.NoError(t, err)
+	assert.Equal(t, "plain", string(value))
+
+	_, err = backend.Get(ctx, SecretRef{Key: "missing"})
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = backend.Get(ctx, SecretRef{Key: "token", Version: "1"})
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,69 @@
+package secretmanager
+
+import (
+	"time"
+
+	"github.com/flyteorg/flytestdlib/config"
+
+	pluginsConfig "github.com/flyteorg/flyteplugins/go/tasks/config"
+)
+
+//go:generate pflags Config --default-var=defaultConfig
+
+const (
+	BackendFile  = "file"
+	BackendEnv   = "env"
+	BackendVault = "vault"
+)
+
+var (
+	defaultConfig = &Config{
+		Backends: []string{BackendFile, BackendEnv},
+		File: FileConfig{
+			Root: "/etc/flyte/secrets",
+		},
+		Env: EnvConfig{
+			Prefix: "_FSEC_",
+		},
+		Vault: VaultConfig{
+			Mount:     "secret",
+			TokenPath: "/var/run/secrets/vault/token",
+			Timeout:   config.Duration{Duration: 10 * time.Second},
+		},
+	}
+
+	cfgSection = pluginsConfig.MustRegisterSubSection("secrets", defaultConfig)
+)
+
+// Config configures the backends secrets are read from.
+type Config struct {
+	Backends []string    `json:"backends" pflag:",Backends secrets are looked up in, in order. Supported backends are file, env and vault."`
+	File     FileConfig  `json:"file" pflag:",Configures the file backend."`
+	Env      EnvConfig   `json:"env" pflag:",Configures the env backend."`
+	Vault    VaultConfig `json:"vault" pflag:",Configures the vault backend."`
+}
+
+type FileConfig struct {
+	Root string `json:"root" pflag:",Directory secrets are mounted under, as <root>/<group>/<key>."`
+}
+
+type EnvConfig struct {
+	Prefix string `json:"prefix" pflag:",Prefix of the environment variables holding secrets, named <prefix><GROUP>_<KEY>."`
+}
+
+type VaultConfig struct {
+	Address   string          `json:"address" pflag:",Address of the vault, e.g. https://vault:8200."`
+	Mount     string          `json:"mount" pflag:",Mount path of the KV version 2 secrets engine."`
+	Namespace string          `json:"namespace" pflag:",Vault namespace, if any."`
+	TokenPath string          `json:"tokenPath" pflag:",Path of the file holding the vault token."`
+	Timeout   config.Duration `json:"timeout" pflag:",Timeout of the requests to the vault."`
+}
+
+func GetConfig() *Config {
+	return cfgSection.GetConfig().(*Config)
+}
+
+// SetConfig should be used for unit testing only
+func SetConfig(cfg *Config) error {
+	return cfgSection.SetConfig(cfg)
+}
@@ -0,0 +1,160 @@
+package secretmanager
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"strings"
+
+	flyteErrors "github.com/flyteorg/flytestdlib/errors"
+	"github.com/flyteorg/flytestdlib/logger"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+)
+
+// Manager looks secrets up in its backends, in order, and returns the first one found.
+type Manager struct {
+	backends []Backend
+}
+
+// GetSecret returns the secret, with its field selected if the reference has one. If none of the backends has the
+// secret, the error is caused by SecretNotFound and wraps ErrNotFound.
+func (m Manager) GetSecret(ctx context.Context, ref SecretRef) (string, error) {
+	for _, backend := range m.backends {
+		value, err := backend.Get(ctx, ref)
+		if stdErrors.Is(err, ErrNotFound) {
+			continue
+		}
+
+		if err != nil {
+			return "", errors.Wrapf(errors.DownstreamSystemError, err, "failed to read secret [%v] from the [%v] backend",
+				ref, backend.Name())
+		}
+
+		logger.Debugf(ctx, "Read secret [%v] from the [%v] backend", ref, backend.Name())
+		if len(ref.Field) == 0 {
+			return string(value), nil
+		}
+
+		selected, err := SelectField(value, ref.Field)
+		if err != nil {
+			return "", errors.Wrapf(errors.SecretNotFound, err, "failed to select the field of secret [%v]", ref)
+		}
+
+		return selected, nil
+	}
+
+	names := make([]string, 0, len(m.backends))
+	for _, backend := range m.backends {
+		names = append(names, backend.Name())
+	}
+
+	return "", errors.Wrapf(errors.SecretNotFound, ErrNotFound, "secret [%v] not found in backends %v", ref, names)
+}
+
+// Get parses the key as a secret reference and returns the secret, it implements core.SecretManager.
+func (m Manager) Get(ctx context.Context, key string) (string, error) {
+	ref, err := ParseSecretRef(key)
+	if err != nil {
+		return "", errors.Wrapf(errors.SecretNotFound, err, "invalid secret reference [%v]", key)
+	}
+
+	return m.GetSecret(ctx, ref)
+}
+
+// Wrap returns a secret manager reading structured references, i.e. references with a group, a version or a field,
+// from the backends of m. Plain keys are read from secretManager first, as plugins did so far, and from the backends
+// of m if secretManager doesn't have them.
+func (m Manager) Wrap(secretManager core.SecretManager) core.SecretManager {
+	return wrappedManager{manager: m, secretManager: secretManager}
+}
+
+type wrappedManager struct {
+	manager       Manager
+	secretManager core.SecretManager
+}
+
+func (w wrappedManager) Get(ctx context.Context, key string) (string, error) {
+	ref, err := ParseSecretRef(key)
+	if err != nil {
+		return "", errors.Wrapf(errors.SecretNotFound, err, "invalid secret reference [%v]", key)
+	}
+
+	if ref != (SecretRef{Key: ref.Key}) || w.secretManager == nil {
+		return w.manager.GetSecret(ctx, ref)
+	}
+
+	value, err := w.secretManager.Get(ctx, key)
+	if err == nil {
+		return value, nil
+	}
+
+	logger.Debugf(ctx, "Secret [%v] not read from the secret manager of the task, trying the backends. Error: %v", key,
+		err)
+	value, backendsErr := w.manager.GetSecret(ctx, ref)
+	if backendsErr != nil {
+		if stdErrors.Is(backendsErr, ErrNotFound) {
+			// The secret manager of the task is the one plain keys are expected in, its error is more telling.
+			return "", err
+		}
+
+		return "", backendsErr
+	}
+
+	return value, nil
+}
+
+// NewManager creates a manager looking secrets up in the backends, in order.
+func NewManager(backends ...Backend) Manager {
+	return Manager{backends: backends}
+}
+
+// NewManagerFromConfig creates a manager with the configured backends.
+func NewManagerFromConfig(cfg *Config) (Manager, error) {
+	backends := make([]Backend, 0, len(cfg.Backends))
+	for _, name := range cfg.Backends {
+		switch name {
+		case BackendFile:
+			backends = append(backends, FileBackend{Root: cfg.File.Root})
+		case BackendEnv:
+			backends = append(backends, EnvBackend{Prefix: cfg.Env.Prefix})
+		case BackendVault:
+			vault, err := NewVaultBackend(cfg.Vault)
+			if err != nil {
+				return Manager{}, errors.Wrapf(errors.PluginInitializationFailed, err, "failed to create the vault backend")
+			}
+
+			backends = append(backends, vault)
+		default:
+			return Manager{}, errors.Errorf(errors.PluginInitializationFailed, "unknown secret backend [%v]", name)
+		}
+	}
+
+	return NewManager(backends...), nil
+}
+
+// Require checks all the secrets are available from the secret manager, so that tasks fail fast instead of failing
+// after some of their remote calls. If some are missing, the error is caused by SecretNotFound and lists them all.
+// Other failures, e.g. a backend being unreachable, are returned as is.
+func Require(ctx context.Context, secretManager core.SecretManager, keys ...string) error {
+	var missing []string
+	for _, key := range keys {
+		_, err := secretManager.Get(ctx, key)
+		if err == nil {
+			continue
+		}
+
+		if code, found := flyteErrors.GetErrorCode(err); found && code != errors.SecretNotFound {
+			return err
+		}
+
+		missing = append(missing, fmt.Sprintf("%v (%v)", key, err))
+	}
+
+	if len(missing) > 0 {
+		return errors.Errorf(errors.SecretNotFound, "missing required secrets: %v", strings.Join(missing, "; "))
+	}
+
+	return nil
+}
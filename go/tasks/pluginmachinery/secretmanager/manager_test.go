@@ -0,0 +1,172 @@
+package secretmanager
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	stdErrors "github.com/flyteorg/flytestdlib/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+)
+
+func TestManager(t *testing.T) {
+	ctx := context.TODO()
+	root := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "snowflake"), 0700))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "snowflake", "token"), []byte("from-file"), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "snowflake", "credentials"), []byte(`{"user": "flyte"}`), 0600))
+	t.Setenv("_FSEC_SNOWFLAKE_TOKEN", "from-env")
+	t.Setenv("_FSEC_SNOWFLAKE_ACCOUNT", "from-env")
+	_, vaultCfg := newFakeVault(t, "root")
+
+	cfg := &Config{
+		Backends: []string{BackendFile, BackendEnv, BackendVault},
+		File:     FileConfig{Root: root},
+		Env:      EnvConfig{Prefix: "_FSEC_"},
+		Vault:    vaultCfg,
+	}
+
+	m, err := NewManagerFromConfig(cfg)
+	assert.NoError(t, err)
+
+	t.Run("first backend wins", func(t *testing.T) {
+		value, err := m.Get(ctx, "snowflake/token")
+		assert.NoError(t, err)
+		assert.Equal(t, "from-file", value)
+
+		value, err = m.Get(ctx, "snowflake/account")
+		assert.NoError(t, err)
+		assert.Equal(t, "from-env", value)
+	})
+
+	t.Run("version", func(t *testing.T) {
+		value, err := m.Get(ctx, "snowflake/token@1")
+		assert.NoError(t, err)
+		assert.Equal(t, "v1", value)
+	})
+
+	t.Run("field", func(t *testing.T) {
+		value, err := m.Get(ctx, "snowflake/credentials#user")
+		assert.NoError(t, err)
+		assert.Equal(t, "flyte", value)
+
+		value, err = m.Get(ctx, "databricks/api#auth.token")
+		assert.NoError(t, err)
+		assert.Equal(t, "abc", value)
+
+		_, err = m.Get(ctx, "databricks/api#password")
+		code, _ := stdErrors.GetErrorCode(err)
+		assert.Equal(t, errors.SecretNotFound, code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := m.Get(ctx, "snowflake/missing")
+		code, _ := stdErrors.GetErrorCode(err)
+		assert.Equal(t, errors.SecretNotFound, code)
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.Contains(t, err.Error(), "[file env vault]")
+	})
+
+	t.Run("invalid reference", func(t *testing.T) {
+		_, err := m.Get(ctx, "a/b/c")
+		code, _ := stdErrors.GetErrorCode(err)
+		assert.Equal(t, errors.SecretNotFound, code)
+	})
+
+	t.Run("backend failure", func(t *testing.T) {
+		m := NewManager(VaultBackend{address: "http://127.0.0.1:1", mount: "secret", client: &http.Client{}}, EnvBackend{})
+		_, err := m.Get(ctx, "TOKEN")
+		code, _ := stdErrors.GetErrorCode(err)
+		assert.Equal(t, errors.DownstreamSystemError, code)
+	})
+}
+
+func TestManager_Wrap(t *testing.T) {
+	ctx := context.TODO()
+	t.Setenv("_FSEC_SNOWFLAKE_TOKEN", "from-env")
+	t.Setenv("_FSEC_TOKEN", "from-env")
+	sm := &mocks.SecretManager{}
+	sm.OnGetMatch(ctx, "TOKEN").Return("from-task", nil)
+	sm.OnGetMatch(ctx, "OTHER").Return("", fmt.Errorf("no such file"))
+	sm.OnGetMatch(ctx, "token").Return("", fmt.Errorf("no such file"))
+	wrapped := NewManager(EnvBackend{Prefix: "_FSEC_"}).Wrap(sm)
+
+	t.Run("structured reference", func(t *testing.T) {
+		value, err := wrapped.Get(ctx, "snowflake/token")
+		assert.NoError(t, err)
+		assert.Equal(t, "from-env", value)
+		sm.AssertNotCalled(t, "Get", ctx, "snowflake/token")
+	})
+
+	t.Run("plain key", func(t *testing.T) {
+		value, err := wrapped.Get(ctx, "TOKEN")
+		assert.NoError(t, err)
+		assert.Equal(t, "from-task", value)
+	})
+
+	t.Run("plain key from the backends", func(t *testing.T) {
+		value, err := wrapped.Get(ctx, "token")
+		assert.NoError(t, err)
+		assert.Equal(t, "from-env", value)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := wrapped.Get(ctx, "OTHER")
+		assert.EqualError(t, err, "no such file")
+
+		_, err = wrapped.Get(ctx, "snowflake/missing")
+		code, _ := stdErrors.GetErrorCode(err)
+		assert.Equal(t, errors.SecretNotFound, code)
+	})
+}
+
+func TestNewManagerFromConfig(t *testing.T) {
+	_, err := NewManagerFromConfig(&Config{Backends: []string{"unknown"}})
+	code, _ := stdErrors.GetErrorCode(err)
+	assert.Equal(t, errors.PluginInitializationFailed, code)
+
+	_, err = NewManagerFromConfig(&Config{Backends: []string{BackendVault}})
+	code, _ = stdErrors.GetErrorCode(err)
+	assert.Equal(t, errors.PluginInitializationFailed, code)
+
+	m, err := NewManagerFromConfig(GetConfig())
+	assert.NoError(t, err)
+	assert.Len(t, m.backends, 2)
+}
+
+func TestRequire(t *testing.T) {
+	ctx := context.TODO()
+	t.Setenv("_FSEC_SNOWFLAKE_TOKEN", "token")
+	m := NewManager(EnvBackend{Prefix: "_FSEC_"})
+
+	assert.NoError(t, Require(ctx, m, "snowflake/token"))
+	assert.NoError(t, Require(ctx, m))
+
+	err := Require(ctx, m, "snowflake/token", "snowflake/user", "snowflake/password")
+	code, _ := stdErrors.GetErrorCode(err)
+	assert.Equal(t, errors.SecretNotFound, code)
+	assert.Contains(t, err.Error(), "snowflake/user")
+	assert.Contains(t, err.Error(), "snowflake/password")
+
+	t.Run("other secret managers", func(t *testing.T) {
+		sm := &mocks.SecretManager{}
+		sm.OnGetMatch(ctx, "token").Return("", fmt.Errorf("no such file"))
+		err := Require(ctx, sm, "token")
+		code, _ := stdErrors.GetErrorCode(err)
+		assert.Equal(t, errors.SecretNotFound, code)
+		assert.Contains(t, err.Error(), "no such file")
+	})
+
+	t.Run("backend failure", func(t *testing.T) {
+		m := NewManager(VaultBackend{address: "http://127.0.0.1:1", mount: "secret", client: &http.Client{}})
+		err := Require(ctx, m, "token")
+		code, _ := stdErrors.GetErrorCode(err)
+		assert.Equal(t, errors.DownstreamSystemError, code)
+	})
+}
@@ -0,0 +1,105 @@
+// Package secretmanager implements pluginmachinery/core.SecretManager on top of pluggable backends: mounted files,
+// environment variables and a Vault compatible HTTP API.
+//
+// Secrets are referenced as [group/]key[@version][#field]. The group and version are optional, backends that don't
+// support versions refuse versioned references. The field selects a value from a secret holding a JSON object, nested
+// fields are separated by dots, e.g. "snowflake/credentials#token" or "databricks/api@3#auth.token". References
+// without a group, version nor field are plain keys, as used by plugins so far.
+package secretmanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ErrNotFound is returned by backends that don't have the secret, so that the next backend is tried.
+var ErrNotFound = fmt.Errorf("secret not found")
+
+// SecretRef references a secret, see the package documentation for its string representation.
+type SecretRef struct {
+	Group   string
+	Key     string
+	Version string
+	Field   string
+}
+
+// ParseSecretRef parses a secret reference of the form [group/]key[@version][#field].
+func ParseSecretRef(s string) (SecretRef, error) {
+	ref := SecretRef{}
+	if i := strings.Index(s, "#"); i >= 0 {
+		s, ref.Field = s[:i], s[i+1:]
+		if len(ref.Field) == 0 {
+			return SecretRef{}, fmt.Errorf("secret reference [%v] has an empty field", s)
+		}
+	}
+
+	if i := strings.LastIndex(s, "@"); i >= 0 {
+		s, ref.Version = s[:i], s[i+1:]
+		if len(ref.Version) == 0 {
+			return SecretRef{}, fmt.Errorf("secret reference [%v] has an empty version", s)
+		}
+	}
+
+	if i := strings.Index(s, "/"); i >= 0 {
+		ref.Group, s = s[:i], s[i+1:]
+		if len(ref.Group) == 0 {
+			return SecretRef{}, fmt.Errorf("secret reference [%v] has an empty group", s)
+		}
+	}
+
+	if len(s) == 0 || strings.Contains(s, "/") {
+		return SecretRef{}, fmt.Errorf("secret reference [%v] must have a single key", s)
+	}
+
+	ref.Key = s
+	return ref, nil
+}
+
+func (r SecretRef) String() string {
+	s := r.Key
+	if len(r.Group) > 0 {
+		s = r.Group + "/" + s
+	}
+
+	if len(r.Version) > 0 {
+		s += "@" + r.Version
+	}
+
+	if len(r.Field) > 0 {
+		s += "#" + r.Field
+	}
+
+	return s
+}
+
+// SelectField returns the field of the JSON object in value. Nested fields are separated by dots. String values are
+// returned as is, other values JSON encoded.
+func SelectField(value []byte, field string) (string, error) {
+	var selected interface{}
+	if err := json.Unmarshal(value, &selected); err != nil {
+		return "", fmt.Errorf("secret isn't a JSON object, can't select field [%v]: %w", field, err)
+	}
+
+	for _, name := range strings.Split(field, ".") {
+		obj, ok := selected.(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf("secret field [%v] isn't in a JSON object", field)
+		}
+
+		if selected, ok = obj[name]; !ok {
+			return "", fmt.Errorf("secret has no field [%v]", field)
+		}
+	}
+
+	if s, ok := selected.(string); ok {
+		return s, nil
+	}
+
+	raw, err := json.Marshal(selected)
+	if err != nil {
+		return "", err
+	}
+
+	return string(raw), nil
+}
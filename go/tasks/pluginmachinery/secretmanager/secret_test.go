@@ -0,0 +1,65 @@
+package secretmanager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSecretRef(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected SecretRef
+	}{
+		{"FLYTE_SNOWFLAKE_CLIENT_TOKEN", SecretRef{Key: "FLYTE_SNOWFLAKE_CLIENT_TOKEN"}},
+		{"snowflake/token", SecretRef{Group: "snowflake", Key: "token"}},
+		{"snowflake/token@3", SecretRef{Group: "snowflake", Key: "token", Version: "3"}},
+		{"databricks/api@3#auth.token", SecretRef{Group: "databricks", Key: "api", Version: "3", Field: "auth.token"}},
+		{"credentials#token", SecretRef{Key: "credentials", Field: "token"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			ref, err := ParseSecretRef(tt.s)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, ref)
+			assert.Equal(t, tt.s, ref.String())
+		})
+	}
+
+	for _, s := range []string{"", "group/", "/key", "a/b/c", "key@", "key#", "group/key@#field"} {
+		t.Run("invalid "+s, func(t *testing.T) {
+			_, err := ParseSecretRef(s)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSelectField(t *testing.T) {
+	value := []byte(`{"user": "flyte", "auth": {"token": "abc", "ttl": 60}}`)
+
+	selected, err := SelectField(value, "user")
+	assert.NoError(t, err)
+	assert.Equal(t, "flyte", selected)
+
+	selected, err = SelectField(value, "auth.token")
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", selected)
+
+	selected, err = SelectField(value, "auth.ttl")
+	assert.NoError(t, err)
+	assert.Equal(t, "60", selected)
+
+	selected, err = SelectField(value, "auth")
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"token": "abc", "ttl": 60}`, selected)
+
+	_, err = SelectField(value, "password")
+	assert.Error(t, err)
+
+	_, err = SelectField(value, "user.name")
+	assert.Error(t, err)
+
+	_, err = SelectField([]byte("plain"), "user")
+	assert.Error(t, err)
+}
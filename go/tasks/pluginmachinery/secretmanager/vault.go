@@ -0,0 +1,120 @@
+package secretmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+
+// VaultBackend reads secrets from the KV version 2 secrets engine of a Vault compatible HTTP API. Secrets are read from
+// <mount>/data/<group>/<key>, or <mount>/data/<key> for secrets without a group. Secrets holding a single field are
+// returned as is, fields of the others must be selected.
+type VaultBackend struct {
+	address   string
+	mount     string
+	namespace string
+	token     string
+	client    *http.Client
+}
+
+func (b VaultBackend) Name() string {
+	return "vault"
+}
+
+type vaultResponse struct {
+	Data struct {
+		Data map[string]interface{} `json:"data"`
+	} `json:"data"`
+}
+
+func (b VaultBackend) Get(ctx context.Context, ref SecretRef) ([]byte, error) {
+	u, err := url.Parse(b.address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vault address [%v]: %w", b.address, err)
+	}
+
+	u.Path = path.Join(u.Path, "v1", b.mount, "data", ref.Group, ref.Key)
+	if len(ref.Version) > 0 {
+		u.RawQuery = url.Values{"version": []string{ref.Version}}.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("X-Vault-Token", b.token)
+	if len(b.namespace) > 0 {
+		req.Header.Set("X-Vault-Namespace", b.namespace)
+	}
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secret [%v] from vault: %w", ref, err)
+	}
+
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("failed to read secret [%v] from vault, status [%v]: %v", ref, resp.StatusCode,
+			strings.TrimSpace(string(body)))
+	}
+
+	vaultResp := vaultResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(&vaultResp); err != nil {
+		return nil, fmt.Errorf("failed to decode secret [%v] from vault: %w", ref, err)
+	}
+
+	data := vaultResp.Data.Data
+	if len(ref.Field) > 0 {
+		return json.Marshal(data)
+	}
+
+	fields := make([]string, 0, len(data))
+	for field := range data {
+		fields = append(fields, field)
+	}
+
+	if len(fields) != 1 {
+		sort.Strings(fields)
+		return nil, fmt.Errorf("secret [%v] has fields %v, select one with [%v#<field>]", ref, fields, ref)
+	}
+
+	if s, ok := data[fields[0]].(string); ok {
+		return []byte(s), nil
+	}
+
+	return json.Marshal(data[fields[0]])
+}
+
+// NewVaultBackend creates a backend reading secrets from the Vault at cfg.Address, authenticating with the token in
+// the file at cfg.TokenPath.
+func NewVaultBackend(cfg VaultConfig) (*VaultBackend, error) {
+	if len(cfg.Address) == 0 {
+		return nil, fmt.Errorf("vault address is required")
+	}
+
+	token, err := os.ReadFile(cfg.TokenPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read vault token: %w", err)
+	}
+
+	return &VaultBackend{
+		address:   cfg.Address,
+		mount:     cfg.Mount,
+		namespace: cfg.Namespace,
+		token:     strings.TrimSpace(string(token)),
+		client:    &http.Client{Timeout: cfg.Timeout.Duration},
+	}, nil
+}
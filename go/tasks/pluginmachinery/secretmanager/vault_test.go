@@ -0,0 +1,128 @@
+package secretmanager
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeVault serves the versions of the secrets of a KV version 2 secrets engine mounted at "secret".
+type fakeVault struct {
+	token   string
+	secrets map[string][]map[string]interface{}
+}
+
+func (v fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("X-Vault-Token") != v.token {
+		http.Error(w, `{"errors": ["permission denied"]}`, http.StatusForbidden)
+		return
+	}
+
+	versions, found := v.secrets[strings.TrimPrefix(r.URL.Path, "/v1/secret/data/")]
+	if !found {
+		http.Error(w, `{"errors": []}`, http.StatusNotFound)
+		return
+	}
+
+	version := len(versions)
+	if q := r.URL.Query().Get("version"); len(q) > 0 {
+		version = int(q[0] - '0')
+	}
+
+	if version < 1 || version > len(versions) {
+		http.Error(w, `{"errors": []}`, http.StatusNotFound)
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": map[string]interface{}{"data": versions[version-1]},
+	})
+}
+
+func newFakeVault(t *testing.T, token string) (*httptest.Server, VaultConfig) {
+	server := httptest.NewServer(fakeVault{
+		token: token,
+		secrets: map[string][]map[string]interface{}{
+			"snowflake/token": {{"value": "v1"}, {"value": "v2"}},
+			"databricks/api":  {{"host": "dbc", "auth": map[string]interface{}{"token": "abc"}}},
+			"TOKEN":           {{"value": "plain"}},
+		},
+	})
+	t.Cleanup(server.Close)
+
+	tokenPath := filepath.Join(t.TempDir(), "token")
+	assert.NoError(t, os.WriteFile(tokenPath, []byte(token+"\n"), 0600))
+	return server, VaultConfig{Address: server.URL, Mount: "secret", TokenPath: tokenPath}
+}
+
+func TestVaultBackend(t *testing.T) {
+	ctx := context.TODO()
+	_, cfg := newFakeVault(t, "root")
+	backend, err := NewVaultBackend(cfg)
+	assert.NoError(t, err)
+
+	t.Run("latest version", func(t *testing.T) {
+		value, err := backend.Get(ctx, SecretRef{Group: "snowflake", Key: "token"})
+		assert.NoError(t, err)
+		assert.Equal(t, "v2", string(value))
+	})
+
+	t.Run("version", func(t *testing.T) {
+		value, err := backend.Get(ctx, SecretRef{Group: "snowflake", Key: "token", Version: "1"})
+		assert.NoError(t, err)
+		assert.Equal(t, "v1", string(value))
+	})
+
+	t.Run("no group", func(t *testing.T) {
+		value, err := backend.Get(ctx, SecretRef{Key: "TOKEN"})
+		assert.NoError(t, err)
+		assert.Equal(t, "plain", string(value))
+	})
+
+	t.Run("field", func(t *testing.T) {
+		value, err := backend.Get(ctx, SecretRef{Group: "databricks", Key: "api", Field: "auth.token"})
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"host": "dbc", "auth": {"token": "abc"}}`, string(value))
+	})
+
+	t.Run("fields not selected", func(t *testing.T) {
+		_, err := backend.Get(ctx, SecretRef{Group: "databricks", Key: "api"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "[auth host]")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := backend.Get(ctx, SecretRef{Group: "snowflake", Key: "missing"})
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		_, err = backend.Get(ctx, SecretRef{Group: "snowflake", Key: "token", Version: "3"})
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("permission denied", func(t *testing.T) {
+		_, cfg := newFakeVault(t, "other")
+		assert.NoError(t, os.WriteFile(cfg.TokenPath, []byte("root"), 0600))
+		backend, err := NewVaultBackend(cfg)
+		assert.NoError(t, err)
+
+		_, err = backend.Get(ctx, SecretRef{Key: "TOKEN"})
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, ErrNotFound)
+		assert.Contains(t, err.Error(), "permission denied")
+	})
+}
+
+func TestNewVaultBackend(t *testing.T) {
+	_, err := NewVaultBackend(VaultConfig{TokenPath: "token"})
+	assert.Error(t, err)
+
+	_, err = NewVaultBackend(VaultConfig{Address: "http://vault", TokenPath: filepath.Join(t.TempDir(), "missing")})
+	assert.Error(t, err)
+}
@@ -14,13 +14,43 @@ type Input struct {
 	PodUnixStartTime  int64  `json:"podUnixStartTime"`
 	PodUnixFinishTime int64  `json:"podUnixFinishTime"`
 	PodUID            string `json:"podUID"`
+
+	// ContainerRole is the role of ContainerName in the pod (see ContainerRole). It's empty if unknown.
+	ContainerRole ContainerRole `json:"containerRole"`
+
+	// TaskExecutionIdentifier identifies the task execution the logs belong to. Its project, domain, execution name,
+	// node id and retry attempt are available to templates.
+	TaskExecutionIdentifier *core.TaskExecutionIdentifier `json:"taskExecutionIdentifier"`
+
+	// ExtraTemplateVars holds additional, plugin-specific values (e.g. the id of a resource created in a remote service
+	// or the account it runs in) that templates can reference by name (e.g. {{ .resourceId }}).
+	ExtraTemplateVars map[string]string `json:"extraTemplateVars"`
 }
 
+// ContainerRole is the role a container plays in a task's pod.
+type ContainerRole = string
+
+const (
+	// ContainerRolePrimary is the container running the task code.
+	ContainerRolePrimary ContainerRole = "primary"
+	// ContainerRoleSidecar is any other (non-init) container in the pod (e.g. the copilot uploader).
+	ContainerRoleSidecar ContainerRole = "sidecar"
+	// ContainerRoleInit is an init container (e.g. the copilot downloader).
+	ContainerRoleInit ContainerRole = "init"
+)
+
 // Output contains all task logs a plugin generates for a given Input.
 type Output struct {
 	TaskLogs []*core.TaskLog `json:"taskLogs"`
 }
 
+// TemplateLogPluginConfig configures a template-based log plugin. See TemplateLogPlugin for the available templates.
+type TemplateLogPluginConfig struct {
+	DisplayName   string                     `json:"displayName" pflag:",Display name for the generated log when displayed in the console."`
+	TemplateURIs  []string                   `json:"templateUris" pflag:",URI Templates for generating task log links."`
+	MessageFormat core.TaskLog_MessageFormat `json:"messageFormat" pflag:",Log Message Format."`
+}
+
 // Plugin represents an interface for task log plugins to implement to plug generated task log links into task events.
 type Plugin interface {
 	// Generates a TaskLog object given necessary computation information
@@ -0,0 +1,211 @@
+package tasklog
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/logger"
+	"k8s.io/utils/clock"
+)
+
+const (
+	defaultSignerTTL                = time.Hour
+	defaultSignerKeyRefreshInterval = 5 * time.Minute
+	defaultSignerSignatureParam     = "signature"
+	defaultSignerExpiryParam        = "expires"
+)
+
+// URLSigner signs rendered log links so that log viewers can authenticate them.
+type URLSigner interface {
+	Sign(uri string) (string, error)
+}
+
+// SecretGetter retrieves secrets by name. pluginmachinery/core.SecretManager satisfies it.
+type SecretGetter interface {
+	Get(ctx context.Context, key string) (string, error)
+}
+
+// SignerConfig configures the signing of log links with an expiring HMAC-SHA256 signature.
+type SignerConfig struct {
+	Enabled        bool            `json:"enabled" pflag:",Enables signing of log links."`
+	Key            string          `json:"key" pflag:",Key used to sign log links. Ignored if key-secret is set."`
+	KeySecretName  string          `json:"key-secret" pflag:",Name of the secret, read using the plugin's SecretManager, that holds the key used to sign log links."`
+	TTL            config.Duration `json:"ttl" pflag:",Duration for which signed log links are valid."`
+	SignatureParam string          `json:"signature-param" pflag:",Name of the query parameter holding the signature."`
+	ExpiryParam    string          `json:"expiry-param" pflag:",Name of the query parameter holding the expiry time, in unix seconds."`
+	// KeyRefreshInterval is how often the key-secret is read again, so that rotated keys are picked up without
+	// restarting. Defaults to 5 minutes.
+	KeyRefreshInterval config.Duration `json:"key-refresh-interval" pflag:",How often the key secret is read again to pick up rotated keys."`
+}
+
+// QueryParams returns the names of the query parameters the signer described by cfg appends to log links, or nil if
+// signing is disabled. They change every time a link is signed.
+func (cfg SignerConfig) QueryParams() []string {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	signatureParam := cfg.SignatureParam
+	if len(signatureParam) == 0 {
+		signatureParam = defaultSignerSignatureParam
+	}
+
+	expiryParam := cfg.ExpiryParam
+	if len(expiryParam) == 0 {
+		expiryParam = defaultSignerExpiryParam
+	}
+
+	return []string{signatureParam, expiryParam}
+}
+
+// HMACSigner appends an expiry and an HMAC-SHA256 signature to log links. The signature is computed over the link,
+// excluding its fragment, once the expiry parameter has been appended and is encoded using unpadded base64url. For
+// example, https://logs.example.com/view?pod=p1#top is signed as
+// https://logs.example.com/view?pod=p1&expires=<unix>&signature=<sig>#top, where sig is computed over
+// https://logs.example.com/view?pod=p1&expires=<unix>.
+type HMACSigner struct {
+	key            []byte
+	ttl            time.Duration
+	signatureParam string
+	expiryParam    string
+	clock          clock.PassiveClock
+}
+
+func (s HMACSigner) sign(payload string) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func appendQueryParam(uri, name, value string) string {
+	sep := "?"
+	if strings.Contains(uri, "?") {
+		sep = "&"
+	}
+
+	return uri + sep + url.QueryEscape(name) + "=" + url.QueryEscape(value)
+}
+
+func (s HMACSigner) Sign(uri string) (string, error) {
+	base, fragment := uri, ""
+	if idx := strings.Index(uri, "#"); idx >= 0 {
+		base, fragment = uri[:idx], uri[idx:]
+	}
+
+	expiry := s.clock.Now().Add(s.ttl).Unix()
+	payload := appendQueryParam(base, s.expiryParam, strconv.FormatInt(expiry, 10))
+	return appendQueryParam(payload, s.signatureParam, s.sign(payload)) + fragment, nil
+}
+
+// NewHMACSigner creates a signer that appends an expiry, ttl from now, and a signature to log links using the given
+// query parameter names.
+func NewHMACSigner(key []byte, ttl time.Duration, signatureParam, expiryParam string, clock clock.PassiveClock) HMACSigner {
+	return HMACSigner{
+		key:            key,
+		ttl:            ttl,
+		signatureParam: signatureParam,
+		expiryParam:    expiryParam,
+		clock:          clock,
+	}
+}
+
+// refreshingSigner signs log links using a key held in a secret. The secret is read again once the key is older than
+// the refresh interval, so that rotated keys are picked up. The previous key is kept if the secret can't be read.
+type refreshingSigner struct {
+	lock       sync.Mutex
+	signer     HMACSigner
+	secrets    SecretGetter
+	secretName string
+	interval   time.Duration
+	readAt     time.Time
+}
+
+func (s *refreshingSigner) current() HMACSigner {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	now := s.signer.clock.Now()
+	if now.Sub(s.readAt) < s.interval {
+		return s.signer
+	}
+
+	// The secret is read again after an interval even if it fails, so that an unavailable backend isn't hammered.
+	s.readAt = now
+	key, err := s.secrets.Get(context.TODO(), s.secretName)
+	if err != nil || len(key) == 0 {
+		logger.Warnf(context.TODO(), "Failed to refresh log link signing key secret [%v], keeping the previous key. "+
+			"Error: %v", s.secretName, err)
+		return s.signer
+	}
+
+	s.signer.key = []byte(key)
+	return s.signer
+}
+
+func (s *refreshingSigner) Sign(uri string) (string, error) {
+	return s.current().Sign(uri)
+}
+
+// NewURLSigner creates the signer described by cfg or returns nil if signing is disabled. The signing key is read from
+// secrets if cfg names a secret, otherwise the key in cfg is used. A key held in a secret is read once here, to fail
+// early if it's missing, then again every key refresh interval. Signers are meant to be created once, e.g. when plugins
+// are loaded, rather than for every task.
+func NewURLSigner(ctx context.Context, cfg SignerConfig, secrets SecretGetter) (URLSigner, error) {
+	return newURLSigner(ctx, cfg, secrets, clock.RealClock{})
+}
+
+func newURLSigner(ctx context.Context, cfg SignerConfig, secrets SecretGetter, clock clock.PassiveClock) (URLSigner,
+	error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	key := cfg.Key
+	if len(cfg.KeySecretName) > 0 {
+		if secrets == nil {
+			return nil, fmt.Errorf("log link signing key secret [%v] can't be read without a secret manager",
+				cfg.KeySecretName)
+		}
+
+		var err error
+		if key, err = secrets.Get(ctx, cfg.KeySecretName); err != nil {
+			return nil, fmt.Errorf("failed to read log link signing key secret [%v]: %w", cfg.KeySecretName, err)
+		}
+	}
+
+	if len(key) == 0 {
+		return nil, fmt.Errorf("log link signing is enabled but no signing key is configured")
+	}
+
+	ttl := cfg.TTL.Duration
+	if ttl <= 0 {
+		ttl = defaultSignerTTL
+	}
+
+	params := cfg.QueryParams()
+	signer := NewHMACSigner([]byte(key), ttl, params[0], params[1], clock)
+	if len(cfg.KeySecretName) == 0 {
+		return signer, nil
+	}
+
+	interval := cfg.KeyRefreshInterval.Duration
+	if interval <= 0 {
+		interval = defaultSignerKeyRefreshInterval
+	}
+
+	return &refreshingSigner{
+		signer:     signer,
+		secrets:    secrets,
+		secretName: cfg.KeySecretName,
+		interval:   interval,
+		readAt:     clock.Now(),
+	}, nil
+}
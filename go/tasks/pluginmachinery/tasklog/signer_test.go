@@ -0,0 +1,158 @@
+package tasklog
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/stretchr/testify/assert"
+	testclock "k8s.io/utils/clock/testing"
+)
+
+type secretsFunc func(ctx context.Context, key string) (string, error)
+
+func (f secretsFunc) Get(ctx context.Context, key string) (string, error) {
+	return f(ctx, key)
+}
+
+func expectedSignature(key, payload string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestHMACSigner_Sign(t *testing.T) {
+	signer := NewHMACSigner([]byte("secret"), time.Hour, "sig", "exp", testclock.NewFakePassiveClock(time.Unix(1000, 0)))
+
+	t.Run("no query", func(t *testing.T) {
+		signed, err := signer.Sign("https://logs.example.com/view")
+		assert.NoError(t, err)
+		payload := "https://logs.example.com/view?exp=4600"
+		assert.Equal(t, payload+"&sig="+expectedSignature("secret", payload), signed)
+	})
+
+	t.Run("query and fragment", func(t *testing.T) {
+		signed, err := signer.Sign("https://logs.example.com/view?pod=p1#top")
+		assert.NoError(t, err)
+		payload := "https://logs.example.com/view?pod=p1&exp=4600"
+		assert.Equal(t, payload+"&sig="+expectedSignature("secret", payload)+"#top", signed)
+	})
+}
+
+func TestNewURLSigner(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("disabled", func(t *testing.T) {
+		signer, err := NewURLSigner(ctx, SignerConfig{Key: "secret"}, nil)
+		assert.NoError(t, err)
+		assert.Nil(t, signer)
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		signer, err := NewURLSigner(ctx, SignerConfig{Enabled: true, Key: "secret"}, nil)
+		assert.NoError(t, err)
+		signed, err := signer.Sign("https://logs.example.com/view")
+		assert.NoError(t, err)
+		assert.Regexp(t, `^https://logs.example.com/view\?expires=\d+&signature=[\w-]+$`, signed)
+	})
+
+	t.Run("key from secret", func(t *testing.T) {
+		signer, err := NewURLSigner(ctx, SignerConfig{
+			Enabled:        true,
+			KeySecretName:  "log-key",
+			TTL:            config.Duration{Duration: time.Minute},
+			SignatureParam: "sig",
+			ExpiryParam:    "exp",
+		}, secretsFunc(func(ctx context.Context, key string) (string, error) {
+			assert.Equal(t, "log-key", key)
+			return "secret", nil
+		}))
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("secret"), signer.(*refreshingSigner).signer.key)
+		assert.Equal(t, time.Minute, signer.(*refreshingSigner).signer.ttl)
+		assert.Equal(t, defaultSignerKeyRefreshInterval, signer.(*refreshingSigner).interval)
+	})
+
+	t.Run("key rotation", func(t *testing.T) {
+		now := time.Unix(1000, 0)
+		clk := testclock.NewFakePassiveClock(now)
+		key, reads := "old", 0
+		signer, err := newURLSigner(ctx, SignerConfig{
+			Enabled:            true,
+			KeySecretName:      "log-key",
+			KeyRefreshInterval: config.Duration{Duration: time.Minute},
+		}, secretsFunc(func(ctx context.Context, _ string) (string, error) {
+			reads++
+			if len(key) == 0 {
+				return "", fmt.Errorf("unavailable")
+			}
+			return key, nil
+		}), clk)
+		assert.NoError(t, err)
+
+		signedWith := func(key string) string {
+			payload := "https://logs/x?expires=" + strconv.FormatInt(clk.Now().Add(defaultSignerTTL).Unix(), 10)
+			return payload + "&signature=" + expectedSignature(key, payload)
+		}
+
+		key = "new"
+		uri, err := signer.Sign("https://logs/x")
+		assert.NoError(t, err)
+		assert.Equal(t, signedWith("old"), uri)
+		assert.Equal(t, 1, reads)
+
+		clk.SetTime(now.Add(time.Minute))
+		uri, err = signer.Sign("https://logs/x")
+		assert.NoError(t, err)
+		assert.Equal(t, signedWith("new"), uri)
+		assert.Equal(t, 2, reads)
+
+		key = ""
+		clk.SetTime(now.Add(2 * time.Minute))
+		uri, err = signer.Sign("https://logs/x")
+		assert.NoError(t, err)
+		assert.Equal(t, signedWith("new"), uri)
+		assert.Equal(t, 3, reads)
+	})
+
+	t.Run("secret error", func(t *testing.T) {
+		_, err := NewURLSigner(ctx, SignerConfig{Enabled: true, KeySecretName: "log-key"},
+			secretsFunc(func(ctx context.Context, key string) (string, error) {
+				return "", fmt.Errorf("not found")
+			}))
+		assert.Error(t, err)
+	})
+
+	t.Run("secret without secret manager", func(t *testing.T) {
+		_, err := NewURLSigner(ctx, SignerConfig{Enabled: true, KeySecretName: "log-key"}, nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		_, err := NewURLSigner(ctx, SignerConfig{Enabled: true}, nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestSignerConfig_QueryParams(t *testing.T) {
+	assert.Nil(t, SignerConfig{Key: "secret"}.QueryParams())
+	assert.Equal(t, []string{"signature", "expires"}, SignerConfig{Enabled: true}.QueryParams())
+	assert.Equal(t, []string{"sig", "exp"},
+		SignerConfig{Enabled: true, SignatureParam: "sig", ExpiryParam: "exp"}.QueryParams())
+}
+
+func TestTemplateLogPlugin_WithSigner(t *testing.T) {
+	signer := NewHMACSigner([]byte("secret"), time.Hour, "sig", "exp", testclock.NewFakePassiveClock(time.Unix(1000, 0)))
+	p := NewTemplateLogPlugin([]string{"https://logs.example.com/{{ .podName }}"}, core.TaskLog_JSON).WithSigner(signer)
+	o, err := p.GetTaskLogs(Input{PodName: "my-pod"})
+	assert.NoError(t, err)
+	payload := "https://logs.example.com/my-pod?exp=4600"
+	assert.Equal(t, payload+"&sig="+expectedSignature("secret", payload), o.TaskLogs[0].Uri)
+}
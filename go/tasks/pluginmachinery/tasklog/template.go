@@ -1,72 +1,305 @@
 package tasklog
 
 import (
+	"bytes"
 	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
+	"text/template"
+	"text/template/parse"
+	"time"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 )
 
-// A simple log plugin that supports templates in urls to build the final log link. Supported templates are:
+// A simple log plugin that supports templates in urls to build the final log link. Templates are rendered using
+// text/template, variable names are case-insensitive. Supported variables are:
 // {{ .podName }}: Gets the pod name as it shows in k8s dashboard,
 // {{ .podUID }}: Gets the pod UID,
 // {{ .namespace }}: K8s namespace where the pod runs,
 // {{ .containerName }}: The container name that generated the log,
 // {{ .containerId }}: The container id docker/crio generated at run time,
+// {{ .containerRole }}: The role of the container in the pod (primary, sidecar or init), if known,
 // {{ .logName }}: A deployment specific name where to expect the logs to be.
 // {{ .hostname }}: The hostname where the pod is running and where logs reside.
 // {{ .podUnixStartTime }}: The pod creation time (in unix seconds, not millis)
 // {{ .podUnixFinishTime }}: Don't have a good mechanism for this yet, but approximating with time.Now for now
+// {{ .podUnixStartTimeMs }}, {{ .podUnixFinishTimeMs }}: Same as above, in unix milliseconds.
+// {{ .podRFC3339StartTime }}, {{ .podRFC3339FinishTime }}: Same as above, formatted as RFC3339.
+// {{ .project }}, {{ .domain }}, {{ .executionName }}: The workflow execution the task belongs to.
+// {{ .nodeId }}: The id of the node that runs the task.
+// {{ .taskRetryAttempt }}: The retry attempt of the task execution.
+// {{ .subtaskIndex }}, {{ .subtaskRetryAttempt }}: The index and retry attempt of the subtask, for subtasks of k8s array
+// tasks ({{ .taskRetryAttempt }} is the retry attempt of the array task).
+// Any key in Input.ExtraTemplateVars is available as well (e.g. {{ .resourceId }}). Variables that aren't available to
+// a link (e.g. a typo, or a variable only some plugins set) render as empty strings. They used to be left in the link
+// as is.
+// Aside from the text/template builtins (e.g. if, with, printf and urlquery), templates can use the following functions:
+// {{ add .podUnixStartTime 60 }}, {{ sub .podUnixStartTime 300 }}: Integer arithmetic.
+// {{ .podUnixStartTime | formatTime "2006-01-02" }}: Formats a unix time (in seconds) using a Go time layout.
+// {{ .podName | default "unknown" }}: Falls back to the given value if the piped one is empty.
+// Rendered links are signed if the plugin has a signer (see WithSigner).
 type TemplateLogPlugin struct {
 	templateUris  []string
 	messageFormat core.TaskLog_MessageFormat
+	signer        URLSigner
 }
 
-type regexValPair struct {
-	regex *regexp.Regexp
-	val   string
+// Lower-cased names of the variables available to all templates. Templates may refer to them using any casing.
+const (
+	varPodName              = "podname"
+	varPodUID               = "poduid"
+	varNamespace            = "namespace"
+	varContainerName        = "containername"
+	varContainerID          = "containerid"
+	varContainerRole        = "containerrole"
+	varLogName              = "logname"
+	varHostname             = "hostname"
+	varPodUnixStartTime     = "podunixstarttime"
+	varPodUnixFinishTime    = "podunixfinishtime"
+	varPodUnixStartTimeMs   = "podunixstarttimems"
+	varPodUnixFinishTimeMs  = "podunixfinishtimems"
+	varPodRFC3339StartTime  = "podrfc3339starttime"
+	varPodRFC3339FinishTime = "podrfc3339finishtime"
+	varProject              = "project"
+	varDomain               = "domain"
+	varExecutionName        = "executionname"
+	varNodeID               = "nodeid"
+	varTaskRetryAttempt     = "taskretryattempt"
+)
+
+var builtinVars = []string{
+	varPodName, varPodUID, varNamespace, varContainerName, varContainerID, varContainerRole, varLogName, varHostname,
+	varPodUnixStartTime, varPodUnixFinishTime, varPodUnixStartTimeMs, varPodUnixFinishTimeMs, varPodRFC3339StartTime,
+	varPodRFC3339FinishTime, varProject, varDomain, varExecutionName, varNodeID, varTaskRetryAttempt,
+}
+
+// dollarVarRegex matches the legacy {{ $podName }} syntax so that it can be rewritten into a field reference before
+// parsing (text/template would otherwise reject it as an undefined variable). Only the text of actions is rewritten,
+// literal text such as var-namespace=$namespace in Grafana links is kept as is.
+var dollarVarRegex = regexp.MustCompile(`(?i)\$(` + strings.Join(builtinVars, "|") + `)\b`)
+
+// actionRegex matches the actions of a template.
+var actionRegex = regexp.MustCompile(`(?s){{.*?}}`)
+
+var templateFuncs = template.FuncMap{
+	"add":        add,
+	"sub":        sub,
+	"formatTime": formatTime,
+	"default":    defaultValue,
+}
+
+// parsedTemplates caches parsed templates by their raw text. Log plugins are rebuilt from config on every status
+// check, so this keeps parsing off the hot path.
+var parsedTemplates sync.Map
+
+// parsedTemplate is a parsed template, along with the lower-cased names of the variables it refers to.
+type parsedTemplate struct {
+	*template.Template
+	vars []string
+}
+
+func toInt64(v interface{}) (int64, error) {
+	switch t := v.(type) {
+	case int:
+		return int64(t), nil
+	case int64:
+		return t, nil
+	case int32:
+		return int64(t), nil
+	case uint32:
+		return int64(t), nil
+	case string:
+		return strconv.ParseInt(t, 10, 64)
+	default:
+		return 0, fmt.Errorf("expected an integer, found [%v]", v)
+	}
 }
 
-type templateRegexes struct {
-	PodName           *regexp.Regexp
-	PodUID            *regexp.Regexp
-	Namespace         *regexp.Regexp
-	ContainerName     *regexp.Regexp
-	ContainerID       *regexp.Regexp
-	LogName           *regexp.Regexp
-	Hostname          *regexp.Regexp
-	PodUnixStartTime  *regexp.Regexp
-	PodUnixFinishTime *regexp.Regexp
+func add(a, b interface{}) (int64, error) {
+	x, err := toInt64(a)
+	if err != nil {
+		return 0, err
+	}
+
+	y, err := toInt64(b)
+	if err != nil {
+		return 0, err
+	}
+
+	return x + y, nil
+}
+
+func sub(a, b interface{}) (int64, error) {
+	x, err := toInt64(a)
+	if err != nil {
+		return 0, err
+	}
+
+	y, err := toInt64(b)
+	if err != nil {
+		return 0, err
+	}
+
+	return x - y, nil
 }
 
-func mustInitTemplateRegexes() templateRegexes {
-	return templateRegexes{
-		PodName:           mustCreateRegex("podName"),
-		PodUID:            mustCreateRegex("podUID"),
-		Namespace:         mustCreateRegex("namespace"),
-		ContainerName:     mustCreateRegex("containerName"),
-		ContainerID:       mustCreateRegex("containerID"),
-		LogName:           mustCreateRegex("logName"),
-		Hostname:          mustCreateRegex("hostname"),
-		PodUnixStartTime:  mustCreateRegex("podUnixStartTime"),
-		PodUnixFinishTime: mustCreateRegex("podUnixFinishTime"),
+func formatTime(layout string, unixTime interface{}) (string, error) {
+	t, err := toInt64(unixTime)
+	if err != nil {
+		return "", err
+	}
+
+	return time.Unix(t, 0).UTC().Format(layout), nil
+}
+
+func defaultValue(def, val interface{}) interface{} {
+	switch t := val.(type) {
+	case nil:
+		return def
+	case string:
+		if len(t) == 0 {
+			return def
+		}
+	}
+
+	return val
+}
+
+// lowerFieldNames rewrites all field references in the parse tree to lower case and collects the names of the variables
+// they refer to in vars. Template data keys are lower case as well, which makes variable names case-insensitive like
+// they have always been.
+func lowerFieldNames(node parse.Node, vars map[string]struct{}) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+
+		for _, child := range n.Nodes {
+			lowerFieldNames(child, vars)
+		}
+	case *parse.ActionNode:
+		lowerFieldNames(n.Pipe, vars)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+
+		for _, cmd := range n.Cmds {
+			lowerFieldNames(cmd, vars)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			lowerFieldNames(arg, vars)
+		}
+	case *parse.FieldNode:
+		for i := range n.Ident {
+			n.Ident[i] = strings.ToLower(n.Ident[i])
+		}
+
+		vars[n.Ident[0]] = struct{}{}
+	case *parse.ChainNode:
+		lowerFieldNames(n.Node, vars)
+		for i := range n.Field {
+			n.Field[i] = strings.ToLower(n.Field[i])
+		}
+	case *parse.IfNode:
+		lowerFieldNames(&n.BranchNode, vars)
+	case *parse.RangeNode:
+		lowerFieldNames(&n.BranchNode, vars)
+	case *parse.WithNode:
+		lowerFieldNames(&n.BranchNode, vars)
+	case *parse.BranchNode:
+		lowerFieldNames(n.Pipe, vars)
+		lowerFieldNames(n.List, vars)
+		lowerFieldNames(n.ElseList, vars)
+	case *parse.TemplateNode:
+		lowerFieldNames(n.Pipe, vars)
+	}
+}
+
+func parseTemplate(templateURI string) (parsedTemplate, error) {
+	if t, ok := parsedTemplates.Load(templateURI); ok {
+		return t.(parsedTemplate), nil
+	}
+
+	text := actionRegex.ReplaceAllStringFunc(templateURI, func(action string) string {
+		return dollarVarRegex.ReplaceAllString(action, ".$1")
+	})
+
+	t, err := template.New("logLink").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return parsedTemplate{}, fmt.Errorf("failed to parse log template [%v]: %w", templateURI, err)
+	}
+
+	vars := map[string]struct{}{}
+	for _, tree := range t.Templates() {
+		lowerFieldNames(tree.Root, vars)
+	}
+
+	parsed := parsedTemplate{Template: t, vars: make([]string, 0, len(vars))}
+	for name := range vars {
+		parsed.vars = append(parsed.vars, name)
 	}
+
+	parsedTemplates.Store(templateURI, parsed)
+	return parsed, nil
 }
 
-var regexes = mustInitTemplateRegexes()
+// ValidateTemplateURIs checks that all given log link templates can be parsed.
+func ValidateTemplateURIs(templateURIs []string) error {
+	for _, templateURI := range templateURIs {
+		if _, err := parseTemplate(templateURI); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
 
-func mustCreateRegex(varName string) *regexp.Regexp {
-	return regexp.MustCompile(fmt.Sprintf(`(?i){{\s*[\.$]%s\s*}}`, varName))
+func formatUnixTime(unixTime int64) string {
+	return time.Unix(unixTime, 0).UTC().Format(time.RFC3339)
 }
 
-func replaceAll(template string, values []regexValPair) string {
-	for _, v := range values {
-		template = v.regex.ReplaceAllString(template, v.val)
+func templateVars(input Input) map[string]interface{} {
+	// Container IDs are prefixed with docker://, cri-o://, etc. which is stripped by fluentd before pushing to a log
+	// stream. Therefore, we must also strip the prefix.
+	containerID := input.ContainerID
+	stripDelimiter := "://"
+	if split := strings.Split(input.ContainerID, stripDelimiter); len(split) > 1 {
+		containerID = split[1]
+	}
+
+	vars := make(map[string]interface{}, len(builtinVars)+len(input.ExtraTemplateVars))
+	for name, val := range input.ExtraTemplateVars {
+		vars[strings.ToLower(name)] = val
 	}
 
-	return template
+	execID := input.TaskExecutionIdentifier.GetNodeExecutionId().GetExecutionId()
+	vars[varPodName] = input.PodName
+	vars[varPodUID] = input.PodUID
+	vars[varNamespace] = input.Namespace
+	vars[varContainerName] = input.ContainerName
+	vars[varContainerID] = containerID
+	vars[varContainerRole] = input.ContainerRole
+	vars[varLogName] = input.LogName
+	vars[varHostname] = input.HostName
+	vars[varPodUnixStartTime] = input.PodUnixStartTime
+	vars[varPodUnixFinishTime] = input.PodUnixFinishTime
+	vars[varPodUnixStartTimeMs] = input.PodUnixStartTime * 1000
+	vars[varPodUnixFinishTimeMs] = input.PodUnixFinishTime * 1000
+	vars[varPodRFC3339StartTime] = formatUnixTime(input.PodUnixStartTime)
+	vars[varPodRFC3339FinishTime] = formatUnixTime(input.PodUnixFinishTime)
+	vars[varProject] = execID.GetProject()
+	vars[varDomain] = execID.GetDomain()
+	vars[varExecutionName] = execID.GetName()
+	vars[varNodeID] = input.TaskExecutionIdentifier.GetNodeExecutionId().GetNodeId()
+	vars[varTaskRetryAttempt] = input.TaskExecutionIdentifier.GetRetryAttempt()
+
+	return vars
 }
 
 func (s TemplateLogPlugin) GetTaskLog(podName, podUID, namespace, containerName, containerID, logName string, podUnixStartTime, podUnixFinishTime int64) (core.TaskLog, error) {
@@ -88,59 +321,50 @@ func (s TemplateLogPlugin) GetTaskLog(podName, podUID, namespace, containerName,
 	return *o.TaskLogs[0], nil
 }
 
-func (s TemplateLogPlugin) GetTaskLogs(input Input) (Output, error) {
-	// Container IDs are prefixed with docker://, cri-o://, etc. which is stripped by fluentd before pushing to a log
-	// stream. Therefore, we must also strip the prefix.
-	containerID := input.ContainerID
-	stripDelimiter := "://"
-	if split := strings.Split(input.ContainerID, stripDelimiter); len(split) > 1 {
-		containerID = split[1]
+func render(templateText string, vars map[string]interface{}, buf *bytes.Buffer) (string, error) {
+	t, err := parseTemplate(templateText)
+	if err != nil {
+		return "", err
 	}
 
+	for _, name := range t.vars {
+		if _, found := vars[name]; !found {
+			vars[name] = ""
+		}
+	}
+
+	buf.Reset()
+	if err := t.Execute(buf, vars); err != nil {
+		return "", fmt.Errorf("failed to render log template [%v]: %w", templateText, err)
+	}
+
+	return buf.String(), nil
+}
+
+// RenderTemplate renders a single template, using the same variables and functions available to TemplateLogPlugin, for
+// the given input. It's meant for log plugins that need to template only parts of their links (e.g. a search query).
+func RenderTemplate(templateText string, input Input) (string, error) {
+	return render(templateText, templateVars(input), &bytes.Buffer{})
+}
+
+func (s TemplateLogPlugin) GetTaskLogs(input Input) (Output, error) {
+	vars := templateVars(input)
 	taskLogs := make([]*core.TaskLog, 0, len(s.templateUris))
+	buf := bytes.Buffer{}
 	for _, templateURI := range s.templateUris {
+		uri, err := render(templateURI, vars, &buf)
+		if err != nil {
+			return Output{}, err
+		}
+
+		if s.signer != nil {
+			if uri, err = s.signer.Sign(uri); err != nil {
+				return Output{}, err
+			}
+		}
+
 		taskLogs = append(taskLogs, &core.TaskLog{
-			Uri: replaceAll(
-				templateURI,
-				[]regexValPair{
-					{
-						regex: regexes.PodName,
-						val:   input.PodName,
-					},
-					{
-						regex: regexes.PodUID,
-						val:   input.PodUID,
-					},
-					{
-						regex: regexes.Namespace,
-						val:   input.Namespace,
-					},
-					{
-						regex: regexes.ContainerName,
-						val:   input.ContainerName,
-					},
-					{
-						regex: regexes.ContainerID,
-						val:   containerID,
-					},
-					{
-						regex: regexes.LogName,
-						val:   input.LogName,
-					},
-					{
-						regex: regexes.Hostname,
-						val:   input.HostName,
-					},
-					{
-						regex: regexes.PodUnixStartTime,
-						val:   strconv.FormatInt(input.PodUnixStartTime, 10),
-					},
-					{
-						regex: regexes.PodUnixFinishTime,
-						val:   strconv.FormatInt(input.PodUnixFinishTime, 10),
-					},
-				},
-			),
+			Uri:           uri,
 			Name:          input.LogName,
 			MessageFormat: s.messageFormat,
 		})
@@ -151,20 +375,18 @@ func (s TemplateLogPlugin) GetTaskLogs(input Input) (Output, error) {
 	}, nil
 }
 
-// NewTemplateLogPlugin creates a template-based log plugin with the provided template Uri and message format. Supported
-// templates are:
-// {{ .podName }}: Gets the pod name as it shows in k8s dashboard,
-// {{ .podUID }}: Gets the pod UID,
-// {{ .namespace }}: K8s namespace where the pod runs,
-// {{ .containerName }}: The container name that generated the log,
-// {{ .containerId }}: The container id docker/crio generated at run time,
-// {{ .logName }}: A deployment specific name where to expect the logs to be.
-// {{ .hostname }}: The hostname where the pod is running and where logs reside.
-// {{ .podUnixStartTime }}: The pod creation time (in unix seconds, not millis)
-// {{ .podUnixFinishTime }}: Don't have a good mechanism for this yet, but approximating with time.Now for now
+// NewTemplateLogPlugin creates a template-based log plugin with the provided template Uri and message format. See
+// TemplateLogPlugin for the supported variables and functions. Templates are parsed lazily, use ValidateTemplateURIs to
+// surface syntax errors early.
 func NewTemplateLogPlugin(templateUris []string, messageFormat core.TaskLog_MessageFormat) TemplateLogPlugin {
 	return TemplateLogPlugin{
 		templateUris:  templateUris,
 		messageFormat: messageFormat,
 	}
 }
+
+// WithSigner returns a copy of the plugin that signs all rendered links using signer. A nil signer disables signing.
+func (s TemplateLogPlugin) WithSigner(signer URLSigner) TemplateLogPlugin {
+	s.signer = signer
+	return s
+}
@@ -28,10 +28,137 @@ func TestTemplateLog(t *testing.T) {
 	assert.Equal(t, "https://console.aws.amazon.com/cloudwatch/home?region=us-east-1#logEventViewer:group=/flyte-production/kubernetes;stream=var.log.containers.f-uuid-driver_pod-uid_flyteexamples-production_spark-kubernetes-driver-abc.log", tl.Uri)
 }
 
-// Latest Run: Benchmark_mustInitTemplateRegexes-16    	   45960	     26914 ns/op
-func Benchmark_mustInitTemplateRegexes(b *testing.B) {
+func TestTemplateLog_ExtraTemplateVars(t *testing.T) {
+	p := NewTemplateLogPlugin([]string{"https://{{ .account }}.example.com/{{ .namespace }}/queries/{{.resourceId}}"}, core.TaskLog_JSON)
+	o, err := p.GetTaskLogs(Input{
+		Namespace: "flyteexamples-production",
+		LogName:   "Console",
+		ExtraTemplateVars: map[string]string{
+			"account":    "my-account",
+			"resourceId": "query-id",
+		},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, o.TaskLogs, 1)
+	assert.Equal(t, "https://my-account.example.com/flyteexamples-production/queries/query-id", o.TaskLogs[0].Uri)
+	assert.Equal(t, "Console", o.TaskLogs[0].Name)
+}
+
+func TestTemplateLog_ExtendedVars(t *testing.T) {
+	p := NewTemplateLogPlugin([]string{
+		"https://grafana.example.com/explore?from={{ .podUnixStartTimeMs }}&to={{ .podUnixFinishTimeMs }}&q={{ .project }}/{{ .domain }}/{{ .executionName }}/{{ .nodeId }}/{{ .taskRetryAttempt }}",
+		"https://kibana.example.com/app/discover?time=(from:'{{ .podRFC3339StartTime }}',to:'{{ .podRFC3339FinishTime }}')",
+		"https://splunk.example.com/search?q={{ .containerName | urlquery }}&pod={{.podName|urlquery}}",
+	}, core.TaskLog_JSON)
+	o, err := p.GetTaskLogs(Input{
+		PodName:           "my pod",
+		ContainerName:     "container=main&x",
+		PodUnixStartTime:  1426349294,
+		PodUnixFinishTime: 1623782877,
+		TaskExecutionIdentifier: &core.TaskExecutionIdentifier{
+			NodeExecutionId: &core.NodeExecutionIdentifier{
+				ExecutionId: &core.WorkflowExecutionIdentifier{
+					Project: "flytesnacks",
+					Domain:  "development",
+					Name:    "exec-name",
+				},
+				NodeId: "n0",
+			},
+			RetryAttempt: 2,
+		},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, o.TaskLogs, 3)
+	assert.Equal(t, "https://grafana.example.com/explore?from=1426349294000&to=1623782877000&q=flytesnacks/development/exec-name/n0/2", o.TaskLogs[0].Uri)
+	assert.Equal(t, "https://kibana.example.com/app/discover?time=(from:'2015-03-14T16:08:14Z',to:'2021-06-15T18:47:57Z')", o.TaskLogs[1].Uri)
+	assert.Equal(t, "https://splunk.example.com/search?q=container%3Dmain%26x&pod=my+pod", o.TaskLogs[2].Uri)
+}
+
+func TestTemplateLog_BackwardCompatibleSyntax(t *testing.T) {
+	p := NewTemplateLogPlugin([]string{
+		"https://example.com/{{ .PODNAME }}/{{ $namespace }}/{{$containerID}}/{{ .podname|urlquery }}",
+	}, core.TaskLog_JSON)
+	o, err := p.GetTaskLogs(Input{
+		PodName:     "my-pod",
+		Namespace:   "my-namespace",
+		ContainerID: "docker://abc",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/my-pod/my-namespace/abc/my-pod", o.TaskLogs[0].Uri)
+}
+
+func TestTemplateLog_Functions(t *testing.T) {
+	p := NewTemplateLogPlugin([]string{
+		"https://example.com/?from={{ sub .podUnixStartTime 300 }}&to={{ add .podUnixFinishTime 60 }}",
+		"https://example.com/?day={{ .podUnixStartTime | formatTime \"2006-01-02\" }}",
+		"https://example.com/?host={{ .hostname | default \"unknown\" }}&ns={{ .namespace | default \"unknown\" }}",
+		"https://example.com/{{ if .account }}{{ .account }}{{ else }}default{{ end }}/{{ add .offset 1 }}",
+	}, core.TaskLog_JSON)
+	o, err := p.GetTaskLogs(Input{
+		Namespace:         "my-namespace",
+		PodUnixStartTime:  1426349294,
+		PodUnixFinishTime: 1623782877,
+		ExtraTemplateVars: map[string]string{
+			"account": "",
+			"offset":  "41",
+		},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, o.TaskLogs, 4)
+	assert.Equal(t, "https://example.com/?from=1426348994&to=1623782937", o.TaskLogs[0].Uri)
+	assert.Equal(t, "https://example.com/?day=2015-03-14", o.TaskLogs[1].Uri)
+	assert.Equal(t, "https://example.com/?host=unknown&ns=my-namespace", o.TaskLogs[2].Uri)
+	assert.Equal(t, "https://example.com/default/42", o.TaskLogs[3].Uri)
+}
+
+func TestTemplateLog_Errors(t *testing.T) {
+	t.Run("invalid syntax", func(t *testing.T) {
+		assert.Error(t, ValidateTemplateURIs([]string{"https://example.com/{{ .podName "}))
+		_, err := NewTemplateLogPlugin([]string{"https://example.com/{{ .podName "}, core.TaskLog_JSON).GetTaskLogs(Input{})
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown function", func(t *testing.T) {
+		assert.Error(t, ValidateTemplateURIs([]string{"https://example.com/{{ exec .podName }}"}))
+	})
+}
+
+func TestTemplateLog_UnknownVariable(t *testing.T) {
+	assert.NoError(t, ValidateTemplateURIs([]string{"https://example.com/{{ .missing }}"}))
+	o, err := NewTemplateLogPlugin([]string{"https://example.com/{{ .missing }}"}, core.TaskLog_JSON).GetTaskLogs(Input{})
+	assert.NoError(t, err)
+	assert.Len(t, o.TaskLogs, 1)
+	assert.Equal(t, "https://example.com/", o.TaskLogs[0].Uri)
+}
+
+func TestTemplateLog_LiteralDollarVariables(t *testing.T) {
+	o, err := NewTemplateLogPlugin([]string{
+		"https://grafana.com/d/abc?var-namespace=$namespace&var-pod={{ $podName }}",
+	}, core.TaskLog_JSON).GetTaskLogs(Input{
+		PodName:   "my-pod",
+		Namespace: "my-namespace",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, o.TaskLogs, 1)
+	assert.Equal(t, "https://grafana.com/d/abc?var-namespace=$namespace&var-pod=my-pod", o.TaskLogs[0].Uri)
+}
+
+// Latest Run: BenchmarkTemplateLogPlugin_GetTaskLogs    	  197286	      5818 ns/op
+func BenchmarkTemplateLogPlugin_GetTaskLogs(b *testing.B) {
+	p := NewTemplateLogPlugin([]string{"https://console.aws.amazon.com/cloudwatch/home?region=us-east-1#logEventViewer:group=/flyte-production/kubernetes;stream=var.log.containers.{{.podName}}_{{.namespace}}_{{.containerName}}-{{.containerId}}.log"}, core.TaskLog_JSON)
+	input := Input{
+		PodName:       "f-uuid-driver",
+		Namespace:     "flyteexamples-production",
+		ContainerName: "spark-kubernetes-driver",
+		ContainerID:   "cri-o://abc",
+		LogName:       "main_logs",
+	}
+
+	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		mustInitTemplateRegexes()
+		if _, err := p.GetTaskLogs(input); err != nil {
+			b.Fatal(err)
+		}
 	}
 }
 
@@ -0,0 +1,147 @@
+// Package timeline keeps a compact history of the phases a task went through, to tell how long it was queued, waiting
+// for resources, initializing or running. Frameworks keep the timeline of a task in its plugin state, surface it in
+// TaskInfo.CustomInfo and observe the duration of each phase. Only the webapi framework records timelines: the phases
+// of k8s plugins are handled by the k8s plugin manager, which owns their plugin state.
+package timeline
+
+import (
+	"time"
+
+	"github.com/flyteorg/flytestdlib/promutils"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
+)
+
+// MaxEntries is the maximum number of entries a timeline keeps. The oldest entries but the first one are dropped past
+// it.
+const MaxEntries = 32
+
+// CustomInfoKey is the key of the timeline in TaskInfo.CustomInfo.
+const CustomInfoKey = "phaseTimeline"
+
+// Entry is a phase, and sub-reason, a task entered.
+type Entry struct {
+	Phase     core.Phase `json:"-"`
+	SubReason string     `json:"subReason,omitempty"`
+	// Reason is the reason reported when the task entered the phase.
+	Reason    string    `json:"reason,omitempty"`
+	StartedAt time.Time `json:"startedAt"`
+}
+
+// Timeline is the history of the phases a task went through. Consecutive observations of the same phase and sub-reason
+// are recorded once.
+type Timeline struct {
+	Entries []Entry
+	// Dropped is the number of entries dropped to keep the timeline under MaxEntries.
+	Dropped int
+}
+
+// Record records the phase of info, observed at now unless info tells when it occurred. It returns the entry the task
+// left, if any, and how long it was in it.
+func (t *Timeline) Record(info core.PhaseInfo, now time.Time) (left *Entry, duration time.Duration) {
+	at := now
+	if info.Info() != nil && info.Info().OccurredAt != nil {
+		at = *info.Info().OccurredAt
+	}
+
+	if len(t.Entries) > 0 {
+		last := t.Entries[len(t.Entries)-1]
+		if last.Phase == info.Phase() && last.SubReason == info.SubReason() {
+			return nil, 0
+		}
+
+		// Phases may be reported with the time they occurred at as seen by another clock.
+		if at.Before(last.StartedAt) {
+			at = last.StartedAt
+		}
+
+		left, duration = &last, at.Sub(last.StartedAt)
+	}
+
+	t.Entries = append(t.Entries, Entry{
+		Phase:     info.Phase(),
+		SubReason: info.SubReason(),
+		Reason:    info.Reason(),
+		StartedAt: at,
+	})
+
+	if len(t.Entries) > MaxEntries {
+		dropped := len(t.Entries) - MaxEntries
+		t.Entries = append(t.Entries[:1], t.Entries[1+dropped:]...)
+		t.Dropped += dropped
+	}
+
+	return left, duration
+}
+
+type customInfoEntry struct {
+	Entry
+	Phase           string  `json:"phase"`
+	DurationSeconds float64 `json:"durationSeconds,omitempty"`
+}
+
+type customInfo struct {
+	Entries []customInfoEntry `json:"entries"`
+	Dropped int               `json:"dropped,omitempty"`
+}
+
+// AddToTaskInfo adds the timeline to the custom info of the task, under CustomInfoKey. The duration of the last entry
+// is counted up to now, unless it's terminal.
+func (t Timeline) AddToTaskInfo(info *core.TaskInfo, now time.Time) error {
+	if info == nil || len(t.Entries) == 0 {
+		return nil
+	}
+
+	entries := make([]customInfoEntry, 0, len(t.Entries))
+	for i, e := range t.Entries {
+		end := now
+		if i+1 < len(t.Entries) {
+			end = t.Entries[i+1].StartedAt
+		} else if e.Phase.IsTerminal() {
+			end = e.StartedAt
+		}
+
+		entries = append(entries, customInfoEntry{
+			Entry:           e,
+			Phase:           e.Phase.String(),
+			DurationSeconds: end.Sub(e.StartedAt).Seconds(),
+		})
+	}
+
+	s, err := utils.MarshalObjToStruct(customInfo{Entries: entries, Dropped: t.Dropped})
+	if err != nil {
+		return err
+	}
+
+	if info.CustomInfo == nil {
+		info.CustomInfo = &structpb.Struct{}
+	}
+
+	if info.CustomInfo.Fields == nil {
+		info.CustomInfo.Fields = map[string]*structpb.Value{}
+	}
+
+	info.CustomInfo.Fields[CustomInfoKey] = &structpb.Value{Kind: &structpb.Value_StructValue{StructValue: s}}
+	return nil
+}
+
+// Metrics observes how long tasks spend in each phase.
+type Metrics struct {
+	durations *prometheus.HistogramVec
+}
+
+// Observe records that a task of the plugin left the entry after duration.
+func (m Metrics) Observe(pluginID string, left Entry, duration time.Duration) {
+	m.durations.WithLabelValues(pluginID, left.Phase.String(), left.SubReason).Observe(duration.Seconds())
+}
+
+// NewMetrics creates metrics publishing a histogram of the phase durations, in seconds, under scope.
+func NewMetrics(scope promutils.Scope) Metrics {
+	return Metrics{
+		durations: scope.MustNewHistogramVec("phase_duration_seconds",
+			"Time tasks spent in a phase by plugin, phase and sub-reason.", "plugin", "phase", "sub_reason"),
+	}
+}
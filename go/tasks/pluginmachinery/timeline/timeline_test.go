@@ -0,0 +1,101 @@
+package timeline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
+)
+
+func TestTimeline_Record(t *testing.T) {
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time { return start.Add(time.Duration(seconds) * time.Second) }
+	queued := func(seconds int) core.PhaseInfo {
+		return core.PhaseInfoQueued(at(seconds), 0, "Quota exceeded").
+			WithSubReason(core.SubReasonWaitingForResources)
+	}
+
+	tl := Timeline{}
+	left, _ := tl.Record(queued(0), at(0))
+	assert.Nil(t, left)
+
+	left, _ = tl.Record(queued(5), at(5))
+	assert.Nil(t, left)
+
+	left, duration := tl.Record(core.PhaseInfoQueued(at(10), 0, "Allocation token acquired"), at(10))
+	assert.Equal(t, &Entry{Phase: core.PhaseQueued, SubReason: core.SubReasonWaitingForResources,
+		Reason: "Quota exceeded", StartedAt: at(0)}, left)
+	assert.Equal(t, 10*time.Second, duration)
+
+	// Phases reported as occurring before the last one are moved to when the last one started.
+	running := core.PhaseInfoRunning(1, &core.TaskInfo{OccurredAt: &start})
+	left, duration = tl.Record(running, at(12))
+	assert.Equal(t, core.PhaseQueued, left.Phase)
+	assert.Equal(t, time.Duration(0), duration)
+
+	left, _ = tl.Record(core.PhaseInfoRunning(2, &core.TaskInfo{OccurredAt: &start}), at(15))
+	assert.Nil(t, left)
+	assert.Len(t, tl.Entries, 3)
+	assert.Equal(t, at(10), tl.Entries[2].StartedAt)
+}
+
+func TestTimeline_Record_compacts(t *testing.T) {
+	start := time.Now()
+	tl := Timeline{}
+	for i := 0; i < MaxEntries+5; i++ {
+		tl.Record(core.PhaseInfoQueued(start, 0, "").WithSubReason(string(rune('a'+i))), start)
+	}
+
+	assert.Len(t, tl.Entries, MaxEntries)
+	assert.Equal(t, 5, tl.Dropped)
+	assert.Equal(t, "a", tl.Entries[0].SubReason)
+	assert.Equal(t, string(rune('a'+6)), tl.Entries[1].SubReason)
+}
+
+func TestTimeline_AddToTaskInfo(t *testing.T) {
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	tl := Timeline{Entries: []Entry{
+		{Phase: core.PhaseQueued, SubReason: core.SubReasonWaitingForResources, Reason: "Quota exceeded",
+			StartedAt: start},
+		{Phase: core.PhaseRunning, StartedAt: start.Add(time.Minute)},
+	}}
+
+	existing, err := utils.MarshalObjToStruct(map[string]string{"jobId": "123"})
+	assert.NoError(t, err)
+	info := &core.TaskInfo{CustomInfo: existing}
+	assert.NoError(t, tl.AddToTaskInfo(info, start.Add(3*time.Minute)))
+
+	actual := map[string]interface{}{}
+	assert.NoError(t, utils.UnmarshalStructToObj(info.CustomInfo, &actual))
+	assert.Equal(t, map[string]interface{}{
+		"jobId": "123",
+		CustomInfoKey: map[string]interface{}{
+			"entries": []interface{}{
+				map[string]interface{}{"phase": "PhaseQueued", "subReason": "WaitingForResources",
+					"reason": "Quota exceeded", "startedAt": "2022-01-01T00:00:00Z", "durationSeconds": float64(60)},
+				map[string]interface{}{"phase": "PhaseRunning", "startedAt": "2022-01-01T00:01:00Z",
+					"durationSeconds": float64(120)},
+			},
+		},
+	}, actual)
+
+	t.Run("terminal", func(t *testing.T) {
+		tl.Entries = append(tl.Entries, Entry{Phase: core.PhaseSuccess, StartedAt: start.Add(2 * time.Minute)})
+		info := &core.TaskInfo{}
+		assert.NoError(t, tl.AddToTaskInfo(info, start.Add(3*time.Minute)))
+		entries := info.CustomInfo.Fields[CustomInfoKey].GetStructValue().Fields["entries"].GetListValue().Values
+		assert.Len(t, entries, 3)
+		assert.NotContains(t, entries[2].GetStructValue().Fields, "durationSeconds")
+	})
+}
+
+func TestMetrics_Observe(t *testing.T) {
+	m := NewMetrics(promutils.NewTestScope())
+	m.Observe("my-plugin", Entry{Phase: core.PhaseQueued, SubReason: core.SubReasonWaitingForResources}, time.Minute)
+	assert.Equal(t, 1, testutil.CollectAndCount(m.durations))
+}
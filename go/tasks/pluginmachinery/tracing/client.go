@@ -0,0 +1,84 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// Attributes set on the spans of kubernetes API calls.
+const (
+	K8sKindKey      = attribute.Key("k8s.kind")
+	K8sNamespaceKey = attribute.Key("k8s.namespace.name")
+	K8sNameKey      = attribute.Key("k8s.object.name")
+)
+
+// KubeClient mirrors pluginmachinery/core.KubeClient, which it's interchangeable with.
+type KubeClient interface {
+	GetClient() client.Client
+	GetCache() cache.Cache
+}
+
+type tracedKubeClient struct {
+	KubeClient
+}
+
+func (k tracedKubeClient) GetClient() client.Client {
+	return NewClient(k.KubeClient.GetClient())
+}
+
+// NewKubeClient wraps kubeClient so that the calls made through its client are traced. See NewClient.
+func NewKubeClient(kubeClient KubeClient) KubeClient {
+	return tracedKubeClient{KubeClient: kubeClient}
+}
+
+type tracedClient struct {
+	client.Client
+}
+
+func (c tracedClient) startSpan(ctx context.Context, name string, key client.ObjectKey, obj client.Object) (
+	context.Context, func(err error)) {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	if scheme := c.Scheme(); len(kind) == 0 && scheme != nil {
+		if gvk, err := apiutil.GVKForObject(obj, scheme); err == nil {
+			kind = gvk.Kind
+		}
+	}
+
+	ctx, span := StartSpan(ctx, name, K8sKindKey.String(kind), K8sNamespaceKey.String(key.Namespace),
+		K8sNameKey.String(key.Name))
+	return ctx, func(err error) { EndSpan(span, err) }
+}
+
+func (c tracedClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	ctx, end := c.startSpan(ctx, "k8s.get", key, obj)
+	err := c.Client.Get(ctx, key, obj)
+	end(err)
+	return err
+}
+
+func (c tracedClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	ctx, end := c.startSpan(ctx, "k8s.create", client.ObjectKeyFromObject(obj), obj)
+	err := c.Client.Create(ctx, obj, opts...)
+	end(err)
+	return err
+}
+
+func (c tracedClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	ctx, end := c.startSpan(ctx, "k8s.delete", client.ObjectKeyFromObject(obj), obj)
+	err := c.Client.Delete(ctx, obj, opts...)
+	end(err)
+	return err
+}
+
+// NewClient wraps c so that its Get, Create and Delete calls are traced by k8s.<verb> spans. Other calls aren't traced.
+func NewClient(c client.Client) client.Client {
+	if c == nil {
+		return nil
+	}
+
+	return tracedClient{Client: c}
+}
@@ -0,0 +1,41 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/codes"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNewClient(t *testing.T) {
+	sr := withSpanRecorder(t)
+	ctx := context.Background()
+	c := NewClient(fake.NewClientBuilder().WithScheme(scheme.Scheme).Build())
+
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "p1", Namespace: "ns"}}
+	assert.NoError(t, c.Create(ctx, pod))
+	assert.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(pod), &v1.Pod{}))
+	assert.NoError(t, c.Delete(ctx, pod))
+	assert.Error(t, c.Get(ctx, client.ObjectKeyFromObject(pod), &v1.Pod{}))
+
+	spans := sr.Ended()
+	if assert.Len(t, spans, 4) {
+		assert.Equal(t, "k8s.create", spans[0].Name())
+		attrs := spanAttributes(spans[0])
+		assert.Equal(t, "Pod", attrs[K8sKindKey].AsString())
+		assert.Equal(t, "ns", attrs[K8sNamespaceKey].AsString())
+		assert.Equal(t, "p1", attrs[K8sNameKey].AsString())
+
+		assert.Equal(t, "k8s.get", spans[1].Name())
+		assert.Equal(t, "k8s.delete", spans[2].Name())
+		assert.Equal(t, codes.Error, spans[3].Status().Code)
+	}
+
+	assert.Nil(t, NewClient(nil))
+}
@@ -0,0 +1,32 @@
+package tracing
+
+import (
+	"github.com/flyteorg/flyteplugins/go/tasks/config"
+)
+
+//go:generate pflags Config --default-var=defaultConfig
+
+// ExporterStdout writes spans to stdout, as json.
+const ExporterStdout = "stdout"
+
+var (
+	defaultConfig = &Config{
+		Exporter:      ExporterStdout,
+		ServiceName:   "flyteplugins",
+		SamplingRatio: 1,
+	}
+
+	cfgSection = config.MustRegisterSubSection("tracing", defaultConfig)
+)
+
+// Config configures the tracer provider installed by InitTracerProvider.
+type Config struct {
+	Enabled       bool    `json:"enabled" pflag:",Enables exporting of the spans created by plugins."`
+	Exporter      string  `json:"exporter" pflag:",Exporter spans are sent to. Only stdout is supported."`
+	ServiceName   string  `json:"serviceName" pflag:",Service name set on exported spans."`
+	SamplingRatio float64 `json:"samplingRatio" pflag:",Fraction of the traces started by plugins to sample."`
+}
+
+func GetConfig() *Config {
+	return cfgSection.GetConfig().(*Config)
+}
@@ -0,0 +1,85 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Propagator propagates the trace context (https://www.w3.org/TR/trace-context/) and baggage of spans to the
+// services and containers tasks run in.
+var Propagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+// InjectIntoAnnotations adds the trace context of the span in ctx, if any, to annotations (e.g. traceparent) so that
+// containers reading their pod's annotations can join the trace. It returns annotations, allocating it if nil and
+// there's a trace context to add.
+func InjectIntoAnnotations(ctx context.Context, annotations map[string]string) map[string]string {
+	carrier := propagation.MapCarrier{}
+	Propagator.Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return annotations
+	}
+
+	if annotations == nil {
+		annotations = make(map[string]string, len(carrier))
+	}
+
+	for k, v := range carrier {
+		annotations[k] = v
+	}
+
+	return annotations
+}
+
+type transport struct {
+	base http.RoundTripper
+}
+
+func (t transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := StartSpan(req.Context(), "HTTP "+req.Method,
+		semconv.HTTPMethodKey.String(req.Method),
+		semconv.HTTPURLKey.String(req.URL.Redacted()),
+		semconv.NetPeerNameKey.String(req.URL.Hostname()))
+
+	req = req.Clone(ctx)
+	Propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		EndSpan(span, err)
+		return resp, err
+	}
+
+	span.SetAttributes(semconv.HTTPStatusCodeKey.Int(resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		EndSpan(span, httpError(resp.StatusCode))
+	} else {
+		EndSpan(span, nil)
+	}
+
+	return resp, nil
+}
+
+type httpError int
+
+func (e httpError) Error() string {
+	return "HTTP " + strconv.Itoa(int(e)) + " " + http.StatusText(int(e))
+}
+
+// NewTransport wraps base, or http.DefaultTransport if nil, so that each request is traced by a client span whose
+// trace context is propagated in the request headers.
+func NewTransport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return transport{base: base}
+}
+
+// SpanContextFromAnnotations returns the trace context added to annotations by InjectIntoAnnotations.
+func SpanContextFromAnnotations(annotations map[string]string) trace.SpanContext {
+	return trace.SpanContextFromContext(Propagator.Extract(context.Background(), propagation.MapCarrier(annotations)))
+}
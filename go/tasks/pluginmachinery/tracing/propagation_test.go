@@ -0,0 +1,84 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestInjectIntoAnnotations(t *testing.T) {
+	withSpanRecorder(t)
+
+	t.Run("no span", func(t *testing.T) {
+		assert.Nil(t, InjectIntoAnnotations(context.Background(), nil))
+		assert.Equal(t, map[string]string{"a": "b"}, InjectIntoAnnotations(context.Background(),
+			map[string]string{"a": "b"}))
+	})
+
+	t.Run("span", func(t *testing.T) {
+		ctx, span := StartSpan(context.Background(), "test")
+		defer span.End()
+
+		annotations := InjectIntoAnnotations(ctx, map[string]string{"a": "b"})
+		assert.Equal(t, "b", annotations["a"])
+		assert.Contains(t, annotations, "traceparent")
+
+		spanCtx := SpanContextFromAnnotations(annotations)
+		assert.True(t, spanCtx.IsValid())
+		assert.Equal(t, span.SpanContext().TraceID(), spanCtx.TraceID())
+		assert.Equal(t, span.SpanContext().SpanID(), spanCtx.SpanID())
+	})
+}
+
+func TestNewTransport(t *testing.T) {
+	sr := withSpanRecorder(t)
+
+	var received trace.SpanContext
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = trace.SpanContextFromContext(Propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header)))
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewTransport(nil)}
+	ctx, parent := StartSpan(context.Background(), "parent")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/ok", nil)
+	assert.NoError(t, err)
+	resp, err := client.Do(req)
+	assert.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+	assert.Empty(t, req.Header.Get("traceparent"), "the caller's request shouldn't be modified")
+
+	req, err = http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/missing", nil)
+	assert.NoError(t, err)
+	resp, err = client.Do(req)
+	assert.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+	parent.End()
+
+	spans := sr.Ended()
+	if assert.Len(t, spans, 3) {
+		assert.Equal(t, "HTTP GET", spans[0].Name())
+		assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+		assert.Equal(t, int64(http.StatusOK), spanAttributes(spans[0])[semconv.HTTPStatusCodeKey].AsInt64())
+		assert.Equal(t, codes.Unset, spans[0].Status().Code)
+
+		assert.Equal(t, "HTTP POST", spans[1].Name())
+		assert.Equal(t, codes.Error, spans[1].Status().Code)
+		assert.Equal(t, "HTTP 404 Not Found", spans[1].Status().Description)
+
+		// The server sees the span of the last request as its parent.
+		assert.Equal(t, spans[1].SpanContext().SpanID(), received.SpanID())
+		assert.Equal(t, parent.SpanContext().TraceID(), received.TraceID())
+	}
+}
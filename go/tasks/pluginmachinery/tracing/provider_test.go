@@ -0,0 +1,37 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+)
+
+func TestInitTracerProvider(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("disabled", func(t *testing.T) {
+		prev := otel.GetTracerProvider()
+		shutdown, err := InitTracerProvider(ctx, &Config{})
+		assert.NoError(t, err)
+		assert.Equal(t, prev, otel.GetTracerProvider())
+		assert.NoError(t, shutdown(ctx))
+	})
+
+	t.Run("unsupported exporter", func(t *testing.T) {
+		_, err := InitTracerProvider(ctx, &Config{Enabled: true, Exporter: "zipkin"})
+		assert.EqualError(t, err, "unsupported span exporter [zipkin]")
+	})
+
+	t.Run("stdout", func(t *testing.T) {
+		prev := otel.GetTracerProvider()
+		defer otel.SetTracerProvider(prev)
+
+		shutdown, err := InitTracerProvider(ctx, &Config{Enabled: true, Exporter: ExporterStdout,
+			ServiceName: "test", SamplingRatio: 0})
+		assert.NoError(t, err)
+		assert.NotEqual(t, prev, otel.GetTracerProvider())
+		assert.NoError(t, shutdown(ctx))
+	})
+}
@@ -0,0 +1,89 @@
+// Package tracing instruments plugins with OpenTelemetry spans. Spans are created using the global tracer provider,
+// which doesn't record anything unless the process embedding the plugins installs one, either its own or the one
+// configured through InitTracerProvider.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/flyteorg/flyteplugins"
+
+// Attributes set on the spans created by plugins.
+const (
+	TaskExecutionIDKey = attribute.Key("flyte.task_execution.id")
+	ProjectKey         = attribute.Key("flyte.project")
+	DomainKey          = attribute.Key("flyte.domain")
+	ExecutionNameKey   = attribute.Key("flyte.execution.name")
+	NodeIDKey          = attribute.Key("flyte.node.id")
+	RetryAttemptKey    = attribute.Key("flyte.task_execution.retry_attempt")
+	PluginIDKey        = attribute.Key("flyte.plugin.id")
+	PhaseKey           = attribute.Key("flyte.phase")
+	StoragePathKey     = attribute.Key("flyte.storage.path")
+	CatalogTaskKey     = attribute.Key("flyte.catalog.task")
+	CatalogVersionKey  = attribute.Key("flyte.catalog.cache_version")
+	CatalogStatusKey   = attribute.Key("flyte.catalog.cache_status")
+)
+
+// Tracer returns the tracer used to instrument plugins.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// StartSpan starts a span, child of the span in ctx if any, with the given attributes.
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// EndSpan ends span, recording err as its status if not nil.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// TaskExecutionID is the subset of pluginmachinery/core.TaskExecutionID used to annotate spans. It's redeclared here so
+// that packages core depends on (e.g. catalog) can be traced.
+type TaskExecutionID interface {
+	GetGeneratedName() string
+	GetID() idlCore.TaskExecutionIdentifier
+}
+
+// TaskAttributes returns the attributes identifying the task execution with the given id.
+func TaskAttributes(taskExecID TaskExecutionID) []attribute.KeyValue {
+	if taskExecID == nil {
+		return nil
+	}
+
+	id := taskExecID.GetID()
+	nodeExecID := id.GetNodeExecutionId()
+	execID := nodeExecID.GetExecutionId()
+	return []attribute.KeyValue{
+		TaskExecutionIDKey.String(taskExecID.GetGeneratedName()),
+		ProjectKey.String(execID.GetProject()),
+		DomainKey.String(execID.GetDomain()),
+		ExecutionNameKey.String(execID.GetName()),
+		NodeIDKey.String(nodeExecID.GetNodeId()),
+		RetryAttemptKey.Int64(int64(id.GetRetryAttempt())),
+	}
+}
+
+// PluginAttribute returns the attribute identifying the plugin with the given id.
+func PluginAttribute(pluginID string) attribute.KeyValue {
+	return PluginIDKey.String(pluginID)
+}
+
+// PhaseAttribute returns the attribute holding a task phase (i.e. a pluginmachinery/core.Phase).
+func PhaseAttribute(phase fmt.Stringer) attribute.KeyValue {
+	return PhaseKey.String(phase.String())
+}
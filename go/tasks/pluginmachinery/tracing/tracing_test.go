@@ -0,0 +1,108 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// taskExecutionID stands in for core.TaskExecutionID, which can't be imported as core depends on this package.
+type taskExecutionID struct {
+	generatedName string
+	id            idlCore.TaskExecutionIdentifier
+}
+
+func (t taskExecutionID) GetGeneratedName() string {
+	return t.generatedName
+}
+
+func (t taskExecutionID) GetID() idlCore.TaskExecutionIdentifier {
+	return t.id
+}
+
+type phase string
+
+func (p phase) String() string {
+	return string(p)
+}
+
+func withSpanRecorder(t *testing.T) *tracetest.SpanRecorder {
+	sr := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+	return sr
+}
+
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	res := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes() {
+		res[kv.Key] = kv.Value
+	}
+
+	return res
+}
+
+func TestTaskAttributes(t *testing.T) {
+	t.Run("nil", func(t *testing.T) {
+		assert.Empty(t, TaskAttributes(nil))
+	})
+
+	t.Run("task execution id", func(t *testing.T) {
+		tID := taskExecutionID{
+			generatedName: "exec-name-n0-1",
+			id: idlCore.TaskExecutionIdentifier{
+				NodeExecutionId: &idlCore.NodeExecutionIdentifier{
+					NodeId: "n0",
+					ExecutionId: &idlCore.WorkflowExecutionIdentifier{
+						Project: "flytesnacks",
+						Domain:  "development",
+						Name:    "exec-name",
+					},
+				},
+				RetryAttempt: 1,
+			},
+		}
+
+		assert.Equal(t, []attribute.KeyValue{
+			TaskExecutionIDKey.String("exec-name-n0-1"),
+			ProjectKey.String("flytesnacks"),
+			DomainKey.String("development"),
+			ExecutionNameKey.String("exec-name"),
+			NodeIDKey.String("n0"),
+			RetryAttemptKey.Int64(1),
+		}, TaskAttributes(tID))
+	})
+}
+
+func TestStartSpan(t *testing.T) {
+	sr := withSpanRecorder(t)
+	ctx := context.Background()
+
+	ctx, parent := StartSpan(ctx, "parent", PluginAttribute("my-plugin"))
+	_, child := StartSpan(ctx, "child", PhaseAttribute(phase("PhaseRunning")))
+	EndSpan(child, fmt.Errorf("failed"))
+	EndSpan(parent, nil)
+
+	spans := sr.Ended()
+	if assert.Len(t, spans, 2) {
+		assert.Equal(t, "child", spans[0].Name())
+		assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+		assert.Equal(t, "PhaseRunning", spanAttributes(spans[0])[PhaseKey].AsString())
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		assert.Equal(t, "failed", spans[0].Status().Description)
+		assert.Len(t, spans[0].Events(), 1)
+
+		assert.Equal(t, "parent", spans[1].Name())
+		assert.Equal(t, "my-plugin", spanAttributes(spans[1])[PluginIDKey].AsString())
+		assert.Equal(t, codes.Unset, spans[1].Status().Code)
+	}
+}
@@ -0,0 +1,61 @@
+package watchdog
+
+import (
+	"github.com/flyteorg/flytestdlib/config"
+
+	pluginsConfig "github.com/flyteorg/flyteplugins/go/tasks/config"
+)
+
+//go:generate pflags Config --default-var=defaultConfig
+
+// Action is what the watchdog does with a task past its threshold.
+type Action string
+
+const (
+	// ActionNone leaves the task alone.
+	ActionNone Action = ""
+	// ActionWarn notes in the reason of the phase that the task seems stuck, which is reported as a new event.
+	ActionWarn Action = "warn"
+	// ActionRetry fails the task with a retryable failure.
+	ActionRetry Action = "retry"
+	// ActionAbort fails the task with a permanent failure.
+	ActionAbort Action = "abort"
+)
+
+// Actions lists the known actions, except ActionNone.
+var Actions = []Action{ActionWarn, ActionRetry, ActionAbort}
+
+var (
+	defaultConfig = &Config{
+		NearLimitRatio: 0.8,
+	}
+
+	cfgSection = pluginsConfig.MustRegisterSubSection("watchdog", defaultConfig)
+)
+
+// Config sets how long tasks of web API plugins may go without any change of their phase or status before the watchdog
+// acts on them.
+type Config struct {
+	// Rules are evaluated in order, the first rule matching the plugin and phase of a task decides its threshold.
+	// Tasks no rule matches are never considered stuck.
+	Rules          []Rule  `json:"rules" pflag:"-,Rules setting the thresholds of tasks per plugin and phase."`
+	NearLimitRatio float64 `json:"nearLimitRatio" pflag:",Fraction of the threshold past which tasks are counted as close to the limit."`
+}
+
+// Rule sets the threshold of the tasks matching all of its (non-empty) selectors.
+type Rule struct {
+	Plugins []string `json:"plugins" pflag:",Ids of the web API plugins the rule applies to. Applies to all web API plugins if empty."`
+	// Phases are names of phases, e.g. PhaseQueued. Only PhaseQueued, PhaseInitializing and PhaseRunning are watched.
+	Phases    []string        `json:"phases" pflag:",Phases the rule applies to. Applies to all watched phases if empty."`
+	Threshold config.Duration `json:"threshold" pflag:",Time without any change after which the task is considered stuck."`
+	Action    Action          `json:"action" pflag:",Action to take on stuck tasks: warn, retry or abort."`
+}
+
+func GetConfig() *Config {
+	return cfgSection.GetConfig().(*Config)
+}
+
+// SetConfig should be used for unit testing only
+func SetConfig(cfg *Config) error {
+	return cfgSection.SetConfig(cfg)
+}
@@ -0,0 +1,215 @@
+// Package watchdog finds tasks stuck in a phase, e.g. queued behind an exhausted quota or running with a remote service
+// that stopped reporting updates. Frameworks keep the watchdog state of a task in its plugin state, observe every phase
+// reported for it and apply the verdict of the watchdog once the task went without any change of phase or status for
+// longer than the configured threshold. The webapi framework is the only one watching its tasks so far, tasks of
+// array, hive, presto and k8s plugins are never considered stuck.
+package watchdog
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+)
+
+// StuckTaskErrorCode is the code of the failures reported for stuck tasks.
+const StuckTaskErrorCode = "StuckTask"
+
+// WatchedPhases are the phases tasks can be found stuck in.
+var WatchedPhases = []core.Phase{core.PhaseQueued, core.PhaseInitializing, core.PhaseRunning}
+
+func isWatched(phase core.Phase) bool {
+	for _, p := range WatchedPhases {
+		if p == phase {
+			return true
+		}
+	}
+
+	return false
+}
+
+// State is the watchdog state of a task.
+type State struct {
+	// Observed is the phase last observed, versioned to tell when the status reported within the phase changes.
+	Observed core.PhaseVersion `json:"observed,omitempty"`
+	// Since is when the phase or status of the task last changed.
+	Since time.Time `json:"since,omitempty"`
+	// NearLimit is set once the task is counted as close to its threshold.
+	NearLimit bool `json:"nearLimit,omitempty"`
+	// Stuck is set once the task is past its threshold.
+	Stuck bool `json:"stuck,omitempty"`
+}
+
+// Verdict is what to do with a task.
+type Verdict struct {
+	Action Action
+	// Threshold the task is past.
+	Threshold time.Duration
+}
+
+// Apply returns the phase to report instead of info: the phase with a note in its reason for ActionWarn, or a timeout
+// failure for ActionRetry and ActionAbort.
+func (v Verdict) Apply(info core.PhaseInfo) core.PhaseInfo {
+	switch v.Action {
+	case ActionWarn:
+		return info.WithReason(fmt.Sprintf("%v (no change observed for over %v)", info.Reason(), v.Threshold))
+	case ActionRetry, ActionAbort:
+		return core.PhaseInfoClassifiedFailure(StuckTaskErrorCode,
+			fmt.Sprintf("Task was stuck in %v with no change observed for over %v", info.Phase(), v.Threshold),
+			errors.Classification{Class: errors.ClassTimeout, Retryable: v.Action == ActionRetry}, info.Info())
+	default:
+		return info
+	}
+}
+
+type rule struct {
+	Rule
+	phases []core.Phase
+}
+
+func (r rule) matches(pluginID string, phase core.Phase) bool {
+	if len(r.Plugins) > 0 && !contains(r.Plugins, pluginID) {
+		return false
+	}
+
+	if len(r.phases) == 0 {
+		return true
+	}
+
+	for _, p := range r.phases {
+		if p == phase {
+			return true
+		}
+	}
+
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isValid(a Action) bool {
+	for _, known := range Actions {
+		if a == known {
+			return true
+		}
+	}
+
+	return false
+}
+
+type metrics struct {
+	nearLimit *prometheus.CounterVec
+	stuck     *prometheus.CounterVec
+}
+
+// Watchdog applies the rules of a Config to the phases observed for tasks.
+type Watchdog struct {
+	rules          []rule
+	nearLimitRatio float64
+	metrics        metrics
+}
+
+func (w *Watchdog) match(pluginID string, phase core.Phase) (rule, bool) {
+	if !isWatched(phase) {
+		return rule{}, false
+	}
+
+	for _, r := range w.rules {
+		if r.matches(pluginID, phase) {
+			return r, true
+		}
+	}
+
+	return rule{}, false
+}
+
+// Observe records in s the phase reported at now for a task of the plugin and returns what to do with the task. The
+// task is left alone until it goes without any change for longer than the threshold of the first rule matching it.
+// ignoredLogParams are the query parameters of log links that don't count as a change (see core.PhaseVersion.Update).
+func (w *Watchdog) Observe(pluginID string, s *State, info core.PhaseInfo, now time.Time, ignoredLogParams ...string) (
+	Verdict, error) {
+	observed := s.Observed
+	if _, err := observed.Update(info, ignoredLogParams...); err != nil {
+		return Verdict{}, err
+	}
+
+	if s.Since.IsZero() || observed.Phase != s.Observed.Phase || observed.Version != s.Observed.Version {
+		*s = State{Observed: observed, Since: now}
+	}
+
+	r, found := w.match(pluginID, info.Phase())
+	if !found {
+		return Verdict{}, nil
+	}
+
+	elapsed := now.Sub(s.Since)
+	if !s.NearLimit && elapsed.Seconds() >= w.nearLimitRatio*r.Threshold.Seconds() {
+		s.NearLimit = true
+		w.metrics.nearLimit.WithLabelValues(pluginID, info.Phase().String()).Inc()
+	}
+
+	if elapsed < r.Threshold.Duration {
+		return Verdict{}, nil
+	}
+
+	if !s.Stuck {
+		s.Stuck = true
+		w.metrics.stuck.WithLabelValues(pluginID, info.Phase().String(), string(r.Action)).Inc()
+	}
+
+	return Verdict{Action: r.Action, Threshold: r.Threshold.Duration}, nil
+}
+
+// NewWatchdog creates a watchdog applying the rules of cfg, publishing its metrics under scope. It fails if a rule has
+// an unknown phase or action, or a threshold that isn't positive.
+func NewWatchdog(cfg *Config, scope promutils.Scope) (*Watchdog, error) {
+	if cfg.NearLimitRatio <= 0 || cfg.NearLimitRatio > 1 {
+		return nil, fmt.Errorf("near limit ratio is expected to be in (0, 1]. Provided value is %v", cfg.NearLimitRatio)
+	}
+
+	rules := make([]rule, 0, len(cfg.Rules))
+	for i, r := range cfg.Rules {
+		if !isValid(r.Action) {
+			return nil, fmt.Errorf("rule [%v] has unknown action [%v], expected one of %v", i, r.Action, Actions)
+		}
+
+		if r.Threshold.Duration <= 0 {
+			return nil, fmt.Errorf("rule [%v] has a threshold that isn't positive [%v]", i, r.Threshold.Duration)
+		}
+
+		parsed := rule{Rule: r}
+		for _, name := range r.Phases {
+			phase, err := core.PhaseString(name)
+			if err != nil || !isWatched(phase) {
+				return nil, fmt.Errorf("rule [%v] has phase [%v], expected one of %v", i, name, WatchedPhases)
+			}
+
+			parsed.phases = append(parsed.phases, phase)
+		}
+
+		rules = append(rules, parsed)
+	}
+
+	return &Watchdog{
+		rules:          rules,
+		nearLimitRatio: cfg.NearLimitRatio,
+		metrics: metrics{
+			nearLimit: scope.MustNewCounterVec("near_limit",
+				"Tasks that went without any change for close to their threshold.", "plugin", "phase"),
+			stuck: scope.MustNewCounterVec("stuck",
+				"Tasks that went without any change for longer than their threshold.", "plugin", "phase", "action"),
+		},
+	}, nil
+}
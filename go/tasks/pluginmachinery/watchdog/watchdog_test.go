@@ -0,0 +1,111 @@
+package watchdog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+)
+
+func TestNewWatchdog(t *testing.T) {
+	threshold := config.Duration{Duration: time.Minute}
+	for _, tc := range []struct {
+		name  string
+		cfg   Config
+		valid bool
+	}{
+		{"default", *defaultConfig, true},
+		{"valid", Config{NearLimitRatio: 1, Rules: []Rule{
+			{Plugins: []string{"databricks"}, Phases: []string{"PhaseQueued", "PhaseRunning"}, Threshold: threshold,
+				Action: ActionAbort},
+			{Threshold: threshold, Action: ActionWarn},
+		}}, true},
+		{"no ratio", Config{}, false},
+		{"unknown action", Config{NearLimitRatio: 0.5, Rules: []Rule{{Threshold: threshold, Action: "kill"}}}, false},
+		{"no threshold", Config{NearLimitRatio: 0.5, Rules: []Rule{{Action: ActionWarn}}}, false},
+		{"unknown phase", Config{NearLimitRatio: 0.5, Rules: []Rule{
+			{Phases: []string{"Queued"}, Threshold: threshold, Action: ActionWarn},
+		}}, false},
+		{"unwatched phase", Config{NearLimitRatio: 0.5, Rules: []Rule{
+			{Phases: []string{"PhaseSuccess"}, Threshold: threshold, Action: ActionWarn},
+		}}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewWatchdog(&tc.cfg, promutils.NewTestScope())
+			assert.Equal(t, tc.valid, err == nil, err)
+		})
+	}
+}
+
+func TestWatchdog_Observe(t *testing.T) {
+	w, err := NewWatchdog(&Config{NearLimitRatio: 0.5, Rules: []Rule{
+		{Plugins: []string{"other"}, Threshold: config.Duration{Duration: time.Second}, Action: ActionAbort},
+		{Phases: []string{"PhaseQueued"}, Threshold: config.Duration{Duration: 10 * time.Minute}, Action: ActionWarn},
+		{Phases: []string{"PhaseRunning"}, Threshold: config.Duration{Duration: time.Hour}, Action: ActionRetry},
+	}}, promutils.NewTestScope())
+	assert.NoError(t, err)
+
+	start := time.Now()
+	s := State{}
+	observe := func(info core.PhaseInfo, elapsed time.Duration) Verdict {
+		v, err := w.Observe("my-plugin", &s, info, start.Add(elapsed))
+		assert.NoError(t, err)
+		return v
+	}
+
+	queued := core.PhaseInfoQueued(start, core.DefaultPhaseVersion, "queued")
+	assert.Equal(t, Verdict{}, observe(queued, 0))
+	assert.Equal(t, Verdict{}, observe(queued, 4*time.Minute))
+	assert.False(t, s.NearLimit)
+
+	assert.Equal(t, Verdict{}, observe(queued, 5*time.Minute))
+	assert.True(t, s.NearLimit)
+	assert.Equal(t, float64(1), testutil.ToFloat64(w.metrics.nearLimit.WithLabelValues("my-plugin", "PhaseQueued")))
+
+	expected := Verdict{Action: ActionWarn, Threshold: 10 * time.Minute}
+	assert.Equal(t, expected, observe(queued, 10*time.Minute))
+	assert.Equal(t, expected, observe(queued, 11*time.Minute))
+	assert.True(t, s.Stuck)
+	assert.Equal(t, float64(1), testutil.ToFloat64(w.metrics.stuck.WithLabelValues("my-plugin", "PhaseQueued", "warn")))
+
+	// Any change of the status reported resets the watchdog.
+	assert.Equal(t, Verdict{}, observe(queued.WithReason("still queued"), 12*time.Minute))
+	assert.Equal(t, start.Add(12*time.Minute), s.Since)
+	assert.False(t, s.Stuck)
+	assert.False(t, s.NearLimit)
+
+	running := core.PhaseInfoRunning(core.DefaultPhaseVersion, nil)
+	assert.Equal(t, Verdict{}, observe(running, 13*time.Minute))
+	assert.Equal(t, Verdict{Action: ActionRetry, Threshold: time.Hour}, observe(running, 73*time.Minute))
+
+	// Unwatched phases are never stuck.
+	assert.Equal(t, Verdict{}, observe(core.PhaseInfoSuccess(nil), 100*time.Hour))
+	assert.Equal(t, Verdict{}, observe(core.PhaseInfoSuccess(nil), 200*time.Hour))
+}
+
+func TestVerdict_Apply(t *testing.T) {
+	info := &core.TaskInfo{}
+	queued := core.PhaseInfoQueuedWithTaskInfo(core.DefaultPhaseVersion, "queued", info)
+
+	assert.Equal(t, queued, Verdict{}.Apply(queued))
+
+	warned := Verdict{Action: ActionWarn, Threshold: time.Minute}.Apply(queued)
+	assert.Equal(t, core.PhaseQueued, warned.Phase())
+	assert.Equal(t, "queued (no change observed for over 1m0s)", warned.Reason())
+
+	retried := Verdict{Action: ActionRetry, Threshold: time.Minute}.Apply(queued)
+	assert.Equal(t, core.PhaseRetryableFailure, retried.Phase())
+	assert.Equal(t, StuckTaskErrorCode, retried.Err().GetCode())
+	assert.Equal(t, errors.Classification{Class: errors.ClassTimeout, Retryable: true}, retried.Classification())
+	assert.Equal(t, info, retried.Info())
+
+	aborted := Verdict{Action: ActionAbort, Threshold: time.Minute}.Apply(queued)
+	assert.Equal(t, core.PhasePermanentFailure, aborted.Phase())
+	assert.Equal(t, errors.Classification{Class: errors.ClassTimeout}, aborted.Classification())
+}
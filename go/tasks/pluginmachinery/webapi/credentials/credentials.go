@@ -0,0 +1,115 @@
+// Package credentials resolves the credentials WebAPI plugins use to call their remote services per project and
+// domain, so that projects don't have to share a single identity.
+//
+// Credentials are looked up from the most specific configuration to the least specific one: the project and domain
+// override, then the project override and finally the default credentials. Resource metas only hold a Ref to the
+// credentials, never the credentials themselves, so that secrets don't end up in the persisted plugin state.
+package credentials
+
+import (
+	"github.com/flyteorg/flytestdlib/config"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+)
+
+// OAuthClient configures an OAuth2 client, whose tokens are obtained using the client credentials grant.
+type OAuthClient struct {
+	ClientID        string   `json:"clientId" pflag:",Client ID of the OAuth2 client."`
+	ClientSecretKey string   `json:"clientSecretKey" pflag:",Name of the key where to find the client secret in the secret manager."`
+	TokenURL        string   `json:"tokenUrl" pflag:",URL of the token endpoint of the authorization server."`
+	Scopes          []string `json:"scopes" pflag:",Scopes to request."`
+}
+
+// IsEmpty returns true if the OAuth2 client is not configured.
+func (c OAuthClient) IsEmpty() bool {
+	return len(c.ClientID) == 0
+}
+
+// Credentials configures how to authenticate to the remote service, and where to reach it.
+type Credentials struct {
+	// TokenKey references the token in the secret manager, either as a plain key or as a structured reference, e.g.
+	// snowflake/token or databricks/api@3#token (see the secretmanager package). It's ignored if the OAuth client is
+	// configured.
+	TokenKey string `json:"tokenKey" pflag:",Reference of the token in the secret manager."`
+
+	// OAuthClient is the OAuth2 client to obtain tokens with.
+	OAuthClient OAuthClient `json:"oauthClient" pflag:",OAuth2 client to obtain tokens with."`
+
+	// Endpoint is the plugin specific endpoint of the remote service, e.g. the workspace or the account.
+	Endpoint string `json:"endpoint" pflag:",Endpoint of the remote service, e.g. the workspace or the account."`
+}
+
+func (c Credentials) hasToken() bool {
+	return len(c.TokenKey) > 0 || !c.OAuthClient.IsEmpty()
+}
+
+// Or returns the credentials with their unset fields taken from the fallback. The token key and the OAuth client are
+// taken together, so that a more specific configuration setting either of them replaces both.
+func (c Credentials) Or(fallback Credentials) Credentials {
+	if !c.hasToken() {
+		c.TokenKey = fallback.TokenKey
+		c.OAuthClient = fallback.OAuthClient
+	}
+
+	if len(c.Endpoint) == 0 {
+		c.Endpoint = fallback.Endpoint
+	}
+
+	return c
+}
+
+// Override replaces the default credentials for a project, or for a domain of a project if the domain is set.
+type Override struct {
+	Project     string      `json:"project" pflag:",Project to override the credentials of."`
+	Domain      string      `json:"domain" pflag:",Domain to override the credentials of, for all domains if empty."`
+	Credentials Credentials `json:"credentials" pflag:",Credentials of the project or of the domain."`
+}
+
+// Config configures the credentials of a WebAPI plugin.
+type Config struct {
+	// Default are the credentials of the projects and domains without an override.
+	Default Credentials `json:"default" pflag:",Credentials of the projects and domains without an override."`
+
+	// Overrides replace the default credentials for some projects or domains.
+	Overrides []Override `json:"overrides" pflag:"-,Overrides of the default credentials for some projects or domains."`
+
+	// CacheTTL is how long to cache the resolved credentials for.
+	CacheTTL config.Duration `json:"cacheTTL" pflag:",How long to cache the resolved credentials for."`
+}
+
+// Lookup returns the credentials of the project and domain, falling back to the project override and then to the
+// default credentials for the fields they don't set.
+func (c Config) Lookup(ref Ref) Credentials {
+	var projectDomain, project Credentials
+	for _, override := range c.Overrides {
+		if override.Project != ref.Project {
+			continue
+		}
+
+		switch override.Domain {
+		case ref.Domain:
+			projectDomain = override.Credentials
+		case "":
+			project = override.Credentials
+		}
+	}
+
+	return projectDomain.Or(project).Or(c.Default)
+}
+
+// Ref references the credentials of a task execution. Resource metas hold it instead of the credentials, which are
+// resolved again when needed.
+type Ref struct {
+	Project string
+	Domain  string
+}
+
+// RefFromTaskExecutionMetadata returns the reference to the credentials of the task execution.
+func RefFromTaskExecutionMetadata(metadata core.TaskExecutionMetadata) Ref {
+	id := metadata.GetTaskExecutionID().GetID()
+	executionID := id.GetNodeExecutionId().GetExecutionId()
+	return Ref{
+		Project: executionID.GetProject(),
+		Domain:  executionID.GetDomain(),
+	}
+}
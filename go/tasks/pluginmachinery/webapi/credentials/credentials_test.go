@@ -0,0 +1,75 @@
+package credentials
+
+import (
+	"testing"
+
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+)
+
+func TestCredentials_Or(t *testing.T) {
+	fallback := Credentials{
+		TokenKey:    "default-token",
+		OAuthClient: OAuthClient{ClientID: "default-client"},
+		Endpoint:    "default.example.com",
+	}
+
+	assert.Equal(t, fallback, Credentials{}.Or(fallback))
+	assert.Equal(t, Credentials{TokenKey: "token", Endpoint: "default.example.com"},
+		Credentials{TokenKey: "token"}.Or(fallback))
+	assert.Equal(t, Credentials{OAuthClient: OAuthClient{ClientID: "client"}, Endpoint: "example.com"},
+		Credentials{OAuthClient: OAuthClient{ClientID: "client"}, Endpoint: "example.com"}.Or(fallback))
+	assert.Equal(t, Credentials{TokenKey: "default-token", OAuthClient: OAuthClient{ClientID: "default-client"},
+		Endpoint: "example.com"}, Credentials{Endpoint: "example.com"}.Or(fallback))
+}
+
+func TestConfig_Lookup(t *testing.T) {
+	cfg := Config{
+		Default: Credentials{TokenKey: "default-token", Endpoint: "default.example.com"},
+		Overrides: []Override{
+			{Project: "flytesnacks", Domain: "production", Credentials: Credentials{TokenKey: "production-token"}},
+			{Project: "flytesnacks", Credentials: Credentials{TokenKey: "flytesnacks-token", Endpoint: "flytesnacks.example.com"}},
+			{Project: "flyteexamples", Domain: "development", Credentials: Credentials{Endpoint: "dev.example.com"}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		ref      Ref
+		expected Credentials
+	}{
+		{"project and domain", Ref{"flytesnacks", "production"},
+			Credentials{TokenKey: "production-token", Endpoint: "flytesnacks.example.com"}},
+		{"project", Ref{"flytesnacks", "development"},
+			Credentials{TokenKey: "flytesnacks-token", Endpoint: "flytesnacks.example.com"}},
+		{"domain without project", Ref{"flyteexamples", "development"},
+			Credentials{TokenKey: "default-token", Endpoint: "dev.example.com"}},
+		{"default", Ref{"flyteexamples", "production"}, cfg.Default},
+		{"empty", Ref{}, cfg.Default},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, cfg.Lookup(tt.ref))
+		})
+	}
+}
+
+func TestRefFromTaskExecutionMetadata(t *testing.T) {
+	tID := &mocks.TaskExecutionID{}
+	tID.OnGetID().Return(idlCore.TaskExecutionIdentifier{
+		NodeExecutionId: &idlCore.NodeExecutionIdentifier{
+			ExecutionId: &idlCore.WorkflowExecutionIdentifier{
+				Project: "flytesnacks",
+				Domain:  "development",
+				Name:    "exec-name",
+			},
+		},
+	})
+
+	tMeta := &mocks.TaskExecutionMetadata{}
+	tMeta.OnGetTaskExecutionID().Return(tID)
+	assert.Equal(t, Ref{Project: "flytesnacks", Domain: "development"}, RefFromTaskExecutionMetadata(tMeta))
+}
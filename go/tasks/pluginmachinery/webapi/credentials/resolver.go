@@ -0,0 +1,147 @@
+package credentials
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	"golang.org/x/oauth2/clientcredentials"
+	"k8s.io/utils/clock"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+)
+
+// tokenExpiryMargin is how long before their expiry OAuth2 tokens are considered expired, so that they don't expire
+// while in use.
+const tokenExpiryMargin = 30 * time.Second
+
+// Resolved are the credentials of a task execution, with their secrets read.
+type Resolved struct {
+	// Token is the token read from the secret manager, or the access token obtained with the OAuth2 client.
+	Token string
+
+	// OAuth is true if the token is an access token obtained with the OAuth2 client.
+	OAuth bool
+
+	// Endpoint is the endpoint of the remote service, if configured.
+	Endpoint string
+}
+
+type cacheEntry struct {
+	resolved  Resolved
+	expiresAt time.Time
+}
+
+// Resolver resolves the credentials of task executions and caches them until they expire.
+type Resolver struct {
+	cfg           Config
+	secretManager core.SecretManager
+	clock         clock.PassiveClock
+
+	lock  sync.Mutex
+	cache map[Ref]cacheEntry
+}
+
+// Lookup returns the configured credentials referenced by ref.
+func (r *Resolver) Lookup(ref Ref) Credentials {
+	return r.cfg.Lookup(ref)
+}
+
+// RequiredSecrets returns the keys of the secrets the credentials referenced by ref are read from, see
+// webapi.SecretsRequirer.
+func (r *Resolver) RequiredSecrets(ref Ref) []string {
+	credentials := r.Lookup(ref)
+	switch {
+	case !credentials.OAuthClient.IsEmpty():
+		return []string{credentials.OAuthClient.ClientSecretKey}
+	case len(credentials.TokenKey) > 0:
+		return []string{credentials.TokenKey}
+	default:
+		return nil
+	}
+}
+
+// Resolve returns the credentials referenced by ref, reading their secrets from the secret manager of the resolver.
+// It's meant for the calls without a task execution context, see ResolveWith.
+func (r *Resolver) Resolve(ctx context.Context, ref Ref) (Resolved, error) {
+	return r.ResolveWith(ctx, r.secretManager, ref)
+}
+
+// ResolveWith returns the credentials referenced by ref, reading their token from secretManager, usually the one of the
+// task execution, or obtaining it with the OAuth2 client. If neither is configured, the token is empty and it's up to
+// the plugin to decide whether that's an error.
+func (r *Resolver) ResolveWith(ctx context.Context, secretManager core.SecretManager, ref Ref) (Resolved, error) {
+	r.lock.Lock()
+	entry, found := r.cache[ref]
+	r.lock.Unlock()
+	if found && r.clock.Now().Before(entry.expiresAt) {
+		return entry.resolved, nil
+	}
+
+	credentials := r.Lookup(ref)
+	resolved := Resolved{Endpoint: credentials.Endpoint}
+	expiresAt := r.clock.Now().Add(r.cfg.CacheTTL.Duration)
+	switch {
+	case !credentials.OAuthClient.IsEmpty():
+		token, expiry, err := r.oauthToken(ctx, secretManager, credentials.OAuthClient)
+		if err != nil {
+			return Resolved{}, err
+		}
+
+		resolved.Token = token
+		resolved.OAuth = true
+		if !expiry.IsZero() && expiry.Add(-tokenExpiryMargin).Before(expiresAt) {
+			expiresAt = expiry.Add(-tokenExpiryMargin)
+		}
+	case len(credentials.TokenKey) > 0:
+		token, err := secretManager.Get(ctx, credentials.TokenKey)
+		if err != nil {
+			return Resolved{}, err
+		}
+
+		resolved.Token = token
+	}
+
+	logger.Debugf(ctx, "Resolved the credentials of project [%v] and domain [%v]", ref.Project, ref.Domain)
+	r.lock.Lock()
+	r.cache[ref] = cacheEntry{resolved: resolved, expiresAt: expiresAt}
+	r.lock.Unlock()
+
+	return resolved, nil
+}
+
+func (r *Resolver) oauthToken(ctx context.Context, secretManager core.SecretManager, client OAuthClient) (
+	token string, expiry time.Time, err error) {
+	clientSecret, err := secretManager.Get(ctx, client.ClientSecretKey)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	cfg := clientcredentials.Config{
+		ClientID:     client.ClientID,
+		ClientSecret: clientSecret,
+		TokenURL:     client.TokenURL,
+		Scopes:       client.Scopes,
+	}
+
+	t, err := cfg.Token(ctx)
+	if err != nil {
+		return "", time.Time{}, errors.Wrapf(errors.DownstreamSystemError, err,
+			"failed to obtain a token for OAuth2 client [%v]", client.ClientID)
+	}
+
+	return t.AccessToken, t.Expiry, nil
+}
+
+// NewResolver creates a resolver. Secrets are read from the secret manager when there's no task execution context to
+// get one from, typically the one of the plugin setup context.
+func NewResolver(cfg Config, secretManager core.SecretManager) *Resolver {
+	return &Resolver{
+		cfg:           cfg,
+		secretManager: secretManager,
+		clock:         clock.RealClock{},
+		cache:         map[Ref]cacheEntry{},
+	}
+}
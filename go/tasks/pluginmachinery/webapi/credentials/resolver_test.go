@@ -0,0 +1,156 @@
+package credentials
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/config"
+	stdErrors "github.com/flyteorg/flytestdlib/errors"
+	"github.com/stretchr/testify/assert"
+	testclock "k8s.io/utils/clock/testing"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+)
+
+// newFakeTokenServer issues access-token-<n> to the client, where n counts the tokens issued, expiring in expiresIn
+// seconds.
+func newFakeTokenServer(t *testing.T, clientID, clientSecret string, expiresIn int) (*httptest.Server, *int) {
+	issued := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, secret, ok := r.BasicAuth()
+		if !ok || id != clientID || secret != clientSecret || r.FormValue("grant_type") != "client_credentials" {
+			http.Error(w, `{"error": "invalid_client"}`, http.StatusUnauthorized)
+			return
+		}
+
+		issued++
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "access-token-" + string(rune('0'+issued)),
+			"token_type":   "bearer",
+			"expires_in":   expiresIn,
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server, &issued
+}
+
+func TestResolver_Resolve(t *testing.T) {
+	ctx := context.TODO()
+	tokenServer, issued := newFakeTokenServer(t, "client", "client-secret", 120)
+
+	sm := &mocks.SecretManager{}
+	sm.OnGetMatch(ctx, "default-token").Return("default", nil)
+	sm.OnGetMatch(ctx, "flytesnacks-token").Return("flytesnacks", nil)
+	sm.OnGetMatch(ctx, "client-secret").Return("client-secret", nil)
+	sm.OnGetMatch(ctx, "missing").Return("", errors.Errorf(errors.SecretNotFound, "secret [missing] not found"))
+
+	cfg := Config{
+		Default: Credentials{TokenKey: "default-token", Endpoint: "default.example.com"},
+		Overrides: []Override{
+			{Project: "flytesnacks", Credentials: Credentials{TokenKey: "flytesnacks-token"}},
+			{Project: "flytesnacks", Domain: "production", Credentials: Credentials{
+				OAuthClient: OAuthClient{ClientID: "client", ClientSecretKey: "client-secret", TokenURL: tokenServer.URL},
+				Endpoint:    "production.example.com",
+			}},
+			{Project: "broken", Credentials: Credentials{TokenKey: "missing"}},
+		},
+		CacheTTL: config.Duration{Duration: 5 * time.Minute},
+	}
+
+	fakeClock := testclock.NewFakePassiveClock(time.Now())
+	r := NewResolver(cfg, sm)
+	r.clock = fakeClock
+
+	t.Run("token", func(t *testing.T) {
+		resolved, err := r.Resolve(ctx, Ref{"flytesnacks", "development"})
+		assert.NoError(t, err)
+		assert.Equal(t, Resolved{Token: "flytesnacks", Endpoint: "default.example.com"}, resolved)
+
+		resolved, err = r.Resolve(ctx, Ref{"flyteexamples", "development"})
+		assert.NoError(t, err)
+		assert.Equal(t, Resolved{Token: "default", Endpoint: "default.example.com"}, resolved)
+	})
+
+	t.Run("oauth client", func(t *testing.T) {
+		resolved, err := r.Resolve(ctx, Ref{"flytesnacks", "production"})
+		assert.NoError(t, err)
+		assert.Equal(t, Resolved{Token: "access-token-1", OAuth: true, Endpoint: "production.example.com"}, resolved)
+	})
+
+	t.Run("cached until the token expires", func(t *testing.T) {
+		fakeClock.SetTime(fakeClock.Now().Add(80 * time.Second))
+		resolved, err := r.Resolve(ctx, Ref{"flytesnacks", "production"})
+		assert.NoError(t, err)
+		assert.Equal(t, "access-token-1", resolved.Token)
+		assert.Equal(t, 1, *issued)
+
+		fakeClock.SetTime(fakeClock.Now().Add(20 * time.Second))
+		resolved, err = r.Resolve(ctx, Ref{"flytesnacks", "production"})
+		assert.NoError(t, err)
+		assert.Equal(t, "access-token-2", resolved.Token)
+		assert.Equal(t, 2, *issued)
+	})
+
+	t.Run("cached until the ttl expires", func(t *testing.T) {
+		sm.AssertNumberOfCalls(t, "Get", 4)
+		fakeClock.SetTime(fakeClock.Now().Add(time.Minute))
+		_, err := r.Resolve(ctx, Ref{"flytesnacks", "development"})
+		assert.NoError(t, err)
+		sm.AssertNumberOfCalls(t, "Get", 4)
+
+		fakeClock.SetTime(fakeClock.Now().Add(5 * time.Minute))
+		_, err = r.Resolve(ctx, Ref{"flytesnacks", "development"})
+		assert.NoError(t, err)
+		sm.AssertNumberOfCalls(t, "Get", 5)
+	})
+
+	t.Run("missing secret", func(t *testing.T) {
+		_, err := r.Resolve(ctx, Ref{"broken", "development"})
+		assert.True(t, stdErrors.IsCausedBy(err, errors.SecretNotFound))
+	})
+
+	t.Run("secret manager of the task execution", func(t *testing.T) {
+		executionSecrets := &mocks.SecretManager{}
+		executionSecrets.OnGetMatch(ctx, "default-token").Return("execution", nil)
+		resolved, err := NewResolver(cfg, sm).ResolveWith(ctx, executionSecrets, Ref{"flyteexamples", "production"})
+		assert.NoError(t, err)
+		assert.Equal(t, "execution", resolved.Token)
+	})
+
+	t.Run("nothing configured", func(t *testing.T) {
+		resolved, err := NewResolver(Config{}, sm).Resolve(ctx, Ref{"flytesnacks", "development"})
+		assert.NoError(t, err)
+		assert.Equal(t, Resolved{}, resolved)
+	})
+
+	t.Run("invalid oauth client", func(t *testing.T) {
+		sm.OnGetMatch(ctx, "wrong-secret").Return("wrong-secret", nil)
+		r := NewResolver(Config{Default: Credentials{OAuthClient: OAuthClient{
+			ClientID: "client", ClientSecretKey: "wrong-secret", TokenURL: tokenServer.URL,
+		}}}, sm)
+
+		_, err := r.Resolve(ctx, Ref{"flytesnacks", "development"})
+		assert.True(t, stdErrors.IsCausedBy(err, errors.DownstreamSystemError))
+	})
+}
+
+func TestResolver_RequiredSecrets(t *testing.T) {
+	r := NewResolver(Config{
+		Default: Credentials{TokenKey: "default-token"},
+		Overrides: []Override{
+			{Project: "flytesnacks", Credentials: Credentials{
+				OAuthClient: OAuthClient{ClientID: "client", ClientSecretKey: "client-secret"},
+			}},
+		},
+	}, nil)
+
+	assert.Equal(t, []string{"default-token"}, r.RequiredSecrets(Ref{"flyteexamples", "development"}))
+	assert.Equal(t, []string{"client-secret"}, r.RequiredSecrets(Ref{"flytesnacks", "development"}))
+	assert.Empty(t, NewResolver(Config{}, nil).RequiredSecrets(Ref{"flytesnacks", "development"}))
+}
@@ -0,0 +1,50 @@
+package webapi
+
+import (
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tasklog"
+)
+
+// LogTemplateVarResourceID is the template variable holding the id of the resource created in the remote service (e.g.
+// query or run id). It's available to the log links of all webapi plugins.
+const LogTemplateVarResourceID = "resourceId"
+
+// GetTaskLogs builds the log links for a resource created in a remote service using the configured templates. Aside
+// from extraVars, templates can use {{ .resourceId }} as well as the task execution identity variables documented in
+// tasklog.TemplateLogPlugin (e.g. {{ .project }}, {{ .domain }} and {{ .executionName }}). Each template config yields
+// as many links as it has template URIs, all named after its display name. Links are signed using signer, if not nil.
+func GetTaskLogs(templates []tasklog.TemplateLogPluginConfig, signer tasklog.URLSigner,
+	taskExecMetadata pluginsCore.TaskExecutionMetadata, resourceID string, extraVars map[string]string) (
+	[]*core.TaskLog, error) {
+
+	if len(templates) == 0 {
+		return nil, nil
+	}
+
+	vars := make(map[string]string, len(extraVars)+1)
+	for k, v := range extraVars {
+		vars[k] = v
+	}
+
+	vars[LogTemplateVarResourceID] = resourceID
+
+	taskExecID := taskExecMetadata.GetTaskExecutionID().GetID()
+	taskLogs := make([]*core.TaskLog, 0, len(templates))
+	for _, cfg := range templates {
+		o, err := tasklog.NewTemplateLogPlugin(cfg.TemplateURIs, cfg.MessageFormat).WithSigner(signer).GetTaskLogs(tasklog.Input{
+			LogName:                 cfg.DisplayName,
+			TaskExecutionIdentifier: &taskExecID,
+			ExtraTemplateVars:       vars,
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		taskLogs = append(taskLogs, o.TaskLogs...)
+	}
+
+	return taskLogs, nil
+}
@@ -0,0 +1,78 @@
+package webapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+	testclock "k8s.io/utils/clock/testing"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tasklog"
+)
+
+func TestGetTaskLogs(t *testing.T) {
+	tID := &mocks.TaskExecutionID{}
+	tID.OnGetID().Return(core.TaskExecutionIdentifier{
+		NodeExecutionId: &core.NodeExecutionIdentifier{
+			ExecutionId: &core.WorkflowExecutionIdentifier{
+				Project: "flytesnacks",
+				Domain:  "development",
+				Name:    "exec-name",
+			},
+		},
+	})
+
+	tMeta := &mocks.TaskExecutionMetadata{}
+	tMeta.OnGetTaskExecutionID().Return(tID)
+
+	t.Run("no templates", func(t *testing.T) {
+		logs, err := GetTaskLogs(nil, nil, tMeta, "query-id", nil)
+		assert.NoError(t, err)
+		assert.Empty(t, logs)
+	})
+
+	t.Run("multiple templates", func(t *testing.T) {
+		logs, err := GetTaskLogs([]tasklog.TemplateLogPluginConfig{
+			{
+				DisplayName: "Console",
+				TemplateURIs: []string{
+					"https://{{ .account }}.example.com/queries/{{ .resourceId }}",
+				},
+			},
+			{
+				DisplayName: "Dashboard",
+				TemplateURIs: []string{
+					"https://dashboard.example.com/{{ .project }}/{{ .domain }}/{{ .executionName }}?q={{ .resourceId }}",
+				},
+				MessageFormat: core.TaskLog_JSON,
+			},
+		}, nil, tMeta, "query-id", map[string]string{"account": "my-account"})
+		assert.NoError(t, err)
+		assert.Equal(t, []*core.TaskLog{
+			{
+				Uri:  "https://my-account.example.com/queries/query-id",
+				Name: "Console",
+			},
+			{
+				Uri:           "https://dashboard.example.com/flytesnacks/development/exec-name?q=query-id",
+				Name:          "Dashboard",
+				MessageFormat: core.TaskLog_JSON,
+			},
+		}, logs)
+	})
+	t.Run("signed", func(t *testing.T) {
+		signer := tasklog.NewHMACSigner([]byte("key"), time.Minute, "sig", "exp", testclock.NewFakePassiveClock(time.Unix(1000, 0)))
+		logs, err := GetTaskLogs([]tasklog.TemplateLogPluginConfig{
+			{
+				DisplayName:  "Console",
+				TemplateURIs: []string{"https://example.com/queries/{{ .resourceId }}"},
+			},
+		}, signer, tMeta, "query-id", nil)
+		assert.NoError(t, err)
+		if assert.Len(t, logs, 1) {
+			assert.Regexp(t, `^https://example.com/queries/query-id\?exp=1060&sig=[\w-]+$`, logs[0].Uri)
+		}
+	})
+}
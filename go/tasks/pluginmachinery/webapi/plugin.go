@@ -44,12 +44,20 @@ type PluginEntry struct {
 	// support the same task type. This must be a subset of RegisteredTaskTypes and at most one default per task type
 	// is supported.
 	DefaultForTaskTypes []pluginsCore.TaskType
+
+	// Optional validator for the task templates handled by the plugin, see pluginsCore.PluginValidator. Plugins are
+	// loaded lazily, the validator lets the system validate task templates without loading them.
+	Validator pluginsCore.PluginValidator
 }
 
 // PluginSetupContext is the interface made available to the plugin loader when initializing the plugin.
 type PluginSetupContext interface {
 	// a metrics scope to publish stats under
 	MetricsScope() promutils.Scope
+
+	// Returns a secret manager that can retrieve configured secrets for this plugin, when there's no task execution
+	// context to get it from
+	SecretManager() pluginsCore.SecretManager
 }
 
 type TaskExecutionContextReader interface {
@@ -144,6 +152,14 @@ type AsyncPlugin interface {
 	Status(ctx context.Context, tCtx StatusContext) (phase pluginsCore.PhaseInfo, err error)
 }
 
+// SecretsRequirer is optionally implemented by AsyncPlugins to declare the secrets they need to create resources. The
+// system checks they're available before calling Create, so that tasks fail fast, listing the missing secrets, instead
+// of failing part way through their remote calls.
+type SecretsRequirer interface {
+	// RequiredSecrets returns the keys of the secrets to read from the SecretManager of the task execution.
+	RequiredSecrets(tCtx TaskExecutionContextReader) []string
+}
+
 // SyncPlugin defines the interface for plugins that call Web APIs synchronously.
 type SyncPlugin interface {
 	// GetConfig gets the loaded plugin config. This will be used to control the interactions with the remote service.
@@ -1,9 +1,12 @@
 package webapi
 
 import (
+	"sync"
 	"time"
 
+	"github.com/flyteorg/flyteplugins/go/tasks/logs"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tasklog"
 	"github.com/flyteorg/flytestdlib/config"
 )
 
@@ -52,17 +55,69 @@ type CachingConfig struct {
 	MaxSystemFailures int `json:"maxSystemFailures" pflag:",Defines the number of failures to fetch a task before failing the task."`
 }
 
+// ResourceQuotas caps the total weight of the tokens allocated at any given time per resource namespace.
 type ResourceQuotas map[core.ResourceNamespace]int
 
+// ResourceRate caps the total weight of the tokens allocated within any window of time.
+type ResourceRate struct {
+	Value  int64           `json:"value" pflag:",Maximum total weight of the tokens allocated within the window."`
+	Window config.Duration `json:"window" pflag:",Window of time the rate applies to."`
+}
+
+// ResourceRates caps the total weight of the tokens allocated within a window of time per resource namespace.
+type ResourceRates map[core.ResourceNamespace]ResourceRate
+
 // Properties that help the system optimize itself to handle the specific plugin
 type PluginConfig struct {
 	// ResourceQuotas allows the plugin to register resources' quotas to ensure the system comply with restrictions in
-	// the remote service.
-	ResourceQuotas   ResourceQuotas    `json:"resourceQuotas" pflag:"-,Defines resource quotas."`
+	// the remote service. Quotas are in units of weight, the plugin states the weight of each task in the constraints
+	// returned by ResourceRequirements.
+	ResourceQuotas ResourceQuotas `json:"resourceQuotas" pflag:"-,Defines resource quotas."`
+	// ResourceRates allows the plugin to register rates for the remote services limiting requests per window of time.
+	// The plugin fails to load if the resource registrar doesn't support rates, see core.ResourceRateRegistrar.
+	ResourceRates    ResourceRates     `json:"resourceRates" pflag:"-,Defines resource rates."`
 	ReadRateLimiter  RateLimiterConfig `json:"readRateLimiter" pflag:",Defines rate limiter properties for read actions (e.g. retrieve status)."`
 	WriteRateLimiter RateLimiterConfig `json:"writeRateLimiter" pflag:",Defines rate limiter properties for write actions."`
 	Caching          CachingConfig     `json:"caching" pflag:",Defines caching characteristics."`
+	// ManagesPhaseVersion tells the plugin sets the version of the phases it reports itself. Otherwise, the version is
+	// bumped whenever the reason, logs, external resources or custom info reported change within the same phase.
+	ManagesPhaseVersion bool `json:"managesPhaseVersion" pflag:",Whether the plugin sets the version of the phases it reports itself."`
+	// Logs defines the templates used to build the log links of the tasks handled by the plugin. Templates can use the
+	// variables listed in GetTaskLogs.
+	Logs []tasklog.TemplateLogPluginConfig `json:"logs" pflag:"-,Defines log link templates."`
+	// LogOverrides customize Logs for the tasks of specific projects and domains, like the overrides of the k8s log config
+	// (see logs.LogConfig.Resolve). Only the templates of their config are used. See GetLogTemplates.
+	LogOverrides []logs.LogConfigOverride `json:"logOverrides" pflag:"-,Defines log link templates for specific projects and domains."`
+	// LogSigner configures the signing of the log links built from Logs. The signer is created when the plugin is loaded. A key-secret is
+	// read using the SecretManager of the plugin's setup context, and read again every key-refresh-interval.
+	LogSigner tasklog.SignerConfig `json:"logSigner" pflag:"-,Configures the signing of log links."`
 	// Gets an empty copy for the custom state that can be used in ResourceMeta when
 	// interacting with the remote service.
 	ResourceMeta ResourceMeta `json:"resourceMeta" pflag:"-,A copy for the custom state."`
 }
+
+// AllocatesTokens returns true if the plugin's tasks need to allocate a token before being launched.
+func (c PluginConfig) AllocatesTokens() bool {
+	return len(c.ResourceQuotas) > 0 || len(c.ResourceRates) > 0
+}
+
+// logConfigResolvers caches the log configs resolved for the tasks of each plugin, by plugin id.
+var logConfigResolvers sync.Map
+
+// GetLogTemplates returns the log link templates to use for the given task execution of the plugin: Logs, with the
+// matching LogOverrides applied on top of them from the least to the most specific one.
+func (c PluginConfig) GetLogTemplates(pluginID string, taskExecMetadata core.TaskExecutionMetadata) (
+	[]tasklog.TemplateLogPluginConfig, error) {
+	resolver, found := logConfigResolvers.Load(pluginID)
+	if !found {
+		resolver, _ = logConfigResolvers.LoadOrStore(pluginID, &logs.ConfigResolver{})
+	}
+
+	cfg, err := resolver.(*logs.ConfigResolver).ResolveForTask(logs.LogConfig{Templates: c.Logs, Overrides: c.LogOverrides},
+		pluginID, taskExecMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg.Templates, nil
+}
@@ -211,4 +211,18 @@ func TestPluginConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_managesPhaseVersion", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("managesPhaseVersion", testValue)
+			if vBool, err := cmdFlags.GetBool("managesPhaseVersion"); err == nil {
+				testDecodeJson_PluginConfig(t, fmt.Sprintf("%v", vBool), &actual.ManagesPhaseVersion)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 }
@@ -0,0 +1,76 @@
+package webapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tasklog"
+)
+
+func taskExecMetadata(project, domain string) *mocks.TaskExecutionMetadata {
+	tID := &mocks.TaskExecutionID{}
+	tID.OnGetID().Return(core.TaskExecutionIdentifier{
+		NodeExecutionId: &core.NodeExecutionIdentifier{
+			ExecutionId: &core.WorkflowExecutionIdentifier{
+				Project: project,
+				Domain:  domain,
+				Name:    "exec-name",
+			},
+		},
+	})
+
+	tMeta := &mocks.TaskExecutionMetadata{}
+	tMeta.OnGetTaskExecutionID().Return(tID)
+	return tMeta
+}
+
+func templates(name string) []tasklog.TemplateLogPluginConfig {
+	return []tasklog.TemplateLogPluginConfig{
+		{DisplayName: name, TemplateURIs: []string{"https://" + name + ".example.com/{{ .resourceId }}"}},
+	}
+}
+
+func TestPluginConfig_GetLogTemplates(t *testing.T) {
+	cfg := PluginConfig{Logs: templates("global")}
+	assert.NoError(t, json.Unmarshal([]byte(`[
+		{"domain": "production", "config": {"templates": [{"displayName": "production", "templateUris": ["https://production.example.com/{{ .resourceId }}"]}]}},
+		{"project": "flytesnacks", "domain": "production", "config": {"templates": [{"displayName": "flytesnacks-production", "templateUris": ["https://flytesnacks-production.example.com/{{ .resourceId }}"]}]}},
+		{"project": "flytesnacks", "config": {"templates": [{"displayName": "flytesnacks", "templateUris": ["https://flytesnacks.example.com/{{ .resourceId }}"]}]}},
+		{"project": "flytesnacks", "config": {"templates": [{"displayName": "flytesnacks-latest", "templateUris": ["https://flytesnacks-latest.example.com/{{ .resourceId }}"]}]}},
+		{"project": "other-plugin", "plugins": ["other"], "config": {"templates": []}},
+		{"project": "no-logs", "config": {"templates": []}}
+	]`), &cfg.LogOverrides))
+
+	tests := []struct {
+		project  string
+		domain   string
+		expected []tasklog.TemplateLogPluginConfig
+	}{
+		{"other", "development", templates("global")},
+		{"other", "production", templates("production")},
+		{"flytesnacks", "development", templates("flytesnacks-latest")},
+		{"flytesnacks", "production", templates("flytesnacks-production")},
+		{"other-plugin", "development", templates("global")},
+		{"no-logs", "development", []tasklog.TemplateLogPluginConfig{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.project+"-"+test.domain, func(t *testing.T) {
+			for i := 0; i < 2; i++ {
+				logTemplates, err := cfg.GetLogTemplates("my-plugin", taskExecMetadata(test.project, test.domain))
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, logTemplates)
+			}
+		})
+	}
+}
+
+func TestPluginConfig_AllocatesTokens(t *testing.T) {
+	assert.False(t, PluginConfig{}.AllocatesTokens())
+	assert.True(t, PluginConfig{ResourceQuotas: ResourceQuotas{"ns": 1}}.AllocatesTokens())
+	assert.True(t, PluginConfig{ResourceRates: ResourceRates{"ns": {Value: 1}}}.AllocatesTokens())
+}
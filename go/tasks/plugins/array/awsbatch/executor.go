@@ -19,7 +19,9 @@ import (
 	"github.com/flyteorg/flyteplugins/go/tasks/aws"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/audit"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/pluginstate"
 
 	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 )
@@ -30,6 +32,9 @@ const (
 	arrayTaskType             = "container_array"
 )
 
+// stateMigrations reads and writes the plugin state, refusing state written by newer versions of the plugin.
+var stateMigrations = core.NewStateMigrations(defaultPluginStateVersion)
+
 type Executor struct {
 	jobStore           *JobStore
 	jobDefinitionCache definition.Cache
@@ -37,6 +42,7 @@ type Executor struct {
 	outputAssembler array.OutputAssembler
 	errorAssembler  array.OutputAssembler
 	metrics         ExecutorMetrics
+	auditSink       audit.Sink
 }
 
 func (e Executor) GetID() string {
@@ -50,10 +56,11 @@ func (e Executor) GetProperties() core.PluginProperties {
 }
 
 func (e Executor) Handle(ctx context.Context, tCtx core.TaskExecutionContext) (core.Transition, error) {
+	ctx, tCtx = audit.NewTaskExecutionContext(ctx, executorName, tCtx, e.auditSink)
 	pluginConfig := batchConfig.GetConfig()
 
 	pluginState := &State{}
-	if _, err := tCtx.PluginStateReader().Get(pluginState); err != nil {
+	if _, err := stateMigrations.Get(pluginstate.NewTaskExecutionContext(ctx, tCtx).PluginStateReader(), pluginState); err != nil {
 		return core.UnknownTransition, errors.Wrapf(errors.CorruptedPluginState, err, "Failed to read unmarshal custom state")
 	}
 
@@ -100,7 +107,7 @@ func (e Executor) Handle(ctx context.Context, tCtx core.TaskExecutionContext) (c
 		return core.UnknownTransition, err
 	}
 
-	if err := tCtx.PluginStateWriter().Put(defaultPluginStateVersion, pluginState); err != nil {
+	if err := stateMigrations.Put(pluginstate.NewTaskExecutionContext(ctx, tCtx).PluginStateWriter(), pluginState); err != nil {
 		return core.UnknownTransition, err
 	}
 
@@ -177,12 +184,18 @@ func NewExecutor(ctx context.Context, awsClient aws.Client, cfg *batchConfig.Con
 		return Executor{}, err
 	}
 
+	auditSink, err := audit.GetSink()
+	if err != nil {
+		return Executor{}, err
+	}
+
 	return Executor{
 		jobStore:           &jobStore,
 		jobDefinitionCache: definition.NewCache(cfg.JobDefCacheSize),
 		outputAssembler:    outputAssembler,
 		errorAssembler:     errorAssembler,
 		metrics:            getAwsBatchExecutorMetrics(scope.NewSubScope("awsbatch")),
+		auditSink:          auditSink,
 	}, nil
 }
 
@@ -202,12 +215,22 @@ func (e Executor) Start(ctx context.Context) error {
 	return nil
 }
 
+// Single aws batch jobs don't have an array job to validate.
+func validate(ctx context.Context, taskTemplate *idlCore.TaskTemplate) error {
+	if taskTemplate.GetType() == array.AwsBatchTaskType {
+		return nil
+	}
+
+	return arrayCore.Validator.Validate(ctx, taskTemplate)
+}
+
 func init() {
 	pluginmachinery.PluginRegistry().RegisterCorePlugin(
 		core.PluginEntry{
 			ID:                  executorName,
 			RegisteredTaskTypes: []core.TaskType{arrayTaskType, array.AwsBatchTaskType},
 			LoadPlugin:          createNewExecutorPlugin,
+			Validator:           core.PluginValidatorFunc(validate),
 			IsDefault:           false,
 		})
 }
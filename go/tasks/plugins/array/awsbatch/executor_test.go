@@ -18,6 +18,7 @@ import (
 
 	arrayCore "github.com/flyteorg/flyteplugins/go/tasks/plugins/array/core"
 
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/audit"
 	pluginCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
@@ -62,12 +63,13 @@ func TestExecutor_Handle(t *testing.T) {
 		jobDefinitionCache: jc,
 		outputAssembler:    oa,
 		errorAssembler:     oa,
+		auditSink:          audit.NopSink,
 	}
 
 	ctx := context.Background()
 
 	tr := &pluginMocks.TaskReader{}
-	tr.OnRead(ctx).Return(&core.TaskTemplate{
+	tr.OnReadMatch(mock.Anything).Return(&core.TaskTemplate{
 		Target: &core.TaskTemplate_Container{
 			Container: createSampleContainerTask(),
 		},
@@ -76,6 +78,9 @@ func TestExecutor_Handle(t *testing.T) {
 	inputState := &State{}
 
 	pluginStateReader := &pluginMocks.PluginStateReader{}
+	pluginStateReader.OnGetStateVersion().Return(0)
+	// Plugin state stored as is doesn't decode into the envelope of offloaded state.
+	pluginStateReader.OnGetMatch(mock.AnythingOfType("*pluginstate.envelope")).Return(0, nil)
 	pluginStateReader.On("Get", mock.AnythingOfType(reflect.TypeOf(&State{}).String())).Return(
 		func(v interface{}) uint8 {
 			*(v.(*State)) = *inputState
@@ -126,6 +131,8 @@ func TestExecutor_Handle(t *testing.T) {
 	tMeta := &pluginMocks.TaskExecutionMetadata{}
 	tMeta.OnGetTaskExecutionID().Return(tID)
 	tMeta.OnGetOverrides().Return(overrides)
+	tMeta.OnGetSecurityContext().Return(core.SecurityContext{})
+	tMeta.OnGetK8sServiceAccount().Return("")
 
 	dataStore, err := storage.NewDataStore(&storage.Config{
 		Type: storage.TypeMemory,
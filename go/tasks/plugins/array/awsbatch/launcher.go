@@ -12,6 +12,7 @@ import (
 	arrayCore "github.com/flyteorg/flyteplugins/go/tasks/plugins/array/core"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/pluginstate"
 	"github.com/flyteorg/flyteplugins/go/tasks/plugins/array/arraystatus"
 	"github.com/flyteorg/flyteplugins/go/tasks/plugins/array/awsbatch/config"
 )
@@ -76,7 +77,7 @@ func LaunchSubTasks(ctx context.Context, tCtx core.TaskExecutionContext, batchCl
 // to call multiple times on the same job. It'll result in multiple calls to AWS Batch in that case, however.
 func TerminateSubTasks(ctx context.Context, tCtx core.TaskExecutionContext, batchClient Client, reason string, metrics ExecutorMetrics) error {
 	pluginState := &State{}
-	if _, err := tCtx.PluginStateReader().Get(pluginState); err != nil {
+	if _, err := stateMigrations.Get(pluginstate.NewTaskExecutionContext(ctx, tCtx).PluginStateReader(), pluginState); err != nil {
 		return errors.Wrapf(errors.CorruptedPluginState, err, "Failed to unmarshal custom state")
 	}
 
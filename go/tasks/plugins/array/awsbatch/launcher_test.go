@@ -143,7 +143,9 @@ func TestLaunchSubTasks(t *testing.T) {
 func TestTerminateSubTasks(t *testing.T) {
 	ctx := context.Background()
 	pStateReader := &mocks.PluginStateReader{}
-	pStateReader.OnGetMatch(mock.Anything).Return(0, nil).Run(func(args mock.Arguments) {
+	pStateReader.OnGetStateVersion().Return(0)
+	pStateReader.OnGetMatch(mock.AnythingOfType("*pluginstate.envelope")).Return(0, nil)
+	pStateReader.OnGetMatch(mock.AnythingOfType("*awsbatch.State")).Return(0, nil).Run(func(args mock.Arguments) {
 		s := args.Get(0).(*State)
 		s.ExternalJobID = refStr("abc-123")
 	})
@@ -0,0 +1,42 @@
+package core
+
+import (
+	"context"
+
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+)
+
+// Validator validates the array job of array task templates. Array plugins register it with their plugin entries.
+var Validator = core.PluginValidatorFunc(validateArrayJob)
+
+// Checks the array job of the task template has a non-negative parallelism, a positive size and a success criteria the
+// array can meet.
+func validateArrayJob(_ context.Context, taskTemplate *idlCore.TaskTemplate) error {
+	arrayJob, err := ToArrayJob(taskTemplate.GetCustom(), taskTemplate.GetTaskTypeVersion())
+	if err != nil {
+		return errors.Wrapf(errors.BadTaskSpecification, err, "invalid TaskSpecification [%v], failed to unmarshal", taskTemplate.GetCustom())
+	}
+
+	if arrayJob.GetParallelism() < 0 {
+		return errors.Errorf(errors.BadTaskSpecification, "array parallelism [%v] must not be negative", arrayJob.GetParallelism())
+	}
+
+	if taskTemplate.GetTaskTypeVersion() == 0 {
+		// Later versions infer the size of the array from the inputs.
+		if arrayJob.GetSize() <= 0 {
+			return errors.Errorf(errors.BadTaskSpecification, "array size [%v] must be positive", arrayJob.GetSize())
+		}
+
+		if minSuccesses := arrayJob.GetMinSuccesses(); minSuccesses < 0 || minSuccesses > arrayJob.GetSize() {
+			return errors.Errorf(errors.BadTaskSpecification, "min successes [%v] must be between 0 and the array size [%v]",
+				minSuccesses, arrayJob.GetSize())
+		}
+	} else if ratio := arrayJob.GetMinSuccessRatio(); ratio < 0 || ratio > 1 {
+		return errors.Errorf(errors.BadTaskSpecification, "min success ratio [%v] must be between 0 and 1", ratio)
+	}
+
+	return nil
+}
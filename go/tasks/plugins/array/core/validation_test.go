@@ -0,0 +1,49 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/plugins"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
+)
+
+func arrayTaskTemplate(t *testing.T, taskTypeVersion int32, arrayJob *plugins.ArrayJob) *idlCore.TaskTemplate {
+	custom := &structpb.Struct{}
+	assert.NoError(t, utils.MarshalStruct(arrayJob, custom))
+	return &idlCore.TaskTemplate{Custom: custom, TaskTypeVersion: taskTypeVersion}
+}
+
+func TestValidator(t *testing.T) {
+	ctx := context.TODO()
+	t.Run("defaults", func(t *testing.T) {
+		assert.NoError(t, Validator.Validate(ctx, &idlCore.TaskTemplate{}))
+		assert.NoError(t, Validator.Validate(ctx, &idlCore.TaskTemplate{TaskTypeVersion: 1}))
+	})
+
+	for name, test := range map[string]struct {
+		taskTypeVersion int32
+		arrayJob        *plugins.ArrayJob
+		valid           bool
+	}{
+		"valid min successes":  {0, &plugins.ArrayJob{Size: 2, SuccessCriteria: &plugins.ArrayJob_MinSuccesses{MinSuccesses: 2}}, true},
+		"valid ratio":          {1, &plugins.ArrayJob{SuccessCriteria: &plugins.ArrayJob_MinSuccessRatio{MinSuccessRatio: 0.5}}, true},
+		"negative parallelism": {0, &plugins.ArrayJob{Size: 2, Parallelism: -1}, false},
+		"empty array":          {0, &plugins.ArrayJob{Size: 0}, false},
+		"too many successes":   {0, &plugins.ArrayJob{Size: 2, SuccessCriteria: &plugins.ArrayJob_MinSuccesses{MinSuccesses: 3}}, false},
+		"ratio above one":      {1, &plugins.ArrayJob{SuccessCriteria: &plugins.ArrayJob_MinSuccessRatio{MinSuccessRatio: 1.5}}, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := Validator.Validate(ctx, arrayTaskTemplate(t, test.taskTypeVersion, test.arrayJob))
+			if test.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
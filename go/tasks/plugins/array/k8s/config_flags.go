@@ -70,5 +70,24 @@ func (cfg Config) GetPFlagSet(prefix string) *pflag.FlagSet {
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.config.gcp-project"), defaultConfig.LogConfig.Config.GCPProjectName, "Name of the project in GCP")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.config.stackdriver-logresourcename"), defaultConfig.LogConfig.Config.StackdriverLogResourceName, "Name of the logresource in stackdriver")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.config.stackdriver-template-uri"), defaultConfig.LogConfig.Config.StackDriverTemplateURI, "Template Uri to use when building stackdriver log links")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "logs.config.loki.enabled"), defaultConfig.LogConfig.Config.Loki.Enabled, "Enable log links to Grafana Loki")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.config.loki.grafana-url"), defaultConfig.LogConfig.Config.Loki.GrafanaURL, "Base URL of the Grafana instance (e.g. https://grafana.example.com)")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "logs.config.loki.org-id"), defaultConfig.LogConfig.Config.Loki.OrgID, "Grafana organization id")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.config.loki.datasource-uid"), defaultConfig.LogConfig.Config.Loki.DatasourceUID, "UID of the Loki datasource in Grafana")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.config.loki.query"), defaultConfig.LogConfig.Config.Loki.Query, "LogQL query template")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "logs.config.datadog.enabled"), defaultConfig.LogConfig.Config.Datadog.Enabled, "Enable log links to Datadog")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.config.datadog.site"), defaultConfig.LogConfig.Config.Datadog.Site, "Datadog site (e.g. datadoghq.com or datadoghq.eu)")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.config.datadog.query"), defaultConfig.LogConfig.Config.Datadog.Query, "Log search query template")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "logs.config.splunk.enabled"), defaultConfig.LogConfig.Config.Splunk.Enabled, "Enable log links to Splunk")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.config.splunk.url"), defaultConfig.LogConfig.Config.Splunk.URL, "Base URL of the Splunk web UI (e.g. https://splunk.example.com)")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.config.splunk.app"), defaultConfig.LogConfig.Config.Splunk.App, "Splunk app to run the search in")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.config.splunk.query"), defaultConfig.LogConfig.Config.Splunk.Query, "SPL search query template")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "logs.config.kibana.enabled"), defaultConfig.LogConfig.Config.Kibana.Enabled, "Enable log links to Kibana")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.config.kibana.url"), defaultConfig.LogConfig.Config.Kibana.URL, "Base URL of Kibana (e.g. https://kibana.example.com)")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.config.kibana.data-view"), defaultConfig.LogConfig.Config.Kibana.DataView, "Id of the Kibana data view (index pattern) to search. Kibana's default is used if empty.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.config.kibana.query"), defaultConfig.LogConfig.Config.Kibana.Query, "KQL query template")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "logs.config.exclude-container-roles.primary"), defaultConfig.LogConfig.Config.ExcludeContainerRoles.Primary, "The container running the task code")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "logs.config.exclude-container-roles.sidecar"), defaultConfig.LogConfig.Config.ExcludeContainerRoles.Sidecar, "All other containers in the pod")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "logs.config.exclude-container-roles.init"), defaultConfig.LogConfig.Config.ExcludeContainerRoles.Init, "Init containers")
 	return cmdFlags
 }
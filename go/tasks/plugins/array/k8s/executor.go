@@ -5,7 +5,10 @@ import (
 
 	"github.com/flyteorg/flyteplugins/go/tasks/errors"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/audit"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/pluginstate"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tracing"
 	"github.com/flyteorg/flyteplugins/go/tasks/plugins/array"
 	arrayCore "github.com/flyteorg/flyteplugins/go/tasks/plugins/array/core"
 
@@ -20,10 +23,14 @@ const executorName = "k8s-array"
 const arrayTaskType = "container_array"
 const pluginStateVersion = 0
 
+// stateMigrations reads and writes the plugin state, refusing state written by newer versions of the plugin.
+var stateMigrations = core.NewStateMigrations(pluginStateVersion)
+
 type Executor struct {
 	kubeClient       core.KubeClient
 	outputsAssembler array.OutputAssembler
 	errorAssembler   array.OutputAssembler
+	auditSink        audit.Sink
 }
 
 type KubeClientObj struct {
@@ -55,10 +62,16 @@ func NewExecutor(kubeClient core.KubeClient, cfg *Config, scope promutils.Scope)
 		return Executor{}, err
 	}
 
+	auditSink, err := audit.GetSink()
+	if err != nil {
+		return Executor{}, err
+	}
+
 	return Executor{
 		kubeClient:       kubeClient,
 		outputsAssembler: outputAssembler,
 		errorAssembler:   errorAssembler,
+		auditSink:        auditSink,
 	}, nil
 }
 
@@ -73,10 +86,11 @@ func (Executor) GetProperties() core.PluginProperties {
 }
 
 func (e Executor) Handle(ctx context.Context, tCtx core.TaskExecutionContext) (core.Transition, error) {
+	ctx, tCtx = audit.NewTaskExecutionContext(ctx, executorName, tCtx, e.auditSink)
 	pluginConfig := GetConfig()
 
 	pluginState := &arrayCore.State{}
-	if _, err := tCtx.PluginStateReader().Get(pluginState); err != nil {
+	if _, err := stateMigrations.Get(pluginstate.NewTaskExecutionContext(ctx, tCtx).PluginStateReader(), pluginState); err != nil {
 		return core.UnknownTransition, errors.Wrapf(errors.CorruptedPluginState, err, "Failed to read unmarshal custom state")
 	}
 
@@ -138,7 +152,7 @@ func (e Executor) Handle(ctx context.Context, tCtx core.TaskExecutionContext) (c
 		return core.UnknownTransition, err
 	}
 
-	if err := tCtx.PluginStateWriter().Put(pluginStateVersion, nextState); err != nil {
+	if err := stateMigrations.Put(pluginstate.NewTaskExecutionContext(ctx, tCtx).PluginStateWriter(), nextState); err != nil {
 		return core.UnknownTransition, err
 	}
 
@@ -152,8 +166,9 @@ func (e Executor) Handle(ctx context.Context, tCtx core.TaskExecutionContext) (c
 }
 
 func (e Executor) Abort(ctx context.Context, tCtx core.TaskExecutionContext) error {
+	ctx = audit.WithTask(ctx, audit.TaskFromMetadata(executorName, tCtx.TaskExecutionMetadata()))
 	pluginState := &arrayCore.State{}
-	if _, err := tCtx.PluginStateReader().Get(pluginState); err != nil {
+	if _, err := stateMigrations.Get(pluginstate.NewTaskExecutionContext(ctx, tCtx).PluginStateReader(), pluginState); err != nil {
 		return errors.Wrapf(errors.CorruptedPluginState, err, "Failed to read unmarshal custom state")
 	}
 
@@ -161,8 +176,9 @@ func (e Executor) Abort(ctx context.Context, tCtx core.TaskExecutionContext) err
 }
 
 func (e Executor) Finalize(ctx context.Context, tCtx core.TaskExecutionContext) error {
+	ctx = audit.WithTask(ctx, audit.TaskFromMetadata(executorName, tCtx.TaskExecutionMetadata()))
 	pluginState := &arrayCore.State{}
-	if _, err := tCtx.PluginStateReader().Get(pluginState); err != nil {
+	if _, err := stateMigrations.Get(pluginstate.NewTaskExecutionContext(ctx, tCtx).PluginStateReader(), pluginState); err != nil {
 		return errors.Wrapf(errors.CorruptedPluginState, err, "Failed to read unmarshal custom state")
 	}
 
@@ -187,6 +203,7 @@ func init() {
 			ID:                  executorName,
 			RegisteredTaskTypes: []core.TaskType{arrayTaskType},
 			LoadPlugin:          GetNewExecutorPlugin,
+			Validator:           arrayCore.Validator,
 			IsDefault:           false,
 		})
 }
@@ -203,6 +220,13 @@ func GetNewExecutorPlugin(ctx context.Context, iCtx core.SetupContext) (core.Plu
 	} else {
 		kubeClient = iCtx.KubeClient()
 	}
+
+	sink, err := audit.GetSink()
+	if err != nil {
+		return nil, err
+	}
+
+	kubeClient = audit.NewKubeClient(tracing.NewKubeClient(kubeClient), sink)
 	exec, err := NewExecutor(kubeClient, GetConfig(), iCtx.MetricsScope())
 	if err != nil {
 		return nil, err
@@ -0,0 +1,93 @@
+package k8s
+
+import (
+	"bytes"
+	"encoding/gob"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/flyteorg/flytestdlib/bitarray"
+	stdErrors "github.com/flyteorg/flytestdlib/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/plugins/array/arraystatus"
+	arrayCore "github.com/flyteorg/flyteplugins/go/tasks/plugins/array/core"
+)
+
+var update = flag.Bool("update", false, "rewrite the serialized plugin states in testdata")
+
+// gobStateReader reads gob encoded state, as FlytePropeller does.
+type gobStateReader struct {
+	version uint8
+	state   []byte
+}
+
+func (r gobStateReader) GetStateVersion() uint8 {
+	return r.version
+}
+
+func (r gobStateReader) Get(t interface{}) (uint8, error) {
+	if len(r.state) == 0 {
+		return 0, nil
+	}
+
+	return r.version, gob.NewDecoder(bytes.NewReader(r.state)).Decode(t)
+}
+
+func TestStateMigrations(t *testing.T) {
+	detailed := arrayCore.NewPhasesCompactArray(3)
+	detailed.SetItem(0, bitarray.Item(core.PhaseSuccess))
+	detailed.SetItem(1, bitarray.Item(core.PhaseRunning))
+	retryAttempts, err := bitarray.NewCompactArray(3, bitarray.Item(1))
+	assert.NoError(t, err)
+	retryAttempts.SetItem(1, 1)
+
+	v0 := arrayCore.State{
+		CurrentPhase:         arrayCore.PhaseCheckingSubTaskExecutions,
+		PhaseVersion:         2,
+		ExecutionArraySize:   3,
+		OriginalArraySize:    3,
+		OriginalMinSuccesses: 2,
+		ArrayStatus: arraystatus.ArrayStatus{
+			Summary:  arraystatus.ArraySummary{core.PhaseSuccess: 1, core.PhaseRunning: 1},
+			Detailed: detailed,
+		},
+		IndexesToCache: arrayCore.InvertBitSet(bitarray.NewBitSet(3), 3),
+		RetryAttempts:  retryAttempts,
+	}
+
+	path := filepath.Join("testdata", "state_v0.gob")
+	if *update {
+		buf := &bytes.Buffer{}
+		assert.NoError(t, gob.NewEncoder(buf).Encode(v0))
+		assert.NoError(t, os.WriteFile(path, buf.Bytes(), 0644))
+	}
+
+	blob, err := os.ReadFile(path)
+	assert.NoError(t, err)
+
+	t.Run("version 0", func(t *testing.T) {
+		state := arrayCore.State{}
+		version, err := stateMigrations.Get(gobStateReader{version: 0, state: blob}, &state)
+		assert.NoError(t, err)
+		assert.Equal(t, uint8(0), version)
+		assert.Equal(t, v0.CurrentPhase, state.CurrentPhase)
+		assert.Equal(t, v0.PhaseVersion, state.PhaseVersion)
+		assert.Equal(t, v0.ExecutionArraySize, state.ExecutionArraySize)
+		assert.Equal(t, v0.OriginalMinSuccesses, state.OriginalMinSuccesses)
+		assert.Equal(t, v0.ArrayStatus.Summary, state.ArrayStatus.Summary)
+		assert.Equal(t, v0.ArrayStatus.Detailed.GetItems(), state.ArrayStatus.Detailed.GetItems())
+		assert.Equal(t, v0.IndexesToCache, state.IndexesToCache)
+		assert.Equal(t, v0.RetryAttempts.GetItems(), state.RetryAttempts.GetItems())
+	})
+
+	t.Run("future version", func(t *testing.T) {
+		_, err := stateMigrations.Get(gobStateReader{version: pluginStateVersion + 1, state: blob}, &arrayCore.State{})
+		assert.Error(t, err)
+		assert.True(t, stdErrors.IsCausedBy(err, errors.UnsupportedStateVersion))
+	})
+}
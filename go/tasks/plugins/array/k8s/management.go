@@ -63,6 +63,9 @@ func deallocateResource(ctx context.Context, tCtx core.TaskExecutionContext, con
 // LaunchAndCheckSubTasksState iterates over each subtask performing operations to transition them
 // to a terminal state. This may include creating new k8s resources, monitoring existing k8s
 // resources, retrying failed attempts, or declaring a permanent failure among others.
+// logConfigs caches the log configs resolved for the subtasks of k8s array tasks.
+var logConfigs logs.ConfigResolver
+
 func LaunchAndCheckSubTasksState(ctx context.Context, tCtx core.TaskExecutionContext, kubeClient core.KubeClient,
 	config *Config, dataStore *storage.DataStore, outputPrefix, baseOutputDataSandbox storage.DataReference, currentState *arrayCore.State) (
 	newState *arrayCore.State, externalResources []*core.ExternalResource, err error) {
@@ -123,7 +126,12 @@ func LaunchAndCheckSubTasksState(ctx context.Context, tCtx core.TaskExecutionCon
 	}
 
 	// initialize log plugin
-	logPlugin, err := logs.InitializeLogPlugins(&config.LogConfig.Config)
+	logConfig, err := logConfigs.ResolveForTask(config.LogConfig.Config, arrayTaskType, tCtx.TaskExecutionMetadata())
+	if err != nil {
+		return currentState, externalResources, err
+	}
+
+	logPlugin, err := logs.InitializeLogPlugins(logConfig)
 	if err != nil {
 		return currentState, externalResources, err
 	}
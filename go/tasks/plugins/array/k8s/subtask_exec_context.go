@@ -158,6 +158,15 @@ func (s SubTaskExecutionID) GetLogSuffix() string {
 	return fmt.Sprintf(" #%d-%d-%d", s.taskRetryAttempt, s.executionIndex, s.subtaskRetryAttempt)
 }
 
+// GetLogTemplateVars returns the variables log link templates can use to refer to the subtask, as {{ .taskRetryAttempt }}
+// refers to the retry attempt of the array task itself.
+func (s SubTaskExecutionID) GetLogTemplateVars() map[string]string {
+	return map[string]string{
+		"subtaskIndex":        strconv.Itoa(s.executionIndex),
+		"subtaskRetryAttempt": strconv.FormatUint(s.subtaskRetryAttempt, 10),
+	}
+}
+
 // NewSubtaskExecutionID constructs a SubTaskExecutionID using the provided parameters
 func NewSubTaskExecutionID(taskExecutionID pluginsCore.TaskExecutionID, executionIndex int, retryAttempt uint64) SubTaskExecutionID {
 	return SubTaskExecutionID{
@@ -28,6 +28,8 @@ func TestSubTaskExecutionContext(t *testing.T) {
 	assert.Nil(t, err)
 
 	assert.Equal(t, fmt.Sprintf("notfound-%d-%d", executionIndex, retryAttempt), stCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName())
+	assert.Equal(t, map[string]string{"subtaskIndex": "0", "subtaskRetryAttempt": "1"},
+		stCtx.TaskExecutionMetadata().GetTaskExecutionID().(SubTaskExecutionID).GetLogTemplateVars())
 
 	subtaskTemplate, err := stCtx.TaskReader().Read(ctx)
 	assert.Nil(t, err)
@@ -248,6 +248,25 @@ func validateQuboleHiveJob(hiveJob plugins.QuboleHiveJob) error {
 	return nil
 }
 
+// ValidateTaskTemplate checks the task template declares a hive query that only references the inputs declared by the
+// task.
+func ValidateTaskTemplate(taskTemplate *idlCore.TaskTemplate) error {
+	hiveJob := plugins.QuboleHiveJob{}
+	if err := utils.UnmarshalStruct(taskTemplate.GetCustom(), &hiveJob); err != nil {
+		return errors.Wrapf(errors.BadTaskSpecification, err, "invalid TaskSpecification [%v], failed to unmarshal", taskTemplate.GetCustom())
+	}
+
+	if err := validateQuboleHiveJob(hiveJob); err != nil {
+		return err
+	}
+
+	if err := template.ValidateInputs([]string{hiveJob.Query.GetQuery()}, taskTemplate.GetInterface().GetInputs()); err != nil {
+		return errors.Wrapf(errors.BadTaskSpecification, err, "query references undeclared inputs")
+	}
+
+	return nil
+}
+
 // This function is the link between the output written by the SDK, and the execution side. It extracts the query
 // out of the task template.
 func GetQueryInfo(ctx context.Context, tCtx core.TaskExecutionContext) (
@@ -511,26 +530,19 @@ func WriteOutputs(ctx context.Context, tCtx core.TaskExecutionContext, currentSt
 	}
 	if len(outputs) == 1 {
 		if results, ok := outputs["results"]; ok {
-			if results.GetType().GetSchema() == nil {
+			if results.GetType().GetSchema() == nil && results.GetType().GetStructuredDatasetType() == nil {
 				return currentState, errors.Errorf(errors.BadTaskSpecification, "A non-SchemaType was found [%v]", results.GetType())
 			}
 			logger.Debugf(ctx, "Writing outputs file for Hive task at [%s]", tCtx.OutputWriter().GetOutputPrefixPath())
-			err = tCtx.OutputWriter().Put(ctx, ioutils.NewInMemoryOutputReader(
-				&idlCore.LiteralMap{
-					Literals: map[string]*idlCore.Literal{
-						"results": {
-							Value: &idlCore.Literal_Scalar{
-								Scalar: &idlCore.Scalar{Value: &idlCore.Scalar_Schema{
-									Schema: &idlCore.Schema{
-										Uri:  externalLocation.String(),
-										Type: results.GetType().GetSchema(),
-									},
-								},
-								},
-							},
-						},
-					},
-				}, nil, nil))
+			// The format of the files the query writes to the external location is up to the query, so it's left as
+			// declared by the task interface.
+			outputReader, err := ioutils.NewQueryResultOutputReader("results", results.GetType(), externalLocation.String(),
+				"")
+			if err != nil {
+				return currentState, errors.Wrapf(errors.BadTaskSpecification, err, "Failed to build the results output")
+			}
+
+			err = tCtx.OutputWriter().Put(ctx, outputReader)
 			if err != nil {
 				logger.Errorf(ctx, "Error writing outputs file: [%s]", err)
 				return currentState, err
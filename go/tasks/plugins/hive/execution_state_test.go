@@ -26,6 +26,7 @@ import (
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
 	pluginsCoreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
 	"github.com/flyteorg/flyteplugins/go/tasks/plugins/hive/client"
 	quboleMocks "github.com/flyteorg/flyteplugins/go/tasks/plugins/hive/client/mocks"
 	"github.com/flyteorg/flyteplugins/go/tasks/plugins/hive/config"
@@ -367,6 +368,43 @@ func TestWriteOutputs(t *testing.T) {
 	fmt.Println(newState)
 }
 
+func TestWriteOutputs_StructuredDataset(t *testing.T) {
+	ctx := context.Background()
+	tt := GetSingleHiveQueryTaskTemplate()
+	tt.Interface.Outputs.Variables["results"].Type = &idlCore.LiteralType{
+		Type: &idlCore.LiteralType_StructuredDatasetType{StructuredDatasetType: &idlCore.StructuredDatasetType{
+			Columns: []*idlCore.StructuredDatasetType_DatasetColumn{
+				{Name: "col1", LiteralType: &idlCore.LiteralType{Type: &idlCore.LiteralType_Simple{Simple: idlCore.SimpleType_INTEGER}}},
+			},
+		}},
+	}
+
+	taskReader := &mocks.TaskReader{}
+	taskReader.OnReadMatch(mock.Anything).Return(&tt, nil)
+	outputWriter := &ioMock.OutputWriter{}
+	outputWriter.OnGetRawOutputPrefix().Return("gs://custom-output-bucket/b")
+	outputWriter.OnGetOutputPrefixPath().Return("/data/")
+	outputWriter.OnPutMatch(mock.Anything, mock.Anything).Return(nil).Run(func(arguments mock.Arguments) {
+		literals, _, err := arguments.Get(1).(io.OutputReader).Read(ctx)
+		assert.NoError(t, err)
+
+		sd := literals.GetLiterals()["results"].GetScalar().GetStructuredDataset()
+		assert.Equal(t, "gs://custom-output-bucket/b", sd.GetUri())
+		assert.Equal(t, "col1", sd.GetMetadata().GetStructuredDatasetType().GetColumns()[0].GetName())
+		// The format of the files is up to the query, it's left as declared.
+		assert.Empty(t, sd.GetMetadata().GetStructuredDatasetType().GetFormat())
+	})
+
+	tCtx := &mocks.TaskExecutionContext{}
+	tCtx.OnTaskReader().Return(taskReader)
+	tCtx.OnOutputWriter().Return(outputWriter)
+
+	newState, err := WriteOutputs(ctx, tCtx, ExecutionState{})
+	assert.NoError(t, err)
+	assert.Equal(t, PhaseQuerySucceeded, newState.Phase)
+	outputWriter.AssertCalled(t, "Put", mock.Anything, mock.Anything)
+}
+
 func createMockQuboleCfg() *config.Config {
 	return &config.Config{
 		DefaultClusterLabel: "default",
@@ -465,3 +503,17 @@ func Test_getClusterPrimaryLabel(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateTaskTemplate(t *testing.T) {
+	taskTemplate := GetSingleHiveQueryTaskTemplate()
+	assert.NoError(t, ValidateTaskTemplate(&taskTemplate))
+
+	hiveJob := plugins.QuboleHiveJob{Query: &plugins.HiveQuery{Query: "select {{ .Inputs.x }}"}}
+	assert.NoError(t, utils.MarshalStruct(&hiveJob, taskTemplate.Custom))
+	assert.Error(t, ValidateTaskTemplate(&taskTemplate))
+
+	taskTemplate.Interface = &idlCore.TypedInterface{Inputs: &idlCore.VariableMap{
+		Variables: map[string]*idlCore.Variable{"x": {}},
+	}}
+	assert.NoError(t, ValidateTaskTemplate(&taskTemplate))
+}
@@ -3,6 +3,7 @@ package hive
 import (
 	"context"
 
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flytestdlib/cache"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/errors"
@@ -21,6 +22,10 @@ const quboleHiveExecutorID = "qubole-hive-executor"
 // the structure of the stored state
 const pluginStateVersion = 0
 
+// Reads and writes the custom state, upgrading state written by older versions of the plugin and refusing state
+// written by newer ones. Register a migration here when bumping pluginStateVersion.
+var stateMigrations = core.NewStateMigrations(pluginStateVersion)
+
 const hiveTaskType = "hive" // This needs to match the type defined in Flytekit constants.py
 
 const DefaultClusterPrimaryLabel = "default"
@@ -37,12 +42,16 @@ func (q QuboleHiveExecutor) GetID() string {
 	return q.id
 }
 
+func (q QuboleHiveExecutor) Validate(_ context.Context, taskTemplate *idlCore.TaskTemplate) error {
+	return ValidateTaskTemplate(taskTemplate)
+}
+
 func (q QuboleHiveExecutor) Handle(ctx context.Context, tCtx core.TaskExecutionContext) (core.Transition, error) {
 	incomingState := ExecutionState{}
 
 	// We assume here that the first time this function is called, the custom state we get back is whatever we passed in,
 	// namely the zero-value of our struct.
-	if _, err := tCtx.PluginStateReader().Get(&incomingState); err != nil {
+	if _, err := stateMigrations.Get(tCtx.PluginStateReader(), &incomingState); err != nil {
 		logger.Errorf(ctx, "Plugin %s failed to unmarshal custom state when handling [%s] [%s]",
 			q.id, tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName(), err)
 		return core.UnknownTransition, errors.Wrapf(errors.CorruptedPluginState, err,
@@ -61,7 +70,7 @@ func (q QuboleHiveExecutor) Handle(ctx context.Context, tCtx core.TaskExecutionC
 	// If no error, then infer the new Phase from the various states
 	phaseInfo := MapExecutionStateToPhaseInfo(outgoingState, q.quboleClient)
 
-	if err := tCtx.PluginStateWriter().Put(pluginStateVersion, outgoingState); err != nil {
+	if err := stateMigrations.Put(tCtx.PluginStateWriter(), outgoingState); err != nil {
 		return core.UnknownTransition, err
 	}
 
@@ -70,7 +79,7 @@ func (q QuboleHiveExecutor) Handle(ctx context.Context, tCtx core.TaskExecutionC
 
 func (q QuboleHiveExecutor) Abort(ctx context.Context, tCtx core.TaskExecutionContext) error {
 	incomingState := ExecutionState{}
-	if _, err := tCtx.PluginStateReader().Get(&incomingState); err != nil {
+	if _, err := stateMigrations.Get(tCtx.PluginStateReader(), &incomingState); err != nil {
 		logger.Errorf(ctx, "Plugin %s failed to unmarshal custom state in Finalize [%s] Err [%s]",
 			q.id, tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName(), err)
 		return errors.Wrapf(errors.CorruptedPluginState, err, "Failed to unmarshal custom state in Finalize")
@@ -87,7 +96,7 @@ func (q QuboleHiveExecutor) Abort(ctx context.Context, tCtx core.TaskExecutionCo
 
 func (q QuboleHiveExecutor) Finalize(ctx context.Context, tCtx core.TaskExecutionContext) error {
 	incomingState := ExecutionState{}
-	if _, err := tCtx.PluginStateReader().Get(&incomingState); err != nil {
+	if _, err := stateMigrations.Get(tCtx.PluginStateReader(), &incomingState); err != nil {
 		logger.Errorf(ctx, "Plugin %s failed to unmarshal custom state in Finalize [%s] Err [%s]",
 			q.id, tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName(), err)
 		return errors.Wrapf(errors.CorruptedPluginState, err, "Failed to unmarshal custom state in Finalize")
@@ -162,6 +171,7 @@ func init() {
 			ID:                  quboleHiveExecutorID,
 			RegisteredTaskTypes: []core.TaskType{hiveTaskType},
 			LoadPlugin:          QuboleHiveExecutorLoader,
+			Validator:           QuboleHiveExecutor{},
 			IsDefault:           false,
 		})
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery"
 	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/template"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/dryrun"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
@@ -107,6 +108,37 @@ func (daskResourceHandler) BuildIdentityResource(_ context.Context, _ pluginsCor
 	}, nil
 }
 
+func validateDaskJob(daskJob *plugins.DaskJob) error {
+	if daskJob.GetScheduler() == nil {
+		return fmt.Errorf("scheduler is not set")
+	}
+
+	if daskJob.GetWorkers() == nil {
+		return fmt.Errorf("workers are not set")
+	}
+
+	if daskJob.GetWorkers().GetNumberOfWorkers() == 0 {
+		return fmt.Errorf("number of workers must be positive")
+	}
+
+	return nil
+}
+
+// Validate checks the scheduler and workers of the task template and builds its dask job.
+func (p daskResourceHandler) Validate(ctx context.Context, taskTemplate *core.TaskTemplate) error {
+	daskJob := plugins.DaskJob{}
+	if err := utils.UnmarshalStruct(taskTemplate.GetCustom(), &daskJob); err != nil {
+		return errors.Wrapf(errors.BadTaskSpecification, err, "invalid TaskSpecification [%v], failed to unmarshal", taskTemplate.GetCustom())
+	}
+
+	if err := validateDaskJob(&daskJob); err != nil {
+		return errors.Wrapf(errors.BadTaskSpecification, err, "invalid TaskSpecification [%v]", taskTemplate.GetCustom())
+	}
+
+	_, err := dryrun.BuildResource(ctx, p, taskTemplate)
+	return err
+}
+
 func (p daskResourceHandler) BuildResource(ctx context.Context, taskCtx pluginsCore.TaskExecutionContext) (client.Object, error) {
 	taskTemplate, err := taskCtx.TaskReader().Read(ctx)
 	if err != nil {
@@ -307,7 +339,12 @@ func createJobSpec(workerSpec daskAPI.WorkerSpec, schedulerSpec daskAPI.Schedule
 }
 
 func (p daskResourceHandler) GetTaskPhase(ctx context.Context, pluginContext k8s.PluginContext, r client.Object) (pluginsCore.PhaseInfo, error) {
-	logPlugin, err := logs.InitializeLogPlugins(logs.GetLogConfig())
+	logConfig, err := logs.GetLogConfigForTask(daskTaskType, pluginContext.TaskExecutionMetadata())
+	if err != nil {
+		return pluginsCore.PhaseInfoUndefined, err
+	}
+
+	logPlugin, err := logs.InitializeLogPlugins(logConfig)
 	if err != nil {
 		return pluginsCore.PhaseInfoUndefined, err
 	}
@@ -323,10 +360,12 @@ func (p daskResourceHandler) GetTaskPhase(ctx context.Context, pluginContext k8s
 		status == daskAPI.DaskJobClusterCreated
 
 	if !isQueued {
+		taskExecID := pluginContext.TaskExecutionMetadata().GetTaskExecutionID().GetID()
 		o, err := logPlugin.GetTaskLogs(tasklog.Input{
-			Namespace: job.ObjectMeta.Namespace,
-			PodName:   job.Status.JobRunnerPodName,
-			LogName:   "(User logs)",
+			Namespace:               job.ObjectMeta.Namespace,
+			PodName:                 job.Status.JobRunnerPodName,
+			LogName:                 "(User logs)",
+			TaskExecutionIdentifier: &taskExecID,
 		},
 		)
 		if err != nil {
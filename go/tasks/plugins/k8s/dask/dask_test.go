@@ -8,9 +8,12 @@ import (
 	daskAPI "github.com/bstadlbauer/dask-k8s-operator-go-client/pkg/apis/kubernetes.dask.org/v1"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/plugins"
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
 	pluginIOMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io/mocks"
+	"github.com/flyteorg/flytestdlib/contextutils"
+	"github.com/flyteorg/flytestdlib/promutils/labeled"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	v1 "k8s.io/api/core/v1"
@@ -21,11 +24,18 @@ import (
 	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
+	k8smocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/plugintest"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/plugintest/cluster"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
 	"github.com/golang/protobuf/jsonpb"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
+func init() {
+	labeled.SetMetricKeys(contextutils.NamespaceKey)
+}
+
 const (
 	defaultTestImage = "image://"
 	testNWorkers     = 10
@@ -486,38 +496,94 @@ func TestGetTaskPhaseDask(t *testing.T) {
 	daskResourceHandler := daskResourceHandler{}
 	ctx := context.TODO()
 
-	taskPhase, err := daskResourceHandler.GetTaskPhase(ctx, nil, dummyDaskJob(daskAPI.DaskJobCreated))
+	taskPhase, err := daskResourceHandler.GetTaskPhase(ctx, dummyPluginContext(), dummyDaskJob(daskAPI.DaskJobCreated))
 	assert.NoError(t, err)
 	assert.Equal(t, taskPhase.Phase(), pluginsCore.PhaseInitializing)
 	assert.NotNil(t, taskPhase.Info())
 	assert.Nil(t, taskPhase.Info().Logs)
 	assert.Nil(t, err)
 
-	taskPhase, err = daskResourceHandler.GetTaskPhase(ctx, nil, dummyDaskJob(daskAPI.DaskJobClusterCreated))
+	taskPhase, err = daskResourceHandler.GetTaskPhase(ctx, dummyPluginContext(), dummyDaskJob(daskAPI.DaskJobClusterCreated))
 	assert.NoError(t, err)
 	assert.Equal(t, taskPhase.Phase(), pluginsCore.PhaseInitializing)
 	assert.NotNil(t, taskPhase.Info())
 	assert.Nil(t, taskPhase.Info().Logs)
 	assert.Nil(t, err)
 
-	taskPhase, err = daskResourceHandler.GetTaskPhase(ctx, nil, dummyDaskJob(daskAPI.DaskJobRunning))
+	taskPhase, err = daskResourceHandler.GetTaskPhase(ctx, dummyPluginContext(), dummyDaskJob(daskAPI.DaskJobRunning))
 	assert.NoError(t, err)
 	assert.Equal(t, taskPhase.Phase(), pluginsCore.PhaseRunning)
 	assert.NotNil(t, taskPhase.Info())
 	assert.NotNil(t, taskPhase.Info().Logs)
 	assert.Nil(t, err)
 
-	taskPhase, err = daskResourceHandler.GetTaskPhase(ctx, nil, dummyDaskJob(daskAPI.DaskJobSuccessful))
+	taskPhase, err = daskResourceHandler.GetTaskPhase(ctx, dummyPluginContext(), dummyDaskJob(daskAPI.DaskJobSuccessful))
 	assert.NoError(t, err)
 	assert.Equal(t, taskPhase.Phase(), pluginsCore.PhaseSuccess)
 	assert.NotNil(t, taskPhase.Info())
 	assert.NotNil(t, taskPhase.Info().Logs)
 	assert.Nil(t, err)
 
-	taskPhase, err = daskResourceHandler.GetTaskPhase(ctx, nil, dummyDaskJob(daskAPI.DaskJobFailed))
+	taskPhase, err = daskResourceHandler.GetTaskPhase(ctx, dummyPluginContext(), dummyDaskJob(daskAPI.DaskJobFailed))
 	assert.NoError(t, err)
 	assert.Equal(t, taskPhase.Phase(), pluginsCore.PhaseRetryableFailure)
 	assert.NotNil(t, taskPhase.Info())
 	assert.NotNil(t, taskPhase.Info().Logs)
 	assert.Nil(t, err)
 }
+
+func dummyPluginContext() *k8smocks.PluginContext {
+	tID := &mocks.TaskExecutionID{}
+	tID.OnGetID().Return(core.TaskExecutionIdentifier{
+		NodeExecutionId: &core.NodeExecutionIdentifier{
+			ExecutionId: &core.WorkflowExecutionIdentifier{
+				Name:    "my_name",
+				Project: "my_project",
+				Domain:  "my_domain",
+			},
+		},
+	})
+
+	taskExecutionMetadata := &mocks.TaskExecutionMetadata{}
+	taskExecutionMetadata.OnGetTaskExecutionID().Return(tID)
+
+	pCtx := &k8smocks.PluginContext{}
+	pCtx.OnTaskExecutionMetadata().Return(taskExecutionMetadata)
+	return pCtx
+}
+
+func TestLifecycleDask(t *testing.T) {
+	ctx := context.TODO()
+	tCtx := plugintest.NewTaskContextBuilder(t).
+		WithTaskTemplate(dummyDaskTaskTemplate("", nil)).
+		WithScheme(cluster.Scheme()).
+		Build()
+
+	res := cluster.Simulate(tCtx, cluster.StagePending, cluster.StageRunning, cluster.StageFailed).
+		Runner(t, daskResourceHandler{}).
+		Run(ctx)
+
+	res.AssertPhases(pluginsCore.PhaseInitializing, pluginsCore.PhaseRunning, pluginsCore.PhaseRetryableFailure)
+	res.AssertFailure(errors.DownstreamSystemError)
+	res.AssertLogs("Kubernetes Logs(User logs)")
+}
+
+func TestValidateDask(t *testing.T) {
+	ctx := context.TODO()
+	daskResourceHandler := daskResourceHandler{}
+	t.Run("valid", func(t *testing.T) {
+		assert.NoError(t, daskResourceHandler.Validate(ctx, dummyDaskTaskTemplate("", nil)))
+	})
+
+	t.Run("no workers", func(t *testing.T) {
+		taskTemplate := dummyDaskTaskTemplate("", nil)
+		delete(taskTemplate.Custom.Fields, "workers")
+		assert.Error(t, daskResourceHandler.Validate(ctx, taskTemplate))
+	})
+
+	t.Run("no image", func(t *testing.T) {
+		taskTemplate := dummyDaskTaskTemplate("", nil)
+		taskTemplate.GetContainer().Image = ""
+		assert.Error(t, daskResourceHandler.Validate(ctx, taskTemplate))
+	})
+}
@@ -1,17 +1,18 @@
 package common
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"time"
 
-	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tasklog"
-
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	flyteerr "github.com/flyteorg/flyteplugins/go/tasks/errors"
 	"github.com/flyteorg/flyteplugins/go/tasks/logs"
 	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
 	commonOp "github.com/kubeflow/common/pkg/apis/common/v1"
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
 	v1 "k8s.io/api/core/v1"
 )
 
@@ -21,6 +22,12 @@ const (
 	PytorchTaskType    = "pytorch"
 )
 
+var defaultContainerNames = map[string]string{
+	TensorflowTaskType: kubeflowv1.TFJobDefaultContainerName,
+	MPITaskType:        kubeflowv1.MPIJobDefaultContainerName,
+	PytorchTaskType:    kubeflowv1.PytorchJobDefaultContainerName,
+}
+
 // ExtractMPICurrentCondition will return the first job condition for MPI
 func ExtractMPICurrentCondition(jobConditions []commonOp.JobCondition) (commonOp.JobCondition, error) {
 	if jobConditions != nil {
@@ -96,11 +103,17 @@ func GetMPIPhaseInfo(currentCondition commonOp.JobCondition, occurredAt time.Tim
 }
 
 // GetLogs will return the logs for kubeflow job
-func GetLogs(taskType string, name string, namespace string,
+func GetLogs(ctx context.Context, pluginContext k8s.PluginContext, taskType string, name string, namespace string,
 	workersCount int32, psReplicasCount int32, chiefReplicasCount int32) ([]*core.TaskLog, error) {
 	taskLogs := make([]*core.TaskLog, 0, 10)
+	taskExecutionID := pluginContext.TaskExecutionMetadata().GetTaskExecutionID()
+
+	logConfig, err := logs.GetLogConfigForTask(taskType, pluginContext.TaskExecutionMetadata())
+	if err != nil {
+		return nil, err
+	}
 
-	logPlugin, err := logs.InitializeLogPlugins(logs.GetLogConfig())
+	logPlugin, err := logs.InitializeLogPlugins(logConfig)
 
 	if err != nil {
 		return nil, err
@@ -110,30 +123,32 @@ func GetLogs(taskType string, name string, namespace string,
 		return nil, nil
 	}
 
+	// The operators force the name of the container running the task code. See OverrideDefaultContainerName. The pods
+	// are read to build the log links of their sidecar and init containers too.
+	containerName := defaultContainerNames[taskType]
+	k8sReader := k8s.GetK8sReader(pluginContext)
+	addLogs := func(podName, nameSuffix string) error {
+		podLogs, err := logs.GetLogsForPodByName(ctx, logPlugin, taskExecutionID, k8sReader, namespace, podName,
+			containerName, nameSuffix)
+		if err != nil {
+			return err
+		}
+
+		taskLogs = append(taskLogs, podLogs...)
+		return nil
+	}
+
 	if taskType == PytorchTaskType {
-		masterTaskLog, masterErr := logPlugin.GetTaskLogs(
-			tasklog.Input{
-				PodName:   name + "-master-0",
-				Namespace: namespace,
-				LogName:   "master",
-			},
-		)
-		if masterErr != nil {
-			return nil, masterErr
+		if err := addLogs(name+"-master-0", "master"); err != nil {
+			return nil, err
 		}
-		taskLogs = append(taskLogs, masterTaskLog.TaskLogs...)
 	}
 
 	// get all workers log
 	for workerIndex := int32(0); workerIndex < workersCount; workerIndex++ {
-		workerLog, err := logPlugin.GetTaskLogs(tasklog.Input{
-			PodName:   name + fmt.Sprintf("-worker-%d", workerIndex),
-			Namespace: namespace,
-		})
-		if err != nil {
+		if err := addLogs(name+fmt.Sprintf("-worker-%d", workerIndex), ""); err != nil {
 			return nil, err
 		}
-		taskLogs = append(taskLogs, workerLog.TaskLogs...)
 	}
 
 	if taskType == MPITaskType || taskType == PytorchTaskType {
@@ -142,25 +157,15 @@ func GetLogs(taskType string, name string, namespace string,
 
 	// get all parameter servers logs
 	for psReplicaIndex := int32(0); psReplicaIndex < psReplicasCount; psReplicaIndex++ {
-		psReplicaLog, err := logPlugin.GetTaskLogs(tasklog.Input{
-			PodName:   name + fmt.Sprintf("-psReplica-%d", psReplicaIndex),
-			Namespace: namespace,
-		})
-		if err != nil {
+		if err := addLogs(name+fmt.Sprintf("-psReplica-%d", psReplicaIndex), ""); err != nil {
 			return nil, err
 		}
-		taskLogs = append(taskLogs, psReplicaLog.TaskLogs...)
 	}
 	// get chief worker log, and the max number of chief worker is 1
 	if chiefReplicasCount != 0 {
-		chiefReplicaLog, err := logPlugin.GetTaskLogs(tasklog.Input{
-			PodName:   name + fmt.Sprintf("-chiefReplica-%d", 0),
-			Namespace: namespace,
-		})
-		if err != nil {
+		if err := addLogs(name+fmt.Sprintf("-chiefReplica-%d", 0), ""); err != nil {
 			return nil, err
 		}
-		taskLogs = append(taskLogs, chiefReplicaLog.TaskLogs...)
 	}
 
 	return taskLogs, nil
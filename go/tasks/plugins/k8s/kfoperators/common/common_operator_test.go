@@ -1,16 +1,25 @@
 package common
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
 
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flyteplugins/go/tasks/logs"
+	pluginsCoreMock "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	k8smocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s/mocks"
 
 	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 	commonOp "github.com/kubeflow/common/pkg/apis/common/v1"
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestExtractMPICurrentCondition(t *testing.T) {
@@ -164,18 +173,18 @@ func TestGetLogs(t *testing.T) {
 	workers := int32(1)
 	launcher := int32(1)
 
-	jobLogs, err := GetLogs(MPITaskType, "test", "mpi-namespace", workers, launcher, 0)
+	jobLogs, err := GetLogs(context.TODO(), dummyPluginContext(), MPITaskType, "test", "mpi-namespace", workers, launcher, 0)
 	assert.NoError(t, err)
 	assert.Equal(t, 1, len(jobLogs))
 	assert.Equal(t, fmt.Sprintf("k8s.com/#!/log/%s/%s-worker-0/pod?namespace=mpi-namespace", "mpi-namespace", "test"), jobLogs[0].Uri)
 
-	jobLogs, err = GetLogs(PytorchTaskType, "test", "pytorch-namespace", workers, launcher, 0)
+	jobLogs, err = GetLogs(context.TODO(), dummyPluginContext(), PytorchTaskType, "test", "pytorch-namespace", workers, launcher, 0)
 	assert.NoError(t, err)
 	assert.Equal(t, 2, len(jobLogs))
 	assert.Equal(t, fmt.Sprintf("k8s.com/#!/log/%s/%s-master-0/pod?namespace=pytorch-namespace", "pytorch-namespace", "test"), jobLogs[0].Uri)
 	assert.Equal(t, fmt.Sprintf("k8s.com/#!/log/%s/%s-worker-0/pod?namespace=pytorch-namespace", "pytorch-namespace", "test"), jobLogs[1].Uri)
 
-	jobLogs, err = GetLogs(TensorflowTaskType, "test", "tensorflow-namespace", workers, launcher, 1)
+	jobLogs, err = GetLogs(context.TODO(), dummyPluginContext(), TensorflowTaskType, "test", "tensorflow-namespace", workers, launcher, 1)
 	assert.NoError(t, err)
 	assert.Equal(t, 3, len(jobLogs))
 	assert.Equal(t, fmt.Sprintf("k8s.com/#!/log/%s/%s-worker-0/pod?namespace=tensorflow-namespace", "tensorflow-namespace", "test"), jobLogs[0].Uri)
@@ -183,3 +192,70 @@ func TestGetLogs(t *testing.T) {
 	assert.Equal(t, fmt.Sprintf("k8s.com/#!/log/%s/%s-chiefReplica-0/pod?namespace=tensorflow-namespace", "tensorflow-namespace", "test"), jobLogs[2].Uri)
 
 }
+
+// k8sReaderPluginContext is a plugin context that can read k8s objects.
+type k8sReaderPluginContext struct {
+	*k8smocks.PluginContext
+	reader client.Reader
+}
+
+func (p k8sReaderPluginContext) K8sReader() client.Reader {
+	return p.reader
+}
+
+func TestGetLogs_AllContainers(t *testing.T) {
+	assert.NoError(t, logs.SetLogConfig(&logs.LogConfig{
+		Templates: []logs.TemplateLogPluginConfig{
+			{DisplayName: "Logs", TemplateURIs: []string{"https://logs/{{ .podName }}/{{ .containerName }}?role={{ .containerRole }}"}},
+		},
+	}))
+
+	worker := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "pytorch-namespace", Name: "test-worker-0"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "proxy"}, {Name: kubeflowv1.PytorchJobDefaultContainerName}},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{Name: "proxy"}, {Name: kubeflowv1.PytorchJobDefaultContainerName}},
+		},
+	}
+
+	pluginContext := k8sReaderPluginContext{
+		PluginContext: dummyPluginContext(),
+		reader:        fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(worker).Build(),
+	}
+
+	// The master pod doesn't exist, so only the link of its primary container is built.
+	jobLogs, err := GetLogs(context.TODO(), pluginContext, PytorchTaskType, "test", "pytorch-namespace", 1, 0, 0)
+	assert.NoError(t, err)
+	uris := make([]string, 0, len(jobLogs))
+	for _, l := range jobLogs {
+		uris = append(uris, l.Uri)
+	}
+
+	assert.Equal(t, []string{
+		"https://logs/test-master-0/pytorch?role=primary",
+		"https://logs/test-worker-0/proxy?role=sidecar",
+		"https://logs/test-worker-0/pytorch?role=primary",
+	}, uris)
+}
+
+func dummyPluginContext() *k8smocks.PluginContext {
+	tID := &pluginsCoreMock.TaskExecutionID{}
+	tID.OnGetID().Return(idlCore.TaskExecutionIdentifier{
+		NodeExecutionId: &idlCore.NodeExecutionIdentifier{
+			ExecutionId: &idlCore.WorkflowExecutionIdentifier{
+				Name:    "my_name",
+				Project: "my_project",
+				Domain:  "my_domain",
+			},
+		},
+	})
+
+	taskExecutionMetadata := &pluginsCoreMock.TaskExecutionMetadata{}
+	taskExecutionMetadata.OnGetTaskExecutionID().Return(tID)
+
+	pCtx := &k8smocks.PluginContext{}
+	pCtx.OnTaskExecutionMetadata().Return(taskExecutionMetadata)
+	return pCtx
+}
@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"time"
 
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/plugins"
 	flyteerr "github.com/flyteorg/flyteplugins/go/tasks/errors"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery"
 	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/dryrun"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
@@ -120,10 +122,33 @@ func (mpiOperatorResourceHandler) BuildResource(ctx context.Context, taskCtx plu
 	return job, nil
 }
 
+// Validate checks the replicas of the task template and builds its job.
+func (h mpiOperatorResourceHandler) Validate(ctx context.Context, taskTemplate *idlCore.TaskTemplate) error {
+	mpiTaskExtraArgs := plugins.DistributedMPITrainingTask{}
+	if err := utils.UnmarshalStruct(taskTemplate.GetCustom(), &mpiTaskExtraArgs); err != nil {
+		return flyteerr.Errorf(flyteerr.BadTaskSpecification, "invalid TaskSpecification [%v], Err: [%v]", taskTemplate.GetCustom(), err.Error())
+	}
+
+	if mpiTaskExtraArgs.GetNumWorkers() < 0 {
+		return flyteerr.Errorf(flyteerr.BadTaskSpecification, "number of workers [%v] must not be negative", mpiTaskExtraArgs.GetNumWorkers())
+	}
+
+	if mpiTaskExtraArgs.GetNumLauncherReplicas() < 0 {
+		return flyteerr.Errorf(flyteerr.BadTaskSpecification, "number of launcher replicas [%v] must not be negative", mpiTaskExtraArgs.GetNumLauncherReplicas())
+	}
+
+	if mpiTaskExtraArgs.GetSlots() < 0 {
+		return flyteerr.Errorf(flyteerr.BadTaskSpecification, "number of slots [%v] must not be negative", mpiTaskExtraArgs.GetSlots())
+	}
+
+	_, err := dryrun.BuildResource(ctx, h, taskTemplate)
+	return err
+}
+
 // Analyzes the k8s resource and reports the status as TaskPhase. This call is expected to be relatively fast,
 // any operations that might take a long time (limits are configured system-wide) should be offloaded to the
 // background.
-func (mpiOperatorResourceHandler) GetTaskPhase(_ context.Context, pluginContext k8s.PluginContext, resource client.Object) (pluginsCore.PhaseInfo, error) {
+func (mpiOperatorResourceHandler) GetTaskPhase(ctx context.Context, pluginContext k8s.PluginContext, resource client.Object) (pluginsCore.PhaseInfo, error) {
 	var numWorkers, numLauncherReplicas *int32
 	app, ok := resource.(*kubeflowv1.MPIJob)
 	if !ok {
@@ -133,7 +158,7 @@ func (mpiOperatorResourceHandler) GetTaskPhase(_ context.Context, pluginContext
 	numWorkers = app.Spec.MPIReplicaSpecs[kubeflowv1.MPIJobReplicaTypeWorker].Replicas
 	numLauncherReplicas = app.Spec.MPIReplicaSpecs[kubeflowv1.MPIJobReplicaTypeLauncher].Replicas
 
-	taskLogs, err := common.GetLogs(common.MPITaskType, app.Name, app.Namespace,
+	taskLogs, err := common.GetLogs(ctx, pluginContext, common.MPITaskType, app.Name, app.Namespace,
 		*numWorkers, *numLauncherReplicas, 0)
 	if err != nil {
 		return pluginsCore.PhaseInfoUndefined, err
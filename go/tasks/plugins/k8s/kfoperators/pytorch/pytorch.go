@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"time"
 
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/plugins"
 
 	flyteerr "github.com/flyteorg/flyteplugins/go/tasks/errors"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery"
 	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/dryrun"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
@@ -104,15 +106,30 @@ func (pytorchOperatorResourceHandler) BuildResource(ctx context.Context, taskCtx
 	return job, nil
 }
 
+// Validate checks the replicas of the task template and builds its job.
+func (h pytorchOperatorResourceHandler) Validate(ctx context.Context, taskTemplate *idlCore.TaskTemplate) error {
+	pytorchTaskExtraArgs := plugins.DistributedPyTorchTrainingTask{}
+	if err := utils.UnmarshalStruct(taskTemplate.GetCustom(), &pytorchTaskExtraArgs); err != nil {
+		return flyteerr.Errorf(flyteerr.BadTaskSpecification, "invalid TaskSpecification [%v], Err: [%v]", taskTemplate.GetCustom(), err.Error())
+	}
+
+	if pytorchTaskExtraArgs.GetWorkers() < 0 {
+		return flyteerr.Errorf(flyteerr.BadTaskSpecification, "number of workers [%v] must not be negative", pytorchTaskExtraArgs.GetWorkers())
+	}
+
+	_, err := dryrun.BuildResource(ctx, h, taskTemplate)
+	return err
+}
+
 // Analyses the k8s resource and reports the status as TaskPhase. This call is expected to be relatively fast,
 // any operations that might take a long time (limits are configured system-wide) should be offloaded to the
 // background.
-func (pytorchOperatorResourceHandler) GetTaskPhase(_ context.Context, pluginContext k8s.PluginContext, resource client.Object) (pluginsCore.PhaseInfo, error) {
+func (pytorchOperatorResourceHandler) GetTaskPhase(ctx context.Context, pluginContext k8s.PluginContext, resource client.Object) (pluginsCore.PhaseInfo, error) {
 	app := resource.(*kubeflowv1.PyTorchJob)
 
 	workersCount := app.Spec.PyTorchReplicaSpecs[kubeflowv1.PyTorchJobReplicaTypeWorker].Replicas
 
-	taskLogs, err := common.GetLogs(common.PytorchTaskType, app.Name, app.Namespace, *workersCount, 0, 0)
+	taskLogs, err := common.GetLogs(ctx, pluginContext, common.PytorchTaskType, app.Name, app.Namespace, *workersCount, 0, 0)
 	if err != nil {
 		return pluginsCore.PhaseInfoUndefined, err
 	}
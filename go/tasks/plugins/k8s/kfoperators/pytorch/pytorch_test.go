@@ -8,6 +8,7 @@ import (
 
 	"github.com/flyteorg/flyteplugins/go/tasks/plugins/k8s/kfoperators/common"
 
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
 	"github.com/flyteorg/flyteplugins/go/tasks/logs"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
@@ -21,11 +22,16 @@ import (
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	k8smocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/plugintest"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/plugintest/cluster"
 
 	pluginIOMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io/mocks"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/plugins"
+	"github.com/flyteorg/flytestdlib/contextutils"
+	"github.com/flyteorg/flytestdlib/promutils/labeled"
 	"github.com/golang/protobuf/jsonpb"
 	structpb "github.com/golang/protobuf/ptypes/struct"
 	"github.com/stretchr/testify/assert"
@@ -34,6 +40,10 @@ import (
 	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
 )
 
+func init() {
+	labeled.SetMetricKeys(contextutils.NamespaceKey)
+}
+
 const testImage = "image://"
 const serviceAccount = "pytorch_sa"
 
@@ -320,31 +330,31 @@ func TestGetTaskPhase(t *testing.T) {
 		return dummyPytorchJobResource(pytorchResourceHandler, 2, conditionType)
 	}
 
-	taskPhase, err := pytorchResourceHandler.GetTaskPhase(ctx, nil, dummyPytorchJobResourceCreator(commonOp.JobCreated))
+	taskPhase, err := pytorchResourceHandler.GetTaskPhase(ctx, dummyPluginContext(), dummyPytorchJobResourceCreator(commonOp.JobCreated))
 	assert.NoError(t, err)
 	assert.Equal(t, pluginsCore.PhaseQueued, taskPhase.Phase())
 	assert.NotNil(t, taskPhase.Info())
 	assert.Nil(t, err)
 
-	taskPhase, err = pytorchResourceHandler.GetTaskPhase(ctx, nil, dummyPytorchJobResourceCreator(commonOp.JobRunning))
+	taskPhase, err = pytorchResourceHandler.GetTaskPhase(ctx, dummyPluginContext(), dummyPytorchJobResourceCreator(commonOp.JobRunning))
 	assert.NoError(t, err)
 	assert.Equal(t, pluginsCore.PhaseRunning, taskPhase.Phase())
 	assert.NotNil(t, taskPhase.Info())
 	assert.Nil(t, err)
 
-	taskPhase, err = pytorchResourceHandler.GetTaskPhase(ctx, nil, dummyPytorchJobResourceCreator(commonOp.JobSucceeded))
+	taskPhase, err = pytorchResourceHandler.GetTaskPhase(ctx, dummyPluginContext(), dummyPytorchJobResourceCreator(commonOp.JobSucceeded))
 	assert.NoError(t, err)
 	assert.Equal(t, pluginsCore.PhaseSuccess, taskPhase.Phase())
 	assert.NotNil(t, taskPhase.Info())
 	assert.Nil(t, err)
 
-	taskPhase, err = pytorchResourceHandler.GetTaskPhase(ctx, nil, dummyPytorchJobResourceCreator(commonOp.JobFailed))
+	taskPhase, err = pytorchResourceHandler.GetTaskPhase(ctx, dummyPluginContext(), dummyPytorchJobResourceCreator(commonOp.JobFailed))
 	assert.NoError(t, err)
 	assert.Equal(t, pluginsCore.PhaseRetryableFailure, taskPhase.Phase())
 	assert.NotNil(t, taskPhase.Info())
 	assert.Nil(t, err)
 
-	taskPhase, err = pytorchResourceHandler.GetTaskPhase(ctx, nil, dummyPytorchJobResourceCreator(commonOp.JobRestarting))
+	taskPhase, err = pytorchResourceHandler.GetTaskPhase(ctx, dummyPluginContext(), dummyPytorchJobResourceCreator(commonOp.JobRestarting))
 	assert.NoError(t, err)
 	assert.Equal(t, pluginsCore.PhaseRunning, taskPhase.Phase())
 	assert.NotNil(t, taskPhase.Info())
@@ -361,7 +371,7 @@ func TestGetLogs(t *testing.T) {
 
 	pytorchResourceHandler := pytorchOperatorResourceHandler{}
 	pytorchJob := dummyPytorchJobResource(pytorchResourceHandler, workers, commonOp.JobRunning)
-	jobLogs, err := common.GetLogs(common.PytorchTaskType, pytorchJob.Name, pytorchJob.Namespace, workers, 0, 0)
+	jobLogs, err := common.GetLogs(context.TODO(), dummyPluginContext(), common.PytorchTaskType, pytorchJob.Name, pytorchJob.Namespace, workers, 0, 0)
 	assert.NoError(t, err)
 	assert.Equal(t, 3, len(jobLogs))
 	assert.Equal(t, fmt.Sprintf("k8s.com/#!/log/%s/%s-master-0/pod?namespace=pytorch-namespace", jobNamespace, jobName), jobLogs[0].Uri)
@@ -415,3 +425,69 @@ func TestReplicaCounts(t *testing.T) {
 		})
 	}
 }
+
+func dummyPluginContext() *k8smocks.PluginContext {
+	tID := &mocks.TaskExecutionID{}
+	tID.OnGetID().Return(core.TaskExecutionIdentifier{
+		NodeExecutionId: &core.NodeExecutionIdentifier{
+			ExecutionId: &core.WorkflowExecutionIdentifier{
+				Name:    "my_name",
+				Project: "my_project",
+				Domain:  "my_domain",
+			},
+		},
+	})
+
+	taskExecutionMetadata := &mocks.TaskExecutionMetadata{}
+	taskExecutionMetadata.OnGetTaskExecutionID().Return(tID)
+
+	pCtx := &k8smocks.PluginContext{}
+	pCtx.OnTaskExecutionMetadata().Return(taskExecutionMetadata)
+	return pCtx
+}
+
+func TestLifecyclePytorch(t *testing.T) {
+	ctx := context.TODO()
+	newTaskContext := func(t *testing.T) *plugintest.TaskContext {
+		return plugintest.NewTaskContextBuilder(t).
+			WithTaskTemplate(dummyPytorchTaskTemplate("job1", dummyPytorchCustomObj(2))).
+			WithScheme(cluster.Scheme()).
+			Build()
+	}
+
+	t.Run("success", func(t *testing.T) {
+		res := cluster.Simulate(newTaskContext(t), cluster.StagePending, cluster.StageRunning, cluster.StageSucceeded).
+			Runner(t, pytorchOperatorResourceHandler{}).
+			Run(ctx)
+
+		res.AssertSuccess()
+		res.AssertPhases(pluginsCore.PhaseQueued, pluginsCore.PhaseRunning, pluginsCore.PhaseSuccess)
+	})
+
+	t.Run("evicted", func(t *testing.T) {
+		res := cluster.Simulate(newTaskContext(t), cluster.StageRunning, cluster.StageEvicted).
+			Runner(t, pytorchOperatorResourceHandler{}).
+			Run(ctx)
+
+		res.AssertFailure(errors.DownstreamSystemError)
+	})
+
+	t.Run("aborted", func(t *testing.T) {
+		res := cluster.Simulate(newTaskContext(t), cluster.StagePending, cluster.StageRunning).
+			Runner(t, pytorchOperatorResourceHandler{}).
+			AbortAfter(2).
+			Run(ctx)
+
+		assert.NoError(t, res.Err)
+		assert.True(t, res.Aborted)
+		res.AssertPhases(pluginsCore.PhaseQueued, pluginsCore.PhaseRunning)
+	})
+}
+
+func TestValidatePytorch(t *testing.T) {
+	ctx := context.TODO()
+	pytorchResourceHandler := pytorchOperatorResourceHandler{}
+	assert.NoError(t, pytorchResourceHandler.Validate(ctx, dummyPytorchTaskTemplate("job", dummyPytorchCustomObj(2))))
+	assert.Error(t, pytorchResourceHandler.Validate(ctx, dummyPytorchTaskTemplate("job", dummyPytorchCustomObj(0))))
+	assert.Error(t, pytorchResourceHandler.Validate(ctx, dummyPytorchTaskTemplate("job", dummyPytorchCustomObj(-1))))
+}
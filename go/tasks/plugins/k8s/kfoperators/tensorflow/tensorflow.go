@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"time"
 
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/plugins"
 
 	flyteerr "github.com/flyteorg/flyteplugins/go/tasks/errors"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery"
 	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/dryrun"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
@@ -115,17 +117,40 @@ func (tensorflowOperatorResourceHandler) BuildResource(ctx context.Context, task
 	return job, nil
 }
 
+// Validate checks the replicas of the task template and builds its job.
+func (h tensorflowOperatorResourceHandler) Validate(ctx context.Context, taskTemplate *idlCore.TaskTemplate) error {
+	tensorflowTaskExtraArgs := plugins.DistributedTensorflowTrainingTask{}
+	if err := utils.UnmarshalStruct(taskTemplate.GetCustom(), &tensorflowTaskExtraArgs); err != nil {
+		return flyteerr.Errorf(flyteerr.BadTaskSpecification, "invalid TaskSpecification [%v], Err: [%v]", taskTemplate.GetCustom(), err.Error())
+	}
+
+	if tensorflowTaskExtraArgs.GetWorkers() < 0 {
+		return flyteerr.Errorf(flyteerr.BadTaskSpecification, "number of workers [%v] must not be negative", tensorflowTaskExtraArgs.GetWorkers())
+	}
+
+	if tensorflowTaskExtraArgs.GetPsReplicas() < 0 {
+		return flyteerr.Errorf(flyteerr.BadTaskSpecification, "number of parameter servers [%v] must not be negative", tensorflowTaskExtraArgs.GetPsReplicas())
+	}
+
+	if tensorflowTaskExtraArgs.GetChiefReplicas() < 0 {
+		return flyteerr.Errorf(flyteerr.BadTaskSpecification, "number of chief replicas [%v] must not be negative", tensorflowTaskExtraArgs.GetChiefReplicas())
+	}
+
+	_, err := dryrun.BuildResource(ctx, h, taskTemplate)
+	return err
+}
+
 // Analyses the k8s resource and reports the status as TaskPhase. This call is expected to be relatively fast,
 // any operations that might take a long time (limits are configured system-wide) should be offloaded to the
 // background.
-func (tensorflowOperatorResourceHandler) GetTaskPhase(_ context.Context, pluginContext k8s.PluginContext, resource client.Object) (pluginsCore.PhaseInfo, error) {
+func (tensorflowOperatorResourceHandler) GetTaskPhase(ctx context.Context, pluginContext k8s.PluginContext, resource client.Object) (pluginsCore.PhaseInfo, error) {
 	app := resource.(*kubeflowv1.TFJob)
 
 	workersCount := app.Spec.TFReplicaSpecs[kubeflowv1.TFJobReplicaTypeWorker].Replicas
 	psReplicasCount := app.Spec.TFReplicaSpecs[kubeflowv1.TFJobReplicaTypePS].Replicas
 	chiefCount := app.Spec.TFReplicaSpecs[kubeflowv1.TFJobReplicaTypeChief].Replicas
 
-	taskLogs, err := common.GetLogs(common.TensorflowTaskType, app.Name, app.Namespace,
+	taskLogs, err := common.GetLogs(ctx, pluginContext, common.TensorflowTaskType, app.Name, app.Namespace,
 		*workersCount, *psReplicasCount, *chiefCount)
 	if err != nil {
 		return pluginsCore.PhaseInfoUndefined, err
@@ -5,22 +5,31 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/flyteorg/flyteidl/clients/go/coreutils"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/contextutils"
+	"github.com/flyteorg/flytestdlib/promutils/labeled"
 
+	"github.com/flyteorg/flyteplugins/go/tasks/logs"
 	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 	pluginsCoreMock "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s"
 	pluginsIOMock "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io/mocks"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
+	k8smocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/plugintest"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/plugintest/cluster"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
 	v1 "k8s.io/api/core/v1"
 
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var containerResourceRequirements = &v1.ResourceRequirements{
@@ -30,6 +39,10 @@ var containerResourceRequirements = &v1.ResourceRequirements{
 	},
 }
 
+func init() {
+	labeled.SetMetricKeys(contextutils.NamespaceKey)
+}
+
 func dummyContainerTaskMetadata(resources *v1.ResourceRequirements) pluginsCore.TaskExecutionMetadata {
 	taskMetadata := &pluginsCoreMock.TaskExecutionMetadata{}
 	taskMetadata.On("GetNamespace").Return("test-namespace")
@@ -147,21 +160,21 @@ func TestContainerTaskExecutor_GetTaskStatus(t *testing.T) {
 	ctx := context.TODO()
 	t.Run("running", func(t *testing.T) {
 		j.Status.Phase = v1.PodRunning
-		phaseInfo, err := DefaultPodPlugin.GetTaskPhase(ctx, nil, j)
+		phaseInfo, err := DefaultPodPlugin.GetTaskPhase(ctx, dummyPluginContext(), j)
 		assert.NoError(t, err)
 		assert.Equal(t, pluginsCore.PhaseRunning, phaseInfo.Phase())
 	})
 
 	t.Run("queued", func(t *testing.T) {
 		j.Status.Phase = v1.PodPending
-		phaseInfo, err := DefaultPodPlugin.GetTaskPhase(ctx, nil, j)
+		phaseInfo, err := DefaultPodPlugin.GetTaskPhase(ctx, dummyPluginContext(), j)
 		assert.NoError(t, err)
 		assert.Equal(t, pluginsCore.PhaseQueued, phaseInfo.Phase())
 	})
 
 	t.Run("failNoCondition", func(t *testing.T) {
 		j.Status.Phase = v1.PodFailed
-		phaseInfo, err := DefaultPodPlugin.GetTaskPhase(ctx, nil, j)
+		phaseInfo, err := DefaultPodPlugin.GetTaskPhase(ctx, dummyPluginContext(), j)
 		assert.NoError(t, err)
 		assert.Equal(t, pluginsCore.PhaseRetryableFailure, phaseInfo.Phase())
 		ec := phaseInfo.Err().GetCode()
@@ -177,7 +190,7 @@ func TestContainerTaskExecutor_GetTaskStatus(t *testing.T) {
 				Type: v1.PodReasonUnschedulable,
 			},
 		}
-		phaseInfo, err := DefaultPodPlugin.GetTaskPhase(ctx, nil, j)
+		phaseInfo, err := DefaultPodPlugin.GetTaskPhase(ctx, dummyPluginContext(), j)
 		assert.NoError(t, err)
 		assert.Equal(t, pluginsCore.PhaseRetryableFailure, phaseInfo.Phase())
 		ec := phaseInfo.Err().GetCode()
@@ -186,7 +199,7 @@ func TestContainerTaskExecutor_GetTaskStatus(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		j.Status.Phase = v1.PodSucceeded
-		phaseInfo, err := DefaultPodPlugin.GetTaskPhase(ctx, nil, j)
+		phaseInfo, err := DefaultPodPlugin.GetTaskPhase(ctx, dummyPluginContext(), j)
 		assert.NoError(t, err)
 		assert.NotNil(t, phaseInfo)
 		assert.Equal(t, pluginsCore.PhaseSuccess, phaseInfo.Phase())
@@ -230,7 +243,7 @@ func TestContainerTaskExecutor_GetTaskStatus_InvalidImageName(t *testing.T) {
 
 	t.Run("failInvalidImageName", func(t *testing.T) {
 		pendingPod.Status.Phase = v1.PodPending
-		phaseInfo, err := DefaultPodPlugin.GetTaskPhase(ctx, nil, pendingPod)
+		phaseInfo, err := DefaultPodPlugin.GetTaskPhase(ctx, dummyPluginContext(), pendingPod)
 		finalReason := fmt.Sprintf("|%s", reason)
 		finalMessage := fmt.Sprintf("|%s", message)
 		assert.NoError(t, err)
@@ -238,3 +251,88 @@ func TestContainerTaskExecutor_GetTaskStatus_InvalidImageName(t *testing.T) {
 		assert.Equal(t, &core.ExecutionError{Code: finalReason, Message: finalMessage, Kind: core.ExecutionError_USER}, phaseInfo.Err())
 	})
 }
+
+func dummyPluginContext() *k8smocks.PluginContext {
+	tID := &pluginsCoreMock.TaskExecutionID{}
+	tID.OnGetID().Return(core.TaskExecutionIdentifier{
+		NodeExecutionId: &core.NodeExecutionIdentifier{
+			ExecutionId: &core.WorkflowExecutionIdentifier{
+				Name:    "my_name",
+				Project: "my_project",
+				Domain:  "my_domain",
+			},
+		},
+	})
+
+	taskExecutionMetadata := &pluginsCoreMock.TaskExecutionMetadata{}
+	taskExecutionMetadata.OnGetTaskExecutionID().Return(tID)
+
+	pCtx := &k8smocks.PluginContext{}
+	pCtx.OnTaskExecutionMetadata().Return(taskExecutionMetadata)
+	return pCtx
+}
+
+func TestContainerTaskExecutor_Lifecycle(t *testing.T) {
+	ctx := context.TODO()
+	assert.NoError(t, logs.SetLogConfig(&logs.LogConfig{
+		IsKubernetesEnabled: true,
+		KubernetesURL:       "k8s.com",
+	}))
+	defer func() { assert.NoError(t, logs.SetLogConfig(&logs.DefaultConfig)) }()
+
+	t.Run("success", func(t *testing.T) {
+		outputs := coreutils.MustMakeLiteral(map[string]interface{}{"x": 42}).GetMap()
+		tCtx := plugintest.NewTaskContextBuilder(t).Build()
+		res := cluster.Simulate(tCtx, cluster.StagePending, cluster.StageRunning, cluster.StageSucceeded).
+			WithOutputs(outputs).
+			Runner(t, DefaultPodPlugin).
+			Run(ctx)
+
+		res.AssertSuccess()
+		res.AssertPhases(pluginsCore.PhaseQueued, pluginsCore.PhaseRunning, pluginsCore.PhaseSuccess)
+		res.AssertLogs("Kubernetes Logs (User)")
+		res.AssertOutputs(ctx, outputs)
+	})
+
+	t.Run("evicted", func(t *testing.T) {
+		tCtx := plugintest.NewTaskContextBuilder(t).Build()
+		res := cluster.Simulate(tCtx, cluster.StageRunning, cluster.StageEvicted).
+			Runner(t, DefaultPodPlugin).
+			Run(ctx)
+
+		res.AssertPhases(pluginsCore.PhaseRunning, pluginsCore.PhaseRetryableFailure)
+		res.AssertFailure(flytek8s.Interrupted)
+	})
+
+	t.Run("aborted", func(t *testing.T) {
+		tCtx := plugintest.NewTaskContextBuilder(t).Build()
+		res := cluster.Simulate(tCtx, cluster.StagePending, cluster.StageRunning).
+			Runner(t, DefaultPodPlugin).
+			AbortAfter(2).
+			Run(ctx)
+
+		assert.NoError(t, res.Err)
+		assert.NoError(t, res.AbortErr)
+		assert.True(t, res.Aborted)
+		err := tCtx.KubeClient().GetClient().Get(ctx, client.ObjectKeyFromObject(res.Resource), &v1.Pod{})
+		assert.True(t, k8serrors.IsNotFound(err))
+	})
+}
+
+func TestContainerTaskExecutor_Validate(t *testing.T) {
+	ctx := context.TODO()
+	t.Run("valid", func(t *testing.T) {
+		taskTemplate := &core.TaskTemplate{
+			Type: ContainerTaskType,
+			Target: &core.TaskTemplate_Container{Container: &core.Container{
+				Image: "image",
+				Args:  []string{"{{ .Input }}", "{{ .OutputPrefix }}"},
+			}},
+		}
+		assert.NoError(t, DefaultPodPlugin.Validate(ctx, taskTemplate))
+	})
+
+	t.Run("no container", func(t *testing.T) {
+		assert.Error(t, DefaultPodPlugin.Validate(ctx, &core.TaskTemplate{Type: ContainerTaskType}))
+	})
+}
@@ -7,11 +7,13 @@ import (
 	"github.com/flyteorg/flyteplugins/go/tasks/logs"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery"
 	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/dryrun"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tasklog"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
 
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flytestdlib/logger"
 
 	v1 "k8s.io/api/core/v1"
@@ -136,7 +138,12 @@ func (p plugin) BuildResource(ctx context.Context, taskCtx pluginsCore.TaskExecu
 }
 
 func (p plugin) GetTaskPhase(ctx context.Context, pluginContext k8s.PluginContext, r client.Object) (pluginsCore.PhaseInfo, error) {
-	logPlugin, err := logs.InitializeLogPlugins(logs.GetLogConfig())
+	logConfig, err := logs.GetLogConfigForTask(podTaskType, pluginContext.TaskExecutionMetadata())
+	if err != nil {
+		return pluginsCore.PhaseInfoUndefined, err
+	}
+
+	logPlugin, err := logs.InitializeLogPlugins(logConfig)
 	if err != nil {
 		return pluginsCore.PhaseInfoUndefined, err
 	}
@@ -153,7 +160,8 @@ func (plugin) GetTaskPhaseWithLogs(ctx context.Context, pluginContext k8s.Plugin
 	}
 
 	if pod.Status.Phase != v1.PodPending && pod.Status.Phase != v1.PodUnknown {
-		taskLogs, err := logs.GetLogsForContainerInPod(ctx, logPlugin, pod, 0, logSuffix)
+		taskLogs, err := logs.GetLogsForContainersInPod(ctx, logPlugin, pluginContext.TaskExecutionMetadata().GetTaskExecutionID(),
+			pod, r.GetAnnotations()[flytek8s.PrimaryContainerKey], logSuffix)
 		if err != nil {
 			return pluginsCore.PhaseInfoUndefined, err
 		}
@@ -192,6 +200,13 @@ func (plugin) GetTaskPhaseWithLogs(ctx context.Context, pluginContext k8s.Plugin
 	return primaryContainerPhase, nil
 }
 
+// Validate builds the pod of the task template, so that malformed containers and pod specs are rejected ahead of the
+// task execution.
+func (p plugin) Validate(ctx context.Context, taskTemplate *idlCore.TaskTemplate) error {
+	_, err := dryrun.BuildResource(ctx, p, taskTemplate)
+	return err
+}
+
 func (plugin) GetProperties() k8s.PluginProperties {
 	return k8s.PluginProperties{}
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/flyteorg/flyteplugins/go/tasks/logs"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery"
 	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/dryrun"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
@@ -36,6 +37,59 @@ const (
 type rayJobResourceHandler struct {
 }
 
+func validateRayJob(rayJob *plugins.RayJob) error {
+	if rayJob.GetRayCluster() == nil {
+		return fmt.Errorf("ray cluster is not set")
+	}
+
+	groupNames := make(map[string]struct{}, len(rayJob.GetRayCluster().GetWorkerGroupSpec()))
+	for _, spec := range rayJob.GetRayCluster().GetWorkerGroupSpec() {
+		if len(spec.GetGroupName()) == 0 {
+			return fmt.Errorf("worker group name is not set")
+		}
+
+		if _, exists := groupNames[spec.GetGroupName()]; exists {
+			return fmt.Errorf("duplicate worker group [%v]", spec.GetGroupName())
+		}
+		groupNames[spec.GetGroupName()] = struct{}{}
+
+		// Unset min and max replicas default to the replicas, as in BuildResource.
+		minReplicas, maxReplicas := spec.GetReplicas(), spec.GetReplicas()
+		if spec.GetMinReplicas() != 0 {
+			minReplicas = spec.GetMinReplicas()
+		}
+		if spec.GetMaxReplicas() != 0 {
+			maxReplicas = spec.GetMaxReplicas()
+		}
+
+		if spec.GetReplicas() < 0 || minReplicas < 0 {
+			return fmt.Errorf("worker group [%v] has negative replicas", spec.GetGroupName())
+		}
+
+		if minReplicas > spec.GetReplicas() || spec.GetReplicas() > maxReplicas {
+			return fmt.Errorf("worker group [%v] replicas [%v] are not between min replicas [%v] and max replicas [%v]",
+				spec.GetGroupName(), spec.GetReplicas(), minReplicas, maxReplicas)
+		}
+	}
+
+	return nil
+}
+
+// Validate checks the ray cluster of the task template and builds its ray job.
+func (r rayJobResourceHandler) Validate(ctx context.Context, taskTemplate *core.TaskTemplate) error {
+	rayJob := plugins.RayJob{}
+	if err := utils.UnmarshalStruct(taskTemplate.GetCustom(), &rayJob); err != nil {
+		return errors.Wrapf(errors.BadTaskSpecification, err, "invalid TaskSpecification [%v], failed to unmarshal", taskTemplate.GetCustom())
+	}
+
+	if err := validateRayJob(&rayJob); err != nil {
+		return errors.Wrapf(errors.BadTaskSpecification, err, "invalid TaskSpecification [%v]", taskTemplate.GetCustom())
+	}
+
+	_, err := dryrun.BuildResource(ctx, r, taskTemplate)
+	return err
+}
+
 func (rayJobResourceHandler) GetProperties() k8s.PluginProperties {
 	return k8s.PluginProperties{}
 }
@@ -317,9 +371,14 @@ func (rayJobResourceHandler) BuildIdentityResource(ctx context.Context, taskCtx
 	}, nil
 }
 
-func getEventInfoForRayJob() (*pluginsCore.TaskInfo, error) {
+func getEventInfoForRayJob(pluginContext k8s.PluginContext) (*pluginsCore.TaskInfo, error) {
 	taskLogs := make([]*core.TaskLog, 0, 3)
-	logPlugin, err := logs.InitializeLogPlugins(logs.GetLogConfig())
+	logConfig, err := logs.GetLogConfigForTask(rayTaskType, pluginContext.TaskExecutionMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	logPlugin, err := logs.InitializeLogPlugins(logConfig)
 
 	if err != nil {
 		return nil, err
@@ -341,7 +400,7 @@ func getEventInfoForRayJob() (*pluginsCore.TaskInfo, error) {
 
 func (rayJobResourceHandler) GetTaskPhase(ctx context.Context, pluginContext k8s.PluginContext, resource client.Object) (pluginsCore.PhaseInfo, error) {
 	rayJob := resource.(*rayv1alpha1.RayJob)
-	info, err := getEventInfoForRayJob()
+	info, err := getEventInfoForRayJob(pluginContext)
 	if err != nil {
 		return pluginsCore.PhaseInfoUndefined, err
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
@@ -17,6 +18,10 @@ import (
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s"
 	pluginIOMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io/mocks"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/plugintest"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/plugintest/cluster"
+	"github.com/flyteorg/flytestdlib/contextutils"
+	"github.com/flyteorg/flytestdlib/promutils/labeled"
 	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -25,6 +30,10 @@ import (
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+func init() {
+	labeled.SetMetricKeys(contextutils.NamespaceKey)
+}
+
 const testImage = "image://"
 const serviceAccount = "ray_sa"
 
@@ -190,3 +199,44 @@ func TestGetPropertiesRay(t *testing.T) {
 	expected := k8s.PluginProperties{}
 	assert.Equal(t, expected, rayJobResourceHandler.GetProperties())
 }
+
+func TestLifecycleRay(t *testing.T) {
+	ctx := context.TODO()
+	tCtx := plugintest.NewTaskContextBuilder(t).
+		WithTaskTemplate(dummyRayTaskTemplate("ray-id", dummyRayCustomObj())).
+		WithScheme(cluster.Scheme()).
+		Build()
+
+	res := cluster.Simulate(tCtx, cluster.StagePending, cluster.StageRunning, cluster.StageFailed).
+		Runner(t, rayJobResourceHandler{}).
+		Run(ctx)
+
+	res.AssertPhases(pluginsCore.PhaseNotReady, pluginsCore.PhaseRunning, pluginsCore.PhasePermanentFailure)
+	res.AssertFailure(errors.TaskFailedWithError)
+}
+
+func TestValidateRay(t *testing.T) {
+	ctx := context.TODO()
+	rayJobResourceHandler := rayJobResourceHandler{}
+	t.Run("valid", func(t *testing.T) {
+		assert.NoError(t, rayJobResourceHandler.Validate(ctx, dummyRayTaskTemplate("ray-id", dummyRayCustomObj())))
+	})
+
+	for name, spec := range map[string][]*plugins.WorkerGroupSpec{
+		"no group name":     {{Replicas: 1}},
+		"duplicate group":   {{GroupName: workerGroupName, Replicas: 1}, {GroupName: workerGroupName, Replicas: 1}},
+		"negative replicas": {{GroupName: workerGroupName, Replicas: -1}},
+		"below min":         {{GroupName: workerGroupName, Replicas: 1, MinReplicas: 2, MaxReplicas: 3}},
+		"above max":         {{GroupName: workerGroupName, Replicas: 4, MinReplicas: 2, MaxReplicas: 3}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			rayJob := dummyRayCustomObj()
+			rayJob.RayCluster.WorkerGroupSpec = spec
+			assert.Error(t, rayJobResourceHandler.Validate(ctx, dummyRayTaskTemplate("ray-id", rayJob)))
+		})
+	}
+
+	t.Run("no ray cluster", func(t *testing.T) {
+		assert.Error(t, rayJobResourceHandler.Validate(ctx, dummyRayTaskTemplate("ray-id", &plugins.RayJob{})))
+	})
+}
@@ -62,6 +62,25 @@ func (cfg Config) GetPFlagSet(prefix string) *pflag.FlagSet {
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.mixed.gcp-project"), defaultConfig.LogConfig.Mixed.GCPProjectName, "Name of the project in GCP")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.mixed.stackdriver-logresourcename"), defaultConfig.LogConfig.Mixed.StackdriverLogResourceName, "Name of the logresource in stackdriver")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.mixed.stackdriver-template-uri"), defaultConfig.LogConfig.Mixed.StackDriverTemplateURI, "Template Uri to use when building stackdriver log links")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "logs.mixed.loki.enabled"), defaultConfig.LogConfig.Mixed.Loki.Enabled, "Enable log links to Grafana Loki")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.mixed.loki.grafana-url"), defaultConfig.LogConfig.Mixed.Loki.GrafanaURL, "Base URL of the Grafana instance (e.g. https://grafana.example.com)")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "logs.mixed.loki.org-id"), defaultConfig.LogConfig.Mixed.Loki.OrgID, "Grafana organization id")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.mixed.loki.datasource-uid"), defaultConfig.LogConfig.Mixed.Loki.DatasourceUID, "UID of the Loki datasource in Grafana")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.mixed.loki.query"), defaultConfig.LogConfig.Mixed.Loki.Query, "LogQL query template")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "logs.mixed.datadog.enabled"), defaultConfig.LogConfig.Mixed.Datadog.Enabled, "Enable log links to Datadog")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.mixed.datadog.site"), defaultConfig.LogConfig.Mixed.Datadog.Site, "Datadog site (e.g. datadoghq.com or datadoghq.eu)")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.mixed.datadog.query"), defaultConfig.LogConfig.Mixed.Datadog.Query, "Log search query template")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "logs.mixed.splunk.enabled"), defaultConfig.LogConfig.Mixed.Splunk.Enabled, "Enable log links to Splunk")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.mixed.splunk.url"), defaultConfig.LogConfig.Mixed.Splunk.URL, "Base URL of the Splunk web UI (e.g. https://splunk.example.com)")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.mixed.splunk.app"), defaultConfig.LogConfig.Mixed.Splunk.App, "Splunk app to run the search in")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.mixed.splunk.query"), defaultConfig.LogConfig.Mixed.Splunk.Query, "SPL search query template")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "logs.mixed.kibana.enabled"), defaultConfig.LogConfig.Mixed.Kibana.Enabled, "Enable log links to Kibana")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.mixed.kibana.url"), defaultConfig.LogConfig.Mixed.Kibana.URL, "Base URL of Kibana (e.g. https://kibana.example.com)")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.mixed.kibana.data-view"), defaultConfig.LogConfig.Mixed.Kibana.DataView, "Id of the Kibana data view (index pattern) to search. Kibana's default is used if empty.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.mixed.kibana.query"), defaultConfig.LogConfig.Mixed.Kibana.Query, "KQL query template")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "logs.mixed.exclude-container-roles.primary"), defaultConfig.LogConfig.Mixed.ExcludeContainerRoles.Primary, "The container running the task code")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "logs.mixed.exclude-container-roles.sidecar"), defaultConfig.LogConfig.Mixed.ExcludeContainerRoles.Sidecar, "All other containers in the pod")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "logs.mixed.exclude-container-roles.init"), defaultConfig.LogConfig.Mixed.ExcludeContainerRoles.Init, "Init containers")
 	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "logs.user.cloudwatch-enabled"), defaultConfig.LogConfig.User.IsCloudwatchEnabled, "Enable Cloudwatch Logging")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.user.cloudwatch-region"), defaultConfig.LogConfig.User.CloudwatchRegion, "AWS region in which Cloudwatch logs are stored.")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.user.cloudwatch-log-group"), defaultConfig.LogConfig.User.CloudwatchLogGroup, "Log group to which streams are associated.")
@@ -73,6 +92,25 @@ func (cfg Config) GetPFlagSet(prefix string) *pflag.FlagSet {
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.user.gcp-project"), defaultConfig.LogConfig.User.GCPProjectName, "Name of the project in GCP")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.user.stackdriver-logresourcename"), defaultConfig.LogConfig.User.StackdriverLogResourceName, "Name of the logresource in stackdriver")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.user.stackdriver-template-uri"), defaultConfig.LogConfig.User.StackDriverTemplateURI, "Template Uri to use when building stackdriver log links")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "logs.user.loki.enabled"), defaultConfig.LogConfig.User.Loki.Enabled, "Enable log links to Grafana Loki")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.user.loki.grafana-url"), defaultConfig.LogConfig.User.Loki.GrafanaURL, "Base URL of the Grafana instance (e.g. https://grafana.example.com)")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "logs.user.loki.org-id"), defaultConfig.LogConfig.User.Loki.OrgID, "Grafana organization id")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.user.loki.datasource-uid"), defaultConfig.LogConfig.User.Loki.DatasourceUID, "UID of the Loki datasource in Grafana")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.user.loki.query"), defaultConfig.LogConfig.User.Loki.Query, "LogQL query template")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "logs.user.datadog.enabled"), defaultConfig.LogConfig.User.Datadog.Enabled, "Enable log links to Datadog")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.user.datadog.site"), defaultConfig.LogConfig.User.Datadog.Site, "Datadog site (e.g. datadoghq.com or datadoghq.eu)")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.user.datadog.query"), defaultConfig.LogConfig.User.Datadog.Query, "Log search query template")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "logs.user.splunk.enabled"), defaultConfig.LogConfig.User.Splunk.Enabled, "Enable log links to Splunk")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.user.splunk.url"), defaultConfig.LogConfig.User.Splunk.URL, "Base URL of the Splunk web UI (e.g. https://splunk.example.com)")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.user.splunk.app"), defaultConfig.LogConfig.User.Splunk.App, "Splunk app to run the search in")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.user.splunk.query"), defaultConfig.LogConfig.User.Splunk.Query, "SPL search query template")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "logs.user.kibana.enabled"), defaultConfig.LogConfig.User.Kibana.Enabled, "Enable log links to Kibana")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.user.kibana.url"), defaultConfig.LogConfig.User.Kibana.URL, "Base URL of Kibana (e.g. https://kibana.example.com)")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.user.kibana.data-view"), defaultConfig.LogConfig.User.Kibana.DataView, "Id of the Kibana data view (index pattern) to search. Kibana's default is used if empty.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.user.kibana.query"), defaultConfig.LogConfig.User.Kibana.Query, "KQL query template")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "logs.user.exclude-container-roles.primary"), defaultConfig.LogConfig.User.ExcludeContainerRoles.Primary, "The container running the task code")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "logs.user.exclude-container-roles.sidecar"), defaultConfig.LogConfig.User.ExcludeContainerRoles.Sidecar, "All other containers in the pod")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "logs.user.exclude-container-roles.init"), defaultConfig.LogConfig.User.ExcludeContainerRoles.Init, "Init containers")
 	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "logs.system.cloudwatch-enabled"), defaultConfig.LogConfig.System.IsCloudwatchEnabled, "Enable Cloudwatch Logging")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.system.cloudwatch-region"), defaultConfig.LogConfig.System.CloudwatchRegion, "AWS region in which Cloudwatch logs are stored.")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.system.cloudwatch-log-group"), defaultConfig.LogConfig.System.CloudwatchLogGroup, "Log group to which streams are associated.")
@@ -84,6 +122,25 @@ func (cfg Config) GetPFlagSet(prefix string) *pflag.FlagSet {
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.system.gcp-project"), defaultConfig.LogConfig.System.GCPProjectName, "Name of the project in GCP")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.system.stackdriver-logresourcename"), defaultConfig.LogConfig.System.StackdriverLogResourceName, "Name of the logresource in stackdriver")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.system.stackdriver-template-uri"), defaultConfig.LogConfig.System.StackDriverTemplateURI, "Template Uri to use when building stackdriver log links")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "logs.system.loki.enabled"), defaultConfig.LogConfig.System.Loki.Enabled, "Enable log links to Grafana Loki")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.system.loki.grafana-url"), defaultConfig.LogConfig.System.Loki.GrafanaURL, "Base URL of the Grafana instance (e.g. https://grafana.example.com)")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "logs.system.loki.org-id"), defaultConfig.LogConfig.System.Loki.OrgID, "Grafana organization id")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.system.loki.datasource-uid"), defaultConfig.LogConfig.System.Loki.DatasourceUID, "UID of the Loki datasource in Grafana")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.system.loki.query"), defaultConfig.LogConfig.System.Loki.Query, "LogQL query template")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "logs.system.datadog.enabled"), defaultConfig.LogConfig.System.Datadog.Enabled, "Enable log links to Datadog")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.system.datadog.site"), defaultConfig.LogConfig.System.Datadog.Site, "Datadog site (e.g. datadoghq.com or datadoghq.eu)")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.system.datadog.query"), defaultConfig.LogConfig.System.Datadog.Query, "Log search query template")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "logs.system.splunk.enabled"), defaultConfig.LogConfig.System.Splunk.Enabled, "Enable log links to Splunk")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.system.splunk.url"), defaultConfig.LogConfig.System.Splunk.URL, "Base URL of the Splunk web UI (e.g. https://splunk.example.com)")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.system.splunk.app"), defaultConfig.LogConfig.System.Splunk.App, "Splunk app to run the search in")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.system.splunk.query"), defaultConfig.LogConfig.System.Splunk.Query, "SPL search query template")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "logs.system.kibana.enabled"), defaultConfig.LogConfig.System.Kibana.Enabled, "Enable log links to Kibana")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.system.kibana.url"), defaultConfig.LogConfig.System.Kibana.URL, "Base URL of Kibana (e.g. https://kibana.example.com)")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.system.kibana.data-view"), defaultConfig.LogConfig.System.Kibana.DataView, "Id of the Kibana data view (index pattern) to search. Kibana's default is used if empty.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.system.kibana.query"), defaultConfig.LogConfig.System.Kibana.Query, "KQL query template")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "logs.system.exclude-container-roles.primary"), defaultConfig.LogConfig.System.ExcludeContainerRoles.Primary, "The container running the task code")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "logs.system.exclude-container-roles.sidecar"), defaultConfig.LogConfig.System.ExcludeContainerRoles.Sidecar, "All other containers in the pod")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "logs.system.exclude-container-roles.init"), defaultConfig.LogConfig.System.ExcludeContainerRoles.Init, "Init containers")
 	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "logs.all-user.cloudwatch-enabled"), defaultConfig.LogConfig.AllUser.IsCloudwatchEnabled, "Enable Cloudwatch Logging")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.all-user.cloudwatch-region"), defaultConfig.LogConfig.AllUser.CloudwatchRegion, "AWS region in which Cloudwatch logs are stored.")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.all-user.cloudwatch-log-group"), defaultConfig.LogConfig.AllUser.CloudwatchLogGroup, "Log group to which streams are associated.")
@@ -95,5 +152,24 @@ func (cfg Config) GetPFlagSet(prefix string) *pflag.FlagSet {
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.all-user.gcp-project"), defaultConfig.LogConfig.AllUser.GCPProjectName, "Name of the project in GCP")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.all-user.stackdriver-logresourcename"), defaultConfig.LogConfig.AllUser.StackdriverLogResourceName, "Name of the logresource in stackdriver")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.all-user.stackdriver-template-uri"), defaultConfig.LogConfig.AllUser.StackDriverTemplateURI, "Template Uri to use when building stackdriver log links")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "logs.all-user.loki.enabled"), defaultConfig.LogConfig.AllUser.Loki.Enabled, "Enable log links to Grafana Loki")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.all-user.loki.grafana-url"), defaultConfig.LogConfig.AllUser.Loki.GrafanaURL, "Base URL of the Grafana instance (e.g. https://grafana.example.com)")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "logs.all-user.loki.org-id"), defaultConfig.LogConfig.AllUser.Loki.OrgID, "Grafana organization id")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.all-user.loki.datasource-uid"), defaultConfig.LogConfig.AllUser.Loki.DatasourceUID, "UID of the Loki datasource in Grafana")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.all-user.loki.query"), defaultConfig.LogConfig.AllUser.Loki.Query, "LogQL query template")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "logs.all-user.datadog.enabled"), defaultConfig.LogConfig.AllUser.Datadog.Enabled, "Enable log links to Datadog")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.all-user.datadog.site"), defaultConfig.LogConfig.AllUser.Datadog.Site, "Datadog site (e.g. datadoghq.com or datadoghq.eu)")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.all-user.datadog.query"), defaultConfig.LogConfig.AllUser.Datadog.Query, "Log search query template")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "logs.all-user.splunk.enabled"), defaultConfig.LogConfig.AllUser.Splunk.Enabled, "Enable log links to Splunk")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.all-user.splunk.url"), defaultConfig.LogConfig.AllUser.Splunk.URL, "Base URL of the Splunk web UI (e.g. https://splunk.example.com)")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.all-user.splunk.app"), defaultConfig.LogConfig.AllUser.Splunk.App, "Splunk app to run the search in")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.all-user.splunk.query"), defaultConfig.LogConfig.AllUser.Splunk.Query, "SPL search query template")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "logs.all-user.kibana.enabled"), defaultConfig.LogConfig.AllUser.Kibana.Enabled, "Enable log links to Kibana")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.all-user.kibana.url"), defaultConfig.LogConfig.AllUser.Kibana.URL, "Base URL of Kibana (e.g. https://kibana.example.com)")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.all-user.kibana.data-view"), defaultConfig.LogConfig.AllUser.Kibana.DataView, "Id of the Kibana data view (index pattern) to search. Kibana's default is used if empty.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "logs.all-user.kibana.query"), defaultConfig.LogConfig.AllUser.Kibana.Query, "KQL query template")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "logs.all-user.exclude-container-roles.primary"), defaultConfig.LogConfig.AllUser.ExcludeContainerRoles.Primary, "The container running the task code")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "logs.all-user.exclude-container-roles.sidecar"), defaultConfig.LogConfig.AllUser.ExcludeContainerRoles.Sidecar, "All other containers in the pod")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "logs.all-user.exclude-container-roles.init"), defaultConfig.LogConfig.AllUser.ExcludeContainerRoles.Init, "Init containers")
 	return cmdFlags
 }
@@ -267,462 +267,1526 @@ func TestConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_logs.mixed.loki.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.mixed.loki.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("logs.mixed.loki.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.Mixed.Loki.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.mixed.loki.grafana-url", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.mixed.loki.grafana-url", testValue)
+			if vString, err := cmdFlags.GetString("logs.mixed.loki.grafana-url"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.Mixed.Loki.GrafanaURL)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.mixed.loki.org-id", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.mixed.loki.org-id", testValue)
+			if vInt, err := cmdFlags.GetInt("logs.mixed.loki.org-id"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt), &actual.LogConfig.Mixed.Loki.OrgID)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.mixed.loki.datasource-uid", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.mixed.loki.datasource-uid", testValue)
+			if vString, err := cmdFlags.GetString("logs.mixed.loki.datasource-uid"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.Mixed.Loki.DatasourceUID)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.mixed.loki.query", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.mixed.loki.query", testValue)
+			if vString, err := cmdFlags.GetString("logs.mixed.loki.query"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.Mixed.Loki.Query)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.mixed.datadog.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.mixed.datadog.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("logs.mixed.datadog.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.Mixed.Datadog.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.mixed.datadog.site", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.mixed.datadog.site", testValue)
+			if vString, err := cmdFlags.GetString("logs.mixed.datadog.site"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.Mixed.Datadog.Site)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.mixed.datadog.query", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.mixed.datadog.query", testValue)
+			if vString, err := cmdFlags.GetString("logs.mixed.datadog.query"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.Mixed.Datadog.Query)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.mixed.splunk.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.mixed.splunk.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("logs.mixed.splunk.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.Mixed.Splunk.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.mixed.splunk.url", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.mixed.splunk.url", testValue)
+			if vString, err := cmdFlags.GetString("logs.mixed.splunk.url"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.Mixed.Splunk.URL)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.mixed.splunk.app", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.mixed.splunk.app", testValue)
+			if vString, err := cmdFlags.GetString("logs.mixed.splunk.app"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.Mixed.Splunk.App)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.mixed.splunk.query", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.mixed.splunk.query", testValue)
+			if vString, err := cmdFlags.GetString("logs.mixed.splunk.query"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.Mixed.Splunk.Query)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.mixed.kibana.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.mixed.kibana.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("logs.mixed.kibana.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.Mixed.Kibana.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.mixed.kibana.url", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.mixed.kibana.url", testValue)
+			if vString, err := cmdFlags.GetString("logs.mixed.kibana.url"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.Mixed.Kibana.URL)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.mixed.kibana.data-view", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.mixed.kibana.data-view", testValue)
+			if vString, err := cmdFlags.GetString("logs.mixed.kibana.data-view"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.Mixed.Kibana.DataView)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.mixed.kibana.query", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.mixed.kibana.query", testValue)
+			if vString, err := cmdFlags.GetString("logs.mixed.kibana.query"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.Mixed.Kibana.Query)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.mixed.exclude-container-roles.primary", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.mixed.exclude-container-roles.primary", testValue)
+			if vBool, err := cmdFlags.GetBool("logs.mixed.exclude-container-roles.primary"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.Mixed.ExcludeContainerRoles.Primary)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.mixed.exclude-container-roles.sidecar", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.mixed.exclude-container-roles.sidecar", testValue)
+			if vBool, err := cmdFlags.GetBool("logs.mixed.exclude-container-roles.sidecar"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.Mixed.ExcludeContainerRoles.Sidecar)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.mixed.exclude-container-roles.init", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.mixed.exclude-container-roles.init", testValue)
+			if vBool, err := cmdFlags.GetBool("logs.mixed.exclude-container-roles.init"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.Mixed.ExcludeContainerRoles.Init)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 	t.Run("Test_logs.user.cloudwatch-enabled", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
 			testValue := "1"
 
-			cmdFlags.Set("logs.user.cloudwatch-enabled", testValue)
-			if vBool, err := cmdFlags.GetBool("logs.user.cloudwatch-enabled"); err == nil {
-				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.User.IsCloudwatchEnabled)
+			cmdFlags.Set("logs.user.cloudwatch-enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("logs.user.cloudwatch-enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.User.IsCloudwatchEnabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.user.cloudwatch-region", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.user.cloudwatch-region", testValue)
+			if vString, err := cmdFlags.GetString("logs.user.cloudwatch-region"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.User.CloudwatchRegion)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.user.cloudwatch-log-group", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.user.cloudwatch-log-group", testValue)
+			if vString, err := cmdFlags.GetString("logs.user.cloudwatch-log-group"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.User.CloudwatchLogGroup)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.user.cloudwatch-template-uri", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.user.cloudwatch-template-uri", testValue)
+			if vString, err := cmdFlags.GetString("logs.user.cloudwatch-template-uri"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.User.CloudwatchTemplateURI)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.user.kubernetes-enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.user.kubernetes-enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("logs.user.kubernetes-enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.User.IsKubernetesEnabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.user.kubernetes-url", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.user.kubernetes-url", testValue)
+			if vString, err := cmdFlags.GetString("logs.user.kubernetes-url"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.User.KubernetesURL)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.user.kubernetes-template-uri", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.user.kubernetes-template-uri", testValue)
+			if vString, err := cmdFlags.GetString("logs.user.kubernetes-template-uri"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.User.KubernetesTemplateURI)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.user.stackdriver-enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.user.stackdriver-enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("logs.user.stackdriver-enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.User.IsStackDriverEnabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.user.gcp-project", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.user.gcp-project", testValue)
+			if vString, err := cmdFlags.GetString("logs.user.gcp-project"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.User.GCPProjectName)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.user.stackdriver-logresourcename", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.user.stackdriver-logresourcename", testValue)
+			if vString, err := cmdFlags.GetString("logs.user.stackdriver-logresourcename"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.User.StackdriverLogResourceName)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.user.stackdriver-template-uri", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.user.stackdriver-template-uri", testValue)
+			if vString, err := cmdFlags.GetString("logs.user.stackdriver-template-uri"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.User.StackDriverTemplateURI)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.user.loki.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.user.loki.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("logs.user.loki.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.User.Loki.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.user.loki.grafana-url", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.user.loki.grafana-url", testValue)
+			if vString, err := cmdFlags.GetString("logs.user.loki.grafana-url"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.User.Loki.GrafanaURL)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.user.loki.org-id", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.user.loki.org-id", testValue)
+			if vInt, err := cmdFlags.GetInt("logs.user.loki.org-id"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt), &actual.LogConfig.User.Loki.OrgID)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.user.loki.datasource-uid", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.user.loki.datasource-uid", testValue)
+			if vString, err := cmdFlags.GetString("logs.user.loki.datasource-uid"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.User.Loki.DatasourceUID)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.user.loki.query", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.user.loki.query", testValue)
+			if vString, err := cmdFlags.GetString("logs.user.loki.query"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.User.Loki.Query)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.user.datadog.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.user.datadog.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("logs.user.datadog.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.User.Datadog.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.user.datadog.site", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.user.datadog.site", testValue)
+			if vString, err := cmdFlags.GetString("logs.user.datadog.site"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.User.Datadog.Site)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.user.datadog.query", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.user.datadog.query", testValue)
+			if vString, err := cmdFlags.GetString("logs.user.datadog.query"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.User.Datadog.Query)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.user.splunk.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.user.splunk.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("logs.user.splunk.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.User.Splunk.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.user.splunk.url", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.user.splunk.url", testValue)
+			if vString, err := cmdFlags.GetString("logs.user.splunk.url"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.User.Splunk.URL)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.user.splunk.app", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.user.splunk.app", testValue)
+			if vString, err := cmdFlags.GetString("logs.user.splunk.app"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.User.Splunk.App)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.user.splunk.query", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.user.splunk.query", testValue)
+			if vString, err := cmdFlags.GetString("logs.user.splunk.query"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.User.Splunk.Query)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.user.kibana.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.user.kibana.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("logs.user.kibana.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.User.Kibana.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.user.kibana.url", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.user.kibana.url", testValue)
+			if vString, err := cmdFlags.GetString("logs.user.kibana.url"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.User.Kibana.URL)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.user.kibana.data-view", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.user.kibana.data-view", testValue)
+			if vString, err := cmdFlags.GetString("logs.user.kibana.data-view"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.User.Kibana.DataView)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.user.kibana.query", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.user.kibana.query", testValue)
+			if vString, err := cmdFlags.GetString("logs.user.kibana.query"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.User.Kibana.Query)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.user.exclude-container-roles.primary", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.user.exclude-container-roles.primary", testValue)
+			if vBool, err := cmdFlags.GetBool("logs.user.exclude-container-roles.primary"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.User.ExcludeContainerRoles.Primary)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.user.exclude-container-roles.sidecar", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.user.exclude-container-roles.sidecar", testValue)
+			if vBool, err := cmdFlags.GetBool("logs.user.exclude-container-roles.sidecar"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.User.ExcludeContainerRoles.Sidecar)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.user.exclude-container-roles.init", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.user.exclude-container-roles.init", testValue)
+			if vBool, err := cmdFlags.GetBool("logs.user.exclude-container-roles.init"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.User.ExcludeContainerRoles.Init)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.system.cloudwatch-enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.system.cloudwatch-enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("logs.system.cloudwatch-enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.System.IsCloudwatchEnabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.system.cloudwatch-region", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.system.cloudwatch-region", testValue)
+			if vString, err := cmdFlags.GetString("logs.system.cloudwatch-region"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.System.CloudwatchRegion)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.system.cloudwatch-log-group", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.system.cloudwatch-log-group", testValue)
+			if vString, err := cmdFlags.GetString("logs.system.cloudwatch-log-group"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.System.CloudwatchLogGroup)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.system.cloudwatch-template-uri", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.system.cloudwatch-template-uri", testValue)
+			if vString, err := cmdFlags.GetString("logs.system.cloudwatch-template-uri"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.System.CloudwatchTemplateURI)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.system.kubernetes-enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.system.kubernetes-enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("logs.system.kubernetes-enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.System.IsKubernetesEnabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.system.kubernetes-url", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.system.kubernetes-url", testValue)
+			if vString, err := cmdFlags.GetString("logs.system.kubernetes-url"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.System.KubernetesURL)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.system.kubernetes-template-uri", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.system.kubernetes-template-uri", testValue)
+			if vString, err := cmdFlags.GetString("logs.system.kubernetes-template-uri"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.System.KubernetesTemplateURI)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.system.stackdriver-enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.system.stackdriver-enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("logs.system.stackdriver-enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.System.IsStackDriverEnabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.system.gcp-project", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.system.gcp-project", testValue)
+			if vString, err := cmdFlags.GetString("logs.system.gcp-project"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.System.GCPProjectName)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.system.stackdriver-logresourcename", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.system.stackdriver-logresourcename", testValue)
+			if vString, err := cmdFlags.GetString("logs.system.stackdriver-logresourcename"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.System.StackdriverLogResourceName)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.system.stackdriver-template-uri", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.system.stackdriver-template-uri", testValue)
+			if vString, err := cmdFlags.GetString("logs.system.stackdriver-template-uri"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.System.StackDriverTemplateURI)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.system.loki.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.system.loki.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("logs.system.loki.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.System.Loki.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.system.loki.grafana-url", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.system.loki.grafana-url", testValue)
+			if vString, err := cmdFlags.GetString("logs.system.loki.grafana-url"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.System.Loki.GrafanaURL)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.system.loki.org-id", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.system.loki.org-id", testValue)
+			if vInt, err := cmdFlags.GetInt("logs.system.loki.org-id"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt), &actual.LogConfig.System.Loki.OrgID)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.system.loki.datasource-uid", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.system.loki.datasource-uid", testValue)
+			if vString, err := cmdFlags.GetString("logs.system.loki.datasource-uid"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.System.Loki.DatasourceUID)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.system.loki.query", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.system.loki.query", testValue)
+			if vString, err := cmdFlags.GetString("logs.system.loki.query"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.System.Loki.Query)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.system.datadog.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.system.datadog.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("logs.system.datadog.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.System.Datadog.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.system.datadog.site", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.system.datadog.site", testValue)
+			if vString, err := cmdFlags.GetString("logs.system.datadog.site"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.System.Datadog.Site)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.system.datadog.query", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.system.datadog.query", testValue)
+			if vString, err := cmdFlags.GetString("logs.system.datadog.query"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.System.Datadog.Query)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.system.splunk.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.system.splunk.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("logs.system.splunk.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.System.Splunk.Enabled)
 
 			} else {
 				assert.FailNow(t, err.Error())
 			}
 		})
 	})
-	t.Run("Test_logs.user.cloudwatch-region", func(t *testing.T) {
+	t.Run("Test_logs.system.splunk.url", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
 			testValue := "1"
 
-			cmdFlags.Set("logs.user.cloudwatch-region", testValue)
-			if vString, err := cmdFlags.GetString("logs.user.cloudwatch-region"); err == nil {
-				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.User.CloudwatchRegion)
+			cmdFlags.Set("logs.system.splunk.url", testValue)
+			if vString, err := cmdFlags.GetString("logs.system.splunk.url"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.System.Splunk.URL)
 
 			} else {
 				assert.FailNow(t, err.Error())
 			}
 		})
 	})
-	t.Run("Test_logs.user.cloudwatch-log-group", func(t *testing.T) {
+	t.Run("Test_logs.system.splunk.app", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
 			testValue := "1"
 
-			cmdFlags.Set("logs.user.cloudwatch-log-group", testValue)
-			if vString, err := cmdFlags.GetString("logs.user.cloudwatch-log-group"); err == nil {
-				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.User.CloudwatchLogGroup)
+			cmdFlags.Set("logs.system.splunk.app", testValue)
+			if vString, err := cmdFlags.GetString("logs.system.splunk.app"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.System.Splunk.App)
 
 			} else {
 				assert.FailNow(t, err.Error())
 			}
 		})
 	})
-	t.Run("Test_logs.user.cloudwatch-template-uri", func(t *testing.T) {
+	t.Run("Test_logs.system.splunk.query", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
 			testValue := "1"
 
-			cmdFlags.Set("logs.user.cloudwatch-template-uri", testValue)
-			if vString, err := cmdFlags.GetString("logs.user.cloudwatch-template-uri"); err == nil {
-				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.User.CloudwatchTemplateURI)
+			cmdFlags.Set("logs.system.splunk.query", testValue)
+			if vString, err := cmdFlags.GetString("logs.system.splunk.query"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.System.Splunk.Query)
 
 			} else {
 				assert.FailNow(t, err.Error())
 			}
 		})
 	})
-	t.Run("Test_logs.user.kubernetes-enabled", func(t *testing.T) {
+	t.Run("Test_logs.system.kibana.enabled", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
 			testValue := "1"
 
-			cmdFlags.Set("logs.user.kubernetes-enabled", testValue)
-			if vBool, err := cmdFlags.GetBool("logs.user.kubernetes-enabled"); err == nil {
-				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.User.IsKubernetesEnabled)
+			cmdFlags.Set("logs.system.kibana.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("logs.system.kibana.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.System.Kibana.Enabled)
 
 			} else {
 				assert.FailNow(t, err.Error())
 			}
 		})
 	})
-	t.Run("Test_logs.user.kubernetes-url", func(t *testing.T) {
+	t.Run("Test_logs.system.kibana.url", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
 			testValue := "1"
 
-			cmdFlags.Set("logs.user.kubernetes-url", testValue)
-			if vString, err := cmdFlags.GetString("logs.user.kubernetes-url"); err == nil {
-				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.User.KubernetesURL)
+			cmdFlags.Set("logs.system.kibana.url", testValue)
+			if vString, err := cmdFlags.GetString("logs.system.kibana.url"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.System.Kibana.URL)
 
 			} else {
 				assert.FailNow(t, err.Error())
 			}
 		})
 	})
-	t.Run("Test_logs.user.kubernetes-template-uri", func(t *testing.T) {
+	t.Run("Test_logs.system.kibana.data-view", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
 			testValue := "1"
 
-			cmdFlags.Set("logs.user.kubernetes-template-uri", testValue)
-			if vString, err := cmdFlags.GetString("logs.user.kubernetes-template-uri"); err == nil {
-				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.User.KubernetesTemplateURI)
+			cmdFlags.Set("logs.system.kibana.data-view", testValue)
+			if vString, err := cmdFlags.GetString("logs.system.kibana.data-view"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.System.Kibana.DataView)
 
 			} else {
 				assert.FailNow(t, err.Error())
 			}
 		})
 	})
-	t.Run("Test_logs.user.stackdriver-enabled", func(t *testing.T) {
+	t.Run("Test_logs.system.kibana.query", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
 			testValue := "1"
 
-			cmdFlags.Set("logs.user.stackdriver-enabled", testValue)
-			if vBool, err := cmdFlags.GetBool("logs.user.stackdriver-enabled"); err == nil {
-				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.User.IsStackDriverEnabled)
+			cmdFlags.Set("logs.system.kibana.query", testValue)
+			if vString, err := cmdFlags.GetString("logs.system.kibana.query"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.System.Kibana.Query)
 
 			} else {
 				assert.FailNow(t, err.Error())
 			}
 		})
 	})
-	t.Run("Test_logs.user.gcp-project", func(t *testing.T) {
+	t.Run("Test_logs.system.exclude-container-roles.primary", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
 			testValue := "1"
 
-			cmdFlags.Set("logs.user.gcp-project", testValue)
-			if vString, err := cmdFlags.GetString("logs.user.gcp-project"); err == nil {
-				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.User.GCPProjectName)
+			cmdFlags.Set("logs.system.exclude-container-roles.primary", testValue)
+			if vBool, err := cmdFlags.GetBool("logs.system.exclude-container-roles.primary"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.System.ExcludeContainerRoles.Primary)
 
 			} else {
 				assert.FailNow(t, err.Error())
 			}
 		})
 	})
-	t.Run("Test_logs.user.stackdriver-logresourcename", func(t *testing.T) {
+	t.Run("Test_logs.system.exclude-container-roles.sidecar", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
 			testValue := "1"
 
-			cmdFlags.Set("logs.user.stackdriver-logresourcename", testValue)
-			if vString, err := cmdFlags.GetString("logs.user.stackdriver-logresourcename"); err == nil {
-				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.User.StackdriverLogResourceName)
+			cmdFlags.Set("logs.system.exclude-container-roles.sidecar", testValue)
+			if vBool, err := cmdFlags.GetBool("logs.system.exclude-container-roles.sidecar"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.System.ExcludeContainerRoles.Sidecar)
 
 			} else {
 				assert.FailNow(t, err.Error())
 			}
 		})
 	})
-	t.Run("Test_logs.user.stackdriver-template-uri", func(t *testing.T) {
+	t.Run("Test_logs.system.exclude-container-roles.init", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
 			testValue := "1"
 
-			cmdFlags.Set("logs.user.stackdriver-template-uri", testValue)
-			if vString, err := cmdFlags.GetString("logs.user.stackdriver-template-uri"); err == nil {
-				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.User.StackDriverTemplateURI)
+			cmdFlags.Set("logs.system.exclude-container-roles.init", testValue)
+			if vBool, err := cmdFlags.GetBool("logs.system.exclude-container-roles.init"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.System.ExcludeContainerRoles.Init)
 
 			} else {
 				assert.FailNow(t, err.Error())
 			}
 		})
 	})
-	t.Run("Test_logs.system.cloudwatch-enabled", func(t *testing.T) {
+	t.Run("Test_logs.all-user.cloudwatch-enabled", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
 			testValue := "1"
 
-			cmdFlags.Set("logs.system.cloudwatch-enabled", testValue)
-			if vBool, err := cmdFlags.GetBool("logs.system.cloudwatch-enabled"); err == nil {
-				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.System.IsCloudwatchEnabled)
+			cmdFlags.Set("logs.all-user.cloudwatch-enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("logs.all-user.cloudwatch-enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.AllUser.IsCloudwatchEnabled)
 
 			} else {
 				assert.FailNow(t, err.Error())
 			}
 		})
 	})
-	t.Run("Test_logs.system.cloudwatch-region", func(t *testing.T) {
+	t.Run("Test_logs.all-user.cloudwatch-region", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
 			testValue := "1"
 
-			cmdFlags.Set("logs.system.cloudwatch-region", testValue)
-			if vString, err := cmdFlags.GetString("logs.system.cloudwatch-region"); err == nil {
-				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.System.CloudwatchRegion)
+			cmdFlags.Set("logs.all-user.cloudwatch-region", testValue)
+			if vString, err := cmdFlags.GetString("logs.all-user.cloudwatch-region"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.AllUser.CloudwatchRegion)
 
 			} else {
 				assert.FailNow(t, err.Error())
 			}
 		})
 	})
-	t.Run("Test_logs.system.cloudwatch-log-group", func(t *testing.T) {
+	t.Run("Test_logs.all-user.cloudwatch-log-group", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
 			testValue := "1"
 
-			cmdFlags.Set("logs.system.cloudwatch-log-group", testValue)
-			if vString, err := cmdFlags.GetString("logs.system.cloudwatch-log-group"); err == nil {
-				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.System.CloudwatchLogGroup)
+			cmdFlags.Set("logs.all-user.cloudwatch-log-group", testValue)
+			if vString, err := cmdFlags.GetString("logs.all-user.cloudwatch-log-group"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.AllUser.CloudwatchLogGroup)
 
 			} else {
 				assert.FailNow(t, err.Error())
 			}
 		})
 	})
-	t.Run("Test_logs.system.cloudwatch-template-uri", func(t *testing.T) {
+	t.Run("Test_logs.all-user.cloudwatch-template-uri", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
 			testValue := "1"
 
-			cmdFlags.Set("logs.system.cloudwatch-template-uri", testValue)
-			if vString, err := cmdFlags.GetString("logs.system.cloudwatch-template-uri"); err == nil {
-				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.System.CloudwatchTemplateURI)
+			cmdFlags.Set("logs.all-user.cloudwatch-template-uri", testValue)
+			if vString, err := cmdFlags.GetString("logs.all-user.cloudwatch-template-uri"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.AllUser.CloudwatchTemplateURI)
 
 			} else {
 				assert.FailNow(t, err.Error())
 			}
 		})
 	})
-	t.Run("Test_logs.system.kubernetes-enabled", func(t *testing.T) {
+	t.Run("Test_logs.all-user.kubernetes-enabled", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
 			testValue := "1"
 
-			cmdFlags.Set("logs.system.kubernetes-enabled", testValue)
-			if vBool, err := cmdFlags.GetBool("logs.system.kubernetes-enabled"); err == nil {
-				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.System.IsKubernetesEnabled)
+			cmdFlags.Set("logs.all-user.kubernetes-enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("logs.all-user.kubernetes-enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.AllUser.IsKubernetesEnabled)
 
 			} else {
 				assert.FailNow(t, err.Error())
 			}
 		})
 	})
-	t.Run("Test_logs.system.kubernetes-url", func(t *testing.T) {
+	t.Run("Test_logs.all-user.kubernetes-url", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
 			testValue := "1"
 
-			cmdFlags.Set("logs.system.kubernetes-url", testValue)
-			if vString, err := cmdFlags.GetString("logs.system.kubernetes-url"); err == nil {
-				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.System.KubernetesURL)
+			cmdFlags.Set("logs.all-user.kubernetes-url", testValue)
+			if vString, err := cmdFlags.GetString("logs.all-user.kubernetes-url"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.AllUser.KubernetesURL)
 
 			} else {
 				assert.FailNow(t, err.Error())
 			}
 		})
 	})
-	t.Run("Test_logs.system.kubernetes-template-uri", func(t *testing.T) {
+	t.Run("Test_logs.all-user.kubernetes-template-uri", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
 			testValue := "1"
 
-			cmdFlags.Set("logs.system.kubernetes-template-uri", testValue)
-			if vString, err := cmdFlags.GetString("logs.system.kubernetes-template-uri"); err == nil {
-				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.System.KubernetesTemplateURI)
+			cmdFlags.Set("logs.all-user.kubernetes-template-uri", testValue)
+			if vString, err := cmdFlags.GetString("logs.all-user.kubernetes-template-uri"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.AllUser.KubernetesTemplateURI)
 
 			} else {
 				assert.FailNow(t, err.Error())
 			}
 		})
 	})
-	t.Run("Test_logs.system.stackdriver-enabled", func(t *testing.T) {
+	t.Run("Test_logs.all-user.stackdriver-enabled", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
 			testValue := "1"
 
-			cmdFlags.Set("logs.system.stackdriver-enabled", testValue)
-			if vBool, err := cmdFlags.GetBool("logs.system.stackdriver-enabled"); err == nil {
-				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.System.IsStackDriverEnabled)
+			cmdFlags.Set("logs.all-user.stackdriver-enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("logs.all-user.stackdriver-enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.AllUser.IsStackDriverEnabled)
 
 			} else {
 				assert.FailNow(t, err.Error())
 			}
 		})
 	})
-	t.Run("Test_logs.system.gcp-project", func(t *testing.T) {
+	t.Run("Test_logs.all-user.gcp-project", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
 			testValue := "1"
 
-			cmdFlags.Set("logs.system.gcp-project", testValue)
-			if vString, err := cmdFlags.GetString("logs.system.gcp-project"); err == nil {
-				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.System.GCPProjectName)
+			cmdFlags.Set("logs.all-user.gcp-project", testValue)
+			if vString, err := cmdFlags.GetString("logs.all-user.gcp-project"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.AllUser.GCPProjectName)
 
 			} else {
 				assert.FailNow(t, err.Error())
 			}
 		})
 	})
-	t.Run("Test_logs.system.stackdriver-logresourcename", func(t *testing.T) {
+	t.Run("Test_logs.all-user.stackdriver-logresourcename", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
 			testValue := "1"
 
-			cmdFlags.Set("logs.system.stackdriver-logresourcename", testValue)
-			if vString, err := cmdFlags.GetString("logs.system.stackdriver-logresourcename"); err == nil {
-				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.System.StackdriverLogResourceName)
+			cmdFlags.Set("logs.all-user.stackdriver-logresourcename", testValue)
+			if vString, err := cmdFlags.GetString("logs.all-user.stackdriver-logresourcename"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.AllUser.StackdriverLogResourceName)
 
 			} else {
 				assert.FailNow(t, err.Error())
 			}
 		})
 	})
-	t.Run("Test_logs.system.stackdriver-template-uri", func(t *testing.T) {
+	t.Run("Test_logs.all-user.stackdriver-template-uri", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
 			testValue := "1"
 
-			cmdFlags.Set("logs.system.stackdriver-template-uri", testValue)
-			if vString, err := cmdFlags.GetString("logs.system.stackdriver-template-uri"); err == nil {
-				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.System.StackDriverTemplateURI)
+			cmdFlags.Set("logs.all-user.stackdriver-template-uri", testValue)
+			if vString, err := cmdFlags.GetString("logs.all-user.stackdriver-template-uri"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.AllUser.StackDriverTemplateURI)
 
 			} else {
 				assert.FailNow(t, err.Error())
 			}
 		})
 	})
-	t.Run("Test_logs.all-user.cloudwatch-enabled", func(t *testing.T) {
+	t.Run("Test_logs.all-user.loki.enabled", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
 			testValue := "1"
 
-			cmdFlags.Set("logs.all-user.cloudwatch-enabled", testValue)
-			if vBool, err := cmdFlags.GetBool("logs.all-user.cloudwatch-enabled"); err == nil {
-				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.AllUser.IsCloudwatchEnabled)
+			cmdFlags.Set("logs.all-user.loki.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("logs.all-user.loki.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.AllUser.Loki.Enabled)
 
 			} else {
 				assert.FailNow(t, err.Error())
 			}
 		})
 	})
-	t.Run("Test_logs.all-user.cloudwatch-region", func(t *testing.T) {
+	t.Run("Test_logs.all-user.loki.grafana-url", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
 			testValue := "1"
 
-			cmdFlags.Set("logs.all-user.cloudwatch-region", testValue)
-			if vString, err := cmdFlags.GetString("logs.all-user.cloudwatch-region"); err == nil {
-				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.AllUser.CloudwatchRegion)
+			cmdFlags.Set("logs.all-user.loki.grafana-url", testValue)
+			if vString, err := cmdFlags.GetString("logs.all-user.loki.grafana-url"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.AllUser.Loki.GrafanaURL)
 
 			} else {
 				assert.FailNow(t, err.Error())
 			}
 		})
 	})
-	t.Run("Test_logs.all-user.cloudwatch-log-group", func(t *testing.T) {
+	t.Run("Test_logs.all-user.loki.org-id", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
 			testValue := "1"
 
-			cmdFlags.Set("logs.all-user.cloudwatch-log-group", testValue)
-			if vString, err := cmdFlags.GetString("logs.all-user.cloudwatch-log-group"); err == nil {
-				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.AllUser.CloudwatchLogGroup)
+			cmdFlags.Set("logs.all-user.loki.org-id", testValue)
+			if vInt, err := cmdFlags.GetInt("logs.all-user.loki.org-id"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt), &actual.LogConfig.AllUser.Loki.OrgID)
 
 			} else {
 				assert.FailNow(t, err.Error())
 			}
 		})
 	})
-	t.Run("Test_logs.all-user.cloudwatch-template-uri", func(t *testing.T) {
+	t.Run("Test_logs.all-user.loki.datasource-uid", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
 			testValue := "1"
 
-			cmdFlags.Set("logs.all-user.cloudwatch-template-uri", testValue)
-			if vString, err := cmdFlags.GetString("logs.all-user.cloudwatch-template-uri"); err == nil {
-				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.AllUser.CloudwatchTemplateURI)
+			cmdFlags.Set("logs.all-user.loki.datasource-uid", testValue)
+			if vString, err := cmdFlags.GetString("logs.all-user.loki.datasource-uid"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.AllUser.Loki.DatasourceUID)
 
 			} else {
 				assert.FailNow(t, err.Error())
 			}
 		})
 	})
-	t.Run("Test_logs.all-user.kubernetes-enabled", func(t *testing.T) {
+	t.Run("Test_logs.all-user.loki.query", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
 			testValue := "1"
 
-			cmdFlags.Set("logs.all-user.kubernetes-enabled", testValue)
-			if vBool, err := cmdFlags.GetBool("logs.all-user.kubernetes-enabled"); err == nil {
-				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.AllUser.IsKubernetesEnabled)
+			cmdFlags.Set("logs.all-user.loki.query", testValue)
+			if vString, err := cmdFlags.GetString("logs.all-user.loki.query"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.AllUser.Loki.Query)
 
 			} else {
 				assert.FailNow(t, err.Error())
 			}
 		})
 	})
-	t.Run("Test_logs.all-user.kubernetes-url", func(t *testing.T) {
+	t.Run("Test_logs.all-user.datadog.enabled", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
 			testValue := "1"
 
-			cmdFlags.Set("logs.all-user.kubernetes-url", testValue)
-			if vString, err := cmdFlags.GetString("logs.all-user.kubernetes-url"); err == nil {
-				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.AllUser.KubernetesURL)
+			cmdFlags.Set("logs.all-user.datadog.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("logs.all-user.datadog.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.AllUser.Datadog.Enabled)
 
 			} else {
 				assert.FailNow(t, err.Error())
 			}
 		})
 	})
-	t.Run("Test_logs.all-user.kubernetes-template-uri", func(t *testing.T) {
+	t.Run("Test_logs.all-user.datadog.site", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
 			testValue := "1"
 
-			cmdFlags.Set("logs.all-user.kubernetes-template-uri", testValue)
-			if vString, err := cmdFlags.GetString("logs.all-user.kubernetes-template-uri"); err == nil {
-				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.AllUser.KubernetesTemplateURI)
+			cmdFlags.Set("logs.all-user.datadog.site", testValue)
+			if vString, err := cmdFlags.GetString("logs.all-user.datadog.site"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.AllUser.Datadog.Site)
 
 			} else {
 				assert.FailNow(t, err.Error())
 			}
 		})
 	})
-	t.Run("Test_logs.all-user.stackdriver-enabled", func(t *testing.T) {
+	t.Run("Test_logs.all-user.datadog.query", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
 			testValue := "1"
 
-			cmdFlags.Set("logs.all-user.stackdriver-enabled", testValue)
-			if vBool, err := cmdFlags.GetBool("logs.all-user.stackdriver-enabled"); err == nil {
-				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.AllUser.IsStackDriverEnabled)
+			cmdFlags.Set("logs.all-user.datadog.query", testValue)
+			if vString, err := cmdFlags.GetString("logs.all-user.datadog.query"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.AllUser.Datadog.Query)
 
 			} else {
 				assert.FailNow(t, err.Error())
 			}
 		})
 	})
-	t.Run("Test_logs.all-user.gcp-project", func(t *testing.T) {
+	t.Run("Test_logs.all-user.splunk.enabled", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
 			testValue := "1"
 
-			cmdFlags.Set("logs.all-user.gcp-project", testValue)
-			if vString, err := cmdFlags.GetString("logs.all-user.gcp-project"); err == nil {
-				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.AllUser.GCPProjectName)
+			cmdFlags.Set("logs.all-user.splunk.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("logs.all-user.splunk.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.AllUser.Splunk.Enabled)
 
 			} else {
 				assert.FailNow(t, err.Error())
 			}
 		})
 	})
-	t.Run("Test_logs.all-user.stackdriver-logresourcename", func(t *testing.T) {
+	t.Run("Test_logs.all-user.splunk.url", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
 			testValue := "1"
 
-			cmdFlags.Set("logs.all-user.stackdriver-logresourcename", testValue)
-			if vString, err := cmdFlags.GetString("logs.all-user.stackdriver-logresourcename"); err == nil {
-				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.AllUser.StackdriverLogResourceName)
+			cmdFlags.Set("logs.all-user.splunk.url", testValue)
+			if vString, err := cmdFlags.GetString("logs.all-user.splunk.url"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.AllUser.Splunk.URL)
 
 			} else {
 				assert.FailNow(t, err.Error())
 			}
 		})
 	})
-	t.Run("Test_logs.all-user.stackdriver-template-uri", func(t *testing.T) {
+	t.Run("Test_logs.all-user.splunk.app", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
 			testValue := "1"
 
-			cmdFlags.Set("logs.all-user.stackdriver-template-uri", testValue)
-			if vString, err := cmdFlags.GetString("logs.all-user.stackdriver-template-uri"); err == nil {
-				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.AllUser.StackDriverTemplateURI)
+			cmdFlags.Set("logs.all-user.splunk.app", testValue)
+			if vString, err := cmdFlags.GetString("logs.all-user.splunk.app"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.AllUser.Splunk.App)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.all-user.splunk.query", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.all-user.splunk.query", testValue)
+			if vString, err := cmdFlags.GetString("logs.all-user.splunk.query"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.AllUser.Splunk.Query)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.all-user.kibana.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.all-user.kibana.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("logs.all-user.kibana.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.AllUser.Kibana.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.all-user.kibana.url", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.all-user.kibana.url", testValue)
+			if vString, err := cmdFlags.GetString("logs.all-user.kibana.url"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.AllUser.Kibana.URL)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.all-user.kibana.data-view", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.all-user.kibana.data-view", testValue)
+			if vString, err := cmdFlags.GetString("logs.all-user.kibana.data-view"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.AllUser.Kibana.DataView)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.all-user.kibana.query", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.all-user.kibana.query", testValue)
+			if vString, err := cmdFlags.GetString("logs.all-user.kibana.query"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogConfig.AllUser.Kibana.Query)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.all-user.exclude-container-roles.primary", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.all-user.exclude-container-roles.primary", testValue)
+			if vBool, err := cmdFlags.GetBool("logs.all-user.exclude-container-roles.primary"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.AllUser.ExcludeContainerRoles.Primary)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.all-user.exclude-container-roles.sidecar", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.all-user.exclude-container-roles.sidecar", testValue)
+			if vBool, err := cmdFlags.GetBool("logs.all-user.exclude-container-roles.sidecar"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.AllUser.ExcludeContainerRoles.Sidecar)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_logs.all-user.exclude-container-roles.init", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("logs.all-user.exclude-container-roles.init", testValue)
+			if vBool, err := cmdFlags.GetBool("logs.all-user.exclude-container-roles.init"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogConfig.AllUser.ExcludeContainerRoles.Init)
 
 			} else {
 				assert.FailNow(t, err.Error())
@@ -20,6 +20,7 @@ import (
 	"github.com/flyteorg/flyteplugins/go/tasks/logs"
 	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/dryrun"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -38,6 +39,9 @@ const KindSparkApplication = "SparkApplication"
 const sparkDriverUI = "sparkDriverUI"
 const sparkHistoryUI = "sparkHistoryUI"
 
+// sparkDriverContainerName is the name the spark operator gives to the container of the driver pod.
+const sparkDriverContainerName = "spark-kubernetes-driver"
+
 var featureRegex = regexp.MustCompile(`^spark.((flyteorg)|(flyte)).(.+).enabled$`)
 
 var sparkTaskType = "spark"
@@ -57,6 +61,33 @@ func validateSparkJob(sparkJob *plugins.SparkJob) error {
 	return nil
 }
 
+// Spark configs the operator expects to be integers.
+var integerSparkConfigs = []string{"spark.driver.cores", "spark.executor.cores", "spark.executor.instances"}
+
+// Validate builds the spark application of the task template and checks the spark configs the operator expects to be
+// integers can be parsed.
+func (s sparkResourceHandler) Validate(ctx context.Context, taskTemplate *core.TaskTemplate) error {
+	if taskTemplate.GetContainer() == nil {
+		return errors.Errorf(errors.BadTaskSpecification, "spark tasks require a container")
+	}
+
+	resource, err := dryrun.BuildResource(ctx, s, taskTemplate)
+	if err != nil {
+		return err
+	}
+
+	sparkConfig := resource.(*sparkOp.SparkApplication).Spec.SparkConf
+	for _, key := range integerSparkConfigs {
+		if value, ok := sparkConfig[key]; ok && len(value) > 0 {
+			if _, err := strconv.ParseInt(value, 10, 32); err != nil {
+				return errors.Errorf(errors.BadTaskSpecification, "invalid spark config [%v], [%v] is not an integer", key, value)
+			}
+		}
+	}
+
+	return nil
+}
+
 func (sparkResourceHandler) GetProperties() k8s.PluginProperties {
 	return k8s.PluginProperties{}
 }
@@ -299,7 +330,22 @@ func (sparkResourceHandler) BuildIdentityResource(ctx context.Context, taskCtx p
 	}, nil
 }
 
-func getEventInfoForSpark(sj *sparkOp.SparkApplication) (*pluginsCore.TaskInfo, error) {
+// Caches of the log configs resolved for spark tasks, one per kind of logs.
+var mixedLogConfigs, userLogConfigs, systemLogConfigs, allUserLogConfigs logs.ConfigResolver
+
+// initializeLogPlugins initializes the log plugins of cfg resolved for the project and domain of the task execution.
+func initializeLogPlugins(resolver *logs.ConfigResolver, cfg logs.LogConfig, pluginContext k8s.PluginContext) (
+	tasklog.Plugin, error) {
+	resolved, err := resolver.ResolveForTask(cfg, sparkTaskType, pluginContext.TaskExecutionMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	return logs.InitializeLogPlugins(resolved)
+}
+
+func getEventInfoForSpark(ctx context.Context, pluginContext k8s.PluginContext, sj *sparkOp.SparkApplication) (
+	*pluginsCore.TaskInfo, error) {
 	state := sj.Status.AppState.State
 	isQueued := state == sparkOp.NewState ||
 		state == sparkOp.PendingSubmissionState ||
@@ -307,58 +353,51 @@ func getEventInfoForSpark(sj *sparkOp.SparkApplication) (*pluginsCore.TaskInfo,
 
 	sparkConfig := GetSparkConfig()
 	taskLogs := make([]*core.TaskLog, 0, 3)
+	taskExecutionID := pluginContext.TaskExecutionMetadata().GetTaskExecutionID()
+	taskExecID := taskExecutionID.GetID()
+	// The driver pod is read to build the log links of its sidecar and init containers too.
+	k8sReader := k8s.GetK8sReader(pluginContext)
 
 	if !isQueued {
 		if sj.Status.DriverInfo.PodName != "" {
-			p, err := logs.InitializeLogPlugins(&sparkConfig.LogConfig.Mixed)
+			p, err := initializeLogPlugins(&mixedLogConfigs, sparkConfig.LogConfig.Mixed, pluginContext)
 			if err != nil {
 				return nil, err
 			}
 
-			if p != nil {
-				o, err := p.GetTaskLogs(tasklog.Input{
-					PodName:   sj.Status.DriverInfo.PodName,
-					Namespace: sj.Namespace,
-					LogName:   "(Driver Logs)",
-				})
-
-				if err != nil {
-					return nil, err
-				}
-
-				taskLogs = append(taskLogs, o.TaskLogs...)
+			driverLogs, err := logs.GetLogsForPodByName(ctx, p, taskExecutionID, k8sReader, sj.Namespace,
+				sj.Status.DriverInfo.PodName, sparkDriverContainerName, "(Driver Logs)")
+			if err != nil {
+				return nil, err
 			}
+
+			taskLogs = append(taskLogs, driverLogs...)
 		}
 
-		p, err := logs.InitializeLogPlugins(&sparkConfig.LogConfig.User)
+		p, err := initializeLogPlugins(&userLogConfigs, sparkConfig.LogConfig.User, pluginContext)
 		if err != nil {
 			return nil, err
 		}
 
-		if p != nil {
-			o, err := p.GetTaskLogs(tasklog.Input{
-				PodName:   sj.Status.DriverInfo.PodName,
-				Namespace: sj.Namespace,
-				LogName:   "(User Logs)",
-			})
-
-			if err != nil {
-				return nil, err
-			}
-
-			taskLogs = append(taskLogs, o.TaskLogs...)
+		userLogs, err := logs.GetLogsForPodByName(ctx, p, taskExecutionID, k8sReader, sj.Namespace,
+			sj.Status.DriverInfo.PodName, sparkDriverContainerName, "(User Logs)")
+		if err != nil {
+			return nil, err
 		}
 
-		p, err = logs.InitializeLogPlugins(&sparkConfig.LogConfig.System)
+		taskLogs = append(taskLogs, userLogs...)
+
+		p, err = initializeLogPlugins(&systemLogConfigs, sparkConfig.LogConfig.System, pluginContext)
 		if err != nil {
 			return nil, err
 		}
 
 		if p != nil {
 			o, err := p.GetTaskLogs(tasklog.Input{
-				PodName:   sj.Name,
-				Namespace: sj.Namespace,
-				LogName:   "(System Logs)",
+				PodName:                 sj.Name,
+				Namespace:               sj.Namespace,
+				LogName:                 "(System Logs)",
+				TaskExecutionIdentifier: &taskExecID,
 			})
 
 			if err != nil {
@@ -369,16 +408,17 @@ func getEventInfoForSpark(sj *sparkOp.SparkApplication) (*pluginsCore.TaskInfo,
 		}
 	}
 
-	p, err := logs.InitializeLogPlugins(&sparkConfig.LogConfig.AllUser)
+	p, err := initializeLogPlugins(&allUserLogConfigs, sparkConfig.LogConfig.AllUser, pluginContext)
 	if err != nil {
 		return nil, err
 	}
 
 	if p != nil {
 		o, err := p.GetTaskLogs(tasklog.Input{
-			PodName:   sj.Name,
-			Namespace: sj.Namespace,
-			LogName:   "(Spark-Submit/All User Logs)",
+			PodName:                 sj.Name,
+			Namespace:               sj.Namespace,
+			LogName:                 "(Spark-Submit/All User Logs)",
+			TaskExecutionIdentifier: &taskExecID,
 		})
 
 		if err != nil {
@@ -426,7 +466,7 @@ func getEventInfoForSpark(sj *sparkOp.SparkApplication) (*pluginsCore.TaskInfo,
 func (sparkResourceHandler) GetTaskPhase(ctx context.Context, pluginContext k8s.PluginContext, resource client.Object) (pluginsCore.PhaseInfo, error) {
 
 	app := resource.(*sparkOp.SparkApplication)
-	info, err := getEventInfoForSpark(app)
+	info, err := getEventInfoForSpark(ctx, pluginContext, app)
 	if err != nil {
 		return pluginsCore.PhaseInfoUndefined, err
 	}
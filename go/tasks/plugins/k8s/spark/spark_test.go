@@ -7,8 +7,11 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/plugintest"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/plugintest/cluster"
 
 	"github.com/stretchr/testify/mock"
 
@@ -18,19 +21,29 @@ import (
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	k8smocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s/mocks"
 
 	pluginIOMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io/mocks"
 
 	sj "github.com/GoogleCloudPlatform/spark-on-k8s-operator/pkg/apis/sparkoperator.k8s.io/v1beta2"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/plugins"
+	"github.com/flyteorg/flytestdlib/contextutils"
+	"github.com/flyteorg/flytestdlib/promutils/labeled"
 	"github.com/golang/protobuf/jsonpb"
 	structpb "github.com/golang/protobuf/ptypes/struct"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
+func init() {
+	labeled.SetMetricKeys(contextutils.NamespaceKey)
+}
+
 const sparkMainClass = "MainClass"
 const sparkApplicationFile = "local:///spark_app.py"
 const testImage = "image://"
@@ -88,7 +101,7 @@ func TestGetEventInfo(t *testing.T) {
 			},
 		},
 	}))
-	info, err := getEventInfoForSpark(dummySparkApplication(sj.RunningState))
+	info, err := getEventInfoForSpark(context.TODO(), dummyPluginContext(), dummySparkApplication(sj.RunningState))
 	assert.NoError(t, err)
 	assert.Len(t, info.Logs, 6)
 	assert.Equal(t, fmt.Sprintf("https://%s", sparkUIAddress), info.CustomInfo.Fields[sparkDriverUI].GetStringValue())
@@ -108,7 +121,7 @@ func TestGetEventInfo(t *testing.T) {
 
 	assert.Equal(t, expectedLinks, generatedLinks)
 
-	info, err = getEventInfoForSpark(dummySparkApplication(sj.SubmittedState))
+	info, err = getEventInfoForSpark(context.TODO(), dummyPluginContext(), dummySparkApplication(sj.SubmittedState))
 	assert.NoError(t, err)
 	assert.Len(t, info.Logs, 1)
 	assert.Equal(t, "https://console.aws.amazon.com/cloudwatch/home?region=us-east-1#logStream:group=/kubernetes/flyte;prefix=var.log.containers.spark-app-name;streamFilter=typeLogStreamPrefix", info.Logs[0].Uri)
@@ -133,7 +146,7 @@ func TestGetEventInfo(t *testing.T) {
 		},
 	}))
 
-	info, err = getEventInfoForSpark(dummySparkApplication(sj.FailedState))
+	info, err = getEventInfoForSpark(context.TODO(), dummyPluginContext(), dummySparkApplication(sj.FailedState))
 	assert.NoError(t, err)
 	assert.Len(t, info.Logs, 5)
 	assert.Equal(t, "spark-history.flyte/history/app-id", info.CustomInfo.Fields[sparkHistoryUI].GetStringValue())
@@ -157,67 +170,113 @@ func TestGetTaskPhase(t *testing.T) {
 	sparkResourceHandler := sparkResourceHandler{}
 
 	ctx := context.TODO()
-	taskPhase, err := sparkResourceHandler.GetTaskPhase(ctx, nil, dummySparkApplication(sj.NewState))
+	taskPhase, err := sparkResourceHandler.GetTaskPhase(ctx, dummyPluginContext(), dummySparkApplication(sj.NewState))
 	assert.NoError(t, err)
 	assert.Equal(t, taskPhase.Phase(), pluginsCore.PhaseQueued)
 	assert.NotNil(t, taskPhase.Info())
 	assert.Nil(t, err)
 
-	taskPhase, err = sparkResourceHandler.GetTaskPhase(ctx, nil, dummySparkApplication(sj.SubmittedState))
+	taskPhase, err = sparkResourceHandler.GetTaskPhase(ctx, dummyPluginContext(), dummySparkApplication(sj.SubmittedState))
 	assert.NoError(t, err)
 	assert.Equal(t, taskPhase.Phase(), pluginsCore.PhaseInitializing)
 	assert.NotNil(t, taskPhase.Info())
 	assert.Nil(t, err)
 
-	taskPhase, err = sparkResourceHandler.GetTaskPhase(ctx, nil, dummySparkApplication(sj.RunningState))
+	taskPhase, err = sparkResourceHandler.GetTaskPhase(ctx, dummyPluginContext(), dummySparkApplication(sj.RunningState))
 	assert.NoError(t, err)
 	assert.Equal(t, taskPhase.Phase(), pluginsCore.PhaseRunning)
 	assert.NotNil(t, taskPhase.Info())
 	assert.Nil(t, err)
 
-	taskPhase, err = sparkResourceHandler.GetTaskPhase(ctx, nil, dummySparkApplication(sj.CompletedState))
+	taskPhase, err = sparkResourceHandler.GetTaskPhase(ctx, dummyPluginContext(), dummySparkApplication(sj.CompletedState))
 	assert.NoError(t, err)
 	assert.Equal(t, taskPhase.Phase(), pluginsCore.PhaseSuccess)
 	assert.NotNil(t, taskPhase.Info())
 	assert.Nil(t, err)
 
-	taskPhase, err = sparkResourceHandler.GetTaskPhase(ctx, nil, dummySparkApplication(sj.InvalidatingState))
+	taskPhase, err = sparkResourceHandler.GetTaskPhase(ctx, dummyPluginContext(), dummySparkApplication(sj.InvalidatingState))
 	assert.NoError(t, err)
 	assert.Equal(t, taskPhase.Phase(), pluginsCore.PhaseRunning)
 	assert.NotNil(t, taskPhase.Info())
 	assert.Nil(t, err)
 
-	taskPhase, err = sparkResourceHandler.GetTaskPhase(ctx, nil, dummySparkApplication(sj.FailingState))
+	taskPhase, err = sparkResourceHandler.GetTaskPhase(ctx, dummyPluginContext(), dummySparkApplication(sj.FailingState))
 	assert.NoError(t, err)
 	assert.Equal(t, taskPhase.Phase(), pluginsCore.PhaseRunning)
 	assert.NotNil(t, taskPhase.Info())
 	assert.Nil(t, err)
 
-	taskPhase, err = sparkResourceHandler.GetTaskPhase(ctx, nil, dummySparkApplication(sj.PendingRerunState))
+	taskPhase, err = sparkResourceHandler.GetTaskPhase(ctx, dummyPluginContext(), dummySparkApplication(sj.PendingRerunState))
 	assert.NoError(t, err)
 	assert.Equal(t, taskPhase.Phase(), pluginsCore.PhaseRunning)
 	assert.NotNil(t, taskPhase.Info())
 	assert.Nil(t, err)
 
-	taskPhase, err = sparkResourceHandler.GetTaskPhase(ctx, nil, dummySparkApplication(sj.SucceedingState))
+	taskPhase, err = sparkResourceHandler.GetTaskPhase(ctx, dummyPluginContext(), dummySparkApplication(sj.SucceedingState))
 	assert.NoError(t, err)
 	assert.Equal(t, taskPhase.Phase(), pluginsCore.PhaseRunning)
 	assert.NotNil(t, taskPhase.Info())
 	assert.Nil(t, err)
 
-	taskPhase, err = sparkResourceHandler.GetTaskPhase(ctx, nil, dummySparkApplication(sj.FailedSubmissionState))
+	taskPhase, err = sparkResourceHandler.GetTaskPhase(ctx, dummyPluginContext(), dummySparkApplication(sj.FailedSubmissionState))
 	assert.NoError(t, err)
 	assert.Equal(t, taskPhase.Phase(), pluginsCore.PhaseRetryableFailure)
 	assert.NotNil(t, taskPhase.Info())
 	assert.Nil(t, err)
 
-	taskPhase, err = sparkResourceHandler.GetTaskPhase(ctx, nil, dummySparkApplication(sj.FailedState))
+	taskPhase, err = sparkResourceHandler.GetTaskPhase(ctx, dummyPluginContext(), dummySparkApplication(sj.FailedState))
 	assert.NoError(t, err)
 	assert.Equal(t, taskPhase.Phase(), pluginsCore.PhaseRetryableFailure)
 	assert.NotNil(t, taskPhase.Info())
 	assert.Nil(t, err)
 }
 
+// k8sReaderPluginContext is a plugin context that can read k8s objects.
+type k8sReaderPluginContext struct {
+	*k8smocks.PluginContext
+	reader client.Reader
+}
+
+func (p k8sReaderPluginContext) K8sReader() client.Reader {
+	return p.reader
+}
+
+func TestGetEventInfo_DriverContainers(t *testing.T) {
+	assert.NoError(t, setSparkConfig(&Config{
+		LogConfig: LogConfig{
+			User: logs.LogConfig{
+				Templates: []logs.TemplateLogPluginConfig{
+					{DisplayName: "User", TemplateURIs: []string{"https://logs/{{ .podName }}/{{ .containerName }}"}},
+				},
+			},
+		},
+	}))
+
+	driver := &corev1.Pod{
+		ObjectMeta: v1.ObjectMeta{Namespace: "spark-namespace", Name: "spark-pod"},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "setup"}},
+			Containers:     []corev1.Container{{Name: sparkDriverContainerName}},
+		},
+		Status: corev1.PodStatus{
+			InitContainerStatuses: []corev1.ContainerStatus{{Name: "setup"}},
+			ContainerStatuses:     []corev1.ContainerStatus{{Name: sparkDriverContainerName}},
+		},
+	}
+
+	pluginContext := k8sReaderPluginContext{
+		PluginContext: dummyPluginContext(),
+		reader:        fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(driver).Build(),
+	}
+
+	info, err := getEventInfoForSpark(context.TODO(), pluginContext, dummySparkApplication(sj.FailedState))
+	assert.NoError(t, err)
+	assert.Equal(t, []*core.TaskLog{
+		{Uri: "https://logs/spark-pod/" + sparkDriverContainerName, Name: "User(User Logs)"},
+		{Uri: "https://logs/spark-pod/setup", Name: "User(User Logs) [init: setup]"},
+	}, info.Logs)
+}
+
 func dummySparkApplication(state sj.ApplicationStateType) *sj.SparkApplication {
 
 	return &sj.SparkApplication{
@@ -663,3 +722,78 @@ func TestGetPropertiesSpark(t *testing.T) {
 	expected := k8s.PluginProperties{}
 	assert.Equal(t, expected, sparkResourceHandler.GetProperties())
 }
+
+func dummyPluginContext() *k8smocks.PluginContext {
+	tID := &mocks.TaskExecutionID{}
+	tID.OnGetID().Return(core.TaskExecutionIdentifier{
+		NodeExecutionId: &core.NodeExecutionIdentifier{
+			ExecutionId: &core.WorkflowExecutionIdentifier{
+				Name:    "my_name",
+				Project: "my_project",
+				Domain:  "my_domain",
+			},
+		},
+	})
+
+	taskExecutionMetadata := &mocks.TaskExecutionMetadata{}
+	taskExecutionMetadata.OnGetTaskExecutionID().Return(tID)
+
+	pCtx := &k8smocks.PluginContext{}
+	pCtx.OnTaskExecutionMetadata().Return(taskExecutionMetadata)
+	return pCtx
+}
+
+func TestLifecycleSpark(t *testing.T) {
+	ctx := context.TODO()
+	newTaskContext := func(t *testing.T) *plugintest.TaskContext {
+		return plugintest.NewTaskContextBuilder(t).
+			WithTaskTemplate(dummySparkTaskTemplate("blah-1", dummySparkConf)).
+			WithScheme(cluster.Scheme()).
+			Build()
+	}
+
+	t.Run("success", func(t *testing.T) {
+		res := cluster.Simulate(newTaskContext(t), cluster.StagePending, cluster.StageRunning, cluster.StageSucceeded).
+			Runner(t, sparkResourceHandler{}).
+			Run(ctx)
+
+		res.AssertSuccess()
+		res.AssertPhases(pluginsCore.PhaseInitializing, pluginsCore.PhaseRunning, pluginsCore.PhaseSuccess)
+		assert.Equal(t, sparkApplicationFile, *res.Resource.(*sj.SparkApplication).Spec.MainApplicationFile)
+	})
+
+	t.Run("evicted", func(t *testing.T) {
+		res := cluster.Simulate(newTaskContext(t), cluster.StagePending, cluster.StageEvicted).
+			Runner(t, sparkResourceHandler{}).
+			Run(ctx)
+
+		res.AssertPhases(pluginsCore.PhaseInitializing, pluginsCore.PhaseRetryableFailure)
+		res.AssertFailure(errors.DownstreamSystemError)
+	})
+}
+
+func TestValidateSpark(t *testing.T) {
+	ctx := context.TODO()
+	sparkResourceHandler := sparkResourceHandler{}
+	t.Run("valid", func(t *testing.T) {
+		taskTemplate := dummySparkTaskTemplate("blah-1", map[string]string{"spark.executor.instances": "2"})
+		assert.NoError(t, sparkResourceHandler.Validate(ctx, taskTemplate))
+	})
+
+	t.Run("invalid spark config", func(t *testing.T) {
+		taskTemplate := dummySparkTaskTemplate("blah-1", map[string]string{"spark.executor.instances": "two"})
+		assert.Error(t, sparkResourceHandler.Validate(ctx, taskTemplate))
+	})
+
+	t.Run("no main application file nor main class", func(t *testing.T) {
+		taskTemplate := dummySparkTaskTemplate("blah-1", nil)
+		taskTemplate.Custom = &structpb.Struct{}
+		assert.Error(t, sparkResourceHandler.Validate(ctx, taskTemplate))
+	})
+
+	t.Run("no container", func(t *testing.T) {
+		taskTemplate := dummySparkTaskTemplate("blah-1", nil)
+		taskTemplate.Target = nil
+		assert.Error(t, sparkResourceHandler.Validate(ctx, taskTemplate))
+	})
+}
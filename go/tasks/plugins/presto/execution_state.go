@@ -26,8 +26,6 @@ import (
 	"github.com/flyteorg/flyteplugins/go/tasks/errors"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 	"github.com/flyteorg/flytestdlib/logger"
-
-	pb "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 )
 
 type ExecutionPhase int
@@ -204,6 +202,31 @@ func composeResourceNamespaceWithRoutingGroup(ctx context.Context, tCtx core.Tas
 	return core.ResourceNamespace(clusterPrimaryLabel), nil
 }
 
+// ValidateTaskTemplate checks the task template declares a presto query that only references the inputs declared by
+// the task.
+func ValidateTaskTemplate(taskTemplate *idlCore.TaskTemplate) error {
+	prestoQuery := plugins.PrestoQuery{}
+	if err := utils.UnmarshalStruct(taskTemplate.GetCustom(), &prestoQuery); err != nil {
+		return errors.Wrapf(errors.BadTaskSpecification, err, "invalid TaskSpecification [%v], failed to unmarshal", taskTemplate.GetCustom())
+	}
+
+	if err := validatePrestoStatement(prestoQuery); err != nil {
+		return err
+	}
+
+	err := template.ValidateInputs([]string{
+		prestoQuery.RoutingGroup,
+		prestoQuery.Catalog,
+		prestoQuery.Schema,
+		prestoQuery.Statement,
+	}, taskTemplate.GetInterface().GetInputs())
+	if err != nil {
+		return errors.Wrapf(errors.BadTaskSpecification, err, "query references undeclared inputs")
+	}
+
+	return nil
+}
+
 // This function is the link between the output written by the SDK, and the execution side. It extracts the query
 // out of the task template.
 func GetQueryInfo(ctx context.Context, tCtx core.TaskExecutionContext) (string, string, string, string, error) {
@@ -447,22 +470,14 @@ func writeOutput(ctx context.Context, tCtx core.TaskExecutionContext, externalLo
 
 	results := taskTemplate.Interface.Outputs.Variables["results"]
 
-	return tCtx.OutputWriter().Put(ctx, ioutils.NewInMemoryOutputReader(
-		&pb.LiteralMap{
-			Literals: map[string]*pb.Literal{
-				"results": {
-					Value: &pb.Literal_Scalar{
-						Scalar: &pb.Scalar{Value: &pb.Scalar_Schema{
-							Schema: &pb.Schema{
-								Uri:  externalLocation,
-								Type: results.GetType().GetSchema(),
-							},
-						},
-						},
-					},
-				},
-			},
-		}, nil, nil))
+	// The results are written by the CREATE TABLE statement wrapping the query, with format = 'PARQUET'.
+	outputReader, err := ioutils.NewQueryResultOutputReader("results", results.GetType(), externalLocation,
+		ioutils.StructuredDatasetFormatParquet)
+	if err != nil {
+		return errors.Wrapf(errors.BadTaskSpecification, err, "Failed to build the results output")
+	}
+
+	return tCtx.OutputWriter().Put(ctx, outputReader)
 }
 
 // The 'PhaseInfoRunning' occurs 15 times (3 for each of the 5 Presto queries that get run for every Presto task) which
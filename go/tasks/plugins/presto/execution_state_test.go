@@ -6,7 +6,10 @@ import (
 	"testing"
 	"time"
 
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io"
+	ioMock "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io/mocks"
 	"github.com/flyteorg/flyteplugins/go/tasks/plugins/presto/client"
 	prestoMocks "github.com/flyteorg/flyteplugins/go/tasks/plugins/presto/client/mocks"
 	"github.com/flyteorg/flyteplugins/go/tasks/plugins/presto/config"
@@ -22,6 +25,7 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
 )
 
 func init() {
@@ -357,3 +361,65 @@ func Test_mapLabelToPrimaryLabel(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateTaskTemplate(t *testing.T) {
+	taskTemplate := GetPrestoQueryTaskTemplate()
+	assert.NoError(t, ValidateTaskTemplate(&taskTemplate))
+
+	prestoQuery := plugins.PrestoQuery{Statement: "select * from {{ .Inputs.table }}"}
+	assert.NoError(t, utils.MarshalStruct(&prestoQuery, taskTemplate.Custom))
+	assert.Error(t, ValidateTaskTemplate(&taskTemplate))
+
+	prestoQuery = plugins.PrestoQuery{}
+	assert.NoError(t, utils.MarshalStruct(&prestoQuery, taskTemplate.Custom))
+	assert.Error(t, ValidateTaskTemplate(&taskTemplate))
+}
+
+func TestWriteOutput(t *testing.T) {
+	ctx := context.Background()
+	results := map[string]*idlCore.LiteralType{
+		"schema": {Type: &idlCore.LiteralType_Schema{Schema: &idlCore.SchemaType{}}},
+		"structured dataset": {Type: &idlCore.LiteralType_StructuredDatasetType{
+			StructuredDatasetType: &idlCore.StructuredDatasetType{},
+		}},
+		"declared format": {Type: &idlCore.LiteralType_StructuredDatasetType{
+			StructuredDatasetType: &idlCore.StructuredDatasetType{Format: "csv"},
+		}},
+	}
+
+	for name, resultsType := range results {
+		t.Run(name, func(t *testing.T) {
+			tt := GetPrestoQueryTaskTemplate()
+			tt.Interface = &idlCore.TypedInterface{Outputs: &idlCore.VariableMap{
+				Variables: map[string]*idlCore.Variable{"results": {Type: resultsType}},
+			}}
+
+			taskReader := &mocks.TaskReader{}
+			taskReader.OnReadMatch(mock.Anything).Return(&tt, nil)
+			var literals *idlCore.LiteralMap
+			outputWriter := &ioMock.OutputWriter{}
+			outputWriter.OnPutMatch(mock.Anything, mock.Anything).Return(nil).Run(func(arguments mock.Arguments) {
+				var err error
+				literals, _, err = arguments.Get(1).(io.OutputReader).Read(ctx)
+				assert.NoError(t, err)
+			})
+
+			tCtx := &mocks.TaskExecutionContext{}
+			tCtx.OnTaskReader().Return(taskReader)
+			tCtx.OnOutputWriter().Return(outputWriter)
+
+			assert.NoError(t, writeOutput(ctx, tCtx, "s3://bucket/results"))
+			scalar := literals.GetLiterals()["results"].GetScalar()
+			switch name {
+			case "schema":
+				assert.Equal(t, "s3://bucket/results", scalar.GetSchema().GetUri())
+			case "structured dataset":
+				assert.Equal(t, "s3://bucket/results", scalar.GetStructuredDataset().GetUri())
+				// The results are written by a CREATE TABLE statement with format = 'PARQUET'.
+				assert.Equal(t, "parquet", scalar.GetStructuredDataset().GetMetadata().GetStructuredDatasetType().GetFormat())
+			case "declared format":
+				assert.Equal(t, "csv", scalar.GetStructuredDataset().GetMetadata().GetStructuredDatasetType().GetFormat())
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/flyteorg/flyteplugins/go/tasks/plugins/presto/client"
 
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flytestdlib/cache"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/errors"
@@ -22,6 +23,10 @@ const prestoPluginID = "presto"
 // the structure of the stored state
 const pluginStateVersion = 0
 
+// Reads and writes the custom state, upgrading state written by older versions of the plugin and refusing state
+// written by newer ones. Register a migration here when bumping pluginStateVersion.
+var stateMigrations = core.NewStateMigrations(pluginStateVersion)
+
 const prestoTaskType = "presto" // This needs to match the type defined in Flytekit constants.py
 
 type Executor struct {
@@ -32,6 +37,10 @@ type Executor struct {
 	cfg             *config.Config
 }
 
+func (p Executor) Validate(_ context.Context, taskTemplate *idlCore.TaskTemplate) error {
+	return ValidateTaskTemplate(taskTemplate)
+}
+
 func (p Executor) GetID() string {
 	return p.id
 }
@@ -41,7 +50,7 @@ func (p Executor) Handle(ctx context.Context, tCtx core.TaskExecutionContext) (c
 
 	// We assume here that the first time this function is called, the custom state we get back is whatever we passed in,
 	// namely the zero-value of our struct.
-	if _, err := tCtx.PluginStateReader().Get(&incomingState); err != nil {
+	if _, err := stateMigrations.Get(tCtx.PluginStateReader(), &incomingState); err != nil {
 		logger.Errorf(ctx, "Plugin %s failed to unmarshal custom state when handling [%s] [%s]",
 			p.id, tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName(), err)
 		return core.UnknownTransition, errors.Wrapf(errors.CorruptedPluginState, err,
@@ -59,7 +68,7 @@ func (p Executor) Handle(ctx context.Context, tCtx core.TaskExecutionContext) (c
 	// If no error, then infer the new Phase from the various states
 	phaseInfo := MapExecutionStateToPhaseInfo(outgoingState)
 
-	if err := tCtx.PluginStateWriter().Put(pluginStateVersion, outgoingState); err != nil {
+	if err := stateMigrations.Put(tCtx.PluginStateWriter(), outgoingState); err != nil {
 		return core.UnknownTransition, err
 	}
 
@@ -68,7 +77,7 @@ func (p Executor) Handle(ctx context.Context, tCtx core.TaskExecutionContext) (c
 
 func (p Executor) Abort(ctx context.Context, tCtx core.TaskExecutionContext) error {
 	incomingState := ExecutionState{}
-	if _, err := tCtx.PluginStateReader().Get(&incomingState); err != nil {
+	if _, err := stateMigrations.Get(tCtx.PluginStateReader(), &incomingState); err != nil {
 		logger.Errorf(ctx, "Plugin %s failed to unmarshal custom state in Finalize [%s] Err [%s]",
 			p.id, tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName(), err)
 		return errors.Wrapf(errors.CorruptedPluginState, err, "Failed to unmarshal custom state in Finalize")
@@ -79,7 +88,7 @@ func (p Executor) Abort(ctx context.Context, tCtx core.TaskExecutionContext) err
 
 func (p Executor) Finalize(ctx context.Context, tCtx core.TaskExecutionContext) error {
 	incomingState := ExecutionState{}
-	if _, err := tCtx.PluginStateReader().Get(&incomingState); err != nil {
+	if _, err := stateMigrations.Get(tCtx.PluginStateReader(), &incomingState); err != nil {
 		logger.Errorf(ctx, "Plugin %s failed to unmarshal custom state in Finalize [%s] Err [%s]",
 			p.id, tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName(), err)
 		return errors.Wrapf(errors.CorruptedPluginState, err, "Failed to unmarshal custom state in Finalize")
@@ -152,6 +161,7 @@ func init() {
 			ID:                  prestoPluginID,
 			RegisteredTaskTypes: []core.TaskType{prestoTaskType},
 			LoadPlugin:          ExecutorLoader,
+			Validator:           Executor{},
 			IsDefault:           false,
 		})
 }
@@ -5,6 +5,7 @@ import (
 
 	pluginsConfig "github.com/flyteorg/flyteplugins/go/tasks/config"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tasklog"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
 	"github.com/flyteorg/flytestdlib/config"
 )
@@ -32,6 +33,12 @@ var (
 				MaxSystemFailures: 5,
 			},
 			ResourceMeta: nil,
+			Logs: []tasklog.TemplateLogPluginConfig{
+				{
+					DisplayName:  "Athena Query Console",
+					TemplateURIs: []string{"https://{{ .region }}.console.aws.amazon.com/athena/home?force&region={{ .region }}#query/history/{{ .resourceId }}"},
+				},
+			},
 		},
 
 		ResourceConstraints: core.ResourceConstraintsSpec{
@@ -58,6 +58,7 @@ func (cfg Config) GetPFlagSet(prefix string) *pflag.FlagSet {
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "webApi.caching.resyncInterval"), defaultConfig.WebAPI.Caching.ResyncInterval.String(), "Defines the sync interval.")
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "webApi.caching.workers"), defaultConfig.WebAPI.Caching.Workers, "Defines the number of workers to start up to process items.")
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "webApi.caching.maxSystemFailures"), defaultConfig.WebAPI.Caching.MaxSystemFailures, "Defines the number of failures to fetch a task before failing the task.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "webApi.managesPhaseVersion"), defaultConfig.WebAPI.ManagesPhaseVersion, "Whether the plugin sets the version of the phases it reports itself.")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "defaultWorkGroup"), defaultConfig.DefaultWorkGroup, "Defines the default workgroup to use when running on Athena unless overwritten by the task.")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "defaultCatalog"), defaultConfig.DefaultCatalog, "Defines the default catalog to use when running on Athena unless overwritten by the task.")
 	return cmdFlags
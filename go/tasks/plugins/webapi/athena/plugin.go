@@ -2,7 +2,6 @@ package athena
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	errors2 "github.com/flyteorg/flyteplugins/go/tasks/errors"
@@ -21,6 +20,7 @@ import (
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tasklog"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
 )
 
@@ -30,11 +30,14 @@ const (
 	ErrSystem       errors.ErrorCode = "System"
 )
 
+const pluginID = "athena"
+
 type Plugin struct {
 	metricScope promutils.Scope
 	client      *athena.Client
 	cfg         *Config
 	awsConfig   awsSdk.Config
+	logSigner   tasklog.URLSigner
 }
 
 type ResourceWrapper struct {
@@ -46,6 +49,11 @@ func (p Plugin) GetConfig() webapi.PluginConfig {
 	return GetConfig().WebAPI
 }
 
+// Validate checks the query of the task template only references the inputs declared by the task.
+func (p Plugin) Validate(_ context.Context, taskTemplate *idlCore.TaskTemplate) error {
+	return validateQueryTemplate(taskTemplate)
+}
+
 func (p Plugin) ResourceRequirements(_ context.Context, _ webapi.TaskExecutionContextReader) (
 	namespace core.ResourceNamespace, constraints core.ResourceConstraintsSpec, err error) {
 
@@ -143,25 +151,36 @@ func (p Plugin) Status(ctx context.Context, tCtx webapi.StatusContext) (phase co
 		return core.PhaseInfoUndefined, errors.Errorf(ErrSystem, "No Status field set.")
 	}
 
+	taskExecMetadata := tCtx.TaskExecutionMetadata()
+	logTemplates, err := p.cfg.WebAPI.GetLogTemplates(pluginID, taskExecMetadata)
+	if err != nil {
+		return core.PhaseInfoUndefined, err
+	}
+
+	taskInfo, err := createTaskInfo(logTemplates, p.logSigner, taskExecMetadata, execID, p.awsConfig)
+	if err != nil {
+		return core.PhaseInfoUndefined, err
+	}
+
 	switch exec.Status.State {
 	case athenaTypes.QueryExecutionStateQueued:
 		fallthrough
 	case athenaTypes.QueryExecutionStateRunning:
-		return core.PhaseInfoRunning(1, createTaskInfo(execID, p.awsConfig)), nil
+		return core.PhaseInfoRunning(1, taskInfo), nil
 	case athenaTypes.QueryExecutionStateCancelled:
 		reason := "Remote execution was aborted."
 		if reasonPtr := exec.Status.StateChangeReason; reasonPtr != nil {
 			reason = *reasonPtr
 		}
 
-		return core.PhaseInfoRetryableFailure("ABORTED", reason, createTaskInfo(execID, p.awsConfig)), nil
+		return core.PhaseInfoRetryableFailure("ABORTED", reason, taskInfo), nil
 	case athenaTypes.QueryExecutionStateFailed:
 		reason := "Remote execution failed"
 		if reasonPtr := exec.Status.StateChangeReason; reasonPtr != nil {
 			reason = *reasonPtr
 		}
 
-		return core.PhaseInfoRetryableFailure("FAILED", reason, createTaskInfo(execID, p.awsConfig)), nil
+		return core.PhaseInfoRetryableFailure("FAILED", reason, taskInfo), nil
 	case athenaTypes.QueryExecutionStateSucceeded:
 		if outputLocation := exec.ResultsConfiguration.OutputLocation; outputLocation != nil {
 			// If WorkGroup settings overrode the client settings, the location submitted in the request might have been
@@ -173,53 +192,64 @@ func (p Plugin) Status(ctx context.Context, tCtx webapi.StatusContext) (phase co
 			}
 		}
 
-		return core.PhaseInfoSuccess(createTaskInfo(execID, p.awsConfig)), nil
+		return core.PhaseInfoSuccess(taskInfo), nil
 	}
 
 	return core.PhaseInfoUndefined, errors.Errorf(ErrSystem, "Unknown execution phase [%v].", exec.Status.State)
 }
 
-func createTaskInfo(queryID string, cfg awsSdk.Config) *core.TaskInfo {
+func createTaskInfo(logCfg []tasklog.TemplateLogPluginConfig, logSigner tasklog.URLSigner,
+	taskExecMetadata core.TaskExecutionMetadata, queryID string, cfg awsSdk.Config) (*core.TaskInfo, error) {
+
+	logs, err := webapi.GetTaskLogs(logCfg, logSigner, taskExecMetadata, queryID, map[string]string{
+		"region": cfg.Region,
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	timeNow := time.Now()
 	return &core.TaskInfo{
 		OccurredAt: &timeNow,
-		Logs: []*idlCore.TaskLog{
-			{
-				Uri: fmt.Sprintf("https://%v.console.aws.amazon.com/athena/home?force&region=%v#query/history/%v",
-					cfg.Region,
-					cfg.Region,
-					queryID),
-				Name: "Athena Query Console",
-			},
-		},
+		Logs:       logs,
 		ExternalResources: []*core.ExternalResource{
 			{
 				ExternalID: queryID,
 			},
 		},
-	}
+	}, nil
 }
 
-func NewPlugin(_ context.Context, cfg *Config, awsConfig *aws.Config, metricScope promutils.Scope) (Plugin, error) {
+// NewPlugin creates the plugin. Athena is called with the AWS credentials of awsConfig, secretManager only reads the
+// signing key of log links, which may be a structured reference, e.g. athena/log-signer#key.
+func NewPlugin(ctx context.Context, cfg *Config, awsConfig *aws.Config, metricScope promutils.Scope,
+	secretManager core.SecretManager) (Plugin, error) {
 	sdkCfg, err := awsConfig.GetSdkConfig()
 	if err != nil {
 		return Plugin{}, err
 	}
 
+	logSigner, err := tasklog.NewURLSigner(ctx, cfg.WebAPI.LogSigner, secretManager)
+	if err != nil {
+		return Plugin{}, err
+	}
+
 	return Plugin{
 		metricScope: metricScope,
 		client:      athena.NewFromConfig(sdkCfg),
 		cfg:         cfg,
 		awsConfig:   sdkCfg,
+		logSigner:   logSigner,
 	}, nil
 }
 
 func init() {
 	pluginmachinery.PluginRegistry().RegisterRemotePlugin(webapi.PluginEntry{
-		ID:                 "athena",
+		ID:                 pluginID,
 		SupportedTaskTypes: []core.TaskType{"hive", "presto"},
 		PluginLoader: func(ctx context.Context, iCtx webapi.PluginSetupContext) (webapi.AsyncPlugin, error) {
-			return NewPlugin(ctx, GetConfig(), aws.GetConfig(), iCtx.MetricsScope())
+			return NewPlugin(ctx, GetConfig(), aws.GetConfig(), iCtx.MetricsScope(), iCtx.SecretManager())
 		},
+		Validator: Plugin{},
 	})
 }
@@ -5,13 +5,15 @@ import (
 
 	awsSdk "github.com/aws/aws-sdk-go-v2/aws"
 	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	coreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestCreateTaskInfo(t *testing.T) {
-	taskInfo := createTaskInfo("query_id", awsSdk.Config{
+	taskInfo, err := createTaskInfo(GetConfig().WebAPI.Logs, nil, newTaskExecutionMetadata(), "query_id", awsSdk.Config{
 		Region: "us-east-1",
 	})
+	assert.NoError(t, err)
 	assert.EqualValues(t, []*idlCore.TaskLog{
 		{
 			Uri:  "https://us-east-1.console.aws.amazon.com/athena/home?force&region=us-east-1#query/history/query_id",
@@ -21,3 +23,20 @@ func TestCreateTaskInfo(t *testing.T) {
 	assert.Len(t, taskInfo.ExternalResources, 1)
 	assert.Equal(t, taskInfo.ExternalResources[0].ExternalID, "query_id")
 }
+
+func newTaskExecutionMetadata() *coreMocks.TaskExecutionMetadata {
+	tID := &coreMocks.TaskExecutionID{}
+	tID.OnGetID().Return(idlCore.TaskExecutionIdentifier{
+		NodeExecutionId: &idlCore.NodeExecutionIdentifier{
+			ExecutionId: &idlCore.WorkflowExecutionIdentifier{
+				Project: "flytesnacks",
+				Domain:  "development",
+				Name:    "exec-name",
+			},
+		},
+	})
+
+	tMeta := &coreMocks.TaskExecutionMetadata{}
+	tMeta.OnGetTaskExecutionID().Return(tID)
+	return tMeta
+}
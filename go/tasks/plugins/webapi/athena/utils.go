@@ -7,10 +7,10 @@ import (
 
 	"github.com/flyteorg/flyteplugins/go/tasks/errors"
 
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	pluginsIdl "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/plugins"
 	"github.com/flyteorg/flytestdlib/utils"
 
-	pb "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/ioutils"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
 	"github.com/flyteorg/flytestdlib/logger"
@@ -33,22 +33,14 @@ func writeOutput(ctx context.Context, tCtx webapi.StatusContext, externalLocatio
 		return nil
 	}
 
-	return tCtx.OutputWriter().Put(ctx, ioutils.NewInMemoryOutputReader(
-		&pb.LiteralMap{
-			Literals: map[string]*pb.Literal{
-				"results": {
-					Value: &pb.Literal_Scalar{
-						Scalar: &pb.Scalar{Value: &pb.Scalar_Schema{
-							Schema: &pb.Schema{
-								Uri:  externalLocation,
-								Type: resultsSchema.GetType().GetSchema(),
-							},
-						},
-						},
-					},
-				},
-			},
-		}, nil, nil))
+	// Athena always writes query results as CSV files.
+	outputReader, err := ioutils.NewQueryResultOutputReader("results", resultsSchema.GetType(), externalLocation,
+		ioutils.StructuredDatasetFormatCSV)
+	if err != nil {
+		return errors.Wrapf(errors.BadTaskSpecification, err, "Failed to build the results output")
+	}
+
+	return tCtx.OutputWriter().Put(ctx, outputReader)
 }
 
 type QueryInfo struct {
@@ -78,6 +70,63 @@ func validatePrestoQuery(prestoQuery pluginsIdl.PrestoQuery) error {
 	return nil
 }
 
+func unmarshalHiveQuery(task *idlCore.TaskTemplate) (pluginsIdl.QuboleHiveJob, error) {
+	hiveQuery := pluginsIdl.QuboleHiveJob{}
+	err := utils.UnmarshalStructToPb(task.GetCustom(), &hiveQuery)
+	if err != nil {
+		return hiveQuery, errors.Wrapf(ErrUser, err, "Expects a valid QubleHiveJob proto in custom field.")
+	}
+
+	if err = validateHiveQuery(hiveQuery); err != nil {
+		return hiveQuery, errors.Wrapf(ErrUser, err, "Expects a valid QubleHiveJob proto in custom field.")
+	}
+
+	return hiveQuery, nil
+}
+
+func unmarshalPrestoQuery(task *idlCore.TaskTemplate) (pluginsIdl.PrestoQuery, error) {
+	prestoQuery := pluginsIdl.PrestoQuery{}
+	err := utils.UnmarshalStructToPb(task.GetCustom(), &prestoQuery)
+	if err != nil {
+		return prestoQuery, errors.Wrapf(ErrUser, err, "Expects a valid PrestoQuery proto in custom field.")
+	}
+
+	if err = validatePrestoQuery(prestoQuery); err != nil {
+		return prestoQuery, errors.Wrapf(ErrUser, err, "Expects a valid PrestoQuery proto in custom field.")
+	}
+
+	return prestoQuery, nil
+}
+
+// Checks the query of the task is valid and only references the inputs declared by the task.
+func validateQueryTemplate(task *idlCore.TaskTemplate) error {
+	var templates []string
+	switch task.GetType() {
+	case "hive":
+		hiveQuery, err := unmarshalHiveQuery(task)
+		if err != nil {
+			return err
+		}
+
+		templates = []string{hiveQuery.Query.Query, hiveQuery.ClusterLabel}
+	case "presto":
+		prestoQuery, err := unmarshalPrestoQuery(task)
+		if err != nil {
+			return err
+		}
+
+		templates = []string{prestoQuery.RoutingGroup, prestoQuery.Catalog, prestoQuery.Schema, prestoQuery.Statement}
+	default:
+		return errors.Errorf(ErrUser, "Unexpected task type [%v].", task.GetType())
+	}
+
+	if err := template.ValidateInputs(templates, task.GetInterface().GetInputs()); err != nil {
+		return errors.Wrapf(ErrUser, err, "Query references undeclared inputs.")
+	}
+
+	return nil
+}
+
 func extractQueryInfo(ctx context.Context, tCtx webapi.TaskExecutionContextReader) (QueryInfo, error) {
 	task, err := tCtx.TaskReader().Read(ctx)
 	if err != nil {
@@ -86,15 +135,9 @@ func extractQueryInfo(ctx context.Context, tCtx webapi.TaskExecutionContextReade
 
 	switch task.Type {
 	case "hive":
-		custom := task.GetCustom()
-		hiveQuery := pluginsIdl.QuboleHiveJob{}
-		err := utils.UnmarshalStructToPb(custom, &hiveQuery)
+		hiveQuery, err := unmarshalHiveQuery(task)
 		if err != nil {
-			return QueryInfo{}, errors.Wrapf(ErrUser, err, "Expects a valid QubleHiveJob proto in custom field.")
-		}
-
-		if err = validateHiveQuery(hiveQuery); err != nil {
-			return QueryInfo{}, errors.Wrapf(ErrUser, err, "Expects a valid QubleHiveJob proto in custom field.")
+			return QueryInfo{}, err
 		}
 
 		outputs, err := template.Render(ctx, []string{
@@ -115,15 +158,9 @@ func extractQueryInfo(ctx context.Context, tCtx webapi.TaskExecutionContextReade
 			Database:    outputs[1],
 		}, nil
 	case "presto":
-		custom := task.GetCustom()
-		prestoQuery := pluginsIdl.PrestoQuery{}
-		err := utils.UnmarshalStructToPb(custom, &prestoQuery)
+		prestoQuery, err := unmarshalPrestoQuery(task)
 		if err != nil {
-			return QueryInfo{}, errors.Wrapf(ErrUser, err, "Expects a valid PrestoQuery proto in custom field.")
-		}
-
-		if err = validatePrestoQuery(prestoQuery); err != nil {
-			return QueryInfo{}, errors.Wrapf(ErrUser, err, "Expects a valid PrestoQuery proto in custom field.")
+			return QueryInfo{}, err
 		}
 
 		outputs, err := template.Render(ctx, []string{
@@ -117,6 +117,66 @@ func Test_writeOutput(t *testing.T) {
 		err = writeOutput(context.Background(), statusContext, externalLocation)
 		assert.NoError(t, err)
 	})
+
+	t.Run("Valid StructuredDataset", func(t *testing.T) {
+		statusContext := &mocks.StatusContext{}
+		taskReader := &mocks2.TaskReader{}
+		columns := []*core.StructuredDatasetType_DatasetColumn{
+			{
+				Name:        "col1",
+				LiteralType: &core.LiteralType{Type: &core.LiteralType_Simple{Simple: core.SimpleType_INTEGER}},
+			},
+		}
+
+		taskReader.OnRead(ctx).Return(&core.TaskTemplate{
+			Interface: &core.TypedInterface{
+				Outputs: &core.VariableMap{
+					Variables: map[string]*core.Variable{
+						"results": {
+							Type: &core.LiteralType{
+								Type: &core.LiteralType_StructuredDatasetType{
+									StructuredDatasetType: &core.StructuredDatasetType{
+										Columns: columns,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}, nil)
+
+		statusContext.OnTaskReader().Return(taskReader)
+
+		ow := &mocks3.OutputWriter{}
+		externalLocation := "s3://my-external-bucket/key"
+		ow.OnPut(ctx, ioutils.NewInMemoryOutputReader(
+			&pb.LiteralMap{
+				Literals: map[string]*pb.Literal{
+					"results": {
+						Value: &pb.Literal_Scalar{
+							Scalar: &pb.Scalar{
+								Value: &pb.Scalar_StructuredDataset{
+									StructuredDataset: &pb.StructuredDataset{
+										Uri: externalLocation,
+										Metadata: &pb.StructuredDatasetMetadata{
+											StructuredDatasetType: &core.StructuredDatasetType{
+												Columns: columns,
+												Format:  ioutils.StructuredDatasetFormatCSV,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}, nil, nil)).Return(nil)
+		statusContext.OnOutputWriter().Return(ow)
+
+		err := writeOutput(context.Background(), statusContext, externalLocation)
+		assert.NoError(t, err)
+	})
 }
 
 func Test_ExtractQueryInfo(t *testing.T) {
@@ -203,3 +263,31 @@ func Test_ExtractQueryInfo(t *testing.T) {
 		})
 	}
 }
+
+func Test_validateQueryTemplate(t *testing.T) {
+	inputs := &core.TypedInterface{Inputs: &core.VariableMap{Variables: map[string]*core.Variable{"ds": {}}}}
+	for name, test := range map[string]struct {
+		taskType string
+		custom   proto.Message
+		valid    bool
+	}{
+		"hive":                 {"hive", &plugins.QuboleHiveJob{Query: &plugins.HiveQuery{Query: "select * from t where ds = '{{ .Inputs.ds }}'"}}, true},
+		"hive without query":   {"hive", &plugins.QuboleHiveJob{}, false},
+		"hive unknown input":   {"hive", &plugins.QuboleHiveJob{Query: &plugins.HiveQuery{Query: "select {{ .Inputs.x }}"}}, false},
+		"presto":               {"presto", &plugins.PrestoQuery{Statement: "select * from t where ds = '{{ .Inputs.ds }}'"}, true},
+		"presto unknown input": {"presto", &plugins.PrestoQuery{Statement: "select 1", Schema: "{{ .Inputs.schema }}"}, false},
+		"unknown task type":    {"spark", &plugins.PrestoQuery{Statement: "select 1"}, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			custom, err := utils.MarshalPbToStruct(test.custom)
+			assert.NoError(t, err)
+
+			err = validateQueryTemplate(&core.TaskTemplate{Type: test.taskType, Custom: custom, Interface: inputs})
+			if test.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -8,7 +8,9 @@ import (
 
 	pluginsConfig "github.com/flyteorg/flyteplugins/go/tasks/config"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tasklog"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/credentials"
 	"github.com/flyteorg/flytestdlib/config"
 )
 
@@ -35,6 +37,12 @@ var (
 				MaxSystemFailures: 5,
 			},
 			ResourceMeta: nil,
+			Logs: []tasklog.TemplateLogPluginConfig{
+				{
+					DisplayName:  "BigQuery Console",
+					TemplateURIs: []string{bigqueryConsolePath + "?project={{ .gcpProject }}&j=bq:{{ .location }}:{{ .resourceId }}&page=queryresults"},
+				},
+			},
 		},
 		ResourceConstraints: core.ResourceConstraintsSpec{
 			ProjectScopeResourceConstraint: &core.ResourceConstraint{
@@ -45,6 +53,9 @@ var (
 			},
 		},
 		GoogleTokenSource: google.GetDefaultConfig(),
+		Credentials: credentials.Config{
+			CacheTTL: config.Duration{Duration: 5 * time.Minute},
+		},
 	}
 
 	configSection = pluginsConfig.MustRegisterSubSection("bigquery", &defaultConfig)
@@ -61,6 +72,11 @@ type Config struct {
 	// GoogleTokenSource configures token source for BigQuery client
 	GoogleTokenSource google.TokenSourceFactoryConfig `json:"googleTokenSource" pflag:",Defines Google token source"`
 
+	// Credentials defines the service account and the BigQuery endpoint per project and domain. The token key names the
+	// secret holding the JSON key of the service account. The Google token source is used for the projects and domains
+	// whose credentials don't set a token key or an OAuth client.
+	Credentials credentials.Config `json:"credentials" pflag:"-,Defines the service account and the BigQuery endpoint per project and domain."`
+
 	// bigQueryEndpoint overrides BigQuery client endpoint, only for testing
 	bigQueryEndpoint string
 }
@@ -211,6 +211,20 @@ func TestConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_webApi.managesPhaseVersion", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("webApi.managesPhaseVersion", testValue)
+			if vBool, err := cmdFlags.GetBool("webApi.managesPhaseVersion"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.WebAPI.ManagesPhaseVersion)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 	t.Run("Test_googleTokenSource.type", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
@@ -162,9 +162,13 @@ func newFakeSetupContext() *pluginCoreMocks.SetupContext {
 	fakeResourceRegistrar.On("RegisterResourceQuota", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	labeled.SetMetricKeys(contextutils.NamespaceKey)
 
+	fakeSecretManager := pluginCoreMocks.SecretManager{}
+	fakeSecretManager.OnGetMatch(mock.Anything, mock.Anything).Return("token", nil)
+
 	fakeSetupContext := pluginCoreMocks.SetupContext{}
 	fakeSetupContext.OnMetricsScope().Return(promutils.NewScope("test"))
 	fakeSetupContext.OnResourceRegistrar().Return(&fakeResourceRegistrar)
+	fakeSetupContext.OnSecretManager().Return(&fakeSecretManager)
 
 	return &fakeSetupContext
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/google"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tasklog"
 	structpb "github.com/golang/protobuf/ptypes/struct"
 	"google.golang.org/api/bigquery/v2"
 	"google.golang.org/api/googleapi"
@@ -30,6 +31,7 @@ import (
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/credentials"
 )
 
 const (
@@ -38,12 +40,17 @@ const (
 	bigqueryStatusRunning = "RUNNING"
 	bigqueryStatusPending = "PENDING"
 	bigqueryStatusDone    = "DONE"
+	pluginID              = "bigquery"
 )
 
 type Plugin struct {
 	metricScope       promutils.Scope
 	cfg               *Config
 	googleTokenSource google.TokenSourceFactory
+	// secretManager reads the secrets of the credentials when there's no task execution context.
+	secretManager core.SecretManager
+	credentials   *credentials.Resolver
+	logSigner     tasklog.URLSigner
 }
 
 type ResourceWrapper struct {
@@ -55,6 +62,7 @@ type ResourceWrapper struct {
 type ResourceMetaWrapper struct {
 	K8sServiceAccount string
 	Namespace         string
+	Credentials       credentials.Ref
 	JobReference      bigquery.JobReference
 }
 
@@ -69,6 +77,28 @@ func (p Plugin) ResourceRequirements(_ context.Context, _ webapi.TaskExecutionCo
 	return "default", p.cfg.ResourceConstraints, nil
 }
 
+// Validate checks the task template declares a query and a valid query job config.
+func (p Plugin) Validate(_ context.Context, taskTemplate *flyteIdlCore.TaskTemplate) error {
+	if taskTemplate.GetType() != bigqueryQueryJobTask {
+		return pluginErrors.Errorf(pluginErrors.BadTaskSpecification, "unexpected task type [%v]", taskTemplate.GetType())
+	}
+
+	if len(taskTemplate.GetSql().GetStatement()) == 0 {
+		return pluginErrors.Errorf(pluginErrors.BadTaskSpecification, "query statement is a required field")
+	}
+
+	queryJobConfig, err := unmarshalQueryJobConfig(taskTemplate.GetCustom())
+	if err != nil {
+		return pluginErrors.Wrapf(pluginErrors.BadTaskSpecification, err, "can't unmarshall struct to QueryJobConfig")
+	}
+
+	if len(queryJobConfig.ProjectID) == 0 {
+		return pluginErrors.Errorf(pluginErrors.BadTaskSpecification, "project id is a required field")
+	}
+
+	return nil
+}
+
 func (p Plugin) Create(ctx context.Context, taskCtx webapi.TaskExecutionContextReader) (webapi.ResourceMeta,
 	webapi.Resource, error) {
 	return p.createImpl(ctx, taskCtx)
@@ -95,7 +125,8 @@ func (p Plugin) createImpl(ctx context.Context, taskCtx webapi.TaskExecutionCont
 	namespace := taskCtx.TaskExecutionMetadata().GetNamespace()
 	k8sServiceAccount := flytek8s.GetServiceAccountNameFromTaskExecutionMetadata(taskCtx.TaskExecutionMetadata())
 	identity := google.Identity{K8sNamespace: namespace, K8sServiceAccount: k8sServiceAccount}
-	client, err := p.newBigQueryClient(ctx, identity)
+	ref := credentials.RefFromTaskExecutionMetadata(taskCtx.TaskExecutionMetadata())
+	client, err := p.newBigQueryClient(ctx, identity, taskCtx.SecretManager(), ref)
 
 	if err != nil {
 		return nil, nil, pluginErrors.Wrapf(pluginErrors.RuntimeFailure, err, "unable to get bigquery client")
@@ -122,6 +153,7 @@ func (p Plugin) createImpl(ctx context.Context, taskCtx webapi.TaskExecutionCont
 			JobReference:      *job.JobReference,
 			Namespace:         namespace,
 			K8sServiceAccount: k8sServiceAccount,
+			Credentials:       ref,
 		}
 
 		if ok && apiError.Code == 409 {
@@ -171,6 +203,7 @@ func (p Plugin) createImpl(ctx context.Context, taskCtx webapi.TaskExecutionCont
 		JobReference:      *job.JobReference,
 		Namespace:         namespace,
 		K8sServiceAccount: k8sServiceAccount,
+		Credentials:       ref,
 	}
 
 	return &resourceMeta, &resource, nil
@@ -214,7 +247,7 @@ func (p Plugin) getImpl(ctx context.Context, taskCtx webapi.GetContext) (wrapper
 		K8sNamespace:      resourceMeta.Namespace,
 		K8sServiceAccount: resourceMeta.K8sServiceAccount,
 	}
-	client, err := p.newBigQueryClient(ctx, identity)
+	client, err := p.newBigQueryClient(ctx, identity, p.secretManager, resourceMeta.Credentials)
 
 	if err != nil {
 		return nil, pluginErrors.Wrapf(pluginErrors.RuntimeFailure, err, "unable to get client")
@@ -250,7 +283,7 @@ func (p Plugin) Delete(ctx context.Context, taskCtx webapi.DeleteContext) error
 		K8sNamespace:      resourceMeta.Namespace,
 		K8sServiceAccount: resourceMeta.K8sServiceAccount,
 	}
-	client, err := p.newBigQueryClient(ctx, identity)
+	client, err := p.newBigQueryClient(ctx, identity, p.secretManager, resourceMeta.Credentials)
 
 	if err != nil {
 		return err
@@ -276,7 +309,16 @@ func (p Plugin) Status(ctx context.Context, tCtx webapi.StatusContext) (phase co
 		return core.PhaseInfoUndefined, nil
 	}
 
-	taskInfo := createTaskInfo(resourceMeta)
+	taskExecMetadata := tCtx.TaskExecutionMetadata()
+	logTemplates, err := p.cfg.WebAPI.GetLogTemplates(pluginID, taskExecMetadata)
+	if err != nil {
+		return core.PhaseInfoUndefined, err
+	}
+
+	taskInfo, err := createTaskInfo(logTemplates, p.logSigner, taskExecMetadata, resourceMeta)
+	if err != nil {
+		return core.PhaseInfoUndefined, err
+	}
 
 	if resource.CreateError != nil {
 		return handleCreateError(resource.CreateError, taskInfo), nil
@@ -333,25 +375,15 @@ func writeOutput(ctx context.Context, tCtx webapi.StatusContext, OutputLocation
 		logger.Infof(ctx, "The task declares no outputs. Skipping writing the outputs.")
 		return nil
 	}
-	return tCtx.OutputWriter().Put(ctx, ioutils.NewInMemoryOutputReader(
-		&flyteIdlCore.LiteralMap{
-			Literals: map[string]*flyteIdlCore.Literal{
-				"results": {
-					Value: &flyteIdlCore.Literal_Scalar{
-						Scalar: &flyteIdlCore.Scalar{
-							Value: &flyteIdlCore.Scalar_StructuredDataset{
-								StructuredDataset: &flyteIdlCore.StructuredDataset{
-									Uri: OutputLocation,
-									Metadata: &flyteIdlCore.StructuredDatasetMetadata{
-										StructuredDatasetType: resultsStructuredDatasetType.GetType().GetStructuredDatasetType(),
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-		}, nil, nil))
+
+	// The results live in a BigQuery table, so the format is left as declared by the task interface.
+	outputReader, err := ioutils.NewQueryResultOutputReader("results", resultsStructuredDatasetType.GetType(),
+		OutputLocation, "")
+	if err != nil {
+		return pluginErrors.Wrapf(pluginErrors.BadTaskSpecification, err, "Failed to build the results output")
+	}
+
+	return tCtx.OutputWriter().Put(ctx, outputReader)
 }
 
 func handleCreateError(createError *googleapi.Error, taskInfo *core.TaskInfo) core.PhaseInfo {
@@ -493,33 +525,32 @@ func handleErrorResult(reason string, message string, taskInfo *core.TaskInfo) c
 	}
 }
 
-func createTaskInfo(resourceMeta *ResourceMetaWrapper) *core.TaskInfo {
-	timeNow := time.Now()
-	j := formatJobReferenceForQueryParam(resourceMeta.JobReference)
+func createTaskInfo(logCfg []tasklog.TemplateLogPluginConfig, logSigner tasklog.URLSigner,
+	taskExecMetadata core.TaskExecutionMetadata, resourceMeta *ResourceMetaWrapper) (*core.TaskInfo, error) {
 
+	logs, err := webapi.GetTaskLogs(logCfg, logSigner, taskExecMetadata, resourceMeta.JobReference.JobId, map[string]string{
+		"gcpProject": resourceMeta.JobReference.ProjectId,
+		"location":   resourceMeta.JobReference.Location,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	timeNow := time.Now()
 	return &core.TaskInfo{
 		OccurredAt: &timeNow,
-		Logs: []*flyteIdlCore.TaskLog{
-			{
-				Uri: fmt.Sprintf("%s?project=%v&j=%v&page=queryresults",
-					bigqueryConsolePath,
-					resourceMeta.JobReference.ProjectId,
-					j),
-				Name: "BigQuery Console",
-			},
-		},
-	}
+		Logs:       logs,
+	}, nil
 }
 
 func formatJobReference(reference bigquery.JobReference) string {
 	return fmt.Sprintf("%s:%s.%s", reference.ProjectId, reference.Location, reference.JobId)
 }
 
-func formatJobReferenceForQueryParam(jobReference bigquery.JobReference) string {
-	return fmt.Sprintf("bq:%s:%s", jobReference.Location, jobReference.JobId)
-}
-
-func (p Plugin) newBigQueryClient(ctx context.Context, identity google.Identity) (*bigquery.Service, error) {
+// newBigQueryClient creates a client with the credentials of the project and domain, reading their secrets from the
+// secret manager of the task execution if there's one, the one of the plugin otherwise.
+func (p Plugin) newBigQueryClient(ctx context.Context, identity google.Identity, secretManager core.SecretManager,
+	ref credentials.Ref) (*bigquery.Service, error) {
 	options := []option.ClientOption{
 		option.WithScopes("https://www.googleapis.com/auth/bigquery"),
 		// FIXME how do I access current version?
@@ -531,6 +562,23 @@ func (p Plugin) newBigQueryClient(ctx context.Context, identity google.Identity)
 		options = append(options,
 			option.WithEndpoint(p.cfg.bigQueryEndpoint),
 			option.WithTokenSource(oauth2.StaticTokenSource(&oauth2.Token{})))
+		return bigquery.NewService(ctx, options...)
+	}
+
+	resolved, err := p.credentials.ResolveWith(ctx, secretManager, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resolved.Endpoint) != 0 {
+		options = append(options, option.WithEndpoint(resolved.Endpoint))
+	}
+
+	if resolved.OAuth {
+		options = append(options, option.WithTokenSource(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: resolved.Token})))
+	} else if len(resolved.Token) != 0 {
+		// The token is the JSON key of the service account of the project or domain
+		options = append(options, option.WithCredentialsJSON([]byte(resolved.Token)))
 	} else if p.cfg.GoogleTokenSource.Type != "default" {
 
 		tokenSource, err := p.googleTokenSource.GetTokenSource(ctx, identity)
@@ -547,29 +595,39 @@ func (p Plugin) newBigQueryClient(ctx context.Context, identity google.Identity)
 	return bigquery.NewService(ctx, options...)
 }
 
-func NewPlugin(cfg *Config, metricScope promutils.Scope) (*Plugin, error) {
+func NewPlugin(ctx context.Context, cfg *Config, metricScope promutils.Scope, secretManager core.SecretManager) (*Plugin,
+	error) {
 	googleTokenSource, err := google.NewTokenSourceFactory(cfg.GoogleTokenSource)
 
 	if err != nil {
 		return nil, pluginErrors.Wrapf(pluginErrors.PluginInitializationFailed, err, "failed to get google token source")
 	}
 
+	logSigner, err := tasklog.NewURLSigner(ctx, cfg.WebAPI.LogSigner, secretManager)
+	if err != nil {
+		return nil, pluginErrors.Wrapf(pluginErrors.PluginInitializationFailed, err, "failed to create log link signer")
+	}
+
 	return &Plugin{
 		metricScope:       metricScope,
 		cfg:               cfg,
 		googleTokenSource: googleTokenSource,
+		secretManager:     secretManager,
+		credentials:       credentials.NewResolver(cfg.Credentials, secretManager),
+		logSigner:         logSigner,
 	}, nil
 }
 
 func newBigQueryJobTaskPlugin() webapi.PluginEntry {
 	return webapi.PluginEntry{
-		ID:                 "bigquery",
+		ID:                 pluginID,
 		SupportedTaskTypes: []core.TaskType{bigqueryQueryJobTask},
 		PluginLoader: func(ctx context.Context, iCtx webapi.PluginSetupContext) (webapi.AsyncPlugin, error) {
 			cfg := GetConfig()
 
-			return NewPlugin(cfg, iCtx.MetricsScope())
+			return NewPlugin(ctx, cfg, iCtx.MetricsScope(), iCtx.SecretManager())
 		},
+		Validator: Plugin{},
 	}
 }
 
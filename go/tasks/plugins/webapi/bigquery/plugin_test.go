@@ -5,11 +5,15 @@ import (
 	"testing"
 	"time"
 
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
 	coreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/google"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io"
 	ioMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/credentials"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/mocks"
 	"github.com/flyteorg/flytestdlib/contextutils"
+	stdErrors "github.com/flyteorg/flytestdlib/errors"
 	"github.com/flyteorg/flytestdlib/promutils"
 	"github.com/flyteorg/flytestdlib/promutils/labeled"
 	"github.com/flyteorg/flytestdlib/storage"
@@ -72,7 +76,8 @@ func TestCreateTaskInfo(t *testing.T) {
 			},
 		}
 
-		taskInfo := createTaskInfo(&resourceMeta)
+		taskInfo, err := createTaskInfo(GetConfig().WebAPI.Logs, nil, newTaskExecutionMetadata(), &resourceMeta)
+		assert.NoError(t, err)
 
 		assert.Equal(t, 1, len(taskInfo.Logs))
 		assert.Equal(t, flyteIdlCore.TaskLog{
@@ -108,6 +113,8 @@ func TestOutputWriter(t *testing.T) {
 		assert.Equal(t, sd.Uri, outputLocation)
 		assert.Equal(t, sd.Metadata.GetStructuredDatasetType().Columns[0].Name, "col1")
 		assert.Equal(t, sd.Metadata.GetStructuredDatasetType().Columns[0].LiteralType.GetSimple(), flyteIdlCore.SimpleType_INTEGER)
+		// The results live in a BigQuery table, the format is left as declared.
+		assert.Empty(t, sd.Metadata.GetStructuredDatasetType().Format)
 
 		if ee != nil {
 			assert.NoError(t, ds.WriteProtobuf(ctx, outputWriter.GetErrorPath(), storage.Options{}, ee))
@@ -313,3 +320,49 @@ func TestHandleErrorResult(t *testing.T) {
 		})
 	}
 }
+
+func newTaskExecutionMetadata() *coreMocks.TaskExecutionMetadata {
+	tID := &coreMocks.TaskExecutionID{}
+	tID.OnGetID().Return(flyteIdlCore.TaskExecutionIdentifier{
+		NodeExecutionId: &flyteIdlCore.NodeExecutionIdentifier{
+			ExecutionId: &flyteIdlCore.WorkflowExecutionIdentifier{
+				Project: "flytesnacks",
+				Domain:  "development",
+				Name:    "exec-name",
+			},
+		},
+	})
+
+	tMeta := &coreMocks.TaskExecutionMetadata{}
+	tMeta.OnGetTaskExecutionID().Return(tID)
+	return tMeta
+}
+
+func TestNewBigQueryClient(t *testing.T) {
+	ctx := context.TODO()
+	sm := &coreMocks.SecretManager{}
+	sm.OnGetMatch(ctx, "flytesnacks-key").Return(
+		`{"type": "authorized_user", "client_id": "id", "client_secret": "secret", "refresh_token": "token"}`, nil)
+	sm.OnGetMatch(ctx, "missing").Return("", errors.Errorf(errors.SecretNotFound, "secret [missing] not found"))
+
+	plugin := Plugin{
+		cfg: &Config{GoogleTokenSource: google.GetDefaultConfig()},
+		credentials: credentials.NewResolver(credentials.Config{
+			Overrides: []credentials.Override{
+				{Project: "flytesnacks", Credentials: credentials.Credentials{
+					TokenKey: "flytesnacks-key",
+					Endpoint: "https://bigquery.flytesnacks.example.com/",
+				}},
+				{Project: "broken", Credentials: credentials.Credentials{TokenKey: "missing"}},
+			},
+		}, &coreMocks.SecretManager{}),
+	}
+
+	// The secrets are read from the secret manager of the task execution.
+	client, err := plugin.newBigQueryClient(ctx, google.Identity{}, sm, credentials.Ref{Project: "flytesnacks"})
+	assert.NoError(t, err)
+	assert.Equal(t, "https://bigquery.flytesnacks.example.com/", client.BasePath)
+
+	_, err = plugin.newBigQueryClient(ctx, google.Identity{}, sm, credentials.Ref{Project: "broken"})
+	assert.True(t, stdErrors.IsCausedBy(err, errors.SecretNotFound))
+}
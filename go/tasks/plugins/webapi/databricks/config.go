@@ -5,13 +5,15 @@ import (
 
 	pluginsConfig "github.com/flyteorg/flyteplugins/go/tasks/config"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tasklog"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/credentials"
 	"github.com/flyteorg/flytestdlib/config"
 )
 
 var (
 	defaultCluster = "COMPUTE_CLUSTER"
-	tokenKey       = "FLYTE_DATABRICKS_API_TOKEN" // nolint: gosec
+	tokenKey       = "databricks/token" // nolint: gosec
 
 	defaultConfig = Config{
 		WebAPI: webapi.PluginConfig{
@@ -33,6 +35,12 @@ var (
 				MaxSystemFailures: 5,
 			},
 			ResourceMeta: nil,
+			Logs: []tasklog.TemplateLogPluginConfig{
+				{
+					DisplayName:  "Databricks Console",
+					TemplateURIs: []string{"https://{{ .workspace }}/#job/{{ .jobId }}/run/{{ .resourceId }}"},
+				},
+			},
 		},
 		ResourceConstraints: core.ResourceConstraintsSpec{
 			ProjectScopeResourceConstraint: &core.ResourceConstraint{
@@ -44,6 +52,9 @@ var (
 		},
 		DefaultCluster: defaultCluster,
 		TokenKey:       tokenKey,
+		Credentials: credentials.Config{
+			CacheTTL: config.Duration{Duration: 5 * time.Minute},
+		},
 	}
 
 	configSection = pluginsConfig.MustRegisterSubSection("databricks", &defaultConfig)
@@ -59,15 +70,28 @@ type Config struct {
 
 	DefaultCluster string `json:"defaultWarehouse" pflag:",Defines the default warehouse to use when running on Databricks unless overwritten by the task."`
 
-	TokenKey string `json:"databricksTokenKey" pflag:",Name of the key where to find Databricks token in the secret manager."`
+	TokenKey string `json:"databricksTokenKey" pflag:",Reference of the Databricks token in the secret manager, as [group/]key[@version][#field]."`
 
 	DatabricksInstance string `json:"databricksInstance" pflag:",Databricks workspace instance name."`
 
 	EntrypointFile string `json:"entrypointFile" pflag:",A URL of the entrypoint file. DBFS and cloud storage (s3://, gcs://, adls://, etc) locations are supported."`
+
+	// Credentials defines the token and the workspace instance per project and domain. The token key and the instance
+	// above are used for the projects and domains whose credentials don't set them.
+	Credentials credentials.Config `json:"credentials" pflag:"-,Defines the token and the workspace instance per project and domain."`
+
 	// databricksEndpoint overrides databricks instance endpoint, only for testing
 	databricksEndpoint string
 }
 
+// credentialsConfig returns the credentials config, with the default credentials falling back to the token key and
+// the workspace instance.
+func (c Config) credentialsConfig() credentials.Config {
+	cfg := c.Credentials
+	cfg.Default = cfg.Default.Or(credentials.Credentials{TokenKey: c.TokenKey, Endpoint: c.DatabricksInstance})
+	return cfg
+}
+
 func GetConfig() *Config {
 	return configSection.GetConfig().(*Config)
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"testing"
 	"time"
 
@@ -14,9 +15,11 @@ import (
 	"github.com/flyteorg/flyteidl/clients/go/coreutils"
 	coreIdl "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	flyteIdlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery"
 	pluginCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 	pluginCoreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/plugintest"
 	"github.com/flyteorg/flyteplugins/tests"
 	"github.com/flyteorg/flytestdlib/contextutils"
 	"github.com/flyteorg/flytestdlib/promutils"
@@ -26,9 +29,10 @@ import (
 )
 
 func TestEndToEnd(t *testing.T) {
+	// The default token key is a structured reference, read from the env backend of the secret manager.
+	t.Setenv("_FSEC_DATABRICKS_TOKEN", "token")
 	server := newFakeDatabricksServer()
 	defer server.Close()
-
 	iter := func(ctx context.Context, tCtx pluginCore.TaskExecutionContext) error {
 		return nil
 	}
@@ -75,6 +79,25 @@ func TestEndToEnd(t *testing.T) {
 		phase := tests.RunPluginEndToEndTest(t, plugin, &template, inputs, nil, nil, iter)
 		assert.Equal(t, true, phase.Phase().IsSuccess())
 	})
+
+	t.Run("missing token", func(t *testing.T) {
+		// The loaded plugin checks the token is available before creating anything.
+		assert.NoError(t, os.Unsetenv("_FSEC_DATABRICKS_TOKEN"))
+		defer func() { assert.NoError(t, os.Setenv("_FSEC_DATABRICKS_TOKEN", "token")) }()
+
+		tCtx := plugintest.NewTaskContextBuilder(t).WithTaskTemplate(&flyteIdlCore.TaskTemplate{Type: "databricks"}).Build()
+		res := plugintest.NewCorePluginRunner(t, plugin, tCtx).Run(context.TODO())
+		if res.AssertFailure(string(errors.SecretNotFound)) {
+			assert.Contains(t, res.Phase().Err().GetMessage(), "databricks/token")
+		}
+	})
+
+	t.Run("check health", func(t *testing.T) {
+		checker, ok := plugin.(pluginCore.HealthChecker)
+		if assert.True(t, ok) {
+			assert.NoError(t, checker.CheckHealth(context.TODO()))
+		}
+	})
 }
 
 func newFakeDatabricksServer() *httptest.Server {
@@ -105,6 +128,12 @@ func newFakeDatabricksServer() *httptest.Server {
 			return
 		}
 
+		if request.URL.Path == fmt.Sprintf("%v/list", databricksAPI) && request.Method == get {
+			writer.WriteHeader(200)
+			_, _ = writer.Write([]byte(`{"runs": [], "has_more": false}`))
+			return
+		}
+
 		writer.WriteHeader(500)
 	}))
 }
@@ -114,9 +143,13 @@ func newFakeSetupContext() *pluginCoreMocks.SetupContext {
 	fakeResourceRegistrar.On("RegisterResourceQuota", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	labeled.SetMetricKeys(contextutils.NamespaceKey)
 
+	fakeSecretManager := pluginCoreMocks.SecretManager{}
+	fakeSecretManager.OnGetMatch(mock.Anything, mock.Anything).Return("token", nil)
+
 	fakeSetupContext := pluginCoreMocks.SetupContext{}
 	fakeSetupContext.OnMetricsScope().Return(promutils.NewScope("test"))
 	fakeSetupContext.OnResourceRegistrar().Return(&fakeResourceRegistrar)
+	fakeSetupContext.OnSecretManager().Return(&fakeSecretManager)
 
 	return &fakeSetupContext
 }
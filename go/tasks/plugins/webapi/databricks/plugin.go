@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/ioutils"
@@ -17,6 +18,7 @@ import (
 	pluginErrors "github.com/flyteorg/flyteplugins/go/tasks/errors"
 	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/template"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tasklog"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
 	"github.com/flyteorg/flytestdlib/errors"
 	"github.com/flyteorg/flytestdlib/logger"
@@ -25,11 +27,14 @@ import (
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tracing"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/credentials"
 )
 
 const (
 	ErrSystem       errors.ErrorCode = "System"
+	pluginID        string           = "databricks"
 	post            string           = "POST"
 	get             string           = "GET"
 	databricksAPI   string           = "/api/2.0/jobs/runs"
@@ -51,6 +56,8 @@ type Plugin struct {
 	metricScope promutils.Scope
 	cfg         *Config
 	client      HTTPClient
+	credentials *credentials.Resolver
+	logSigner   tasklog.URLSigner
 }
 
 type ResourceWrapper struct {
@@ -64,13 +71,20 @@ type ResourceWrapper struct {
 type ResourceMetaWrapper struct {
 	RunID              string
 	DatabricksInstance string
-	Token              string
+	Credentials        credentials.Ref
+	// Token is the token set by the task, if any. The configured token isn't stored, it's resolved from Credentials.
+	Token string
 }
 
 func (p Plugin) GetConfig() webapi.PluginConfig {
 	return GetConfig().WebAPI
 }
 
+// RequiredSecrets returns the Databricks token of the project and domain, so that tasks fail fast when it's missing.
+func (p Plugin) RequiredSecrets(taskCtx webapi.TaskExecutionContextReader) []string {
+	return p.credentials.RequiredSecrets(credentials.RefFromTaskExecutionMetadata(taskCtx.TaskExecutionMetadata()))
+}
+
 func (p Plugin) ResourceRequirements(_ context.Context, _ webapi.TaskExecutionContextReader) (
 	namespace core.ResourceNamespace, constraints core.ResourceConstraintsSpec, err error) {
 
@@ -78,6 +92,37 @@ func (p Plugin) ResourceRequirements(_ context.Context, _ webapi.TaskExecutionCo
 	return "default", p.cfg.ResourceConstraints, nil
 }
 
+// Validate checks the task template declares a container and a valid databricks job, and that the container arguments
+// only reference the inputs declared by the task.
+func (p Plugin) Validate(_ context.Context, taskTemplate *flyteIdlCore.TaskTemplate) error {
+	container := taskTemplate.GetContainer()
+	if container == nil {
+		return errors.Errorf(pluginErrors.BadTaskSpecification, "databricks tasks require a container")
+	}
+
+	sparkJob := plugins.SparkJob{}
+	if err := utils.UnmarshalStruct(taskTemplate.GetCustom(), &sparkJob); err != nil {
+		return errors.Wrapf(pluginErrors.BadTaskSpecification, err, "invalid TaskSpecification [%v], failed to unmarshal", taskTemplate.GetCustom())
+	}
+
+	databricksJob := make(map[string]interface{})
+	if err := utils.UnmarshalStructToObj(sparkJob.DatabricksConf, &databricksJob); err != nil {
+		return errors.Wrapf(pluginErrors.BadTaskSpecification, err, "failed to unmarshal databricksJob: %v", sparkJob.DatabricksConf)
+	}
+
+	if cluster, ok := databricksJob[newCluster]; ok {
+		if _, ok := cluster.(map[string]interface{}); !ok {
+			return errors.Errorf(pluginErrors.BadTaskSpecification, "databricksJob [%v] must be an object", newCluster)
+		}
+	}
+
+	if err := template.ValidateInputs(container.GetArgs(), taskTemplate.GetInterface().GetInputs()); err != nil {
+		return errors.Wrapf(pluginErrors.BadTaskSpecification, err, "container arguments reference undeclared inputs")
+	}
+
+	return nil
+}
+
 func (p Plugin) Create(ctx context.Context, taskCtx webapi.TaskExecutionContextReader) (webapi.ResourceMeta,
 	webapi.Resource, error) {
 	taskTemplate, err := taskCtx.TaskReader().Read(ctx)
@@ -85,10 +130,12 @@ func (p Plugin) Create(ctx context.Context, taskCtx webapi.TaskExecutionContextR
 		return nil, nil, err
 	}
 
-	token, err := taskCtx.SecretManager().Get(ctx, p.cfg.TokenKey)
+	ref := credentials.RefFromTaskExecutionMetadata(taskCtx.TaskExecutionMetadata())
+	resolved, err := p.credentials.ResolveWith(ctx, taskCtx.SecretManager(), ref)
 	if err != nil {
 		return nil, nil, err
 	}
+	token := resolved.Token
 
 	container := taskTemplate.GetContainer()
 	sparkJob := plugins.SparkJob{}
@@ -126,12 +173,12 @@ func (p Plugin) Create(ctx context.Context, taskCtx webapi.TaskExecutionContextR
 	databricksJob[sparkPythonTask] = map[string]interface{}{pythonFile: p.cfg.EntrypointFile, parameters: modifiedArgs}
 
 	req, err := buildRequest(post, databricksJob, p.cfg.databricksEndpoint,
-		p.cfg.DatabricksInstance, token, "", false)
+		resolved.Endpoint, token, "", false)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	resp, err := p.client.Do(req)
+	resp, err := p.client.Do(req.WithContext(ctx))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -146,19 +193,43 @@ func (p Plugin) Create(ctx context.Context, taskCtx webapi.TaskExecutionContextR
 	}
 	runID := fmt.Sprintf("%v", data["run_id"])
 
-	return &ResourceMetaWrapper{runID, p.cfg.DatabricksInstance, token},
+	return &ResourceMetaWrapper{
+			RunID:              runID,
+			DatabricksInstance: resolved.Endpoint,
+			Credentials:        ref,
+			Token:              sparkJob.DatabricksToken,
+		},
 		&ResourceWrapper{StatusCode: resp.StatusCode}, nil
 }
 
+// token returns the token the run was submitted with.
+func (p Plugin) token(ctx context.Context, exec ResourceMetaWrapper) (string, error) {
+	if len(exec.Token) != 0 {
+		return exec.Token, nil
+	}
+
+	resolved, err := p.credentials.Resolve(ctx, exec.Credentials)
+	if err != nil {
+		return "", err
+	}
+
+	return resolved.Token, nil
+}
+
 func (p Plugin) Get(ctx context.Context, taskCtx webapi.GetContext) (latest webapi.Resource, err error) {
 	exec := taskCtx.ResourceMeta().(*ResourceMetaWrapper)
+	token, err := p.token(ctx, *exec)
+	if err != nil {
+		return nil, err
+	}
+
 	req, err := buildRequest(get, nil, p.cfg.databricksEndpoint,
-		p.cfg.DatabricksInstance, exec.Token, exec.RunID, false)
+		exec.DatabricksInstance, token, exec.RunID, false)
 	if err != nil {
 		logger.Errorf(ctx, "Failed to build databricks job request [%v]", err)
 		return nil, err
 	}
-	resp, err := p.client.Do(req)
+	resp, err := p.client.Do(req.WithContext(ctx))
 	if err != nil {
 		logger.Errorf(ctx, "Failed to get databricks job status [%v]", resp)
 		return nil, err
@@ -184,12 +255,17 @@ func (p Plugin) Get(ctx context.Context, taskCtx webapi.GetContext) (latest weba
 
 func (p Plugin) Delete(ctx context.Context, taskCtx webapi.DeleteContext) error {
 	exec := taskCtx.ResourceMeta().(ResourceMetaWrapper)
+	token, err := p.token(ctx, exec)
+	if err != nil {
+		return err
+	}
+
 	req, err := buildRequest(post, nil, p.cfg.databricksEndpoint,
-		p.cfg.DatabricksInstance, exec.Token, exec.RunID, true)
+		exec.DatabricksInstance, token, exec.RunID, true)
 	if err != nil {
 		return err
 	}
-	resp, err := p.client.Do(req)
+	resp, err := p.client.Do(req.WithContext(ctx))
 	if err != nil {
 		return err
 	}
@@ -199,6 +275,44 @@ func (p Plugin) Delete(ctx context.Context, taskCtx webapi.DeleteContext) error
 	return nil
 }
 
+// CheckHealth lists a single run of the default workspace with the default credentials, to tell that the Databricks
+// API is reachable and accepts them. Plugins without a default workspace have nothing to probe.
+func (p Plugin) CheckHealth(ctx context.Context) error {
+	resolved, err := p.credentials.Resolve(ctx, credentials.Ref{})
+	if err != nil {
+		return err
+	}
+
+	var databricksURL string
+	// for mocking/testing purposes
+	if p.cfg.databricksEndpoint == "" {
+		if len(resolved.Endpoint) == 0 {
+			return nil
+		}
+
+		databricksURL = fmt.Sprintf("https://%v%v/list?limit=1", resolved.Endpoint, databricksAPI)
+	} else {
+		databricksURL = fmt.Sprintf("%v%v/list?limit=1", p.cfg.databricksEndpoint, databricksAPI)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, get, databricksURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Add("Authorization", "Bearer "+resolved.Token)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("databricks API responded with status [%v]", resp.Status)
+	}
+
+	return nil
+}
+
 func (p Plugin) Status(ctx context.Context, taskCtx webapi.StatusContext) (phase core.PhaseInfo, err error) {
 	exec := taskCtx.ResourceMeta().(*ResourceMetaWrapper)
 	resource := taskCtx.Resource().(*ResourceWrapper)
@@ -212,7 +326,18 @@ func (p Plugin) Status(ctx context.Context, taskCtx webapi.StatusContext) (phase
 		return core.PhaseInfoUndefined, errors.Errorf(ErrSystem, "No Status field set.")
 	}
 
-	taskInfo := createTaskInfo(exec.RunID, jobID, exec.DatabricksInstance)
+	taskExecMetadata := taskCtx.TaskExecutionMetadata()
+	logTemplates, err := p.cfg.WebAPI.GetLogTemplates(pluginID, taskExecMetadata)
+	if err != nil {
+		return core.PhaseInfoUndefined, err
+	}
+
+	taskInfo, err := createTaskInfo(logTemplates, p.logSigner, taskExecMetadata, exec.RunID, jobID,
+		exec.DatabricksInstance)
+	if err != nil {
+		return core.PhaseInfoUndefined, err
+	}
+
 	switch statusCode {
 	// Job response format. https://docs.databricks.com/dev-tools/api/latest/jobs.html#operation/JobsRunsSubmit
 	case http.StatusAccepted:
@@ -228,13 +353,16 @@ func (p Plugin) Status(ctx context.Context, taskCtx webapi.StatusContext) (phase
 			return pluginsCore.PhaseInfoFailure(string(rune(statusCode)), message, taskInfo), nil
 		}
 		return core.PhaseInfoRunning(pluginsCore.DefaultPhaseVersion, taskInfo), nil
-	case http.StatusBadRequest:
-		fallthrough
-	case http.StatusInternalServerError:
-		fallthrough
-	case http.StatusUnauthorized:
-		return pluginsCore.PhaseInfoFailure(string(rune(statusCode)), message, taskInfo), nil
+	case http.StatusBadRequest, http.StatusInternalServerError, http.StatusUnauthorized:
+		// These have always been permanent user failures, error classification rules can remap them.
+		return pluginsCore.PhaseInfoFailure(strconv.Itoa(statusCode), message, taskInfo), nil
 	}
+
+	if statusCode >= http.StatusBadRequest {
+		return pluginsCore.PhaseInfoClassifiedFailure(strconv.Itoa(statusCode), message,
+			pluginErrors.ClassifyHTTPStatus(statusCode), taskInfo), nil
+	}
+
 	return core.PhaseInfoUndefined, pluginErrors.Errorf(pluginsCore.SystemErrorCode, "unknown execution phase [%v].", statusCode)
 }
 
@@ -312,34 +440,44 @@ func buildResponse(response *http.Response) (map[string]interface{}, error) {
 	return data, nil
 }
 
-func createTaskInfo(runID, jobID, databricksInstance string) *core.TaskInfo {
-	timeNow := time.Now()
+func createTaskInfo(logCfg []tasklog.TemplateLogPluginConfig, logSigner tasklog.URLSigner,
+	taskExecMetadata core.TaskExecutionMetadata, runID, jobID, databricksInstance string) (*core.TaskInfo, error) {
 
+	logs, err := webapi.GetTaskLogs(logCfg, logSigner, taskExecMetadata, runID, map[string]string{
+		"jobId":     jobID,
+		"workspace": databricksInstance,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	timeNow := time.Now()
 	return &core.TaskInfo{
 		OccurredAt: &timeNow,
-		Logs: []*flyteIdlCore.TaskLog{
-			{
-				Uri: fmt.Sprintf("https://%s/#job/%s/run/%s",
-					databricksInstance,
-					jobID,
-					runID),
-				Name: "Databricks Console",
-			},
-		},
-	}
+		Logs:       logs,
+	}, nil
 }
 
 func newDatabricksJobTaskPlugin() webapi.PluginEntry {
 	return webapi.PluginEntry{
-		ID:                 "databricks",
+		ID:                 pluginID,
 		SupportedTaskTypes: []core.TaskType{"spark"},
 		PluginLoader: func(ctx context.Context, iCtx webapi.PluginSetupContext) (webapi.AsyncPlugin, error) {
+			cfg := GetConfig()
+			logSigner, err := tasklog.NewURLSigner(ctx, cfg.WebAPI.LogSigner, iCtx.SecretManager())
+			if err != nil {
+				return nil, err
+			}
+
 			return &Plugin{
 				metricScope: iCtx.MetricsScope(),
-				cfg:         GetConfig(),
-				client:      &http.Client{},
+				cfg:         cfg,
+				client:      &http.Client{Transport: tracing.NewTransport(nil)},
+				credentials: credentials.NewResolver(cfg.credentialsConfig(), iCtx.SecretManager()),
+				logSigner:   logSigner,
 			}, nil
 		},
+		Validator: Plugin{},
 	}
 }
 
@@ -9,9 +9,14 @@ import (
 	"testing"
 	"time"
 
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/plugins"
 	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 	pluginCoreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/credentials"
+	webapiMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/mocks"
 	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/utils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -50,11 +55,114 @@ func TestPlugin(t *testing.T) {
 		assert.Equal(t, pluginsCore.ResourceNamespace("default"), namespace)
 		assert.Equal(t, plugin.cfg.ResourceConstraints, constraints)
 	})
+	t.Run("get RequiredSecrets", func(t *testing.T) {
+		plugin := Plugin{credentials: credentials.NewResolver(credentials.Config{
+			Default: credentials.Credentials{TokenKey: "default-token"},
+			Overrides: []credentials.Override{
+				{Project: "flytesnacks", Credentials: credentials.Credentials{TokenKey: "flytesnacks-token"}},
+			},
+		}, nil)}
+
+		taskCtx := &webapiMocks.TaskExecutionContextReader{}
+		taskCtx.OnTaskExecutionMetadata().Return(newTaskExecutionMetadata())
+		assert.Equal(t, []string{"flytesnacks-token"}, plugin.RequiredSecrets(taskCtx))
+	})
+}
+
+func TestToken(t *testing.T) {
+	ctx := context.TODO()
+	sm := &pluginCoreMocks.SecretManager{}
+	sm.OnGetMatch(ctx, "flytesnacks-token").Return("flytesnacks", nil)
+	plugin := Plugin{
+		credentials: credentials.NewResolver(credentials.Config{
+			Overrides: []credentials.Override{
+				{Project: "flytesnacks", Credentials: credentials.Credentials{TokenKey: "flytesnacks-token"}},
+			},
+		}, sm),
+	}
+
+	t.Run("resolved from the credentials", func(t *testing.T) {
+		token, err := plugin.token(ctx, ResourceMetaWrapper{Credentials: credentials.Ref{Project: "flytesnacks"}})
+		assert.NoError(t, err)
+		assert.Equal(t, "flytesnacks", token)
+	})
+
+	t.Run("set by the task", func(t *testing.T) {
+		token, err := plugin.token(ctx, ResourceMetaWrapper{Credentials: credentials.Ref{Project: "flytesnacks"}, Token: "task"})
+		assert.NoError(t, err)
+		assert.Equal(t, "task", token)
+	})
+}
+
+func TestCheckHealth(t *testing.T) {
+	ctx := context.TODO()
+	sm := &pluginCoreMocks.SecretManager{}
+	sm.OnGetMatch(ctx, "default-token").Return("default", nil)
+	newPlugin := func(endpoint string) Plugin {
+		return Plugin{
+			cfg:    &Config{},
+			client: &MockClient{},
+			credentials: credentials.NewResolver(credentials.Config{
+				Default: credentials.Credentials{TokenKey: "default-token", Endpoint: endpoint},
+			}, sm),
+		}
+	}
+
+	t.Run("healthy", func(t *testing.T) {
+		MockDo = func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "https://"+testInstance+"/api/2.0/jobs/runs/list?limit=1", req.URL.String())
+			assert.Equal(t, "Bearer default", req.Header.Get("Authorization"))
+			return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader("{}"))}, nil
+		}
+
+		assert.NoError(t, newPlugin(testInstance).CheckHealth(ctx))
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		MockDo = func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusUnauthorized, Status: "401 Unauthorized",
+				Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+		}
+
+		assert.EqualError(t, newPlugin(testInstance).CheckHealth(ctx),
+			"databricks API responded with status [401 Unauthorized]")
+	})
+
+	t.Run("no default workspace", func(t *testing.T) {
+		MockDo = func(req *http.Request) (*http.Response, error) {
+			assert.FailNow(t, "unexpected request")
+			return nil, nil
+		}
+
+		assert.NoError(t, newPlugin("").CheckHealth(ctx))
+	})
+}
+
+func TestCredentialsConfig(t *testing.T) {
+	cfg := Config{
+		TokenKey:           tokenKey,
+		DatabricksInstance: testInstance,
+		Credentials: credentials.Config{
+			Default: credentials.Credentials{TokenKey: "default-token"},
+			Overrides: []credentials.Override{
+				{Project: "flytesnacks", Credentials: credentials.Credentials{Endpoint: "flytesnacks.cloud.databricks.com"}},
+			},
+		},
+	}
+
+	credentialsConfig := cfg.credentialsConfig()
+	assert.Equal(t, credentials.Credentials{TokenKey: "default-token", Endpoint: testInstance},
+		credentialsConfig.Lookup(credentials.Ref{Project: "flyteexamples"}))
+	assert.Equal(t, credentials.Credentials{TokenKey: "default-token", Endpoint: "flytesnacks.cloud.databricks.com"},
+		credentialsConfig.Lookup(credentials.Ref{Project: "flytesnacks"}))
+	assert.Equal(t, credentials.Credentials{TokenKey: tokenKey, Endpoint: testInstance},
+		Config{TokenKey: tokenKey, DatabricksInstance: testInstance}.credentialsConfig().Default)
 }
 
 func TestCreateTaskInfo(t *testing.T) {
 	t.Run("create task info", func(t *testing.T) {
-		taskInfo := createTaskInfo("run-id", "job-id", testInstance)
+		taskInfo, err := createTaskInfo(GetConfig().WebAPI.Logs, nil, newTaskExecutionMetadata(), "run-id", "job-id", testInstance)
+		assert.NoError(t, err)
 
 		assert.Equal(t, 1, len(taskInfo.Logs))
 		assert.Equal(t, taskInfo.Logs[0].Uri, "https://test-account.cloud.databricks.com/#job/job-id/run/run-id")
@@ -110,3 +218,73 @@ func TestBuildResponse(t *testing.T) {
 		assert.Equal(t, expectedData, actualData)
 	})
 }
+
+func TestStatus_Failures(t *testing.T) {
+	plugin := Plugin{cfg: GetConfig()}
+	for _, tt := range []struct {
+		statusCode int
+		code       string
+		phase      pluginsCore.Phase
+	}{
+		{http.StatusBadRequest, "400", pluginsCore.PhasePermanentFailure},
+		{http.StatusUnauthorized, "401", pluginsCore.PhasePermanentFailure},
+		{http.StatusInternalServerError, "500", pluginsCore.PhasePermanentFailure},
+		{http.StatusNotFound, "404", pluginsCore.PhasePermanentFailure},
+		{http.StatusTooManyRequests, "429", pluginsCore.PhaseRetryableFailure},
+		{http.StatusServiceUnavailable, "503", pluginsCore.PhaseRetryableFailure},
+	} {
+		t.Run(tt.code, func(t *testing.T) {
+			taskCtx := &webapiMocks.StatusContext{}
+			taskCtx.OnResourceMeta().Return(&ResourceMetaWrapper{RunID: "run"})
+			taskCtx.OnResource().Return(&ResourceWrapper{StatusCode: tt.statusCode, Message: "failed"})
+			taskCtx.OnTaskExecutionMetadata().Return(newTaskExecutionMetadata())
+
+			phaseInfo, err := plugin.Status(context.TODO(), taskCtx)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.phase, phaseInfo.Phase())
+			assert.Equal(t, tt.code, phaseInfo.Err().GetCode())
+			assert.Equal(t, "failed", phaseInfo.Err().GetMessage())
+		})
+	}
+}
+
+func newTaskExecutionMetadata() *pluginCoreMocks.TaskExecutionMetadata {
+	tID := &pluginCoreMocks.TaskExecutionID{}
+	tID.OnGetID().Return(idlCore.TaskExecutionIdentifier{
+		NodeExecutionId: &idlCore.NodeExecutionIdentifier{
+			ExecutionId: &idlCore.WorkflowExecutionIdentifier{
+				Project: "flytesnacks",
+				Domain:  "development",
+				Name:    "exec-name",
+			},
+		},
+	})
+
+	tMeta := &pluginCoreMocks.TaskExecutionMetadata{}
+	tMeta.OnGetTaskExecutionID().Return(tID)
+	return tMeta
+}
+
+func TestValidate(t *testing.T) {
+	ctx := context.TODO()
+	taskTemplate := func(t *testing.T, databricksConf map[string]interface{}, args []string) *idlCore.TaskTemplate {
+		databricksConfig, err := utils.MarshalObjToStruct(databricksConf)
+		assert.NoError(t, err)
+		custom, err := utils.MarshalPbToStruct(&plugins.SparkJob{DatabricksConf: databricksConfig})
+		assert.NoError(t, err)
+
+		return &idlCore.TaskTemplate{
+			Custom: custom,
+			Target: &idlCore.TaskTemplate_Container{Container: &idlCore.Container{Args: args}},
+			Interface: &idlCore.TypedInterface{Inputs: &idlCore.VariableMap{
+				Variables: map[string]*idlCore.Variable{"x": {}},
+			}},
+		}
+	}
+	newCluster := map[string]interface{}{"new_cluster": map[string]string{"spark_version": "11.0.x-scala2.12"}}
+
+	assert.NoError(t, Plugin{}.Validate(ctx, taskTemplate(t, newCluster, []string{"--x", "{{ .Inputs.x }}"})))
+	assert.Error(t, Plugin{}.Validate(ctx, taskTemplate(t, newCluster, []string{"--y", "{{ .Inputs.y }}"})))
+	assert.Error(t, Plugin{}.Validate(ctx, taskTemplate(t, map[string]interface{}{"new_cluster": "cluster"}, nil)))
+	assert.Error(t, Plugin{}.Validate(ctx, &idlCore.TaskTemplate{}))
+}
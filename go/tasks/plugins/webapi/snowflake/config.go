@@ -5,7 +5,9 @@ import (
 
 	pluginsConfig "github.com/flyteorg/flyteplugins/go/tasks/config"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tasklog"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/credentials"
 	"github.com/flyteorg/flytestdlib/config"
 )
 
@@ -30,6 +32,12 @@ var (
 				MaxSystemFailures: 5,
 			},
 			ResourceMeta: nil,
+			Logs: []tasklog.TemplateLogPluginConfig{
+				{
+					DisplayName:  "Snowflake Console",
+					TemplateURIs: []string{"https://{{ .account }}.snowflakecomputing.com/console#/monitoring/queries/detail?queryId={{ .resourceId }}"},
+				},
+			},
 		},
 		ResourceConstraints: core.ResourceConstraintsSpec{
 			ProjectScopeResourceConstraint: &core.ResourceConstraint{
@@ -40,7 +48,10 @@ var (
 			},
 		},
 		DefaultWarehouse: "COMPUTE_WH",
-		TokenKey:         "FLYTE_SNOWFLAKE_CLIENT_TOKEN",
+		TokenKey:         "snowflake/token",
+		Credentials: credentials.Config{
+			CacheTTL: config.Duration{Duration: 5 * time.Minute},
+		},
 	}
 
 	configSection = pluginsConfig.MustRegisterSubSection("snowflake", &defaultConfig)
@@ -56,12 +67,24 @@ type Config struct {
 
 	DefaultWarehouse string `json:"defaultWarehouse" pflag:",Defines the default warehouse to use when running on Snowflake unless overwritten by the task."`
 
-	TokenKey string `json:"snowflakeTokenKey" pflag:",Name of the key where to find Snowflake token in the secret manager."`
+	TokenKey string `json:"snowflakeTokenKey" pflag:",Reference of the Snowflake token in the secret manager, as [group/]key[@version][#field]."`
+
+	// Credentials defines the token and the endpoint per project and domain. The endpoint replaces the
+	// <account>.snowflakecomputing.com host, e.g. to reach the account through private connectivity. The token key
+	// above is used for the projects and domains whose credentials don't set one.
+	Credentials credentials.Config `json:"credentials" pflag:"-,Defines the token and the endpoint per project and domain."`
 
 	// snowflakeEndpoint overrides Snowflake client endpoint, only for testing
 	snowflakeEndpoint string
 }
 
+// credentialsConfig returns the credentials config, with the default credentials falling back to the token key.
+func (c Config) credentialsConfig() credentials.Config {
+	cfg := c.Credentials
+	cfg.Default = cfg.Default.Or(credentials.Credentials{TokenKey: c.TokenKey})
+	return cfg
+}
+
 func GetConfig() *Config {
 	return configSection.GetConfig().(*Config)
 }
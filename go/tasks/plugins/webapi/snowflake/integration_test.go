@@ -5,15 +5,18 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"testing"
 	"time"
 
 	"github.com/flyteorg/flyteidl/clients/go/coreutils"
 	coreIdl "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	flyteIdlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery"
 	pluginCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 	pluginCoreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/plugintest"
 	"github.com/flyteorg/flyteplugins/tests"
 	"github.com/flyteorg/flytestdlib/contextutils"
 	"github.com/flyteorg/flytestdlib/promutils"
@@ -23,9 +26,10 @@ import (
 )
 
 func TestEndToEnd(t *testing.T) {
+	// The default token key is a structured reference, read from the env backend of the secret manager.
+	t.Setenv("_FSEC_SNOWFLAKE_TOKEN", "token")
 	server := newFakeSnowflakeServer()
 	defer server.Close()
-
 	iter := func(ctx context.Context, tCtx pluginCore.TaskExecutionContext) error {
 		return nil
 	}
@@ -60,6 +64,25 @@ func TestEndToEnd(t *testing.T) {
 
 		assert.Equal(t, true, phase.Phase().IsSuccess())
 	})
+
+	t.Run("missing token", func(t *testing.T) {
+		// The loaded plugin checks the token is available before creating anything.
+		assert.NoError(t, os.Unsetenv("_FSEC_SNOWFLAKE_TOKEN"))
+		defer func() { assert.NoError(t, os.Setenv("_FSEC_SNOWFLAKE_TOKEN", "token")) }()
+
+		tCtx := plugintest.NewTaskContextBuilder(t).WithTaskTemplate(&flyteIdlCore.TaskTemplate{Type: "snowflake"}).Build()
+		res := plugintest.NewCorePluginRunner(t, plugin, tCtx).Run(context.TODO())
+		if res.AssertFailure(string(errors.SecretNotFound)) {
+			assert.Contains(t, res.Phase().Err().GetMessage(), "snowflake/token")
+		}
+	})
+
+	t.Run("check health", func(t *testing.T) {
+		checker, ok := plugin.(pluginCore.HealthChecker)
+		if assert.True(t, ok) {
+			assert.NoError(t, checker.CheckHealth(context.TODO()))
+		}
+	})
 }
 
 func newFakeSnowflakeServer() *httptest.Server {
@@ -90,6 +113,11 @@ func newFakeSnowflakeServer() *httptest.Server {
 			return
 		}
 
+		if request.URL.Path == "/api/v2/statements/"+healthProbeQueryID && request.Method == "GET" {
+			writer.WriteHeader(404)
+			return
+		}
+
 		writer.WriteHeader(500)
 	}))
 }
@@ -99,9 +127,13 @@ func newFakeSetupContext() *pluginCoreMocks.SetupContext {
 	fakeResourceRegistrar.On("RegisterResourceQuota", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	labeled.SetMetricKeys(contextutils.NamespaceKey)
 
+	fakeSecretManager := pluginCoreMocks.SecretManager{}
+	fakeSecretManager.OnGetMatch(mock.Anything, mock.Anything).Return("token", nil)
+
 	fakeSetupContext := pluginCoreMocks.SetupContext{}
 	fakeSetupContext.OnMetricsScope().Return(promutils.NewScope("test"))
 	fakeSetupContext.OnResourceRegistrar().Return(&fakeResourceRegistrar)
+	fakeSetupContext.OnSecretManager().Return(&fakeSecretManager)
 
 	return &fakeSetupContext
 }
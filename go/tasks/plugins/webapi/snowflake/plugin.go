@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 	"time"
 
 	flyteIdlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
@@ -15,6 +16,7 @@ import (
 	pluginErrors "github.com/flyteorg/flyteplugins/go/tasks/errors"
 	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/template"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tasklog"
 	"github.com/flyteorg/flytestdlib/errors"
 	"github.com/flyteorg/flytestdlib/logger"
 
@@ -22,13 +24,20 @@ import (
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tracing"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/credentials"
 )
 
 const (
 	ErrSystem errors.ErrorCode = "System"
 	post      string           = "POST"
 	get       string           = "GET"
+	pluginID  string           = "snowflake"
+
+	// healthProbeQueryID is the handle of a statement that doesn't exist. Looking it up is authenticated but doesn't
+	// run anything in the account.
+	healthProbeQueryID = "00000000-0000-0000-0000-000000000000"
 )
 
 // for mocking/testing purposes, and we'll override this method
@@ -40,6 +49,8 @@ type Plugin struct {
 	metricScope promutils.Scope
 	cfg         *Config
 	client      HTTPClient
+	credentials *credentials.Resolver
+	logSigner   tasklog.URLSigner
 }
 
 type ResourceWrapper struct {
@@ -48,15 +59,20 @@ type ResourceWrapper struct {
 }
 
 type ResourceMetaWrapper struct {
-	QueryID string
-	Account string
-	Token   string
+	QueryID     string
+	Account     string
+	Credentials credentials.Ref
 }
 
 func (p Plugin) GetConfig() webapi.PluginConfig {
 	return GetConfig().WebAPI
 }
 
+// RequiredSecrets returns the Snowflake token of the project and domain, so that tasks fail fast when it's missing.
+func (p Plugin) RequiredSecrets(taskCtx webapi.TaskExecutionContextReader) []string {
+	return p.credentials.RequiredSecrets(credentials.RefFromTaskExecutionMetadata(taskCtx.TaskExecutionMetadata()))
+}
+
 type QueryInfo struct {
 	Account   string
 	Warehouse string
@@ -72,6 +88,27 @@ func (p Plugin) ResourceRequirements(_ context.Context, _ webapi.TaskExecutionCo
 	return "default", p.cfg.ResourceConstraints, nil
 }
 
+// Validate checks the task template declares the snowflake account and database, and that its statement only
+// references the inputs declared by the task.
+func (p Plugin) Validate(_ context.Context, task *flyteIdlCore.TaskTemplate) error {
+	config := task.GetConfig()
+	if len(config["account"]) == 0 {
+		return errors.Errorf(errors2.BadTaskSpecification, "Account must not be empty.")
+	}
+	if len(config["database"]) == 0 {
+		return errors.Errorf(errors2.BadTaskSpecification, "Database must not be empty.")
+	}
+	if len(task.GetSql().GetStatement()) == 0 {
+		return errors.Errorf(errors2.BadTaskSpecification, "Statement must not be empty.")
+	}
+
+	if err := template.ValidateInputs([]string{task.GetSql().GetStatement()}, task.GetInterface().GetInputs()); err != nil {
+		return errors.Wrapf(errors2.BadTaskSpecification, err, "Statement references undeclared inputs.")
+	}
+
+	return nil
+}
+
 func (p Plugin) Create(ctx context.Context, taskCtx webapi.TaskExecutionContextReader) (webapi.ResourceMeta,
 	webapi.Resource, error) {
 	task, err := taskCtx.TaskReader().Read(ctx)
@@ -79,7 +116,8 @@ func (p Plugin) Create(ctx context.Context, taskCtx webapi.TaskExecutionContextR
 		return nil, nil, err
 	}
 
-	token, err := taskCtx.SecretManager().Get(ctx, p.cfg.TokenKey)
+	ref := credentials.RefFromTaskExecutionMetadata(taskCtx.TaskExecutionMetadata())
+	resolved, err := p.credentials.ResolveWith(ctx, taskCtx.SecretManager(), ref)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -113,12 +151,12 @@ func (p Plugin) Create(ctx context.Context, taskCtx webapi.TaskExecutionContextR
 	if len(queryInfo.Database) == 0 {
 		return nil, nil, errors.Errorf(errors2.BadTaskSpecification, "Database must not be empty.")
 	}
-	req, err := buildRequest(post, queryInfo, p.cfg.snowflakeEndpoint,
-		config["account"], token, "", false)
+	req, err := buildRequest(post, queryInfo, p.endpoint(resolved),
+		config["account"], resolved.Token, "", false)
 	if err != nil {
 		return nil, nil, err
 	}
-	resp, err := p.client.Do(req)
+	resp, err := p.client.Do(req.WithContext(ctx))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -139,18 +177,23 @@ func (p Plugin) Create(ctx context.Context, taskCtx webapi.TaskExecutionContextR
 	queryID := fmt.Sprintf("%v", data["statementHandle"])
 	message := fmt.Sprintf("%v", data["message"])
 
-	return &ResourceMetaWrapper{queryID, queryInfo.Account, token},
+	return &ResourceMetaWrapper{queryID, queryInfo.Account, ref},
 		&ResourceWrapper{StatusCode: resp.StatusCode, Message: message}, nil
 }
 
 func (p Plugin) Get(ctx context.Context, taskCtx webapi.GetContext) (latest webapi.Resource, err error) {
 	exec := taskCtx.ResourceMeta().(*ResourceMetaWrapper)
-	req, err := buildRequest(get, QueryInfo{}, p.cfg.snowflakeEndpoint,
-		exec.Account, exec.Token, exec.QueryID, false)
+	resolved, err := p.credentials.Resolve(ctx, exec.Credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := buildRequest(get, QueryInfo{}, p.endpoint(resolved),
+		exec.Account, resolved.Token, exec.QueryID, false)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := p.client.Do(req)
+	resp, err := p.client.Do(req.WithContext(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -168,12 +211,17 @@ func (p Plugin) Get(ctx context.Context, taskCtx webapi.GetContext) (latest weba
 
 func (p Plugin) Delete(ctx context.Context, taskCtx webapi.DeleteContext) error {
 	exec := taskCtx.ResourceMeta().(*ResourceMetaWrapper)
-	req, err := buildRequest(post, QueryInfo{}, p.cfg.snowflakeEndpoint,
-		exec.Account, exec.Token, exec.QueryID, true)
+	resolved, err := p.credentials.Resolve(ctx, exec.Credentials)
+	if err != nil {
+		return err
+	}
+
+	req, err := buildRequest(post, QueryInfo{}, p.endpoint(resolved),
+		exec.Account, resolved.Token, exec.QueryID, true)
 	if err != nil {
 		return err
 	}
-	resp, err := p.client.Do(req)
+	resp, err := p.client.Do(req.WithContext(ctx))
 	if err != nil {
 		return err
 	}
@@ -183,25 +231,85 @@ func (p Plugin) Delete(ctx context.Context, taskCtx webapi.DeleteContext) error
 	return nil
 }
 
-func (p Plugin) Status(_ context.Context, taskCtx webapi.StatusContext) (phase core.PhaseInfo, err error) {
+// CheckHealth looks up a statement that doesn't exist with the default credentials, to tell that the Snowflake SQL API
+// is reachable and accepts them without running a statement. Plugins whose default credentials don't set an endpoint
+// have no account to probe.
+func (p Plugin) CheckHealth(ctx context.Context) error {
+	resolved, err := p.credentials.Resolve(ctx, credentials.Ref{})
+	if err != nil {
+		return err
+	}
+
+	endpoint := p.endpoint(resolved)
+	if len(endpoint) == 0 {
+		return nil
+	}
+
+	req, err := buildRequest(get, QueryInfo{}, endpoint, "", resolved.Token, healthProbeQueryID, false)
+	if err != nil {
+		return err
+	}
+
+	resp, err := p.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// The statement isn't found once the request is authenticated.
+	if resp.StatusCode >= http.StatusBadRequest && resp.StatusCode != http.StatusNotFound &&
+		resp.StatusCode != http.StatusUnprocessableEntity {
+		return fmt.Errorf("snowflake API responded with status [%v]", resp.Status)
+	}
+
+	return nil
+}
+
+func (p Plugin) Status(ctx context.Context, taskCtx webapi.StatusContext) (phase core.PhaseInfo, err error) {
 	exec := taskCtx.ResourceMeta().(*ResourceMetaWrapper)
-	statusCode := taskCtx.Resource().(*ResourceWrapper).StatusCode
+	resource := taskCtx.Resource().(*ResourceWrapper)
+	statusCode := resource.StatusCode
 	if statusCode == 0 {
 		return core.PhaseInfoUndefined, errors.Errorf(ErrSystem, "No Status field set.")
 	}
 
-	taskInfo := createTaskInfo(exec.QueryID, exec.Account)
+	taskExecMetadata := taskCtx.TaskExecutionMetadata()
+	logTemplates, err := p.cfg.WebAPI.GetLogTemplates(pluginID, taskExecMetadata)
+	if err != nil {
+		return core.PhaseInfoUndefined, err
+	}
+
+	taskInfo, err := createTaskInfo(logTemplates, p.logSigner, taskExecMetadata, exec.QueryID, exec.Account)
+	if err != nil {
+		return core.PhaseInfoUndefined, err
+	}
+
 	switch statusCode {
 	case http.StatusAccepted:
-		return core.PhaseInfoRunning(pluginsCore.DefaultPhaseVersion, createTaskInfo(exec.QueryID, exec.Account)), nil
+		return core.PhaseInfoRunning(pluginsCore.DefaultPhaseVersion, taskInfo), nil
 	case http.StatusOK:
 		return pluginsCore.PhaseInfoSuccess(taskInfo), nil
 	case http.StatusUnprocessableEntity:
-		return pluginsCore.PhaseInfoFailure(string(rune(statusCode)), "phaseReason", taskInfo), nil
+		// Failed queries have always been permanent user failures, error classification rules can remap them.
+		return pluginsCore.PhaseInfoFailure(strconv.Itoa(statusCode), resource.Message, taskInfo), nil
+	}
+
+	if statusCode >= http.StatusBadRequest {
+		return pluginsCore.PhaseInfoClassifiedFailure(strconv.Itoa(statusCode), resource.Message,
+			pluginErrors.ClassifyHTTPStatus(statusCode), taskInfo), nil
 	}
+
 	return core.PhaseInfoUndefined, pluginErrors.Errorf(pluginsCore.SystemErrorCode, "unknown execution phase [%v].", statusCode)
 }
 
+// endpoint returns the endpoint to reach the account at, if it's not the default one.
+func (p Plugin) endpoint(resolved credentials.Resolved) string {
+	if len(p.cfg.snowflakeEndpoint) == 0 && len(resolved.Endpoint) != 0 {
+		return "https://" + resolved.Endpoint
+	}
+
+	return p.cfg.snowflakeEndpoint
+}
+
 func buildRequest(method string, queryInfo QueryInfo, snowflakeEndpoint string, account string, token string,
 	queryID string, isCancel bool) (*http.Request, error) {
 	var snowflakeURL string
@@ -252,33 +360,43 @@ func buildResponse(response *http.Response) (map[string]interface{}, error) {
 	return data, nil
 }
 
-func createTaskInfo(queryID string, account string) *core.TaskInfo {
-	timeNow := time.Now()
+func createTaskInfo(logCfg []tasklog.TemplateLogPluginConfig, logSigner tasklog.URLSigner,
+	taskExecMetadata core.TaskExecutionMetadata, queryID string, account string) (*core.TaskInfo, error) {
+
+	logs, err := webapi.GetTaskLogs(logCfg, logSigner, taskExecMetadata, queryID, map[string]string{
+		"account": account,
+	})
+	if err != nil {
+		return nil, err
+	}
 
+	timeNow := time.Now()
 	return &core.TaskInfo{
 		OccurredAt: &timeNow,
-		Logs: []*flyteIdlCore.TaskLog{
-			{
-				Uri: fmt.Sprintf("https://%v.snowflakecomputing.com/console#/monitoring/queries/detail?queryId=%v",
-					account,
-					queryID),
-				Name: "Snowflake Console",
-			},
-		},
-	}
+		Logs:       logs,
+	}, nil
 }
 
 func newSnowflakeJobTaskPlugin() webapi.PluginEntry {
 	return webapi.PluginEntry{
-		ID:                 "snowflake",
+		ID:                 pluginID,
 		SupportedTaskTypes: []core.TaskType{"snowflake"},
 		PluginLoader: func(ctx context.Context, iCtx webapi.PluginSetupContext) (webapi.AsyncPlugin, error) {
+			cfg := GetConfig()
+			logSigner, err := tasklog.NewURLSigner(ctx, cfg.WebAPI.LogSigner, iCtx.SecretManager())
+			if err != nil {
+				return nil, err
+			}
+
 			return &Plugin{
 				metricScope: iCtx.MetricsScope(),
-				cfg:         GetConfig(),
-				client:      &http.Client{},
+				cfg:         cfg,
+				client:      &http.Client{Transport: tracing.NewTransport(nil)},
+				credentials: credentials.NewResolver(cfg.credentialsConfig(), iCtx.SecretManager()),
+				logSigner:   logSigner,
 			}, nil
 		},
+		Validator: Plugin{},
 	}
 }
 
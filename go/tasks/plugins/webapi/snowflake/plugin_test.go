@@ -9,8 +9,11 @@ import (
 	"testing"
 	"time"
 
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 	pluginCoreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/credentials"
+	webapiMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/mocks"
 	"github.com/flyteorg/flytestdlib/promutils"
 	"github.com/stretchr/testify/assert"
 )
@@ -49,11 +52,102 @@ func TestPlugin(t *testing.T) {
 		assert.Equal(t, pluginsCore.ResourceNamespace("default"), namespace)
 		assert.Equal(t, plugin.cfg.ResourceConstraints, constraints)
 	})
+	t.Run("get RequiredSecrets", func(t *testing.T) {
+		plugin := Plugin{credentials: credentials.NewResolver(credentials.Config{
+			Default: credentials.Credentials{TokenKey: "default-token"},
+			Overrides: []credentials.Override{
+				{Project: "flytesnacks", Credentials: credentials.Credentials{TokenKey: "flytesnacks-token"}},
+			},
+		}, nil)}
+
+		taskCtx := &webapiMocks.TaskExecutionContextReader{}
+		taskCtx.OnTaskExecutionMetadata().Return(newTaskExecutionMetadata())
+		assert.Equal(t, []string{"flytesnacks-token"}, plugin.RequiredSecrets(taskCtx))
+	})
+}
+
+func TestEndpoint(t *testing.T) {
+	plugin := Plugin{cfg: &Config{}}
+	assert.Equal(t, "", plugin.endpoint(credentials.Resolved{}))
+	assert.Equal(t, "https://account.privatelink.snowflakecomputing.com",
+		plugin.endpoint(credentials.Resolved{Endpoint: "account.privatelink.snowflakecomputing.com"}))
+
+	plugin.cfg.snowflakeEndpoint = "http://127.0.0.1:8080"
+	assert.Equal(t, "http://127.0.0.1:8080",
+		plugin.endpoint(credentials.Resolved{Endpoint: "account.privatelink.snowflakecomputing.com"}))
+}
+
+func TestCheckHealth(t *testing.T) {
+	ctx := context.TODO()
+	sm := &pluginCoreMocks.SecretManager{}
+	sm.OnGetMatch(ctx, "default-token").Return("default", nil)
+	newPlugin := func(endpoint string) Plugin {
+		return Plugin{
+			cfg:    &Config{},
+			client: &MockClient{},
+			credentials: credentials.NewResolver(credentials.Config{
+				Default: credentials.Credentials{TokenKey: "default-token", Endpoint: endpoint},
+			}, sm),
+		}
+	}
+
+	respond := func(statusCode int, status string) func(req *http.Request) (*http.Response, error) {
+		return func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: statusCode, Status: status, Body: ioutil.NopCloser(strings.NewReader(""))},
+				nil
+		}
+	}
+
+	t.Run("healthy", func(t *testing.T) {
+		MockDo = func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, get, req.Method)
+			assert.Equal(t, "https://account.privatelink.snowflakecomputing.com/api/v2/statements/"+healthProbeQueryID,
+				req.URL.String())
+			assert.Equal(t, "Bearer default", req.Header.Get("Authorization"))
+			return respond(http.StatusNotFound, "404 Not Found")(req)
+		}
+
+		assert.NoError(t, newPlugin("account.privatelink.snowflakecomputing.com").CheckHealth(ctx))
+
+		MockDo = respond(http.StatusUnprocessableEntity, "422 Unprocessable Entity")
+		assert.NoError(t, newPlugin("account.privatelink.snowflakecomputing.com").CheckHealth(ctx))
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		MockDo = respond(http.StatusUnauthorized, "401 Unauthorized")
+		assert.EqualError(t, newPlugin("account.privatelink.snowflakecomputing.com").CheckHealth(ctx),
+			"snowflake API responded with status [401 Unauthorized]")
+	})
+
+	t.Run("no default account", func(t *testing.T) {
+		MockDo = func(req *http.Request) (*http.Response, error) {
+			assert.FailNow(t, "unexpected request")
+			return nil, nil
+		}
+
+		assert.NoError(t, newPlugin("").CheckHealth(ctx))
+	})
+}
+
+func TestCredentialsConfig(t *testing.T) {
+	cfg := Config{
+		TokenKey: "FLYTE_SNOWFLAKE_CLIENT_TOKEN",
+		Credentials: credentials.Config{
+			Overrides: []credentials.Override{
+				{Project: "flytesnacks", Credentials: credentials.Credentials{TokenKey: "flytesnacks-token"}},
+			},
+		},
+	}
+
+	credentialsConfig := cfg.credentialsConfig()
+	assert.Equal(t, "FLYTE_SNOWFLAKE_CLIENT_TOKEN", credentialsConfig.Lookup(credentials.Ref{Project: "flyteexamples"}).TokenKey)
+	assert.Equal(t, "flytesnacks-token", credentialsConfig.Lookup(credentials.Ref{Project: "flytesnacks"}).TokenKey)
 }
 
 func TestCreateTaskInfo(t *testing.T) {
 	t.Run("create task info", func(t *testing.T) {
-		taskInfo := createTaskInfo("d5493e36", "test-account")
+		taskInfo, err := createTaskInfo(GetConfig().WebAPI.Logs, nil, newTaskExecutionMetadata(), "d5493e36", "test-account")
+		assert.NoError(t, err)
 
 		assert.Equal(t, 1, len(taskInfo.Logs))
 		assert.Equal(t, taskInfo.Logs[0].Uri, "https://test-account.snowflakecomputing.com/console#/monitoring/queries/detail?queryId=d5493e36")
@@ -120,3 +214,66 @@ func TestBuildResponse(t *testing.T) {
 		assert.Equal(t, expectedData, actualData)
 	})
 }
+
+func TestStatus_Failures(t *testing.T) {
+	plugin := Plugin{cfg: GetConfig()}
+	for _, tt := range []struct {
+		statusCode int
+		code       string
+		phase      pluginsCore.Phase
+	}{
+		{http.StatusUnprocessableEntity, "422", pluginsCore.PhasePermanentFailure},
+		{http.StatusNotFound, "404", pluginsCore.PhasePermanentFailure},
+		{http.StatusTooManyRequests, "429", pluginsCore.PhaseRetryableFailure},
+		{http.StatusInternalServerError, "500", pluginsCore.PhaseRetryableFailure},
+	} {
+		t.Run(tt.code, func(t *testing.T) {
+			taskCtx := &webapiMocks.StatusContext{}
+			taskCtx.OnResourceMeta().Return(&ResourceMetaWrapper{QueryID: "query", Account: "account"})
+			taskCtx.OnResource().Return(&ResourceWrapper{StatusCode: tt.statusCode, Message: "failed"})
+			taskCtx.OnTaskExecutionMetadata().Return(newTaskExecutionMetadata())
+
+			phaseInfo, err := plugin.Status(context.TODO(), taskCtx)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.phase, phaseInfo.Phase())
+			assert.Equal(t, tt.code, phaseInfo.Err().GetCode())
+			assert.Equal(t, "failed", phaseInfo.Err().GetMessage())
+		})
+	}
+}
+
+func newTaskExecutionMetadata() *pluginCoreMocks.TaskExecutionMetadata {
+	tID := &pluginCoreMocks.TaskExecutionID{}
+	tID.OnGetID().Return(idlCore.TaskExecutionIdentifier{
+		NodeExecutionId: &idlCore.NodeExecutionIdentifier{
+			ExecutionId: &idlCore.WorkflowExecutionIdentifier{
+				Project: "flytesnacks",
+				Domain:  "development",
+				Name:    "exec-name",
+			},
+		},
+	})
+
+	tMeta := &pluginCoreMocks.TaskExecutionMetadata{}
+	tMeta.OnGetTaskExecutionID().Return(tID)
+	return tMeta
+}
+
+func TestValidate(t *testing.T) {
+	ctx := context.TODO()
+	taskTemplate := func(config map[string]string, statement string) *idlCore.TaskTemplate {
+		return &idlCore.TaskTemplate{
+			Config: config,
+			Target: &idlCore.TaskTemplate_Sql{Sql: &idlCore.Sql{Statement: statement}},
+			Interface: &idlCore.TypedInterface{Inputs: &idlCore.VariableMap{
+				Variables: map[string]*idlCore.Variable{"ds": {}},
+			}},
+		}
+	}
+	config := map[string]string{"account": "test-account", "database": "test-database"}
+
+	assert.NoError(t, Plugin{}.Validate(ctx, taskTemplate(config, "select * from t where ds = '{{ .Inputs.ds }}'")))
+	assert.Error(t, Plugin{}.Validate(ctx, taskTemplate(config, "select {{ .Inputs.x }}")))
+	assert.Error(t, Plugin{}.Validate(ctx, taskTemplate(config, "")))
+	assert.Error(t, Plugin{}.Validate(ctx, taskTemplate(map[string]string{"account": "test-account"}, "select 1")))
+}